@@ -3,23 +3,29 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Mail     MailConfig
-	Redis    RedisConfig
+	App       AppConfig
+	Database  DatabaseConfig
+	JWT       JWTConfig
+	Auth      AuthConfig
+	Mail      MailConfig
+	Storage   StorageConfig
+	Redis     RedisConfig
+	Scheduler SchedulerConfig
+	OAuth     OAuthConfig
 }
 
 type AppConfig struct {
-	Name string
-	Port string
-	Env  string
+	Name        string
+	Port        string
+	Env         string
+	FrontendURL string
 }
 
 type DatabaseConfig struct {
@@ -32,6 +38,7 @@ type DatabaseConfig struct {
 	AutoMigrate    bool
 	RunSeeder      bool
 	SeedUsers      bool
+	SeedRoles      bool
 	MigrateOnStart bool
 }
 
@@ -39,14 +46,85 @@ type JWTConfig struct {
 	Secret                  string
 	ExpireHours             int
 	RefreshTokenExpireHours int
+	Algorithm               string
+	KeyID                   string
+	PrivateKeyFile          string
+	VerificationKeyFiles    []string
+}
+
+const (
+	EmailVerificationPolicyOptional   = "optional"
+	EmailVerificationPolicyBlockLogin = "block_login"
+	EmailVerificationPolicyLimited    = "limited"
+)
+
+type AuthConfig struct {
+	EmailVerificationPolicy      string
+	EmailVerificationExpireHours int
+	BruteForce                   BruteForceConfig
+}
+
+type BruteForceConfig struct {
+	MaxAttemptsPerEmail int
+	MaxAttemptsPerIP    int
+	WindowMinutes       int
+	LockoutMinutes      int
+	DelayAfterAttempts  int
+	DelayBaseSeconds    int
 }
 
 type MailConfig struct {
+	Driver   string
 	Host     string
 	Port     string
 	Username string
 	Password string
 	From     string
+	FileDir  string
+}
+
+type StorageConfig struct {
+	Driver                     string
+	LocalDir                   string
+	S3Endpoint                 string
+	S3Region                   string
+	S3Bucket                   string
+	S3AccessKey                string
+	S3SecretKey                string
+	S3PathStyle                bool
+	HTTPTimeoutSeconds         int
+	MaxAttachmentSizeMB        int
+	MaxAttachmentsPerTransaksi int
+}
+
+type SchedulerConfig struct {
+	Enabled                          bool
+	TokenCleanupIntervalMinutes      int
+	TransaksiBerulangIntervalMinutes int
+}
+
+type OAuthConfig struct {
+	RedirectBaseURL    string
+	StateTTLMinutes    int
+	HTTPTimeoutSeconds int
+	Google             OAuthProviderConfig
+	GitHub             OAuthProviderConfig
+	OIDC               OAuthProviderConfig
+	Fake               FakeOAuthConfig
+}
+
+type OAuthProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	IssuerURL    string
+	Scopes       []string
+}
+
+type FakeOAuthConfig struct {
+	Enabled bool
+	Email   string
+	Name    string
 }
 
 type RedisConfig struct {
@@ -65,9 +143,10 @@ func LoadConfig() *Config {
 
 	config := &Config{
 		App: AppConfig{
-			Name: getEnv("APP_NAME", "Fiber Boilerplate"),
-			Port: getEnv("APP_PORT", "3000"),
-			Env:  getEnv("APP_ENV", "development"),
+			Name:        getEnv("APP_NAME", "Fiber Boilerplate"),
+			Port:        getEnv("APP_PORT", "3000"),
+			Env:         getEnv("APP_ENV", "development"),
+			FrontendURL: getEnv("APP_FRONTEND_URL", "http://localhost:3000"),
 		},
 		Database: DatabaseConfig{
 			Host:           getEnv("DB_HOST", "localhost"),
@@ -79,19 +158,51 @@ func LoadConfig() *Config {
 			AutoMigrate:    getEnvAsBool("DB_AUTO_MIGRATE", true),
 			RunSeeder:      getEnvAsBool("DB_RUN_SEEDER", false),
 			SeedUsers:      getEnvAsBool("DB_SEED_USERS", false),
+			SeedRoles:      getEnvAsBool("DB_SEED_ROLES", false),
 			MigrateOnStart: getEnvAsBool("DB_MIGRATE_ON_START", true),
 		},
 		JWT: JWTConfig{
 			Secret:                  getEnv("JWT_SECRET", "your-jwt-secret"),
 			ExpireHours:             getEnvAsInt("JWT_EXPIRE_HOURS", 24),
 			RefreshTokenExpireHours: getEnvAsInt("REFRESH_TOKEN_EXPIRE_HOURS", 168),
+			Algorithm:               getEnv("JWT_ALGORITHM", "HS256"),
+			KeyID:                   getEnv("JWT_KEY_ID", ""),
+			PrivateKeyFile:          getEnv("JWT_PRIVATE_KEY_FILE", ""),
+			VerificationKeyFiles:    getEnvAsSlice("JWT_VERIFICATION_KEY_FILES", nil),
+		},
+		Auth: AuthConfig{
+			EmailVerificationPolicy:      getEnv("AUTH_EMAIL_VERIFICATION_POLICY", EmailVerificationPolicyOptional),
+			EmailVerificationExpireHours: getEnvAsInt("AUTH_EMAIL_VERIFICATION_EXPIRE_HOURS", 24),
+			BruteForce: BruteForceConfig{
+				MaxAttemptsPerEmail: getEnvAsInt("AUTH_BRUTE_FORCE_MAX_ATTEMPTS_PER_EMAIL", 5),
+				MaxAttemptsPerIP:    getEnvAsInt("AUTH_BRUTE_FORCE_MAX_ATTEMPTS_PER_IP", 20),
+				WindowMinutes:       getEnvAsInt("AUTH_BRUTE_FORCE_WINDOW_MINUTES", 15),
+				LockoutMinutes:      getEnvAsInt("AUTH_BRUTE_FORCE_LOCKOUT_MINUTES", 15),
+				DelayAfterAttempts:  getEnvAsInt("AUTH_BRUTE_FORCE_DELAY_AFTER_ATTEMPTS", 3),
+				DelayBaseSeconds:    getEnvAsInt("AUTH_BRUTE_FORCE_DELAY_BASE_SECONDS", 2),
+			},
 		},
 		Mail: MailConfig{
+			Driver:   getEnv("MAIL_DRIVER", "file"),
 			Host:     getEnv("MAIL_HOST", "localhost"),
 			Port:     getEnv("MAIL_PORT", "587"),
 			Username: getEnv("MAIL_USERNAME", ""),
 			Password: getEnv("MAIL_PASSWORD", ""),
 			From:     getEnv("MAIL_FROM", "noreply@example.com"),
+			FileDir:  getEnv("MAIL_FILE_DIR", "storage/mail"),
+		},
+		Storage: StorageConfig{
+			Driver:                     getEnv("STORAGE_DRIVER", "local"),
+			LocalDir:                   getEnv("STORAGE_LOCAL_DIR", "storage/attachments"),
+			S3Endpoint:                 getEnv("STORAGE_S3_ENDPOINT", ""),
+			S3Region:                   getEnv("STORAGE_S3_REGION", "us-east-1"),
+			S3Bucket:                   getEnv("STORAGE_S3_BUCKET", ""),
+			S3AccessKey:                getEnv("STORAGE_S3_ACCESS_KEY", ""),
+			S3SecretKey:                getEnv("STORAGE_S3_SECRET_KEY", ""),
+			S3PathStyle:                getEnvAsBool("STORAGE_S3_PATH_STYLE", true),
+			HTTPTimeoutSeconds:         getEnvAsInt("STORAGE_HTTP_TIMEOUT_SECONDS", 30),
+			MaxAttachmentSizeMB:        getEnvAsInt("STORAGE_MAX_ATTACHMENT_SIZE_MB", 10),
+			MaxAttachmentsPerTransaksi: getEnvAsInt("STORAGE_MAX_ATTACHMENTS_PER_TRANSAKSI", 10),
 		},
 		Redis: RedisConfig{
 			Host:       getEnv("REDIS_HOST", "localhost"),
@@ -101,6 +212,38 @@ func LoadConfig() *Config {
 			MaxRetries: getEnvAsInt("REDIS_MAX_RETRIES", 3),
 			PoolSize:   getEnvAsInt("REDIS_POOL_SIZE", 10),
 		},
+		Scheduler: SchedulerConfig{
+			Enabled:                          getEnvAsBool("SCHEDULER_ENABLED", true),
+			TokenCleanupIntervalMinutes:      getEnvAsInt("SCHEDULER_TOKEN_CLEANUP_INTERVAL_MINUTES", 60),
+			TransaksiBerulangIntervalMinutes: getEnvAsInt("SCHEDULER_TRANSAKSI_BERULANG_INTERVAL_MINUTES", 15),
+		},
+		OAuth: OAuthConfig{
+			RedirectBaseURL:    getEnv("OAUTH_REDIRECT_BASE_URL", "http://localhost:3000/oauth/callback"),
+			StateTTLMinutes:    getEnvAsInt("OAUTH_STATE_TTL_MINUTES", 10),
+			HTTPTimeoutSeconds: getEnvAsInt("OAUTH_HTTP_TIMEOUT_SECONDS", 10),
+			Google: OAuthProviderConfig{
+				ClientID:     getEnv("OAUTH_GOOGLE_CLIENT_ID", ""),
+				ClientSecret: getEnv("OAUTH_GOOGLE_CLIENT_SECRET", ""),
+				RedirectURL:  getEnv("OAUTH_GOOGLE_REDIRECT_URL", ""),
+			},
+			GitHub: OAuthProviderConfig{
+				ClientID:     getEnv("OAUTH_GITHUB_CLIENT_ID", ""),
+				ClientSecret: getEnv("OAUTH_GITHUB_CLIENT_SECRET", ""),
+				RedirectURL:  getEnv("OAUTH_GITHUB_REDIRECT_URL", ""),
+			},
+			OIDC: OAuthProviderConfig{
+				ClientID:     getEnv("OAUTH_OIDC_CLIENT_ID", ""),
+				ClientSecret: getEnv("OAUTH_OIDC_CLIENT_SECRET", ""),
+				RedirectURL:  getEnv("OAUTH_OIDC_REDIRECT_URL", ""),
+				IssuerURL:    getEnv("OAUTH_OIDC_ISSUER_URL", ""),
+				Scopes:       getEnvAsSlice("OAUTH_OIDC_SCOPES", nil),
+			},
+			Fake: FakeOAuthConfig{
+				Enabled: getEnvAsBool("OAUTH_FAKE_ENABLED", false),
+				Email:   getEnv("OAUTH_FAKE_EMAIL", "oauth.fake@example.com"),
+				Name:    getEnv("OAUTH_FAKE_NAME", "Fake OAuth User"),
+			},
+		},
 	}
 
 	return config
@@ -121,6 +264,21 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	var values []string
+	for _, value := range strings.Split(valueStr, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func getEnvAsBool(key string, defaultValue bool) bool {
 	valueStr := getEnv(key, "")
 	if value, err := strconv.ParseBool(valueStr); err == nil {
@@ -13,8 +13,18 @@ func RunMigration(db *gorm.DB) {
 	if err := db.AutoMigrate(
 		&domain.User{},
 		&domain.RefreshToken{},
+		&domain.RevokedAccessToken{},
 		&domain.PasswordResetToken{},
+		&domain.EmailVerificationToken{},
+		&domain.UserMFA{},
+		&domain.MFARecoveryCode{},
+		&domain.APIKey{},
+		&domain.UserIdentity{},
+		&domain.OAuthState{},
 		&domain.Kantong{},
+		&domain.Transfer{},
+		&domain.TransaksiBerulang{},
+		&domain.KejadianTransaksiBerulang{},
 		&domain.Permission{},
 		&domain.Role{},
 		&domain.RolePermission{},
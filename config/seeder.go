@@ -3,6 +3,7 @@ package config
 import (
 	"fiber-boiler-plate/internal/domain"
 	"fiber-boiler-plate/internal/helper"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
@@ -12,6 +13,12 @@ import (
 func RunSeeder(db *gorm.DB, cfg *Config) {
 	helper.Info("Menjalankan database seeder...")
 
+	if cfg.Database.SeedRoles {
+		seedAdminRole(db)
+	} else {
+		helper.Info("Seeder roles dinonaktifkan melalui konfigurasi")
+	}
+
 	if cfg.Database.SeedUsers {
 		seedUsers(db)
 	} else {
@@ -35,11 +42,13 @@ func seedUsers(db *gorm.DB) {
 		helper.Fatal("Gagal hash password", err)
 	}
 
+	verifiedAt := time.Now()
 	user := domain.User{
-		Email:    "user@example.com",
-		Password: string(hashedPassword),
-		Name:     "user example",
-		IsActive: true,
+		Email:           "user@example.com",
+		Password:        string(hashedPassword),
+		Name:            "user example",
+		IsActive:        true,
+		EmailVerifiedAt: &verifiedAt,
 	}
 
 	if err := db.Create(&user).Error; err != nil {
@@ -52,3 +61,34 @@ func seedUsers(db *gorm.DB) {
 		"email": user.Email,
 	})
 }
+
+func seedAdminRole(db *gorm.DB) {
+	permissions := make([]domain.Permission, 0, len(domain.AdminPermissions))
+	for _, nama := range domain.AdminPermissions {
+		permission := domain.Permission{Nama: nama, Kategori: "admin"}
+		if err := db.Where("nama = ?", nama).FirstOrCreate(&permission).Error; err != nil {
+			helper.Fatal("Gagal membuat permission seed", err, logrus.Fields{
+				"permission": nama,
+			})
+		}
+		permissions = append(permissions, permission)
+	}
+
+	deskripsi := "Administrator dengan akses penuh ke modul admin"
+	role := domain.Role{Nama: "admin", Deskripsi: &deskripsi, Status: "aktif"}
+	if err := db.Where("nama = ?", role.Nama).FirstOrCreate(&role).Error; err != nil {
+		helper.Fatal("Gagal membuat role seed", err, logrus.Fields{
+			"role": role.Nama,
+		})
+	}
+
+	if err := db.Model(&role).Association("Permissions").Replace(permissions); err != nil {
+		helper.Fatal("Gagal menghubungkan permission ke role seed", err, logrus.Fields{
+			"role": role.Nama,
+		})
+	}
+
+	helper.Info("Role admin seed berhasil dibuat", logrus.Fields{
+		"permissions": len(permissions),
+	})
+}
@@ -83,6 +83,21 @@ func TestLoadConfig_MailConfiguration(t *testing.T) {
 	assert.Equal(t, "test@test.com", cfg.Mail.Username)
 	assert.Equal(t, "mailpass", cfg.Mail.Password)
 	assert.Equal(t, "noreply@test.com", cfg.Mail.From)
+	assert.Equal(t, "file", cfg.Mail.Driver)
+	assert.Equal(t, "storage/mail", cfg.Mail.FileDir)
+	assert.Equal(t, "http://localhost:3000", cfg.App.FrontendURL)
+}
+
+func TestLoadConfig_MailDriver(t *testing.T) {
+	os.Clearenv()
+
+	os.Setenv("MAIL_DRIVER", "smtp")
+	os.Setenv("APP_FRONTEND_URL", "https://app.example.com")
+
+	cfg := config.LoadConfig()
+
+	assert.Equal(t, "smtp", cfg.Mail.Driver)
+	assert.Equal(t, "https://app.example.com", cfg.App.FrontendURL)
 }
 
 func TestConfig_StructureValidation(t *testing.T) {
@@ -169,3 +184,33 @@ func TestLoadConfig_RedisCustomValues(t *testing.T) {
 	assert.Equal(t, 5, cfg.Redis.MaxRetries)
 	assert.Equal(t, 20, cfg.Redis.PoolSize)
 }
+
+func TestLoadConfig_BruteForceDefaultValues(t *testing.T) {
+	os.Clearenv()
+
+	cfg := config.LoadConfig()
+
+	assert.Equal(t, 5, cfg.Auth.BruteForce.MaxAttemptsPerEmail)
+	assert.Equal(t, 20, cfg.Auth.BruteForce.MaxAttemptsPerIP)
+	assert.Equal(t, 15, cfg.Auth.BruteForce.WindowMinutes)
+	assert.Equal(t, 15, cfg.Auth.BruteForce.LockoutMinutes)
+	assert.Equal(t, 3, cfg.Auth.BruteForce.DelayAfterAttempts)
+	assert.Equal(t, 2, cfg.Auth.BruteForce.DelayBaseSeconds)
+}
+
+func TestLoadConfig_JWTKeyConfiguration(t *testing.T) {
+	os.Clearenv()
+
+	cfg := config.LoadConfig()
+	assert.Equal(t, "HS256", cfg.JWT.Algorithm)
+	assert.Empty(t, cfg.JWT.VerificationKeyFiles)
+
+	os.Setenv("JWT_ALGORITHM", "RS256")
+	os.Setenv("JWT_PRIVATE_KEY_FILE", "/etc/keys/current.pem")
+	os.Setenv("JWT_VERIFICATION_KEY_FILES", "2024-01=/etc/keys/old.pub.pem, /etc/keys/older.pub.pem")
+
+	cfg = config.LoadConfig()
+	assert.Equal(t, "RS256", cfg.JWT.Algorithm)
+	assert.Equal(t, "/etc/keys/current.pem", cfg.JWT.PrivateKeyFile)
+	assert.Equal(t, []string{"2024-01=/etc/keys/old.pub.pem", "/etc/keys/older.pub.pem"}, cfg.JWT.VerificationKeyFiles)
+}
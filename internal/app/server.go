@@ -1,11 +1,18 @@
 package app
 
 import (
+	"context"
 	"fiber-boiler-plate/config"
 	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/domain"
 	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/mailer"
+	"fiber-boiler-plate/internal/oauth"
+	"fiber-boiler-plate/internal/scheduler"
+	"fiber-boiler-plate/internal/storage"
 	"fiber-boiler-plate/internal/usecase"
 	"fiber-boiler-plate/internal/usecase/repo"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -16,7 +23,13 @@ import (
 )
 
 func NewServer(cfg *config.Config, db *gorm.DB, rdb *redis.Client) *fiber.App {
+	bodyLimit := fiber.DefaultBodyLimit
+	if limit := (cfg.Storage.MaxAttachmentSizeMB + 1) * 1024 * 1024; limit > bodyLimit {
+		bodyLimit = limit
+	}
+
 	app := fiber.New(fiber.Config{
+		BodyLimit: bodyLimit,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			return helper.SendInternalServerErrorResponse(c)
 		},
@@ -26,16 +39,28 @@ func NewServer(cfg *config.Config, db *gorm.DB, rdb *redis.Client) *fiber.App {
 	app.Use(recover.New())
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-Refresh-Token",
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-Refresh-Token, X-Device-Name",
 		AllowMethods: "GET, POST, PUT, DELETE, OPTIONS",
 	}))
 
+	blobStorage, err := storage.New(cfg.Storage)
+	if err != nil {
+		helper.Fatal("Gagal menginisialisasi storage", err)
+	}
+
 	userRepo := repo.NewUserRepository(db)
 	refreshTokenRepo := repo.NewRefreshTokenRepository(db)
 	resetTokenRepo := repo.NewPasswordResetTokenRepository(db)
+	verificationTokenRepo := repo.NewEmailVerificationTokenRepository(db)
+	mfaRepo := repo.NewMFARepository(db)
 	redisRepo := repo.NewRedisRepository(rdb)
+	loginAttemptRepo := repo.NewLoginAttemptRepository(redisRepo)
+	tokenRevocationRepo := repo.NewTokenRevocationRepository(db, redisRepo)
 	kantongRepo := repo.NewKantongRepository(db, redisRepo)
-	transaksiRepo := repo.NewTransaksiRepository(db)
+	transaksiRepo := repo.NewTransaksiRepository(db, blobStorage)
+	tagRepo := repo.NewTagRepository(db)
+	lampiranTransaksiRepo := repo.NewLampiranTransaksiRepository(db)
+	transaksiBerulangRepo := repo.NewTransaksiBerulangRepository(db)
 	anggaranRepo := repo.NewAnggaranRepository(db, redisRepo)
 	laporanRepo := repo.NewLaporanRepository(db)
 	subscriptionPlanRepo := repo.NewSubscriptionPlanRepository(db, redisRepo)
@@ -43,11 +68,42 @@ func NewServer(cfg *config.Config, db *gorm.DB, rdb *redis.Client) *fiber.App {
 	roleRepo := repo.NewRoleRepository(db, redisRepo)
 	userSubscriptionRepo := repo.NewUserSubscriptionRepository(db, redisRepo)
 	invoiceRepo := repo.NewInvoiceRepository(db, redisRepo)
+	jobLockRepo := repo.NewJobLockRepository(redisRepo)
+	apiKeyRepo := repo.NewAPIKeyRepository(db)
+	oauthStateRepo := repo.NewOAuthStateRepository(db)
+	userIdentityRepo := repo.NewUserIdentityRepository(db)
+
+	mailSender, err := mailer.New(cfg.Mail)
+	if err != nil {
+		helper.Fatal("Gagal menginisialisasi mailer", err)
+	}
+
+	jwtKeys, err := helper.LoadJWTKeySet(helper.JWTKeyOptions{
+		Algorithm:            cfg.JWT.Algorithm,
+		Secret:               cfg.JWT.Secret,
+		KeyID:                cfg.JWT.KeyID,
+		PrivateKeyFile:       cfg.JWT.PrivateKeyFile,
+		VerificationKeyFiles: cfg.JWT.VerificationKeyFiles,
+	})
+	if err != nil {
+		helper.Fatal("Gagal memuat kunci JWT", err)
+	}
 
-	authUsecase := usecase.NewAuthUsecase(userRepo, refreshTokenRepo, resetTokenRepo, cfg)
+	authUsecase := usecase.NewAuthUsecase(userRepo, refreshTokenRepo, resetTokenRepo, verificationTokenRepo, mfaRepo, tokenRevocationRepo, mailSender, jwtKeys, cfg)
 	authController := http.NewAuthController(authUsecase)
 
-	profilUsecase := usecase.NewProfilUsecase(userRepo, redisRepo)
+	oauthUsecase := usecase.NewOAuthUsecase(oauth.New(cfg.OAuth, cfg.App.Env), oauthStateRepo, userIdentityRepo, userRepo, authUsecase, cfg)
+	oauthController := http.NewOAuthController(oauthUsecase)
+
+	loginAttemptUsecase := usecase.NewLoginAttemptUsecase(loginAttemptRepo, cfg)
+
+	mfaUsecase := usecase.NewMFAUsecase(mfaRepo, userRepo, cfg)
+	mfaController := http.NewMFAController(mfaUsecase)
+
+	sessionUsecase := usecase.NewSessionUsecase(refreshTokenRepo, tokenRevocationRepo, cfg)
+	sessionController := http.NewSessionController(sessionUsecase)
+
+	profilUsecase := usecase.NewProfilUsecase(userRepo, redisRepo, refreshTokenRepo, tokenRevocationRepo, jwtKeys, cfg)
 	profilController := http.NewProfilController(profilUsecase)
 
 	kantongUsecase := usecase.NewKantongUsecase(kantongRepo, userRepo)
@@ -56,6 +112,15 @@ func NewServer(cfg *config.Config, db *gorm.DB, rdb *redis.Client) *fiber.App {
 	transaksiUsecase := usecase.NewTransaksiUsecase(transaksiRepo, kantongRepo, redisRepo)
 	transaksiController := http.NewTransaksiController(transaksiUsecase)
 
+	tagUsecase := usecase.NewTagUsecase(tagRepo, redisRepo)
+	tagController := http.NewTagController(tagUsecase)
+
+	lampiranTransaksiUsecase := usecase.NewLampiranTransaksiUsecase(lampiranTransaksiRepo, transaksiRepo, blobStorage, int64(cfg.Storage.MaxAttachmentSizeMB)*1024*1024, cfg.Storage.MaxAttachmentsPerTransaksi)
+	lampiranTransaksiController := http.NewLampiranTransaksiController(lampiranTransaksiUsecase)
+
+	transaksiBerulangUsecase := usecase.NewTransaksiBerulangUsecase(transaksiBerulangRepo, kantongRepo, transaksiUsecase)
+	transaksiBerulangController := http.NewTransaksiBerulangController(transaksiBerulangUsecase)
+
 	anggaranUsecase := usecase.NewAnggaranUsecase(anggaranRepo, kantongRepo, transaksiRepo, redisRepo)
 	anggaranController := http.NewAnggaranController(anggaranUsecase)
 
@@ -77,30 +142,94 @@ func NewServer(cfg *config.Config, db *gorm.DB, rdb *redis.Client) *fiber.App {
 	invoiceUsecase := usecase.NewInvoiceUsecase(invoiceRepo)
 	invoiceController := http.NewInvoiceController(invoiceUsecase)
 
+	adminUserUsecase := usecase.NewAdminUserUsecase(userRepo, roleRepo, userSubscriptionRepo, invoiceRepo, refreshTokenRepo, tokenRevocationRepo, redisRepo)
+	adminUserController := http.NewAdminUserController(adminUserUsecase)
+
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepo, userRepo)
+	apiKeyController := http.NewAPIKeyController(apiKeyUsecase)
+
 	kantongUsecase.SetAnggaranUsecase(anggaranUsecase)
 	transaksiUsecase.SetAnggaranUsecase(anggaranUsecase)
 
 	healthUsecase := usecase.NewHealthUsecase(db, rdb, cfg)
 	healthController := http.NewHealthController(healthUsecase)
 
+	tokenCleanupUsecase := usecase.NewTokenCleanupUsecase(refreshTokenRepo, resetTokenRepo, verificationTokenRepo, oauthStateRepo)
+
+	jobScheduler := scheduler.New(jobLockRepo)
+	jobScheduler.Register(scheduler.Job{
+		Name:     domain.JobTokenCleanup,
+		Interval: time.Duration(cfg.Scheduler.TokenCleanupIntervalMinutes) * time.Minute,
+		Run:      tokenCleanupUsecase.CleanupExpiredTokens,
+	})
+	jobScheduler.Register(scheduler.Job{
+		Name:     domain.JobTransaksiBerulang,
+		Interval: time.Duration(cfg.Scheduler.TransaksiBerulangIntervalMinutes) * time.Minute,
+		Run:      transaksiBerulangUsecase.ProcessDueTransaksi,
+	})
+	healthUsecase.SetJobMetricsProvider(jobScheduler)
+
+	if cfg.Scheduler.Enabled {
+		schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+		jobScheduler.Start(schedulerCtx)
+		app.Hooks().OnShutdown(func() error {
+			stopScheduler()
+			jobScheduler.Wait()
+			return nil
+		})
+	}
+
+	jwksController := http.NewJWKSController(jwtKeys)
+
+	verifiedEmail := func(c *fiber.Ctx) error {
+		return c.Next()
+	}
+	if cfg.Auth.EmailVerificationPolicy == config.EmailVerificationPolicyLimited {
+		verifiedEmail = helper.RequireVerifiedEmail(userRepo)
+	}
+
+	app.Get("/.well-known/jwks.json", jwksController.GetJWKS)
+
 	api := app.Group("/api/v1")
 
 	auth := api.Group("/auth")
 	auth.Post("/register", authController.Register)
-	auth.Post("/login", authController.Login)
-	auth.Post("/refresh", authController.RefreshToken)
-	auth.Post("/reset-password", authController.ResetPassword)
+	auth.Post("/login", helper.BruteForceProtection(loginAttemptUsecase, "login", false), authController.Login)
+	auth.Post("/login/mfa", helper.BruteForceProtection(loginAttemptUsecase, "mfa", false), authController.VerifyMFA)
+	auth.Post("/refresh", helper.BruteForceProtection(loginAttemptUsecase, "refresh", false), authController.RefreshToken)
+	auth.Post("/reset-password", helper.BruteForceProtection(loginAttemptUsecase, "reset_password", true), authController.ResetPassword)
 	auth.Post("/reset-password/confirm", authController.ConfirmResetPassword)
+	auth.Post("/verify-email", authController.VerifyEmail)
+	auth.Post("/resend-verification", authController.ResendVerification)
+	auth.Get("/oauth/providers", oauthController.GetProviders)
+	auth.Get("/oauth/:provider", oauthController.Start)
+	auth.Get("/oauth/:provider/callback", helper.BruteForceProtection(loginAttemptUsecase, "oauth", false), oauthController.Callback)
 
-	protected := auth.Group("/", helper.JWTAuthMiddleware(cfg.JWT.Secret))
+	protected := auth.Group("/", helper.JWTAuthMiddleware(jwtKeys, tokenRevocationRepo))
 	protected.Post("logout", authController.Logout)
-
-	profil := api.Group("/profil", helper.JWTAuthMiddleware(cfg.JWT.Secret))
+	protected.Get("sessions", sessionController.GetSessions)
+	protected.Delete("sessions", sessionController.RevokeAllSessions)
+	protected.Delete("sessions/:id", sessionController.RevokeSession)
+	protected.Post("mfa/setup", mfaController.Setup)
+	protected.Post("mfa/confirm", mfaController.Confirm)
+	protected.Post("mfa/disable", mfaController.Disable)
+	protected.Post("mfa/recovery-codes", mfaController.RegenerateRecoveryCodes)
+
+	profil := api.Group("/profil", helper.JWTAuthMiddleware(jwtKeys, tokenRevocationRepo))
 	profil.Get("/me", profilController.GetProfil)
 	profil.Put("/me", profilController.UpdateProfil)
+	profil.Put("/password", profilController.ChangePassword)
 
-	kantong := api.Group("/kantong", helper.JWTAuthMiddleware(cfg.JWT.Secret))
+	apiKey := api.Group("/api-keys", helper.JWTAuthMiddleware(jwtKeys, tokenRevocationRepo))
+	apiKey.Get("/", apiKeyController.GetAll)
+	apiKey.Post("/", apiKeyController.Create)
+	apiKey.Delete("/:id", apiKeyController.Delete)
+
+	kantong := api.Group("/kantong", helper.AuthMiddleware(jwtKeys, tokenRevocationRepo, apiKeyUsecase, domain.APIKeyResourceKantong), verifiedEmail)
 	kantong.Get("/", kantongController.GetKantongList)
+	kantong.Get("/transfer", kantongController.GetTransferList)
+	kantong.Get("/transfer/:id", kantongController.GetTransferByID)
+	kantong.Post("/transfer/:id/reverse", kantongController.ReverseTransfer)
 	kantong.Get("/:id", kantongController.GetKantongByID)
 	kantong.Post("/", kantongController.CreateKantong)
 	kantong.Put("/:id", kantongController.UpdateKantong)
@@ -108,65 +237,96 @@ func NewServer(cfg *config.Config, db *gorm.DB, rdb *redis.Client) *fiber.App {
 	kantong.Delete("/:id", kantongController.DeleteKantong)
 	kantong.Post("/transfer", kantongController.TransferKantong)
 
-	transaksi := api.Group("/transaksi", helper.JWTAuthMiddleware(cfg.JWT.Secret))
+	transaksi := api.Group("/transaksi", helper.AuthMiddleware(jwtKeys, tokenRevocationRepo, apiKeyUsecase, domain.APIKeyResourceTransaksi), verifiedEmail)
 	transaksi.Get("/", transaksiController.GetTransaksiList)
+	transaksi.Get("/export", transaksiController.ExportTransaksi)
+	transaksi.Post("/import/preview", transaksiController.PreviewImportTransaksi)
+	transaksi.Post("/import", transaksiController.ImportTransaksi)
+	transaksi.Post("/batch", transaksiController.BatchTransaksi)
+	transaksi.Get("/berulang", transaksiBerulangController.GetTransaksiBerulangList)
+	transaksi.Post("/berulang", transaksiBerulangController.CreateTransaksiBerulang)
+	transaksi.Get("/berulang/:id", transaksiBerulangController.GetTransaksiBerulangDetail)
+	transaksi.Patch("/berulang/:id", transaksiBerulangController.PatchTransaksiBerulang)
+	transaksi.Delete("/berulang/:id", transaksiBerulangController.DeleteTransaksiBerulang)
+	transaksi.Post("/berulang/:id/jeda", transaksiBerulangController.PauseTransaksiBerulang)
+	transaksi.Post("/berulang/:id/lanjutkan", transaksiBerulangController.ResumeTransaksiBerulang)
+	transaksi.Post("/berulang/:id/lewati", transaksiBerulangController.SkipKejadian)
 	transaksi.Get("/:id", transaksiController.GetTransaksiDetail)
 	transaksi.Post("/", transaksiController.CreateTransaksi)
 	transaksi.Put("/:id", transaksiController.UpdateTransaksi)
 	transaksi.Patch("/:id", transaksiController.PatchTransaksi)
 	transaksi.Delete("/:id", transaksiController.DeleteTransaksi)
-
-	anggaran := api.Group("/anggaran", helper.JWTAuthMiddleware(cfg.JWT.Secret))
+	transaksi.Get("/:id/attachments", lampiranTransaksiController.GetLampiranList)
+	transaksi.Post("/:id/attachments", lampiranTransaksiController.UploadLampiran)
+	transaksi.Get("/:id/attachments/:lampiran_id", lampiranTransaksiController.DownloadLampiran)
+	transaksi.Delete("/:id/attachments/:lampiran_id", lampiranTransaksiController.DeleteLampiran)
+
+	tag := api.Group("/tag", helper.AuthMiddleware(jwtKeys, tokenRevocationRepo, apiKeyUsecase, domain.APIKeyResourceTransaksi), verifiedEmail)
+	tag.Get("/", tagController.GetTagList)
+	tag.Get("/:id", tagController.GetTagDetail)
+	tag.Post("/", tagController.CreateTag)
+	tag.Put("/:id", tagController.UpdateTag)
+	tag.Delete("/:id", tagController.DeleteTag)
+
+	anggaran := api.Group("/anggaran", helper.JWTAuthMiddleware(jwtKeys, tokenRevocationRepo), verifiedEmail)
 	anggaran.Get("/", anggaranController.GetAnggaranList)
 	anggaran.Get("/:kantong_id", anggaranController.GetAnggaranDetail)
 	anggaran.Post("/penyesuaian", anggaranController.CreatePenyesuaianAnggaran)
 
-	laporan := api.Group("/laporan", helper.JWTAuthMiddleware(cfg.JWT.Secret))
+	laporan := api.Group("/laporan", helper.AuthMiddleware(jwtKeys, tokenRevocationRepo, apiKeyUsecase, domain.APIKeyResourceLaporan))
 	laporan.Get("/ringkasan", laporanController.GetRingkasanLaporan)
 	laporan.Get("/statistik/tahunan", laporanController.GetStatistikTahunan)
 	laporan.Get("/statistik/kantong-bulanan", laporanController.GetStatistikKantongBulanan)
 	laporan.Get("/statistik/top-kantong", laporanController.GetTopKantongPengeluaran)
 	laporan.Get("/statistik/kantong-periode", laporanController.GetStatistikKantongPeriode)
 	laporan.Get("/pengeluaran-kantong-detail", laporanController.GetPengeluaranKantongDetail)
+	laporan.Get("/pengeluaran-tag", laporanController.GetPengeluaranPerTag)
 	laporan.Get("/tren/bulanan", laporanController.GetTrenBulanan)
 	laporan.Get("/perbandingan/kantong", laporanController.GetPerbandinganKantong)
 	laporan.Get("/perbandingan/kantong/detail", laporanController.GetDetailPerbandinganKantong)
 
-	subscriptionPlan := api.Group("/subscription-plans", helper.JWTAuthMiddleware(cfg.JWT.Secret))
-	subscriptionPlan.Get("/", subscriptionPlanController.GetAll)
-	subscriptionPlan.Get("/:id", subscriptionPlanController.GetByID)
-	subscriptionPlan.Post("/", subscriptionPlanController.Create)
-	subscriptionPlan.Put("/:id", subscriptionPlanController.Update)
-	subscriptionPlan.Patch("/:id", subscriptionPlanController.Patch)
-	subscriptionPlan.Delete("/:id", subscriptionPlanController.Delete)
-
-	permission := api.Group("/permission", helper.JWTAuthMiddleware(cfg.JWT.Secret))
-	permission.Get("/", permissionController.GetPermissionList)
-	permission.Get("/:id", permissionController.GetPermissionByID)
-	permission.Post("/", permissionController.CreatePermission)
-	permission.Put("/:id", permissionController.UpdatePermission)
-	permission.Delete("/:id", permissionController.DeletePermission)
-
-	role := api.Group("/role", helper.JWTAuthMiddleware(cfg.JWT.Secret))
-	role.Get("/", roleController.GetRoleList)
-	role.Get("/:id", roleController.GetRoleByID)
-	role.Post("/", roleController.CreateRole)
-	role.Put("/:id", roleController.UpdateRole)
-	role.Delete("/:id", roleController.DeleteRole)
-	role.Get("/:id/permissions", roleController.GetRolePermissions)
-
-	userSubscription := api.Group("/user-subscriptions", helper.JWTAuthMiddleware(cfg.JWT.Secret))
-	userSubscription.Get("/", userSubscriptionController.GetAll)
-	userSubscription.Get("/statistics", userSubscriptionController.GetStatistics)
-	userSubscription.Get("/:id", userSubscriptionController.GetByID)
-	userSubscription.Patch("/:id", userSubscriptionController.UpdateStatus)
-	userSubscription.Patch("/:id/payment-method", userSubscriptionController.UpdatePaymentMethod)
-
-	invoice := api.Group("/invoice", helper.JWTAuthMiddleware(cfg.JWT.Secret))
-	invoice.Get("/", invoiceController.GetAll)
-	invoice.Get("/statistics", invoiceController.GetStatistics)
-	invoice.Get("/:invoice_id", invoiceController.GetByID)
-	invoice.Patch("/:invoice_id", invoiceController.UpdateStatus)
+	subscriptionPlan := api.Group("/subscription-plans", helper.JWTAuthMiddleware(jwtKeys, tokenRevocationRepo))
+	subscriptionPlan.Get("/", helper.RequirePermission(roleRepo, domain.PermissionSubscriptionPlanRead), subscriptionPlanController.GetAll)
+	subscriptionPlan.Get("/:id", helper.RequirePermission(roleRepo, domain.PermissionSubscriptionPlanRead), subscriptionPlanController.GetByID)
+	subscriptionPlan.Post("/", helper.RequirePermission(roleRepo, domain.PermissionSubscriptionPlanCreate), subscriptionPlanController.Create)
+	subscriptionPlan.Put("/:id", helper.RequirePermission(roleRepo, domain.PermissionSubscriptionPlanUpdate), subscriptionPlanController.Update)
+	subscriptionPlan.Patch("/:id", helper.RequirePermission(roleRepo, domain.PermissionSubscriptionPlanUpdate), subscriptionPlanController.Patch)
+	subscriptionPlan.Delete("/:id", helper.RequirePermission(roleRepo, domain.PermissionSubscriptionPlanDelete), subscriptionPlanController.Delete)
+
+	permission := api.Group("/permission", helper.JWTAuthMiddleware(jwtKeys, tokenRevocationRepo))
+	permission.Get("/", helper.RequirePermission(roleRepo, domain.PermissionPermissionRead), permissionController.GetPermissionList)
+	permission.Get("/:id", helper.RequirePermission(roleRepo, domain.PermissionPermissionRead), permissionController.GetPermissionByID)
+	permission.Post("/", helper.RequirePermission(roleRepo, domain.PermissionPermissionCreate), permissionController.CreatePermission)
+	permission.Put("/:id", helper.RequirePermission(roleRepo, domain.PermissionPermissionUpdate), permissionController.UpdatePermission)
+	permission.Delete("/:id", helper.RequirePermission(roleRepo, domain.PermissionPermissionDelete), permissionController.DeletePermission)
+
+	role := api.Group("/role", helper.JWTAuthMiddleware(jwtKeys, tokenRevocationRepo))
+	role.Get("/", helper.RequirePermission(roleRepo, domain.PermissionRoleRead), roleController.GetRoleList)
+	role.Get("/:id", helper.RequirePermission(roleRepo, domain.PermissionRoleRead), roleController.GetRoleByID)
+	role.Post("/", helper.RequirePermission(roleRepo, domain.PermissionRoleCreate), roleController.CreateRole)
+	role.Put("/:id", helper.RequirePermission(roleRepo, domain.PermissionRoleUpdate), roleController.UpdateRole)
+	role.Delete("/:id", helper.RequirePermission(roleRepo, domain.PermissionRoleDelete), roleController.DeleteRole)
+	role.Get("/:id/permissions", helper.RequirePermission(roleRepo, domain.PermissionRoleRead), roleController.GetRolePermissions)
+
+	userSubscription := api.Group("/user-subscriptions", helper.JWTAuthMiddleware(jwtKeys, tokenRevocationRepo))
+	userSubscription.Get("/", helper.RequirePermission(roleRepo, domain.PermissionUserSubscriptionRead), userSubscriptionController.GetAll)
+	userSubscription.Get("/statistics", helper.RequirePermission(roleRepo, domain.PermissionUserSubscriptionRead), userSubscriptionController.GetStatistics)
+	userSubscription.Get("/:id", helper.RequirePermission(roleRepo, domain.PermissionUserSubscriptionRead), userSubscriptionController.GetByID)
+	userSubscription.Patch("/:id", helper.RequirePermission(roleRepo, domain.PermissionUserSubscriptionUpdate), userSubscriptionController.UpdateStatus)
+	userSubscription.Patch("/:id/payment-method", helper.RequirePermission(roleRepo, domain.PermissionUserSubscriptionUpdate), userSubscriptionController.UpdatePaymentMethod)
+
+	invoice := api.Group("/invoice", helper.JWTAuthMiddleware(jwtKeys, tokenRevocationRepo))
+	invoice.Get("/", helper.RequirePermission(roleRepo, domain.PermissionInvoiceRead), invoiceController.GetAll)
+	invoice.Get("/statistics", helper.RequirePermission(roleRepo, domain.PermissionInvoiceRead), invoiceController.GetStatistics)
+	invoice.Get("/:invoice_id", helper.RequirePermission(roleRepo, domain.PermissionInvoiceRead), invoiceController.GetByID)
+	invoice.Patch("/:invoice_id", helper.RequirePermission(roleRepo, domain.PermissionInvoiceUpdate), invoiceController.UpdateStatus)
+
+	adminUser := api.Group("/admin/users", helper.JWTAuthMiddleware(jwtKeys, tokenRevocationRepo))
+	adminUser.Get("/", helper.RequirePermission(roleRepo, domain.PermissionUserRead), adminUserController.GetAll)
+	adminUser.Get("/:id", helper.RequirePermission(roleRepo, domain.PermissionUserRead), adminUserController.GetByID)
+	adminUser.Patch("/:id/status", helper.RequirePermission(roleRepo, domain.PermissionUserUpdate), adminUserController.UpdateStatus)
+	adminUser.Put("/:id/role", helper.RequirePermission(roleRepo, domain.PermissionUserUpdate), adminUserController.AssignRole)
+	adminUser.Post("/:id/logout", helper.RequirePermission(roleRepo, domain.PermissionUserUpdate), adminUserController.ForceLogout)
 
 	monitoring := api.Group("/monitoring")
 	monitoring.Get("/health", healthController.ComprehensiveHealthCheck)
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AdminUserController struct {
+	adminUserUsecase usecase.AdminUserUsecase
+}
+
+func NewAdminUserController(adminUserUsecase usecase.AdminUserUsecase) *AdminUserController {
+	return &AdminUserController{
+		adminUserUsecase: adminUserUsecase,
+	}
+}
+
+func (ctrl *AdminUserController) GetAll(c *fiber.Ctx) error {
+	var req domain.AdminUserListRequest
+
+	if err := c.QueryParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format query parameter tidak valid", nil)
+	}
+
+	req.SetDefaults()
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	users, meta, err := ctrl.adminUserUsecase.GetAll(&req)
+	if err != nil {
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendPaginatedResponse(c, fiber.StatusOK, "Daftar pengguna berhasil diambil", users, *meta)
+}
+
+func (ctrl *AdminUserController) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "ID pengguna tidak valid", nil)
+	}
+
+	user, err := ctrl.adminUserUsecase.GetByID(uint(id))
+	if err != nil {
+		if err.Error() == "pengguna tidak ditemukan" {
+			return helper.SendNotFoundResponse(c, err.Error())
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Detail pengguna berhasil diambil", user)
+}
+
+func (ctrl *AdminUserController) UpdateStatus(c *fiber.Ctx) error {
+	adminID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "ID pengguna tidak valid", nil)
+	}
+
+	var req domain.UpdateUserStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format data tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	user, err := ctrl.adminUserUsecase.UpdateStatus(adminID, uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "pengguna tidak ditemukan":
+			return helper.SendNotFoundResponse(c, err.Error())
+		case "tidak dapat menonaktifkan akun sendiri":
+			return helper.SendErrorResponse(c, fiber.StatusConflict, err.Error(), nil)
+		default:
+			return helper.SendInternalServerErrorResponse(c)
+		}
+	}
+
+	if user.IsActive {
+		return helper.SendSuccessResponse(c, fiber.StatusOK, "Pengguna berhasil diaktifkan", user)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Pengguna berhasil dinonaktifkan", user)
+}
+
+func (ctrl *AdminUserController) ForceLogout(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "ID pengguna tidak valid", nil)
+	}
+
+	if err := ctrl.adminUserUsecase.ForceLogout(uint(id)); err != nil {
+		if err.Error() == "pengguna tidak ditemukan" {
+			return helper.SendNotFoundResponse(c, err.Error())
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Semua sesi pengguna berhasil diakhiri", nil)
+}
+
+func (ctrl *AdminUserController) AssignRole(c *fiber.Ctx) error {
+	adminID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "ID pengguna tidak valid", nil)
+	}
+
+	var req domain.AssignUserRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format data tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	user, err := ctrl.adminUserUsecase.AssignRole(adminID, uint(id), &req)
+	if err != nil {
+		switch err.Error() {
+		case "pengguna tidak ditemukan", "role tidak ditemukan":
+			return helper.SendNotFoundResponse(c, err.Error())
+		case "role tidak aktif":
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+		case "tidak dapat mengubah role akun sendiri":
+			return helper.SendErrorResponse(c, fiber.StatusConflict, err.Error(), nil)
+		default:
+			return helper.SendInternalServerErrorResponse(c)
+		}
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Role pengguna berhasil diubah", user)
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type APIKeyController struct {
+	apiKeyUsecase usecase.APIKeyUsecase
+}
+
+func NewAPIKeyController(apiKeyUsecase usecase.APIKeyUsecase) *APIKeyController {
+	return &APIKeyController{
+		apiKeyUsecase: apiKeyUsecase,
+	}
+}
+
+func (ctrl *APIKeyController) GetAll(c *fiber.Ctx) error {
+	userID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	apiKeys, err := ctrl.apiKeyUsecase.GetAll(userID)
+	if err != nil {
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Daftar api key berhasil diambil", apiKeys)
+}
+
+func (ctrl *APIKeyController) Create(c *fiber.Ctx) error {
+	userID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	var req domain.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format data tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	apiKey, err := ctrl.apiKeyUsecase.Create(userID, &req)
+	if err != nil {
+		switch err.Error() {
+		case "tanggal kedaluwarsa harus di masa depan":
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+		case "jumlah api key sudah mencapai batas maksimal":
+			return helper.SendErrorResponse(c, fiber.StatusConflict, err.Error(), nil)
+		default:
+			return helper.SendInternalServerErrorResponse(c)
+		}
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusCreated, "Api key berhasil dibuat, simpan key ini karena tidak akan ditampilkan lagi", apiKey)
+}
+
+func (ctrl *APIKeyController) Delete(c *fiber.Ctx) error {
+	userID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "ID api key tidak valid", nil)
+	}
+
+	if err := ctrl.apiKeyUsecase.Delete(userID, uint(id)); err != nil {
+		if err.Error() == "api key tidak ditemukan" {
+			return helper.SendNotFoundResponse(c, err.Error())
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Api key berhasil dihapus", nil)
+}
@@ -28,7 +28,7 @@ func (ctrl *AuthController) Register(c *fiber.Ctx) error {
 		return helper.SendValidationErrorResponse(c, validationErrors)
 	}
 
-	result, err := ctrl.authUsecase.Register(req)
+	result, err := ctrl.authUsecase.Register(req, helper.GetSessionMeta(c))
 	if err != nil {
 		if err.Error() == "email sudah terdaftar" {
 			return helper.SendErrorResponse(c, fiber.StatusConflict, err.Error(), nil)
@@ -36,6 +36,10 @@ func (ctrl *AuthController) Register(c *fiber.Ctx) error {
 		return helper.SendInternalServerErrorResponse(c)
 	}
 
+	if result.EmailVerificationRequired && result.AccessToken == "" {
+		return helper.SendSuccessResponse(c, fiber.StatusCreated, "Registrasi berhasil, silakan verifikasi email Anda sebelum login", result)
+	}
+
 	return helper.SendSuccessResponse(c, fiber.StatusCreated, "Registrasi berhasil", result)
 }
 
@@ -49,11 +53,40 @@ func (ctrl *AuthController) Login(c *fiber.Ctx) error {
 		return helper.SendValidationErrorResponse(c, validationErrors)
 	}
 
-	result, err := ctrl.authUsecase.Login(req)
+	result, err := ctrl.authUsecase.Login(req, helper.GetSessionMeta(c))
 	if err != nil {
 		if err.Error() == "email atau password salah" {
 			return helper.SendErrorResponse(c, fiber.StatusUnauthorized, err.Error(), nil)
 		}
+		if err.Error() == "email belum diverifikasi" || err.Error() == "akun dinonaktifkan" {
+			return helper.SendErrorResponse(c, fiber.StatusForbidden, err.Error(), nil)
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	if result.MFARequired {
+		return helper.SendSuccessResponse(c, fiber.StatusOK, "Verifikasi 2FA diperlukan", result)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Login berhasil", result)
+}
+
+func (ctrl *AuthController) VerifyMFA(c *fiber.Ctx) error {
+	var req domain.MFAVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := ctrl.authUsecase.VerifyMFA(req, helper.GetSessionMeta(c))
+	if err != nil {
+		switch err.Error() {
+		case "token 2FA tidak valid atau sudah expired", "kode 2FA tidak valid":
+			return helper.SendErrorResponse(c, fiber.StatusUnauthorized, err.Error(), nil)
+		}
 		return helper.SendInternalServerErrorResponse(c)
 	}
 
@@ -70,11 +103,14 @@ func (ctrl *AuthController) RefreshToken(c *fiber.Ctx) error {
 		return helper.SendValidationErrorResponse(c, validationErrors)
 	}
 
-	result, err := ctrl.authUsecase.RefreshToken(req)
+	result, err := ctrl.authUsecase.RefreshToken(req, helper.GetSessionMeta(c))
 	if err != nil {
 		if err.Error() == "refresh token tidak valid atau sudah expired" {
 			return helper.SendErrorResponse(c, fiber.StatusUnauthorized, err.Error(), nil)
 		}
+		if err.Error() == "akun dinonaktifkan" {
+			return helper.SendErrorResponse(c, fiber.StatusForbidden, err.Error(), nil)
+		}
 		return helper.SendInternalServerErrorResponse(c)
 	}
 
@@ -123,13 +159,59 @@ func (ctrl *AuthController) ConfirmResetPassword(c *fiber.Ctx) error {
 	return helper.SendSuccessResponse(c, fiber.StatusOK, "Password berhasil direset", nil)
 }
 
+func (ctrl *AuthController) VerifyEmail(c *fiber.Ctx) error {
+	var req domain.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	err := ctrl.authUsecase.VerifyEmail(req)
+	if err != nil {
+		if err.Error() == "token verifikasi email tidak valid atau sudah expired" {
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Token verifikasi email tidak valid atau sudah expired", nil)
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Email berhasil diverifikasi", nil)
+}
+
+func (ctrl *AuthController) ResendVerification(c *fiber.Ctx) error {
+	var req domain.ResendVerificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	err := ctrl.authUsecase.ResendVerification(req)
+	if err != nil {
+		switch err.Error() {
+		case "email tidak ditemukan":
+			return helper.SendNotFoundResponse(c, err.Error())
+		case "email sudah diverifikasi":
+			return helper.SendErrorResponse(c, fiber.StatusConflict, err.Error(), nil)
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Link verifikasi telah dikirim ke email Anda", nil)
+}
+
 func (ctrl *AuthController) Logout(c *fiber.Ctx) error {
 	token := c.Get("X-Refresh-Token")
 	if token == "" {
 		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Refresh token diperlukan", nil)
 	}
 
-	err := ctrl.authUsecase.Logout(token)
+	accessTokenID, accessTokenExpiresAt := helper.GetAccessTokenFromContext(c)
+	err := ctrl.authUsecase.Logout(token, accessTokenID, accessTokenExpiresAt)
 	if err != nil {
 		return helper.SendInternalServerErrorResponse(c)
 	}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"fiber-boiler-plate/internal/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JWKSController struct {
+	keys *helper.JWTKeySet
+}
+
+func NewJWKSController(keys *helper.JWTKeySet) *JWKSController {
+	return &JWKSController{
+		keys: keys,
+	}
+}
+
+func (ctrl *JWKSController) GetJWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.Status(fiber.StatusOK).JSON(ctrl.keys.JWKS())
+}
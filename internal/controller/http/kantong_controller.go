@@ -181,7 +181,8 @@ func (c *KantongController) DeleteKantong(ctx *fiber.Ctx) error {
 		if err.Error() == "kantong tidak ditemukan" {
 			return helper.SendNotFoundResponse(ctx, err.Error())
 		}
-		if err.Error() == "kantong tidak dapat dihapus karena masih memiliki saldo" {
+		if err.Error() == "kantong tidak dapat dihapus karena masih memiliki saldo" ||
+			err.Error() == "kantong tidak dapat dihapus karena masih dipakai pada rincian transaksi" {
 			return helper.SendErrorResponse(ctx, 409, err.Error(), nil)
 		}
 		return helper.SendInternalServerErrorResponse(ctx)
@@ -220,3 +221,92 @@ func (c *KantongController) TransferKantong(ctx *fiber.Ctx) error {
 
 	return helper.SendSuccessResponse(ctx, result.Code, result.Message, result.Data)
 }
+
+func (c *KantongController) GetTransferList(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(uint)
+
+	req := domain.NewTransferListRequest()
+
+	if kantongID := ctx.Query("kantong_id"); kantongID != "" {
+		req.KantongID = &kantongID
+	}
+
+	if tanggalMulai := ctx.Query("tanggal_mulai"); tanggalMulai != "" {
+		req.TanggalMulai = &tanggalMulai
+	}
+
+	if tanggalSelesai := ctx.Query("tanggal_selesai"); tanggalSelesai != "" {
+		req.TanggalSelesai = &tanggalSelesai
+	}
+
+	if pageStr := ctx.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			req.Page = page
+		}
+	}
+
+	if perPageStr := ctx.Query("per_page"); perPageStr != "" {
+		if perPage, err := strconv.Atoi(perPageStr); err == nil && perPage > 0 {
+			req.PerPage = perPage
+		}
+	}
+
+	validationErrors := helper.ValidateStruct(req)
+	if len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(ctx, validationErrors)
+	}
+
+	transfers, meta, err := c.kantongUsecase.GetTransferList(userID, req)
+	if err != nil {
+		return helper.SendInternalServerErrorResponse(ctx)
+	}
+
+	return helper.SendPaginatedResponse(ctx, 200, "Riwayat transfer berhasil diambil", transfers, *meta)
+}
+
+func (c *KantongController) GetTransferByID(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(uint)
+	id := ctx.Params("id")
+
+	transfer, err := c.kantongUsecase.GetTransferByID(id, userID)
+	if err != nil {
+		if err.Error() == "transfer tidak ditemukan" {
+			return helper.SendNotFoundResponse(ctx, err.Error())
+		}
+		return helper.SendInternalServerErrorResponse(ctx)
+	}
+
+	return helper.SendSuccessResponse(ctx, 200, "Detail transfer berhasil diambil", transfer)
+}
+
+func (c *KantongController) ReverseTransfer(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(uint)
+	id := ctx.Params("id")
+
+	var req domain.ReverseTransferRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return helper.SendErrorResponse(ctx, fiber.StatusBadRequest, "Format request tidak valid", nil)
+		}
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(ctx, validationErrors)
+	}
+
+	result, err := c.kantongUsecase.ReverseTransfer(id, &req, userID)
+	if err != nil {
+		switch err.Error() {
+		case "transfer tidak ditemukan", "kantong tidak ditemukan":
+			return helper.SendNotFoundResponse(ctx, err.Error())
+		case "transfer sudah dibatalkan", "transfer pembatalan tidak dapat dibatalkan":
+			return helper.SendErrorResponse(ctx, fiber.StatusConflict, err.Error(), nil)
+		case "saldo kantong tujuan tidak mencukupi untuk membatalkan transfer":
+			return helper.SendErrorResponse(ctx, fiber.StatusBadRequest, err.Error(), nil)
+		default:
+			return helper.SendInternalServerErrorResponse(ctx)
+		}
+	}
+
+	return helper.SendSuccessResponse(ctx, fiber.StatusOK, "Transfer berhasil dibatalkan", result)
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase"
+	"mime"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LampiranTransaksiController struct {
+	lampiranUsecase usecase.LampiranTransaksiUsecase
+}
+
+func NewLampiranTransaksiController(lampiranUsecase usecase.LampiranTransaksiUsecase) *LampiranTransaksiController {
+	return &LampiranTransaksiController{
+		lampiranUsecase: lampiranUsecase,
+	}
+}
+
+func (ctrl *LampiranTransaksiController) GetLampiranList(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	result, err := ctrl.lampiranUsecase.GetLampiranList(c.Params("id"), userID)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Daftar lampiran berhasil diambil", result)
+}
+
+func (ctrl *LampiranTransaksiController) UploadLampiran(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "File lampiran wajib diunggah", nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "File lampiran tidak dapat dibaca", nil)
+	}
+	defer file.Close()
+
+	result, err := ctrl.lampiranUsecase.UploadLampiran(c.Params("id"), userID, fileHeader.Filename, fileHeader.Size, file)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusCreated, "Lampiran berhasil diunggah", result)
+}
+
+func (ctrl *LampiranTransaksiController) DownloadLampiran(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	lampiran, reader, err := ctrl.lampiranUsecase.DownloadLampiran(c.Params("lampiran_id"), c.Params("id"), userID)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, lampiran.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": lampiran.NamaFile}))
+	return c.SendStream(reader, int(lampiran.Ukuran))
+}
+
+func (ctrl *LampiranTransaksiController) DeleteLampiran(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	if err := ctrl.lampiranUsecase.DeleteLampiran(c.Params("lampiran_id"), c.Params("id"), userID); err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Lampiran berhasil dihapus", nil)
+}
+
+func (ctrl *LampiranTransaksiController) handleError(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "transaksi tidak ditemukan", "lampiran tidak ditemukan":
+		return helper.SendErrorResponse(c, fiber.StatusNotFound, err.Error(), nil)
+	case "file lampiran kosong", "jumlah lampiran melebihi batas":
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+	case "ukuran file melebihi batas":
+		return helper.SendErrorResponse(c, fiber.StatusRequestEntityTooLarge, err.Error(), nil)
+	case "tipe file tidak didukung":
+		return helper.SendErrorResponse(c, fiber.StatusUnsupportedMediaType, err.Error(), nil)
+	default:
+		return helper.SendInternalServerErrorResponse(c)
+	}
+}
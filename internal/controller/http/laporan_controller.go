@@ -236,3 +236,27 @@ func (ctrl *LaporanController) GetDetailPerbandinganKantong(c *fiber.Ctx) error
 
 	return helper.SendSuccessResponse(c, fiber.StatusOK, response.Message, response.Data)
 }
+
+func (ctrl *LaporanController) GetPengeluaranPerTag(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	req := &domain.PengeluaranPerTagRequest{}
+
+	if tanggalMulai := c.Query("tanggal_mulai"); tanggalMulai != "" {
+		req.TanggalMulai = &tanggalMulai
+	}
+	if tanggalSelesai := c.Query("tanggal_selesai"); tanggalSelesai != "" {
+		req.TanggalSelesai = &tanggalSelesai
+	}
+
+	if err := helper.ValidateStruct(req); err != nil {
+		return helper.SendValidationErrorResponse(c, err)
+	}
+
+	response, err := ctrl.laporanUsecase.GetPengeluaranPerTag(userID, req)
+	if err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusInternalServerError, "Terjadi kesalahan pada server", err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, response.Message, response.Data)
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MFAController struct {
+	mfaUsecase usecase.MFAUsecase
+}
+
+func NewMFAController(mfaUsecase usecase.MFAUsecase) *MFAController {
+	return &MFAController{
+		mfaUsecase: mfaUsecase,
+	}
+}
+
+func (ctrl *MFAController) Setup(c *fiber.Ctx) error {
+	userID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	result, err := ctrl.mfaUsecase.Setup(userID)
+	if err != nil {
+		switch err.Error() {
+		case "user tidak ditemukan":
+			return helper.SendNotFoundResponse(c, err.Error())
+		case "2FA sudah aktif":
+			return helper.SendErrorResponse(c, fiber.StatusConflict, err.Error(), nil)
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Silakan pindai QR code dan konfirmasi kode 2FA", result)
+}
+
+func (ctrl *MFAController) Confirm(c *fiber.Ctx) error {
+	userID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	var req domain.MFAConfirmRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := ctrl.mfaUsecase.Confirm(userID, req)
+	if err != nil {
+		switch err.Error() {
+		case "2FA belum disiapkan", "kode 2FA tidak valid":
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+		case "2FA sudah aktif":
+			return helper.SendErrorResponse(c, fiber.StatusConflict, err.Error(), nil)
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "2FA berhasil diaktifkan, simpan recovery code Anda", result)
+}
+
+func (ctrl *MFAController) Disable(c *fiber.Ctx) error {
+	userID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	var req domain.MFADisableRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	err = ctrl.mfaUsecase.Disable(userID, req)
+	if err != nil {
+		switch err.Error() {
+		case "user tidak ditemukan":
+			return helper.SendNotFoundResponse(c, err.Error())
+		case "password salah", "kode 2FA tidak valid":
+			return helper.SendErrorResponse(c, fiber.StatusUnauthorized, err.Error(), nil)
+		case "2FA belum aktif":
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "2FA berhasil dinonaktifkan", nil)
+}
+
+func (ctrl *MFAController) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	userID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	var req domain.MFARegenerateRecoveryCodesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := ctrl.mfaUsecase.RegenerateRecoveryCodes(userID, req)
+	if err != nil {
+		switch err.Error() {
+		case "kode 2FA tidak valid":
+			return helper.SendErrorResponse(c, fiber.StatusUnauthorized, err.Error(), nil)
+		case "2FA belum aktif":
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Recovery code berhasil dibuat ulang", result)
+}
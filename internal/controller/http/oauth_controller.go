@@ -0,0 +1,76 @@
+package http
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OAuthController struct {
+	oauthUsecase usecase.OAuthUsecase
+}
+
+func NewOAuthController(oauthUsecase usecase.OAuthUsecase) *OAuthController {
+	return &OAuthController{
+		oauthUsecase: oauthUsecase,
+	}
+}
+
+func (ctrl *OAuthController) GetProviders(c *fiber.Ctx) error {
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Daftar provider oauth berhasil diambil", ctrl.oauthUsecase.GetProviders())
+}
+
+func (ctrl *OAuthController) Start(c *fiber.Ctx) error {
+	result, err := ctrl.oauthUsecase.Start(c.Params("provider"))
+	if err != nil {
+		switch err.Error() {
+		case "provider oauth tidak didukung":
+			return helper.SendNotFoundResponse(c, err.Error())
+		case "provider oauth tidak dapat dihubungi":
+			return helper.SendErrorResponse(c, fiber.StatusBadGateway, err.Error(), nil)
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Silakan lanjutkan otorisasi di provider", result)
+}
+
+func (ctrl *OAuthController) Callback(c *fiber.Ctx) error {
+	if c.Query("error") != "" {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Otorisasi di provider dibatalkan atau gagal", nil)
+	}
+
+	var req domain.OAuthCallbackRequest
+	if err := c.QueryParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format query parameter tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := ctrl.oauthUsecase.Callback(c.Params("provider"), &req, helper.GetSessionMeta(c))
+	if err != nil {
+		switch err.Error() {
+		case "provider oauth tidak didukung":
+			return helper.SendNotFoundResponse(c, err.Error())
+		case "state oauth tidak valid atau sudah kedaluwarsa":
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+		case "gagal memverifikasi akun provider":
+			return helper.SendErrorResponse(c, fiber.StatusUnauthorized, err.Error(), nil)
+		case "email akun provider belum terverifikasi", "email belum diverifikasi", "akun dinonaktifkan":
+			return helper.SendErrorResponse(c, fiber.StatusForbidden, err.Error(), nil)
+		case "email sudah terdaftar dan belum diverifikasi, silakan login dengan password lalu verifikasi email":
+			return helper.SendErrorResponse(c, fiber.StatusConflict, err.Error(), nil)
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	if result.MFARequired {
+		return helper.SendSuccessResponse(c, fiber.StatusOK, "Verifikasi 2FA diperlukan", result)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Login berhasil", result)
+}
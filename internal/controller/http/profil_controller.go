@@ -60,3 +60,33 @@ func (ctrl *ProfilController) UpdateProfil(c *fiber.Ctx) error {
 
 	return helper.SendSuccessResponse(c, fiber.StatusOK, "Profil berhasil diperbarui", profil)
 }
+
+func (ctrl *ProfilController) ChangePassword(c *fiber.Ctx) error {
+	userID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	var req domain.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := ctrl.profilUsecase.ChangePassword(userID, helper.GetSessionIDFromToken(c), req)
+	if err != nil {
+		switch err.Error() {
+		case "profil tidak ditemukan":
+			return helper.SendNotFoundResponse(c, err.Error())
+		case "password saat ini salah", "password baru tidak boleh sama dengan password saat ini":
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+		default:
+			return helper.SendInternalServerErrorResponse(c)
+		}
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Password berhasil diubah, sesi di perangkat lain telah diakhiri", result)
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SessionController struct {
+	sessionUsecase usecase.SessionUsecase
+}
+
+func NewSessionController(sessionUsecase usecase.SessionUsecase) *SessionController {
+	return &SessionController{
+		sessionUsecase: sessionUsecase,
+	}
+}
+
+func (ctrl *SessionController) GetSessions(c *fiber.Ctx) error {
+	userID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	sessions, err := ctrl.sessionUsecase.GetSessions(userID, helper.GetSessionIDFromToken(c))
+	if err != nil {
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Daftar sesi berhasil diambil", sessions)
+}
+
+func (ctrl *SessionController) RevokeSession(c *fiber.Ctx) error {
+	userID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	sessionID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || sessionID == 0 {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "ID sesi tidak valid", nil)
+	}
+
+	err = ctrl.sessionUsecase.RevokeSession(userID, uint(sessionID))
+	if err != nil {
+		if err.Error() == "sesi tidak ditemukan" {
+			return helper.SendNotFoundResponse(c, err.Error())
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Sesi berhasil diakhiri", nil)
+}
+
+func (ctrl *SessionController) RevokeAllSessions(c *fiber.Ctx) error {
+	userID, err := helper.GetUserIDFromToken(c)
+	if err != nil {
+		return helper.SendUnauthorizedResponse(c)
+	}
+
+	err = ctrl.sessionUsecase.RevokeAllSessions(userID)
+	if err != nil {
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Berhasil keluar dari semua perangkat", nil)
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TagController struct {
+	tagUsecase usecase.TagUsecase
+}
+
+func NewTagController(tagUsecase usecase.TagUsecase) *TagController {
+	return &TagController{
+		tagUsecase: tagUsecase,
+	}
+}
+
+func (ctrl *TagController) GetTagList(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	req := domain.NewTagListRequest()
+
+	if search := c.Query("search"); search != "" {
+		req.Search = &search
+	}
+
+	if page, err := strconv.Atoi(c.Query("page", "1")); err == nil && page > 0 {
+		req.Page = page
+	}
+	if perPage, err := strconv.Atoi(c.Query("per_page", "10")); err == nil && perPage > 0 && perPage <= 100 {
+		req.PerPage = perPage
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, meta, err := ctrl.tagUsecase.GetTagList(userID, req)
+	if err != nil {
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendPaginatedResponse(c, fiber.StatusOK, "Daftar tag berhasil diambil", result, *meta)
+}
+
+func (ctrl *TagController) GetTagDetail(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	result, err := ctrl.tagUsecase.GetTagByID(c.Params("id"), userID)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Detail tag berhasil diambil", result)
+}
+
+func (ctrl *TagController) CreateTag(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	var req domain.CreateTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := ctrl.tagUsecase.CreateTag(userID, &req)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusCreated, "Tag berhasil dibuat", result)
+}
+
+func (ctrl *TagController) UpdateTag(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	var req domain.UpdateTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := ctrl.tagUsecase.UpdateTag(c.Params("id"), userID, &req)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Tag berhasil diperbarui", result)
+}
+
+func (ctrl *TagController) DeleteTag(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	if err := ctrl.tagUsecase.DeleteTag(c.Params("id"), userID); err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Tag berhasil dihapus", nil)
+}
+
+func (ctrl *TagController) handleError(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "tag tidak ditemukan":
+		return helper.SendNotFoundResponse(c, err.Error())
+	case "nama tag tidak boleh kosong":
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+	case "nama tag sudah digunakan":
+		return helper.SendErrorResponse(c, fiber.StatusConflict, err.Error(), nil)
+	default:
+		return helper.SendInternalServerErrorResponse(c)
+	}
+}
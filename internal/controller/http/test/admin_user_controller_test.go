@@ -0,0 +1,184 @@
+package http_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/domain"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAdminUserUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAdminUserUsecase) GetAll(req *domain.AdminUserListRequest) ([]*domain.AdminUserResponse, *domain.PaginationMeta, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*domain.AdminUserResponse), args.Get(1).(*domain.PaginationMeta), args.Error(2)
+}
+
+func (m *MockAdminUserUsecase) GetByID(id uint) (*domain.AdminUserDetailResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AdminUserDetailResponse), args.Error(1)
+}
+
+func (m *MockAdminUserUsecase) UpdateStatus(adminID, id uint, req *domain.UpdateUserStatusRequest) (*domain.AdminUserResponse, error) {
+	args := m.Called(adminID, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AdminUserResponse), args.Error(1)
+}
+
+func (m *MockAdminUserUsecase) ForceLogout(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAdminUserUsecase) AssignRole(adminID, id uint, req *domain.AssignUserRoleRequest) (*domain.AdminUserResponse, error) {
+	args := m.Called(adminID, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AdminUserResponse), args.Error(1)
+}
+
+func setupAdminUserController() (*fiber.App, *MockAdminUserUsecase) {
+	app := fiber.New()
+	mockUsecase := new(MockAdminUserUsecase)
+	controller := http.NewAdminUserController(mockUsecase)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", uint(1))
+		return c.Next()
+	})
+
+	app.Get("/admin/users", controller.GetAll)
+	app.Get("/admin/users/:id", controller.GetByID)
+	app.Patch("/admin/users/:id/status", controller.UpdateStatus)
+	app.Put("/admin/users/:id/role", controller.AssignRole)
+	app.Post("/admin/users/:id/logout", controller.ForceLogout)
+
+	return app, mockUsecase
+}
+
+func TestAdminUserController_GetAll_Success(t *testing.T) {
+	app, mockUsecase := setupAdminUserController()
+
+	mockUsecase.On("GetAll", mock.MatchedBy(func(req *domain.AdminUserListRequest) bool {
+		return req.Search != nil && *req.Search == "budi" && req.Status != nil && *req.Status == "inactive"
+	})).Return([]*domain.AdminUserResponse{{ID: 2, Nama: "Budi"}}, &domain.PaginationMeta{CurrentPage: 1, TotalPages: 1, TotalRecords: 1, PerPage: 10}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/admin/users?search=budi&status=inactive", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAdminUserController_GetAll_InvalidStatus(t *testing.T) {
+	app, mockUsecase := setupAdminUserController()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/admin/users?status=banned", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "GetAll", mock.Anything)
+}
+
+func TestAdminUserController_GetByID_NotFound(t *testing.T) {
+	app, mockUsecase := setupAdminUserController()
+
+	mockUsecase.On("GetByID", uint(99)).Return(nil, errors.New("pengguna tidak ditemukan"))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/admin/users/99", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestAdminUserController_GetByID_InvalidID(t *testing.T) {
+	app, _ := setupAdminUserController()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/admin/users/abc", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAdminUserController_UpdateStatus_Deactivate(t *testing.T) {
+	app, mockUsecase := setupAdminUserController()
+
+	mockUsecase.On("UpdateStatus", uint(1), uint(2), mock.MatchedBy(func(req *domain.UpdateUserStatusRequest) bool {
+		return req.IsActive != nil && !*req.IsActive
+	})).Return(&domain.AdminUserResponse{ID: 2, IsActive: false}, nil)
+
+	req := httptest.NewRequest("PATCH", "/admin/users/2/status", strings.NewReader(`{"is_active":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAdminUserController_UpdateStatus_MissingField(t *testing.T) {
+	app, mockUsecase := setupAdminUserController()
+
+	req := httptest.NewRequest("PATCH", "/admin/users/2/status", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdminUserController_UpdateStatus_Self(t *testing.T) {
+	app, mockUsecase := setupAdminUserController()
+
+	mockUsecase.On("UpdateStatus", uint(1), uint(1), mock.Anything).Return(nil, errors.New("tidak dapat menonaktifkan akun sendiri"))
+
+	req := httptest.NewRequest("PATCH", "/admin/users/1/status", strings.NewReader(`{"is_active":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
+
+func TestAdminUserController_AssignRole_RoleNotFound(t *testing.T) {
+	app, mockUsecase := setupAdminUserController()
+
+	mockUsecase.On("AssignRole", uint(1), uint(2), mock.Anything).Return(nil, errors.New("role tidak ditemukan"))
+
+	req := httptest.NewRequest("PUT", "/admin/users/2/role", strings.NewReader(`{"role_id":"7d3f1c2a-8a43-4a5e-9f0e-3b8f1f2d4c11"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestAdminUserController_ForceLogout_Success(t *testing.T) {
+	app, mockUsecase := setupAdminUserController()
+
+	mockUsecase.On("ForceLogout", uint(2)).Return(nil)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/admin/users/2/logout", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
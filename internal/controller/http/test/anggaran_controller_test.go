@@ -44,6 +44,11 @@ func (m *MockAnggaranUsecase) UpdateAnggaranAfterTransaction(kantongID string, u
 	return args.Error(0)
 }
 
+func (m *MockAnggaranUsecase) RecalculateAnggaranBulan(kantongID string, userID uint, bulan, tahun int) error {
+	args := m.Called(kantongID, userID, bulan, tahun)
+	return args.Error(0)
+}
+
 func setupAnggaranTest() (*fiber.App, *MockAnggaranUsecase) {
 	app := fiber.New()
 	mockUsecase := &MockAnggaranUsecase{}
@@ -68,11 +73,11 @@ func TestGetAnggaranList_Success(t *testing.T) {
 		{
 			KantongID:   "550e8400-e29b-41d4-a716-446655440001",
 			NamaKantong: "Kantong Test",
-			Rencana:     &[]float64{1000000}[0],
-			CarryIn:     0,
-			Penyesuaian: 0,
-			Terpakai:    450000,
-			Sisa:        550000,
+			Rencana:     domain.MoneyPtr(domain.NewMoney(1000000)),
+			CarryIn:     domain.NewMoney(0),
+			Penyesuaian: domain.NewMoney(0),
+			Terpakai:    domain.NewMoney(450000),
+			Sisa:        domain.NewMoney(550000),
 			Progres:     45.0,
 			Bulan:       9,
 			Tahun:       2024,
@@ -107,14 +112,14 @@ func TestGetAnggaranDetail_Success(t *testing.T) {
 			IDKartu:  "K4N7G1",
 			Nama:     "Kantong Test",
 			Kategori: "Pengeluaran",
-			Saldo:    750000,
+			Saldo:    domain.NewMoney(750000),
 			Warna:    "Navy",
 		},
-		Rencana:        &[]float64{1000000}[0],
-		CarryIn:        0,
-		Penyesuaian:    0,
-		Terpakai:       450000,
-		Sisa:           550000,
+		Rencana:        domain.MoneyPtr(domain.NewMoney(1000000)),
+		CarryIn:        domain.NewMoney(0),
+		Penyesuaian:    domain.NewMoney(0),
+		Terpakai:       domain.NewMoney(450000),
+		Sisa:           domain.NewMoney(550000),
 		Progres:        45.0,
 		StatistikBulan: []domain.StatistikHarian{},
 		Bulan:          9,
@@ -152,7 +157,7 @@ func TestCreatePenyesuaianAnggaran_Success(t *testing.T) {
 	reqBody := domain.PenyesuaianAnggaranRequest{
 		KantongID: "550e8400-e29b-41d4-a716-446655440001",
 		Jenis:     "kurangi",
-		Jumlah:    50000,
+		Jumlah:    domain.NewMoney(50000),
 		Bulan:     9,
 		Tahun:     2024,
 	}
@@ -160,11 +165,11 @@ func TestCreatePenyesuaianAnggaran_Success(t *testing.T) {
 	mockResponse := &domain.AnggaranResponse{
 		KantongID:   "550e8400-e29b-41d4-a716-446655440001",
 		NamaKantong: "Kantong Test",
-		Rencana:     &[]float64{1000000}[0],
-		CarryIn:     0,
-		Penyesuaian: -50000,
-		Terpakai:    450000,
-		Sisa:        500000,
+		Rencana:     domain.MoneyPtr(domain.NewMoney(1000000)),
+		CarryIn:     domain.NewMoney(0),
+		Penyesuaian: domain.NewMoney(-50000),
+		Terpakai:    domain.NewMoney(450000),
+		Sisa:        domain.NewMoney(500000),
 		Progres:     47.37,
 		Bulan:       9,
 		Tahun:       2024,
@@ -188,7 +193,7 @@ func TestCreatePenyesuaianAnggaran_ValidationError(t *testing.T) {
 	reqBody := domain.PenyesuaianAnggaranRequest{
 		KantongID: "",
 		Jenis:     "invalid",
-		Jumlah:    -100,
+		Jumlah:    domain.NewMoney(-100),
 		Bulan:     13,
 		Tahun:     2019,
 	}
@@ -209,7 +214,7 @@ func TestCreatePenyesuaianAnggaran_KantongNotFound(t *testing.T) {
 	reqBody := domain.PenyesuaianAnggaranRequest{
 		KantongID: "550e8400-e29b-41d4-a716-446655440001",
 		Jenis:     "tambah",
-		Jumlah:    50000,
+		Jumlah:    domain.NewMoney(50000),
 		Bulan:     9,
 		Tahun:     2024,
 	}
@@ -234,11 +239,11 @@ func TestGetAnggaranList_WithSearchAndSort(t *testing.T) {
 		{
 			KantongID:   "550e8400-e29b-41d4-a716-446655440001",
 			NamaKantong: "Kantong Belanja",
-			Rencana:     &[]float64{1000000}[0],
-			CarryIn:     0,
-			Penyesuaian: 0,
-			Terpakai:    450000,
-			Sisa:        550000,
+			Rencana:     domain.MoneyPtr(domain.NewMoney(1000000)),
+			CarryIn:     domain.NewMoney(0),
+			Penyesuaian: domain.NewMoney(0),
+			Terpakai:    domain.NewMoney(450000),
+			Sisa:        domain.NewMoney(550000),
 			Progres:     45.0,
 			Bulan:       9,
 			Tahun:       2024,
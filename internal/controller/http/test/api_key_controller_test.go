@@ -0,0 +1,141 @@
+package http_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/domain"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyUsecase) GetAll(userID uint) ([]*domain.APIKeyResponse, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.APIKeyResponse), args.Error(1)
+}
+
+func (m *MockAPIKeyUsecase) Create(userID uint, req *domain.CreateAPIKeyRequest) (*domain.CreateAPIKeyResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CreateAPIKeyResponse), args.Error(1)
+}
+
+func (m *MockAPIKeyUsecase) Delete(userID, id uint) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyUsecase) Authenticate(key string) (*domain.APIKey, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func setupAPIKeyController() (*fiber.App, *MockAPIKeyUsecase) {
+	app := fiber.New()
+	mockUsecase := new(MockAPIKeyUsecase)
+	controller := http.NewAPIKeyController(mockUsecase)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", uint(1))
+		return c.Next()
+	})
+
+	app.Get("/api-keys", controller.GetAll)
+	app.Post("/api-keys", controller.Create)
+	app.Delete("/api-keys/:id", controller.Delete)
+
+	return app, mockUsecase
+}
+
+func TestAPIKeyController_GetAll_Success(t *testing.T) {
+	app, mockUsecase := setupAPIKeyController()
+
+	mockUsecase.On("GetAll", uint(1)).Return([]*domain.APIKeyResponse{{ID: 1, Nama: "Script import", Prefix: "a1b2c3d4e5f6"}}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api-keys", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAPIKeyController_Create_Success(t *testing.T) {
+	app, mockUsecase := setupAPIKeyController()
+
+	mockUsecase.On("Create", uint(1), mock.MatchedBy(func(req *domain.CreateAPIKeyRequest) bool {
+		return req.Nama == "Script import" && len(req.Scopes) == 2 && req.ExpiresAt != nil
+	})).Return(&domain.CreateAPIKeyResponse{Key: "fbk_a1b2c3d4e5f6_secret"}, nil)
+
+	body := `{"nama":"Script import","scopes":["transaksi:write","laporan:read"],"expires_at":"2030-01-01T00:00:00Z"}`
+	req := httptest.NewRequest("POST", "/api-keys", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAPIKeyController_Create_InvalidScope(t *testing.T) {
+	app, mockUsecase := setupAPIKeyController()
+
+	req := httptest.NewRequest("POST", "/api-keys", strings.NewReader(`{"nama":"Script import","scopes":["anggaran:write"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyController_Create_MissingScopes(t *testing.T) {
+	app, mockUsecase := setupAPIKeyController()
+
+	req := httptest.NewRequest("POST", "/api-keys", strings.NewReader(`{"nama":"Script import","scopes":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyController_Create_LimitReached(t *testing.T) {
+	app, mockUsecase := setupAPIKeyController()
+
+	mockUsecase.On("Create", uint(1), mock.Anything).Return(nil, errors.New("jumlah api key sudah mencapai batas maksimal"))
+
+	req := httptest.NewRequest("POST", "/api-keys", strings.NewReader(`{"nama":"Script import","scopes":["laporan:read"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
+
+func TestAPIKeyController_Delete_NotFound(t *testing.T) {
+	app, mockUsecase := setupAPIKeyController()
+
+	mockUsecase.On("Delete", uint(1), uint(9)).Return(errors.New("api key tidak ditemukan"))
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/api-keys/9", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
@@ -8,6 +8,7 @@ import (
 	"fiber-boiler-plate/internal/domain"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -18,24 +19,40 @@ type MockAuthUsecase struct {
 	mock.Mock
 }
 
-func (m *MockAuthUsecase) Register(req domain.RegisterRequest) (*domain.AuthResponse, error) {
-	args := m.Called(req)
+func (m *MockAuthUsecase) Register(req domain.RegisterRequest, meta domain.SessionMeta) (*domain.AuthResponse, error) {
+	args := m.Called(req, meta)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.AuthResponse), args.Error(1)
 }
 
-func (m *MockAuthUsecase) Login(req domain.AuthRequest) (*domain.AuthResponse, error) {
-	args := m.Called(req)
+func (m *MockAuthUsecase) Login(req domain.AuthRequest, meta domain.SessionMeta) (*domain.AuthResponse, error) {
+	args := m.Called(req, meta)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.AuthResponse), args.Error(1)
 }
 
-func (m *MockAuthUsecase) RefreshToken(req domain.RefreshTokenRequest) (*domain.RefreshTokenResponse, error) {
-	args := m.Called(req)
+func (m *MockAuthUsecase) CompleteLogin(user *domain.User, meta domain.SessionMeta) (*domain.AuthResponse, error) {
+	args := m.Called(user, meta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthUsecase) VerifyMFA(req domain.MFAVerifyRequest, meta domain.SessionMeta) (*domain.AuthResponse, error) {
+	args := m.Called(req, meta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthUsecase) RefreshToken(req domain.RefreshTokenRequest, meta domain.SessionMeta) (*domain.RefreshTokenResponse, error) {
+	args := m.Called(req, meta)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -52,8 +69,18 @@ func (m *MockAuthUsecase) ConfirmResetPassword(req domain.NewPasswordRequest) er
 	return args.Error(0)
 }
 
-func (m *MockAuthUsecase) Logout(token string) error {
-	args := m.Called(token)
+func (m *MockAuthUsecase) VerifyEmail(req domain.VerifyEmailRequest) error {
+	args := m.Called(req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) ResendVerification(req domain.ResendVerificationRequest) error {
+	args := m.Called(req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUsecase) Logout(refreshToken, accessTokenID string, accessTokenExpiresAt time.Time) error {
+	args := m.Called(refreshToken, accessTokenID, accessTokenExpiresAt)
 	return args.Error(0)
 }
 
@@ -82,7 +109,7 @@ func TestAuthController_Register_Success(t *testing.T) {
 		ExpiresIn:    3600,
 	}
 
-	mockAuthUC.On("Register", reqBody).Return(expectedResponse, nil)
+	mockAuthUC.On("Register", reqBody, mock.AnythingOfType("domain.SessionMeta")).Return(expectedResponse, nil)
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/register", bytes.NewReader(bodyBytes))
@@ -109,7 +136,7 @@ func TestAuthController_Register_EmailAlreadyExists(t *testing.T) {
 		Password: "password123",
 	}
 
-	mockAuthUC.On("Register", reqBody).Return(nil, errors.New("email sudah terdaftar"))
+	mockAuthUC.On("Register", reqBody, mock.AnythingOfType("domain.SessionMeta")).Return(nil, errors.New("email sudah terdaftar"))
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/register", bytes.NewReader(bodyBytes))
@@ -146,7 +173,7 @@ func TestAuthController_Login_Success(t *testing.T) {
 		ExpiresIn:    3600,
 	}
 
-	mockAuthUC.On("Login", reqBody).Return(expectedResponse, nil)
+	mockAuthUC.On("Login", reqBody, mock.AnythingOfType("domain.SessionMeta")).Return(expectedResponse, nil)
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/login", bytes.NewReader(bodyBytes))
@@ -172,7 +199,7 @@ func TestAuthController_Login_InvalidCredentials(t *testing.T) {
 		Password: "wrongpassword",
 	}
 
-	mockAuthUC.On("Login", reqBody).Return(nil, errors.New("email atau password salah"))
+	mockAuthUC.On("Login", reqBody, mock.AnythingOfType("domain.SessionMeta")).Return(nil, errors.New("email atau password salah"))
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/login", bytes.NewReader(bodyBytes))
@@ -186,6 +213,58 @@ func TestAuthController_Login_InvalidCredentials(t *testing.T) {
 	mockAuthUC.AssertExpectations(t)
 }
 
+func TestAuthController_Login_EmailNotVerified(t *testing.T) {
+	mockAuthUC := new(MockAuthUsecase)
+	controller := http.NewAuthController(mockAuthUC)
+
+	app := fiber.New()
+	app.Post("/login", controller.Login)
+
+	reqBody := domain.AuthRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	}
+
+	mockAuthUC.On("Login", reqBody, mock.AnythingOfType("domain.SessionMeta")).Return(nil, errors.New("email belum diverifikasi"))
+
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/login", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+
+	mockAuthUC.AssertExpectations(t)
+}
+
+func TestAuthController_VerifyMFA_InvalidCode(t *testing.T) {
+	mockAuthUC := new(MockAuthUsecase)
+	controller := http.NewAuthController(mockAuthUC)
+
+	app := fiber.New()
+	app.Post("/login/mfa", controller.VerifyMFA)
+
+	reqBody := domain.MFAVerifyRequest{
+		MFAToken: "mfa_token",
+		Code:     "123456",
+	}
+
+	mockAuthUC.On("VerifyMFA", reqBody, mock.AnythingOfType("domain.SessionMeta")).Return(nil, errors.New("kode 2FA tidak valid"))
+
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/login/mfa", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	mockAuthUC.AssertExpectations(t)
+}
+
 func TestAuthController_RefreshToken_Success(t *testing.T) {
 	mockAuthUC := new(MockAuthUsecase)
 	controller := http.NewAuthController(mockAuthUC)
@@ -204,7 +283,7 @@ func TestAuthController_RefreshToken_Success(t *testing.T) {
 		ExpiresIn:    3600,
 	}
 
-	mockAuthUC.On("RefreshToken", reqBody).Return(expectedResponse, nil)
+	mockAuthUC.On("RefreshToken", reqBody, mock.AnythingOfType("domain.SessionMeta")).Return(expectedResponse, nil)
 
 	bodyBytes, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest("POST", "/refresh", bytes.NewReader(bodyBytes))
@@ -269,16 +348,91 @@ func TestAuthController_ConfirmResetPassword_Success(t *testing.T) {
 	mockAuthUC.AssertExpectations(t)
 }
 
+func TestAuthController_VerifyEmail_Success(t *testing.T) {
+	mockAuthUC := new(MockAuthUsecase)
+	controller := http.NewAuthController(mockAuthUC)
+
+	app := fiber.New()
+	app.Post("/verify-email", controller.VerifyEmail)
+
+	reqBody := domain.VerifyEmailRequest{Token: "valid_token"}
+
+	mockAuthUC.On("VerifyEmail", reqBody).Return(nil)
+
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/verify-email", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	mockAuthUC.AssertExpectations(t)
+}
+
+func TestAuthController_VerifyEmail_InvalidToken(t *testing.T) {
+	mockAuthUC := new(MockAuthUsecase)
+	controller := http.NewAuthController(mockAuthUC)
+
+	app := fiber.New()
+	app.Post("/verify-email", controller.VerifyEmail)
+
+	reqBody := domain.VerifyEmailRequest{Token: "expired_token"}
+
+	mockAuthUC.On("VerifyEmail", reqBody).Return(errors.New("token verifikasi email tidak valid atau sudah expired"))
+
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/verify-email", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	mockAuthUC.AssertExpectations(t)
+}
+
+func TestAuthController_ResendVerification_AlreadyVerified(t *testing.T) {
+	mockAuthUC := new(MockAuthUsecase)
+	controller := http.NewAuthController(mockAuthUC)
+
+	app := fiber.New()
+	app.Post("/resend-verification", controller.ResendVerification)
+
+	reqBody := domain.ResendVerificationRequest{Email: "test@example.com"}
+
+	mockAuthUC.On("ResendVerification", reqBody).Return(errors.New("email sudah diverifikasi"))
+
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/resend-verification", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	mockAuthUC.AssertExpectations(t)
+}
+
 func TestAuthController_Logout_Success(t *testing.T) {
 	mockAuthUC := new(MockAuthUsecase)
 	controller := http.NewAuthController(mockAuthUC)
 
+	expiresAt := time.Now().Add(time.Hour)
 	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("token_id", "access-jti")
+		c.Locals("token_expires_at", expiresAt)
+		return c.Next()
+	})
 	app.Post("/logout", controller.Logout)
 
 	refreshToken := "valid_refresh_token"
 
-	mockAuthUC.On("Logout", refreshToken).Return(nil)
+	mockAuthUC.On("Logout", refreshToken, "access-jti", expiresAt).Return(nil)
 
 	req := httptest.NewRequest("POST", "/logout", nil)
 	req.Header.Set("X-Refresh-Token", refreshToken)
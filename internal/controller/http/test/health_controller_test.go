@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fiber-boiler-plate/internal/controller/http"
 	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -37,6 +38,10 @@ func (m *MockHealthUsecase) GetApplicationStatus() *domain.ApplicationStatus {
 	return args.Get(0).(*domain.ApplicationStatus)
 }
 
+func (m *MockHealthUsecase) SetJobMetricsProvider(provider usecase.JobMetricsProvider) {
+	m.Called(provider)
+}
+
 func TestHealthController_BasicHealthCheck_Success(t *testing.T) {
 	mockUsecase := new(MockHealthUsecase)
 	controller := http.NewHealthController(mockUsecase)
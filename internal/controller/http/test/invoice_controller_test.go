@@ -76,7 +76,7 @@ func TestInvoiceController_GetAll_Success(t *testing.T) {
 			NamaUser:             "John Doe",
 			UserID:               userID,
 			UserEmail:            "john@example.com",
-			Jumlah:               99000,
+			Jumlah:               domain.NewMoney(99000),
 			Status:               "sukses",
 			DibayarPada:          &now,
 			MetodePembayaran:     stringPtr("Bank Transfer"),
@@ -185,7 +185,7 @@ func TestInvoiceController_GetByID_Success(t *testing.T) {
 		NamaUser:             "John Doe",
 		UserID:               userID,
 		UserEmail:            "john@example.com",
-		Jumlah:               99000,
+		Jumlah:               domain.NewMoney(99000),
 		Status:               "sukses",
 		DibayarPada:          &now,
 		MetodePembayaran:     stringPtr("Bank Transfer"),
@@ -311,7 +311,7 @@ func TestInvoiceController_UpdateStatus_Success(t *testing.T) {
 		NamaUser:             "John Doe",
 		UserID:               userID,
 		UserEmail:            "john@example.com",
-		Jumlah:               99000,
+		Jumlah:               domain.NewMoney(99000),
 		Status:               "sukses",
 		DibayarPada:          &now,
 		MetodePembayaran:     stringPtr("Bank Transfer"),
@@ -449,21 +449,21 @@ func TestInvoiceController_GetStatistics_Success(t *testing.T) {
 		TotalSukses:        85,
 		TotalGagal:         10,
 		TotalPending:       5,
-		TotalPendapatan:    8500000,
-		RataRataPembayaran: 100000,
+		TotalPendapatan:    domain.NewMoney(8500000),
+		RataRataPembayaran: domain.NewMoney(100000),
 		InvoiceBulanan: []domain.InvoiceStatsBulanan{
 			{
 				Bulan:           "Januari",
 				TotalInvoice:    100,
 				TotalSukses:     85,
-				TotalPendapatan: 8500000,
+				TotalPendapatan: domain.NewMoney(8500000),
 			},
 		},
 		TopSubscriptionPlans: []domain.TopSubscriptionPlan{
 			{
 				SubscriptionPlanNama: "PRO Monthly",
 				JumlahInvoice:        50,
-				TotalPendapatan:      5000000,
+				TotalPendapatan:      domain.NewMoney(5000000),
 			},
 		},
 	}
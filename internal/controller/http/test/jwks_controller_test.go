@@ -0,0 +1,29 @@
+package http_test
+
+import (
+	"encoding/json"
+	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/helper"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWKSController_GetJWKS_HS256PublishesNoKeys(t *testing.T) {
+	app := fiber.New()
+	controller := http.NewJWKSController(helper.NewHMACKeySet("test-secret"))
+	app.Get("/.well-known/jwks.json", controller.GetJWKS)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "public, max-age=300", resp.Header.Get("Cache-Control"))
+
+	var body helper.JWKS
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.NotNil(t, body.Keys)
+	assert.Empty(t, body.Keys)
+}
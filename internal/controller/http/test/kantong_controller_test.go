@@ -54,6 +54,21 @@ func (m *MockKantongUsecase) TransferKantong(req *domain.TransferKantongRequest,
 	return args.Get(0).(*domain.TransferKantongResponse), args.Error(1)
 }
 
+func (m *MockKantongUsecase) GetTransferList(userID uint, req *domain.TransferListRequest) ([]*domain.TransferResult, *domain.PaginationMeta, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).([]*domain.TransferResult), args.Get(1).(*domain.PaginationMeta), args.Error(2)
+}
+
+func (m *MockKantongUsecase) GetTransferByID(id string, userID uint) (*domain.TransferResult, error) {
+	args := m.Called(id, userID)
+	return args.Get(0).(*domain.TransferResult), args.Error(1)
+}
+
+func (m *MockKantongUsecase) ReverseTransfer(id string, req *domain.ReverseTransferRequest, userID uint) (*domain.TransferResult, error) {
+	args := m.Called(id, req, userID)
+	return args.Get(0).(*domain.TransferResult), args.Error(1)
+}
+
 func (m *MockKantongUsecase) SetAnggaranUsecase(anggaranUsecase usecase.AnggaranUsecase) {
 }
 
@@ -68,6 +83,9 @@ func setupKantongController() (*fiber.App, *MockKantongUsecase) {
 	})
 
 	app.Get("/kantong", controller.GetKantongList)
+	app.Get("/kantong/transfer", controller.GetTransferList)
+	app.Get("/kantong/transfer/:id", controller.GetTransferByID)
+	app.Post("/kantong/transfer/:id/reverse", controller.ReverseTransfer)
 	app.Get("/kantong/:id", controller.GetKantongByID)
 	app.Post("/kantong", controller.CreateKantong)
 	app.Put("/kantong/:id", controller.UpdateKantong)
@@ -86,7 +104,7 @@ func TestGetKantongList_Success(t *testing.T) {
 			IDKartu:  "ABC123",
 			Nama:     "Kantong Utama",
 			Kategori: "Pengeluaran",
-			Saldo:    100000,
+			Saldo:    domain.NewMoney(100000),
 			Warna:    "Navy",
 		},
 	}
@@ -115,7 +133,7 @@ func TestGetKantongByID_Success(t *testing.T) {
 		IDKartu:  "ABC123",
 		Nama:     "Kantong Utama",
 		Kategori: "Pengeluaran",
-		Saldo:    100000,
+		Saldo:    domain.NewMoney(100000),
 		Warna:    "Navy",
 	}
 
@@ -156,7 +174,7 @@ func TestCreateKantong_Success(t *testing.T) {
 	app, mockUsecase := setupKantongController()
 
 	deskripsi := "Deskripsi kantong baru"
-	saldo := 50000.0
+	saldo := domain.NewMoney(50000)
 
 	kantongRequest := domain.CreateKantongRequest{
 		Nama:      "Kantong Baru",
@@ -171,7 +189,7 @@ func TestCreateKantong_Success(t *testing.T) {
 		IDKartu:  "XYZ456",
 		Nama:     "Kantong Baru",
 		Kategori: "Pengeluaran",
-		Saldo:    50000,
+		Saldo:    domain.NewMoney(50000),
 		Warna:    "Navy",
 	}
 
@@ -190,7 +208,7 @@ func TestCreateKantong_DuplicateName(t *testing.T) {
 	app, mockUsecase := setupKantongController()
 
 	deskripsi := "Deskripsi kantong"
-	saldo := 50000.0
+	saldo := domain.NewMoney(50000)
 
 	kantongRequest := domain.CreateKantongRequest{
 		Nama:      "Kantong Existing",
@@ -225,7 +243,7 @@ func TestTransferKantong_Success(t *testing.T) {
 	transferRequest := domain.TransferKantongRequest{
 		KantongAsalID:   "550e8400-e29b-41d4-a716-446655440001",
 		KantongTujuanID: "550e8400-e29b-41d4-a716-446655440002",
-		Jumlah:          100000,
+		Jumlah:          domain.NewMoney(100000),
 		Catatan:         nil,
 	}
 
@@ -238,16 +256,16 @@ func TestTransferKantong_Success(t *testing.T) {
 			KantongAsal: domain.TransferKantongDetail{
 				ID:           "550e8400-e29b-41d4-a716-446655440001",
 				Nama:         "Kantong Transport",
-				SaldoSebelum: 500000,
-				SaldoSesudah: 400000,
+				SaldoSebelum: domain.NewMoney(500000),
+				SaldoSesudah: domain.NewMoney(400000),
 			},
 			KantongTujuan: domain.TransferKantongDetail{
 				ID:           "550e8400-e29b-41d4-a716-446655440002",
 				Nama:         "Kantong Darurat",
-				SaldoSebelum: 200000,
-				SaldoSesudah: 300000,
+				SaldoSebelum: domain.NewMoney(200000),
+				SaldoSesudah: domain.NewMoney(300000),
 			},
-			Jumlah:  100000,
+			Jumlah:  domain.NewMoney(100000),
 			Catatan: nil,
 		},
 	}
@@ -277,7 +295,7 @@ func TestTransferKantong_SameKantong(t *testing.T) {
 	transferRequest := domain.TransferKantongRequest{
 		KantongAsalID:   "550e8400-e29b-41d4-a716-446655440001",
 		KantongTujuanID: "550e8400-e29b-41d4-a716-446655440001",
-		Jumlah:          100000,
+		Jumlah:          domain.NewMoney(100000),
 		Catatan:         nil,
 	}
 
@@ -306,7 +324,7 @@ func TestTransferKantong_InsufficientBalance(t *testing.T) {
 	transferRequest := domain.TransferKantongRequest{
 		KantongAsalID:   "550e8400-e29b-41d4-a716-446655440001",
 		KantongTujuanID: "550e8400-e29b-41d4-a716-446655440002",
-		Jumlah:          1000000,
+		Jumlah:          domain.NewMoney(1000000),
 		Catatan:         nil,
 	}
 
@@ -320,3 +338,130 @@ func TestTransferKantong_InsufficientBalance(t *testing.T) {
 	assert.Equal(t, 400, resp.StatusCode)
 	mockUsecase.AssertExpectations(t)
 }
+
+func TestGetTransferList_Success(t *testing.T) {
+	app, mockUsecase := setupKantongController()
+
+	kantongID := "550e8400-e29b-41d4-a716-446655440001"
+	tanggalMulai := "2024-01-01"
+	expectedRequest := &domain.TransferListRequest{
+		KantongID:    &kantongID,
+		TanggalMulai: &tanggalMulai,
+		Page:         2,
+		PerPage:      5,
+	}
+	transfers := []*domain.TransferResult{
+		{TransferID: "550e8400-e29b-41d4-a716-446655440003", Jumlah: domain.NewMoney(100000)},
+	}
+	meta := &domain.PaginationMeta{CurrentPage: 2, TotalPages: 2, TotalRecords: 6, PerPage: 5}
+
+	mockUsecase.On("GetTransferList", uint(1), expectedRequest).Return(transfers, meta, nil)
+
+	req := httptest.NewRequest("GET", "/kantong/transfer?kantong_id="+kantongID+"&tanggal_mulai=2024-01-01&page=2&per_page=5", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, 200, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestGetTransferList_InvalidFilter(t *testing.T) {
+	app, mockUsecase := setupKantongController()
+
+	req := httptest.NewRequest("GET", "/kantong/transfer?tanggal_mulai=01-01-2024", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, 400, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "GetTransferList", mock.Anything, mock.Anything)
+}
+
+func TestGetTransferByID_Success(t *testing.T) {
+	app, mockUsecase := setupKantongController()
+
+	transferID := "550e8400-e29b-41d4-a716-446655440003"
+	mockUsecase.On("GetTransferByID", transferID, uint(1)).Return(&domain.TransferResult{TransferID: transferID}, nil)
+
+	req := httptest.NewRequest("GET", "/kantong/transfer/"+transferID, nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&response)
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, transferID, data["transfer_id"])
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestGetTransferByID_NotFound(t *testing.T) {
+	app, mockUsecase := setupKantongController()
+
+	mockUsecase.On("GetTransferByID", "invalid-uuid", uint(1)).Return((*domain.TransferResult)(nil), fmt.Errorf("transfer tidak ditemukan"))
+
+	req := httptest.NewRequest("GET", "/kantong/transfer/invalid-uuid", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, 404, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestReverseTransfer_Success(t *testing.T) {
+	app, mockUsecase := setupKantongController()
+
+	transferID := "550e8400-e29b-41d4-a716-446655440003"
+	catatan := "salah kantong"
+	reversal := &domain.TransferResult{
+		TransferID:   "550e8400-e29b-41d4-a716-446655440004",
+		Jumlah:       domain.NewMoney(100000),
+		Catatan:      &catatan,
+		ReversalOfID: &transferID,
+	}
+
+	mockUsecase.On("ReverseTransfer", transferID, &domain.ReverseTransferRequest{Catatan: &catatan}, uint(1)).Return(reversal, nil)
+
+	body, _ := json.Marshal(map[string]string{"catatan": catatan})
+	req := httptest.NewRequest("POST", "/kantong/transfer/"+transferID+"/reverse", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, 200, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestReverseTransfer_WithoutBody(t *testing.T) {
+	app, mockUsecase := setupKantongController()
+
+	transferID := "550e8400-e29b-41d4-a716-446655440003"
+	mockUsecase.On("ReverseTransfer", transferID, &domain.ReverseTransferRequest{}, uint(1)).Return(&domain.TransferResult{ReversalOfID: &transferID}, nil)
+
+	req := httptest.NewRequest("POST", "/kantong/transfer/"+transferID+"/reverse", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, 200, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestReverseTransfer_AlreadyReversed(t *testing.T) {
+	app, mockUsecase := setupKantongController()
+
+	transferID := "550e8400-e29b-41d4-a716-446655440003"
+	mockUsecase.On("ReverseTransfer", transferID, &domain.ReverseTransferRequest{}, uint(1)).Return((*domain.TransferResult)(nil), fmt.Errorf("transfer sudah dibatalkan"))
+
+	req := httptest.NewRequest("POST", "/kantong/transfer/"+transferID+"/reverse", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, 409, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestReverseTransfer_InsufficientBalance(t *testing.T) {
+	app, mockUsecase := setupKantongController()
+
+	transferID := "550e8400-e29b-41d4-a716-446655440003"
+	mockUsecase.On("ReverseTransfer", transferID, &domain.ReverseTransferRequest{}, uint(1)).Return((*domain.TransferResult)(nil), fmt.Errorf("saldo kantong tujuan tidak mencukupi untuk membatalkan transfer"))
+
+	req := httptest.NewRequest("POST", "/kantong/transfer/"+transferID+"/reverse", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, 400, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
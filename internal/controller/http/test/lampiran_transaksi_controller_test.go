@@ -0,0 +1,158 @@
+package http_test
+
+import (
+	"bytes"
+	"errors"
+	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/domain"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLampiranTransaksiUsecase struct {
+	mock.Mock
+}
+
+func (m *MockLampiranTransaksiUsecase) GetLampiranList(transaksiID string, userID uint) ([]*domain.LampiranTransaksiResponse, error) {
+	args := m.Called(transaksiID, userID)
+	return args.Get(0).([]*domain.LampiranTransaksiResponse), args.Error(1)
+}
+
+func (m *MockLampiranTransaksiUsecase) UploadLampiran(transaksiID string, userID uint, namaFile string, ukuran int64, file io.Reader) (*domain.LampiranTransaksiResponse, error) {
+	args := m.Called(transaksiID, userID, namaFile, ukuran, file)
+	return args.Get(0).(*domain.LampiranTransaksiResponse), args.Error(1)
+}
+
+func (m *MockLampiranTransaksiUsecase) DownloadLampiran(id, transaksiID string, userID uint) (*domain.LampiranTransaksiResponse, io.ReadCloser, error) {
+	args := m.Called(id, transaksiID, userID)
+	reader, _ := args.Get(1).(io.ReadCloser)
+	return args.Get(0).(*domain.LampiranTransaksiResponse), reader, args.Error(2)
+}
+
+func (m *MockLampiranTransaksiUsecase) DeleteLampiran(id, transaksiID string, userID uint) error {
+	args := m.Called(id, transaksiID, userID)
+	return args.Error(0)
+}
+
+func setupLampiranTransaksiController() (*fiber.App, *MockLampiranTransaksiUsecase) {
+	app := fiber.New()
+	mockUsecase := new(MockLampiranTransaksiUsecase)
+	controller := http.NewLampiranTransaksiController(mockUsecase)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", uint(1))
+		return c.Next()
+	})
+
+	app.Get("/transaksi/:id/attachments", controller.GetLampiranList)
+	app.Post("/transaksi/:id/attachments", controller.UploadLampiran)
+	app.Get("/transaksi/:id/attachments/:lampiran_id", controller.DownloadLampiran)
+	app.Delete("/transaksi/:id/attachments/:lampiran_id", controller.DeleteLampiran)
+
+	return app, mockUsecase
+}
+
+func buatUploadLampiran(namaFile, isi string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", namaFile)
+	part.Write([]byte(isi))
+	writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+func TestUploadLampiran_Success(t *testing.T) {
+	app, mockUsecase := setupLampiranTransaksiController()
+
+	mockUsecase.On("UploadLampiran", "trx-1", uint(1), "struk.pdf", int64(9), mock.Anything).Return(&domain.LampiranTransaksiResponse{ID: "lampiran-1"}, nil)
+
+	body, contentType := buatUploadLampiran("struk.pdf", "%PDF-1.7\n")
+	req := httptest.NewRequest("POST", "/transaksi/trx-1/attachments", body)
+	req.Header.Set("Content-Type", contentType)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestUploadLampiran_TanpaFile(t *testing.T) {
+	app, mockUsecase := setupLampiranTransaksiController()
+
+	req := httptest.NewRequest("POST", "/transaksi/trx-1/attachments", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "UploadLampiran", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUploadLampiran_ErrorMapping(t *testing.T) {
+	cases := map[string]int{
+		"transaksi tidak ditemukan":      fiber.StatusNotFound,
+		"ukuran file melebihi batas":     fiber.StatusRequestEntityTooLarge,
+		"tipe file tidak didukung":       fiber.StatusUnsupportedMediaType,
+		"jumlah lampiran melebihi batas": fiber.StatusBadRequest,
+		"database error":                 fiber.StatusInternalServerError,
+	}
+
+	for message, expected := range cases {
+		app, mockUsecase := setupLampiranTransaksiController()
+		mockUsecase.On("UploadLampiran", "trx-1", uint(1), "file.txt", mock.Anything, mock.Anything).Return((*domain.LampiranTransaksiResponse)(nil), errors.New(message))
+
+		body, contentType := buatUploadLampiran("file.txt", "teks")
+		req := httptest.NewRequest("POST", "/transaksi/trx-1/attachments", body)
+		req.Header.Set("Content-Type", contentType)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, expected, resp.StatusCode, message)
+	}
+}
+
+func TestDownloadLampiran_Success(t *testing.T) {
+	app, mockUsecase := setupLampiranTransaksiController()
+
+	mockUsecase.On("DownloadLampiran", "lampiran-1", "trx-1", uint(1)).Return(&domain.LampiranTransaksiResponse{
+		ID:          "lampiran-1",
+		NamaFile:    "struk makan.pdf",
+		ContentType: "application/pdf",
+		Ukuran:      9,
+	}, io.NopCloser(strings.NewReader("%PDF-1.7\n")), nil)
+
+	req := httptest.NewRequest("GET", "/transaksi/trx-1/attachments/lampiran-1", nil)
+	resp, _ := app.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/pdf", resp.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, `attachment; filename="struk makan.pdf"`, resp.Header.Get(fiber.HeaderContentDisposition))
+	assert.Equal(t, "%PDF-1.7\n", string(body))
+}
+
+func TestDownloadLampiran_NotFound(t *testing.T) {
+	app, mockUsecase := setupLampiranTransaksiController()
+
+	mockUsecase.On("DownloadLampiran", "lampiran-1", "trx-1", uint(1)).Return((*domain.LampiranTransaksiResponse)(nil), nil, errors.New("lampiran tidak ditemukan"))
+
+	req := httptest.NewRequest("GET", "/transaksi/trx-1/attachments/lampiran-1", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestDeleteLampiran_Success(t *testing.T) {
+	app, mockUsecase := setupLampiranTransaksiController()
+
+	mockUsecase.On("DeleteLampiran", "lampiran-1", "trx-1", uint(1)).Return(nil)
+
+	req := httptest.NewRequest("DELETE", "/transaksi/trx-1/attachments/lampiran-1", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
@@ -89,6 +89,14 @@ func (m *MockLaporanUsecase) GetDetailPerbandinganKantong(userID uint) (*domain.
 	return args.Get(0).(*domain.DetailPerbandinganKantongResponse), args.Error(1)
 }
 
+func (m *MockLaporanUsecase) GetPengeluaranPerTag(userID uint, req *domain.PengeluaranPerTagRequest) (*domain.PengeluaranPerTagResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PengeluaranPerTagResponse), args.Error(1)
+}
+
 func setupLaporanController() (*fiber.App, *MockLaporanUsecase) {
 	app := fiber.New()
 	mockUsecase := new(MockLaporanUsecase)
@@ -102,6 +110,7 @@ func setupLaporanController() (*fiber.App, *MockLaporanUsecase) {
 	app.Get("/laporan/tren/bulanan", controller.GetTrenBulanan)
 	app.Get("/laporan/perbandingan/kantong", controller.GetPerbandinganKantong)
 	app.Get("/laporan/perbandingan/kantong/detail", controller.GetDetailPerbandinganKantong)
+	app.Get("/laporan/pengeluaran-tag", controller.GetPengeluaranPerTag)
 
 	return app, mockUsecase
 }
@@ -119,12 +128,12 @@ func TestLaporanController_GetTrenBulanan_Success(t *testing.T) {
 				{
 					Bulan:            1,
 					NamaBulan:        "Januari",
-					TotalPemasukan:   5000000,
-					TotalPengeluaran: 3500000,
+					TotalPemasukan:   domain.NewMoney(5000000),
+					TotalPengeluaran: domain.NewMoney(3500000),
 				},
 			},
-			TotalPemasukanTahun:   62400000,
-			TotalPengeluaranTahun: 45600000,
+			TotalPemasukanTahun:   domain.NewMoney(62400000),
+			TotalPengeluaranTahun: domain.NewMoney(45600000),
 		},
 		Timestamp: time.Now(),
 	}
@@ -185,12 +194,12 @@ func TestLaporanController_GetPerbandinganKantong_Success(t *testing.T) {
 				{
 					KantongID:       "550e8400-e29b-41d4-a716-446655440001",
 					KantongNama:     "Kantong Belanja",
-					JumlahBulanIni:  1500000,
-					JumlahBulanLalu: 1200000,
+					JumlahBulanIni:  domain.NewMoney(1500000),
+					JumlahBulanLalu: domain.NewMoney(1200000),
 				},
 			},
-			TotalBulanIni:  2300000,
-			TotalBulanLalu: 1900000,
+			TotalBulanIni:  domain.NewMoney(2300000),
+			TotalBulanLalu: domain.NewMoney(1900000),
 		},
 		Timestamp: time.Now(),
 	}
@@ -240,16 +249,16 @@ func TestLaporanController_GetDetailPerbandinganKantong_Success(t *testing.T) {
 				{
 					KantongID:           "550e8400-e29b-41d4-a716-446655440001",
 					KantongNama:         "Kantong Belanja",
-					JumlahBulanIni:      1500000,
-					JumlahBulanLalu:     1200000,
-					RataRataPengeluaran: 100000,
+					JumlahBulanIni:      domain.NewMoney(1500000),
+					JumlahBulanLalu:     domain.NewMoney(1200000),
+					RataRataPengeluaran: domain.NewMoney(100000),
 					Persentase:          25.0,
 					Trend:               "naik",
 				},
 			},
-			TotalBulanIni:   2300000,
-			TotalBulanLalu:  1900000,
-			RataRataTotal:   100000,
+			TotalBulanIni:   domain.NewMoney(2300000),
+			TotalBulanLalu:  domain.NewMoney(1900000),
+			RataRataTotal:   domain.NewMoney(100000),
 			PersentaseTotal: 21.05,
 			TrendTotal:      "naik",
 		},
@@ -278,3 +287,31 @@ func TestLaporanController_GetDetailPerbandinganKantong_UsecaseError(t *testing.
 
 	mockUsecase.AssertExpectations(t)
 }
+
+func TestLaporanController_GetPengeluaranPerTag_Success(t *testing.T) {
+	app, mockUsecase := setupLaporanController()
+
+	expectedResponse := &domain.PengeluaranPerTagResponse{
+		Success: true,
+		Message: "Pengeluaran per tag berhasil diambil",
+		Code:    200,
+		Data: domain.PengeluaranPerTag{
+			DataTag: []domain.DataTagPengeluaran{
+				{TagID: "tag-1", TagNama: "kantor", TotalPengeluaran: domain.NewMoney(750000), JumlahTransaksi: 3},
+			},
+			TotalPengeluaran: domain.NewMoney(750000),
+		},
+	}
+
+	mockUsecase.On("GetPengeluaranPerTag", uint(1), mock.MatchedBy(func(req *domain.PengeluaranPerTagRequest) bool {
+		return req.TanggalMulai != nil && *req.TanggalMulai == "2024-07-01" &&
+			req.TanggalSelesai != nil && *req.TanggalSelesai == "2024-07-31"
+	})).Return(expectedResponse, nil)
+
+	req := httptest.NewRequest("GET", "/laporan/pengeluaran-tag?tanggal_mulai=2024-07-01&tanggal_selesai=2024-07-31", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,147 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/domain"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMFAUsecase struct {
+	mock.Mock
+}
+
+func (m *MockMFAUsecase) Setup(userID uint) (*domain.MFASetupResponse, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MFASetupResponse), args.Error(1)
+}
+
+func (m *MockMFAUsecase) Confirm(userID uint, req domain.MFAConfirmRequest) (*domain.MFARecoveryCodesResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MFARecoveryCodesResponse), args.Error(1)
+}
+
+func (m *MockMFAUsecase) Disable(userID uint, req domain.MFADisableRequest) error {
+	args := m.Called(userID, req)
+	return args.Error(0)
+}
+
+func (m *MockMFAUsecase) RegenerateRecoveryCodes(userID uint, req domain.MFARegenerateRecoveryCodesRequest) (*domain.MFARecoveryCodesResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MFARecoveryCodesResponse), args.Error(1)
+}
+
+func setupMFAController() (*fiber.App, *MockMFAUsecase) {
+	app := fiber.New()
+	mockUsecase := new(MockMFAUsecase)
+	controller := http.NewMFAController(mockUsecase)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", uint(1))
+		return c.Next()
+	})
+
+	app.Post("/mfa/setup", controller.Setup)
+	app.Post("/mfa/confirm", controller.Confirm)
+	app.Post("/mfa/disable", controller.Disable)
+	app.Post("/mfa/recovery-codes", controller.RegenerateRecoveryCodes)
+
+	return app, mockUsecase
+}
+
+func TestMFAController_Setup_Success(t *testing.T) {
+	app, mockUsecase := setupMFAController()
+
+	mockUsecase.On("Setup", uint(1)).Return(&domain.MFASetupResponse{
+		Secret:     "JBSWY3DPEHPK3PXP",
+		OTPAuthURI: "otpauth://totp/App:user@example.com?secret=JBSWY3DPEHPK3PXP",
+	}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/mfa/setup", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestMFAController_Confirm_ValidationError(t *testing.T) {
+	app, _ := setupMFAController()
+
+	bodyBytes, _ := json.Marshal(domain.MFAConfirmRequest{Code: "12ab"})
+	req := httptest.NewRequest("POST", "/mfa/confirm", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestMFAController_Confirm_Success(t *testing.T) {
+	app, mockUsecase := setupMFAController()
+
+	reqBody := domain.MFAConfirmRequest{Code: "123456"}
+	mockUsecase.On("Confirm", uint(1), reqBody).Return(&domain.MFARecoveryCodesResponse{
+		RecoveryCodes: []string{"abcde-12345"},
+	}, nil)
+
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/mfa/confirm", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestMFAController_Disable_WrongPassword(t *testing.T) {
+	app, mockUsecase := setupMFAController()
+
+	reqBody := domain.MFADisableRequest{Password: "wrongpassword", Code: "123456"}
+	mockUsecase.On("Disable", uint(1), reqBody).Return(errors.New("password salah"))
+
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/mfa/disable", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestMFAController_RegenerateRecoveryCodes_NotEnabled(t *testing.T) {
+	app, mockUsecase := setupMFAController()
+
+	reqBody := domain.MFARegenerateRecoveryCodesRequest{Code: "123456"}
+	mockUsecase.On("RegenerateRecoveryCodes", uint(1), reqBody).Return(nil, errors.New("2FA belum aktif"))
+
+	bodyBytes, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/mfa/recovery-codes", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
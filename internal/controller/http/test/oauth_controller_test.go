@@ -0,0 +1,151 @@
+package http_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/domain"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOAuthUsecase struct {
+	mock.Mock
+}
+
+func (m *MockOAuthUsecase) GetProviders() *domain.OAuthProvidersResponse {
+	args := m.Called()
+	return args.Get(0).(*domain.OAuthProvidersResponse)
+}
+
+func (m *MockOAuthUsecase) Start(provider string) (*domain.OAuthStartResponse, error) {
+	args := m.Called(provider)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OAuthStartResponse), args.Error(1)
+}
+
+func (m *MockOAuthUsecase) Callback(provider string, req *domain.OAuthCallbackRequest, meta domain.SessionMeta) (*domain.AuthResponse, error) {
+	args := m.Called(provider, req, meta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AuthResponse), args.Error(1)
+}
+
+func setupOAuthController() (*fiber.App, *MockOAuthUsecase) {
+	app := fiber.New()
+	mockUsecase := new(MockOAuthUsecase)
+	controller := http.NewOAuthController(mockUsecase)
+
+	app.Get("/auth/oauth/providers", controller.GetProviders)
+	app.Get("/auth/oauth/:provider", controller.Start)
+	app.Get("/auth/oauth/:provider/callback", controller.Callback)
+
+	return app, mockUsecase
+}
+
+func TestOAuthController_GetProviders(t *testing.T) {
+	app, mockUsecase := setupOAuthController()
+
+	mockUsecase.On("GetProviders").Return(&domain.OAuthProvidersResponse{Providers: []string{"fake", "google"}})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/auth/oauth/providers", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestOAuthController_Start_Success(t *testing.T) {
+	app, mockUsecase := setupOAuthController()
+
+	mockUsecase.On("Start", "google").Return(&domain.OAuthStartResponse{AuthorizationURL: "https://accounts.google.com/o/oauth2/v2/auth?state=abc", State: "abc"}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/auth/oauth/google", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestOAuthController_Start_UnknownProvider(t *testing.T) {
+	app, mockUsecase := setupOAuthController()
+
+	mockUsecase.On("Start", "myspace").Return(nil, errors.New("provider oauth tidak didukung"))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/auth/oauth/myspace", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestOAuthController_Callback_Success(t *testing.T) {
+	app, mockUsecase := setupOAuthController()
+
+	mockUsecase.On("Callback", "fake", mock.MatchedBy(func(req *domain.OAuthCallbackRequest) bool {
+		return req.Code == "kode" && req.State == "abc"
+	}), mock.Anything).Return(&domain.AuthResponse{AccessToken: "access"}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/auth/oauth/fake/callback?code=kode&state=abc", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestOAuthController_Callback_MissingState(t *testing.T) {
+	app, mockUsecase := setupOAuthController()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/auth/oauth/fake/callback?code=kode", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "Callback", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOAuthController_Callback_ProviderError(t *testing.T) {
+	app, mockUsecase := setupOAuthController()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/auth/oauth/google/callback?error=access_denied&state=abc", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "Callback", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOAuthController_Callback_UnverifiedEmail(t *testing.T) {
+	app, mockUsecase := setupOAuthController()
+
+	mockUsecase.On("Callback", "github", mock.Anything, mock.Anything).Return(nil, errors.New("email akun provider belum terverifikasi"))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/auth/oauth/github/callback?code=kode&state=abc", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestOAuthController_Callback_EmailTerdaftarBelumDiverifikasi(t *testing.T) {
+	app, mockUsecase := setupOAuthController()
+
+	mockUsecase.On("Callback", "google", mock.Anything, mock.Anything).Return(nil, errors.New("email sudah terdaftar dan belum diverifikasi, silakan login dengan password lalu verifikasi email"))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/auth/oauth/google/callback?code=kode&state=abc", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
+
+func TestOAuthController_Callback_InvalidState(t *testing.T) {
+	app, mockUsecase := setupOAuthController()
+
+	mockUsecase.On("Callback", "fake", mock.Anything, mock.Anything).Return(nil, errors.New("state oauth tidak valid atau sudah kedaluwarsa"))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/auth/oauth/fake/callback?code=kode&state=basi", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
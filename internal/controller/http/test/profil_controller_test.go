@@ -0,0 +1,102 @@
+package http_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/domain"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProfilUsecase struct {
+	mock.Mock
+}
+
+func (m *MockProfilUsecase) GetProfil(userID uint) (*domain.ProfilResponse, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProfilResponse), args.Error(1)
+}
+
+func (m *MockProfilUsecase) UpdateProfil(userID uint, req domain.UpdateProfilRequest) (*domain.ProfilResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProfilResponse), args.Error(1)
+}
+
+func (m *MockProfilUsecase) ChangePassword(userID uint, sessionID string, req domain.ChangePasswordRequest) (*domain.ChangePasswordResponse, error) {
+	args := m.Called(userID, sessionID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ChangePasswordResponse), args.Error(1)
+}
+
+func setupProfilController() (*fiber.App, *MockProfilUsecase) {
+	app := fiber.New()
+	mockUsecase := new(MockProfilUsecase)
+	controller := http.NewProfilController(mockUsecase)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", uint(1))
+		c.Locals("session_id", "family-5")
+		return c.Next()
+	})
+	app.Put("/profil/password", controller.ChangePassword)
+
+	return app, mockUsecase
+}
+
+func TestProfilController_ChangePassword_Success(t *testing.T) {
+	app, mockUsecase := setupProfilController()
+
+	reqBody := domain.ChangePasswordRequest{CurrentPassword: "oldpassword123", NewPassword: "newpassword123"}
+	mockUsecase.On("ChangePassword", uint(1), "family-5", reqBody).Return(&domain.ChangePasswordResponse{AccessToken: "token", TokenType: "Bearer", ExpiresIn: 3600}, nil)
+
+	req := httptest.NewRequest("PUT", "/profil/password", strings.NewReader(`{"current_password":"oldpassword123","new_password":"newpassword123"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestProfilController_ChangePassword_TooShort(t *testing.T) {
+	app, mockUsecase := setupProfilController()
+
+	req := httptest.NewRequest("PUT", "/profil/password", strings.NewReader(`{"current_password":"oldpassword123","new_password":"short"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProfilController_ChangePassword_WrongCurrentPassword(t *testing.T) {
+	app, mockUsecase := setupProfilController()
+
+	reqBody := domain.ChangePasswordRequest{CurrentPassword: "wrongpassword", NewPassword: "newpassword123"}
+	mockUsecase.On("ChangePassword", uint(1), "family-5", reqBody).Return(nil, errors.New("password saat ini salah"))
+
+	req := httptest.NewRequest("PUT", "/profil/password", strings.NewReader(`{"current_password":"wrongpassword","new_password":"newpassword123"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
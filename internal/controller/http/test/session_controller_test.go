@@ -0,0 +1,100 @@
+package http_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/domain"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSessionUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSessionUsecase) GetSessions(userID uint, currentSessionID string) ([]*domain.SessionResponse, error) {
+	args := m.Called(userID, currentSessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SessionResponse), args.Error(1)
+}
+
+func (m *MockSessionUsecase) RevokeSession(userID, sessionID uint) error {
+	args := m.Called(userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionUsecase) RevokeAllSessions(userID uint) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func setupSessionController() (*fiber.App, *MockSessionUsecase) {
+	app := fiber.New()
+	mockUsecase := new(MockSessionUsecase)
+	controller := http.NewSessionController(mockUsecase)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", uint(1))
+		c.Locals("session_id", "family-7")
+		return c.Next()
+	})
+
+	app.Get("/sessions", controller.GetSessions)
+	app.Delete("/sessions", controller.RevokeAllSessions)
+	app.Delete("/sessions/:id", controller.RevokeSession)
+
+	return app, mockUsecase
+}
+
+func TestSessionController_GetSessions_Success(t *testing.T) {
+	app, mockUsecase := setupSessionController()
+
+	mockUsecase.On("GetSessions", uint(1), "family-7").Return([]*domain.SessionResponse{
+		{ID: 7, DeviceName: "Chrome di Windows", IsCurrent: true},
+	}, nil)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/sessions", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestSessionController_RevokeSession_InvalidID(t *testing.T) {
+	app, _ := setupSessionController()
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/sessions/abc", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestSessionController_RevokeSession_NotFound(t *testing.T) {
+	app, mockUsecase := setupSessionController()
+
+	mockUsecase.On("RevokeSession", uint(1), uint(99)).Return(errors.New("sesi tidak ditemukan"))
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/sessions/99", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestSessionController_RevokeAllSessions_Success(t *testing.T) {
+	app, mockUsecase := setupSessionController()
+
+	mockUsecase.On("RevokeAllSessions", uint(1)).Return(nil)
+
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/sessions", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
@@ -75,7 +75,7 @@ func TestSubscriptionPlanController_GetAll(t *testing.T) {
 			{
 				ID:            uuid.New(),
 				Nama:          "PRO Monthly",
-				Harga:         99000,
+				Harga:         domain.NewMoney(99000),
 				Interval:      "bulan",
 				HariPercobaan: 7,
 				Status:        "aktif",
@@ -128,7 +128,7 @@ func TestSubscriptionPlanController_GetByID(t *testing.T) {
 		expectedPlan := &domain.SubscriptionPlan{
 			ID:            uuid.MustParse(planID),
 			Nama:          "PRO Monthly",
-			Harga:         99000,
+			Harga:         domain.NewMoney(99000),
 			Interval:      "bulan",
 			HariPercobaan: 7,
 			Status:        "aktif",
@@ -167,7 +167,7 @@ func TestSubscriptionPlanController_Create(t *testing.T) {
 
 		requestBody := domain.CreateSubscriptionPlanRequest{
 			Nama:          "PRO Monthly",
-			Harga:         99000,
+			Harga:         domain.NewMoney(99000),
 			Interval:      "bulan",
 			HariPercobaan: 7,
 			Status:        "aktif",
@@ -203,7 +203,7 @@ func TestSubscriptionPlanController_Create(t *testing.T) {
 
 		requestBody := domain.CreateSubscriptionPlanRequest{
 			Nama:          "PRO Monthly",
-			Harga:         99000,
+			Harga:         domain.NewMoney(99000),
 			Interval:      "bulan",
 			HariPercobaan: 7,
 			Status:        "aktif",
@@ -0,0 +1,143 @@
+package http_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/domain"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTagUsecase) GetTagList(userID uint, req *domain.TagListRequest) ([]*domain.TagResponse, *domain.PaginationMeta, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*domain.TagResponse), args.Get(1).(*domain.PaginationMeta), args.Error(2)
+}
+
+func (m *MockTagUsecase) GetTagByID(id string, userID uint) (*domain.TagResponse, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TagResponse), args.Error(1)
+}
+
+func (m *MockTagUsecase) CreateTag(userID uint, req *domain.CreateTagRequest) (*domain.TagResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TagResponse), args.Error(1)
+}
+
+func (m *MockTagUsecase) UpdateTag(id string, userID uint, req *domain.UpdateTagRequest) (*domain.TagResponse, error) {
+	args := m.Called(id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TagResponse), args.Error(1)
+}
+
+func (m *MockTagUsecase) DeleteTag(id string, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func setupTagController() (*fiber.App, *MockTagUsecase) {
+	app := fiber.New()
+	mockUsecase := new(MockTagUsecase)
+	controller := http.NewTagController(mockUsecase)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", uint(1))
+		return c.Next()
+	})
+
+	app.Get("/tag", controller.GetTagList)
+	app.Get("/tag/:id", controller.GetTagDetail)
+	app.Post("/tag", controller.CreateTag)
+	app.Put("/tag/:id", controller.UpdateTag)
+	app.Delete("/tag/:id", controller.DeleteTag)
+
+	return app, mockUsecase
+}
+
+func TestTagController_GetTagList(t *testing.T) {
+	app, mockUsecase := setupTagController()
+
+	mockUsecase.On("GetTagList", uint(1), mock.MatchedBy(func(req *domain.TagListRequest) bool {
+		return req.Search != nil && *req.Search == "lib" && req.Page == 2
+	})).Return([]*domain.TagResponse{{ID: "tag-1", Nama: "liburan"}}, &domain.PaginationMeta{CurrentPage: 2, PerPage: 10}, nil)
+
+	req := httptest.NewRequest("GET", "/tag?search=lib&page=2", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestTagController_CreateTag(t *testing.T) {
+	app, mockUsecase := setupTagController()
+
+	mockUsecase.On("CreateTag", uint(1), &domain.CreateTagRequest{Nama: "liburan"}).Return(&domain.TagResponse{ID: "tag-1", Nama: "liburan"}, nil)
+
+	req := httptest.NewRequest("POST", "/tag", strings.NewReader(`{"nama":"liburan"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+}
+
+func TestTagController_CreateTag_ValidationError(t *testing.T) {
+	app, mockUsecase := setupTagController()
+
+	req := httptest.NewRequest("POST", "/tag", strings.NewReader(`{"nama":""}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "CreateTag", mock.Anything, mock.Anything)
+}
+
+func TestTagController_ErrorMapping(t *testing.T) {
+	cases := map[string]int{
+		"tag tidak ditemukan":         fiber.StatusNotFound,
+		"nama tag sudah digunakan":    fiber.StatusConflict,
+		"nama tag tidak boleh kosong": fiber.StatusBadRequest,
+		"database error":              fiber.StatusInternalServerError,
+	}
+
+	for message, expected := range cases {
+		app, mockUsecase := setupTagController()
+		mockUsecase.On("UpdateTag", "tag-1", uint(1), mock.Anything).Return(nil, errors.New(message))
+
+		req := httptest.NewRequest("PUT", "/tag/tag-1", strings.NewReader(`{"nama":"kantor"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, expected, resp.StatusCode, message)
+	}
+}
+
+func TestTagController_DeleteTag(t *testing.T) {
+	app, mockUsecase := setupTagController()
+
+	mockUsecase.On("DeleteTag", "tag-1", uint(1)).Return(nil)
+
+	req := httptest.NewRequest("DELETE", "/tag/tag-1", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,183 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/domain"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTransaksiBerulangUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTransaksiBerulangUsecase) GetTransaksiBerulangList(userID uint, req *domain.TransaksiBerulangListRequest) ([]*domain.TransaksiBerulangResponse, *domain.PaginationMeta, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).([]*domain.TransaksiBerulangResponse), args.Get(1).(*domain.PaginationMeta), args.Error(2)
+}
+
+func (m *MockTransaksiBerulangUsecase) GetTransaksiBerulangByID(id string, userID uint) (*domain.TransaksiBerulangResponse, error) {
+	args := m.Called(id, userID)
+	return args.Get(0).(*domain.TransaksiBerulangResponse), args.Error(1)
+}
+
+func (m *MockTransaksiBerulangUsecase) CreateTransaksiBerulang(userID uint, req *domain.CreateTransaksiBerulangRequest) (*domain.TransaksiBerulangResponse, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).(*domain.TransaksiBerulangResponse), args.Error(1)
+}
+
+func (m *MockTransaksiBerulangUsecase) PatchTransaksiBerulang(id string, userID uint, req *domain.PatchTransaksiBerulangRequest) (*domain.TransaksiBerulangResponse, error) {
+	args := m.Called(id, userID, req)
+	return args.Get(0).(*domain.TransaksiBerulangResponse), args.Error(1)
+}
+
+func (m *MockTransaksiBerulangUsecase) DeleteTransaksiBerulang(id string, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiBerulangUsecase) PauseTransaksiBerulang(id string, userID uint) (*domain.TransaksiBerulangResponse, error) {
+	args := m.Called(id, userID)
+	return args.Get(0).(*domain.TransaksiBerulangResponse), args.Error(1)
+}
+
+func (m *MockTransaksiBerulangUsecase) ResumeTransaksiBerulang(id string, userID uint) (*domain.TransaksiBerulangResponse, error) {
+	args := m.Called(id, userID)
+	return args.Get(0).(*domain.TransaksiBerulangResponse), args.Error(1)
+}
+
+func (m *MockTransaksiBerulangUsecase) SkipKejadian(id string, userID uint, req *domain.LewatiKejadianRequest) (*domain.TransaksiBerulangResponse, error) {
+	args := m.Called(id, userID, req)
+	return args.Get(0).(*domain.TransaksiBerulangResponse), args.Error(1)
+}
+
+func (m *MockTransaksiBerulangUsecase) ProcessDueTransaksi() (map[string]int64, error) {
+	args := m.Called()
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
+func setupTransaksiBerulangController() (*fiber.App, *MockTransaksiBerulangUsecase) {
+	app := fiber.New()
+	mockUsecase := new(MockTransaksiBerulangUsecase)
+	controller := http.NewTransaksiBerulangController(mockUsecase)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", uint(1))
+		return c.Next()
+	})
+
+	app.Get("/transaksi/berulang", controller.GetTransaksiBerulangList)
+	app.Post("/transaksi/berulang", controller.CreateTransaksiBerulang)
+	app.Get("/transaksi/berulang/:id", controller.GetTransaksiBerulangDetail)
+	app.Patch("/transaksi/berulang/:id", controller.PatchTransaksiBerulang)
+	app.Delete("/transaksi/berulang/:id", controller.DeleteTransaksiBerulang)
+	app.Post("/transaksi/berulang/:id/jeda", controller.PauseTransaksiBerulang)
+	app.Post("/transaksi/berulang/:id/lanjutkan", controller.ResumeTransaksiBerulang)
+	app.Post("/transaksi/berulang/:id/lewati", controller.SkipKejadian)
+
+	return app, mockUsecase
+}
+
+func TestCreateTransaksiBerulang_Success(t *testing.T) {
+	app, mockUsecase := setupTransaksiBerulangController()
+
+	tanggalBerikutnya := "2026-11-01"
+	expected := &domain.TransaksiBerulangResponse{
+		ID:                "berulang-1",
+		Frekuensi:         domain.FrekuensiBulanan,
+		Jumlah:            domain.NewMoney(8000000),
+		TanggalBerikutnya: &tanggalBerikutnya,
+		Status:            domain.StatusTransaksiBerulangAktif,
+	}
+
+	mockUsecase.On("CreateTransaksiBerulang", uint(1), mock.MatchedBy(func(req *domain.CreateTransaksiBerulangRequest) bool {
+		return req.Frekuensi == domain.FrekuensiBulanan && req.Jumlah == domain.NewMoney(8000000)
+	})).Return(expected, nil)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"kantong_id":    "550e8400-e29b-41d4-a716-446655440001",
+		"jenis":         "Pemasukan",
+		"jumlah":        8000000,
+		"frekuensi":     "bulanan",
+		"tanggal_mulai": "2026-11-01",
+	})
+	req := httptest.NewRequest("POST", "/transaksi/berulang", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestCreateTransaksiBerulang_ValidationError(t *testing.T) {
+	app, mockUsecase := setupTransaksiBerulangController()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"kantong_id":    "550e8400-e29b-41d4-a716-446655440001",
+		"jenis":         "Pemasukan",
+		"jumlah":        8000000,
+		"frekuensi":     "per_jam",
+		"tanggal_mulai": "01-11-2026",
+	})
+	req := httptest.NewRequest("POST", "/transaksi/berulang", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "CreateTransaksiBerulang", mock.Anything, mock.Anything)
+}
+
+func TestGetTransaksiBerulangDetail_NotFound(t *testing.T) {
+	app, mockUsecase := setupTransaksiBerulangController()
+
+	mockUsecase.On("GetTransaksiBerulangByID", "berulang-1", uint(1)).Return((*domain.TransaksiBerulangResponse)(nil), errors.New("transaksi berulang tidak ditemukan"))
+
+	req := httptest.NewRequest("GET", "/transaksi/berulang/berulang-1", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestPauseTransaksiBerulang_Conflict(t *testing.T) {
+	app, mockUsecase := setupTransaksiBerulangController()
+
+	mockUsecase.On("PauseTransaksiBerulang", "berulang-1", uint(1)).Return((*domain.TransaksiBerulangResponse)(nil), errors.New("transaksi berulang tidak aktif"))
+
+	req := httptest.NewRequest("POST", "/transaksi/berulang/berulang-1/jeda", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
+
+func TestSkipKejadian_WithoutBody(t *testing.T) {
+	app, mockUsecase := setupTransaksiBerulangController()
+
+	mockUsecase.On("SkipKejadian", "berulang-1", uint(1), &domain.LewatiKejadianRequest{}).Return(&domain.TransaksiBerulangResponse{ID: "berulang-1"}, nil)
+
+	req := httptest.NewRequest("POST", "/transaksi/berulang/berulang-1/lewati", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestSkipKejadian_NotOnSchedule(t *testing.T) {
+	app, mockUsecase := setupTransaksiBerulangController()
+
+	tanggal := "2026-11-03"
+	mockUsecase.On("SkipKejadian", "berulang-1", uint(1), &domain.LewatiKejadianRequest{Tanggal: &tanggal}).Return((*domain.TransaksiBerulangResponse)(nil), errors.New("tanggal bukan jadwal transaksi berulang"))
+
+	body, _ := json.Marshal(map[string]string{"tanggal": tanggal})
+	req := httptest.NewRequest("POST", "/transaksi/berulang/berulang-1/lewati", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,414 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fiber-boiler-plate/internal/controller/http"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTransaksiUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTransaksiUsecase) GetTransaksiList(userID uint, req *domain.TransaksiListRequest) (*domain.TransaksiListResponse, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).(*domain.TransaksiListResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) GetTransaksiDetail(id string, userID uint) (*domain.TransaksiDetailResponse, error) {
+	args := m.Called(id, userID)
+	return args.Get(0).(*domain.TransaksiDetailResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) CreateTransaksi(userID uint, req *domain.CreateTransaksiRequest) (*domain.TransaksiDetailResponse, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).(*domain.TransaksiDetailResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) CreateTransaksiWithID(userID uint, id string, req *domain.CreateTransaksiRequest) (*domain.TransaksiDetailResponse, error) {
+	args := m.Called(userID, id, req)
+	return args.Get(0).(*domain.TransaksiDetailResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) UpdateTransaksi(id string, userID uint, req *domain.UpdateTransaksiRequest) (*domain.TransaksiDetailResponse, error) {
+	args := m.Called(id, userID, req)
+	return args.Get(0).(*domain.TransaksiDetailResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) PatchTransaksi(id string, userID uint, req *domain.PatchTransaksiRequest) (*domain.TransaksiDetailResponse, error) {
+	args := m.Called(id, userID, req)
+	return args.Get(0).(*domain.TransaksiDetailResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) DeleteTransaksi(id string, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiUsecase) PreviewImportTransaksi(userID uint, req *domain.ImportTransaksiRequest, file io.Reader) (*domain.ImportTransaksiPreview, error) {
+	args := m.Called(userID, req, file)
+	return args.Get(0).(*domain.ImportTransaksiPreview), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) ImportTransaksi(userID uint, req *domain.ImportTransaksiRequest, file io.Reader) (*domain.ImportTransaksiResult, error) {
+	args := m.Called(userID, req, file)
+	return args.Get(0).(*domain.ImportTransaksiResult), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) ExportTransaksi(userID uint, req *domain.TransaksiListRequest, format string) (func(w io.Writer) error, error) {
+	args := m.Called(userID, req, format)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(func(w io.Writer) error), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) BatchTransaksi(userID uint, req *domain.BatchTransaksiRequest) (*domain.BatchTransaksiResult, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).(*domain.BatchTransaksiResult), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) SetAnggaranUsecase(anggaranUsecase usecase.AnggaranUsecase) {
+}
+
+func setupTransaksiController() (*fiber.App, *MockTransaksiUsecase) {
+	app := fiber.New()
+	mockUsecase := new(MockTransaksiUsecase)
+	controller := http.NewTransaksiController(mockUsecase)
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", uint(1))
+		return c.Next()
+	})
+
+	app.Get("/transaksi/export", controller.ExportTransaksi)
+	app.Post("/transaksi/import/preview", controller.PreviewImportTransaksi)
+	app.Post("/transaksi/import", controller.ImportTransaksi)
+	app.Post("/transaksi", controller.CreateTransaksi)
+	app.Post("/transaksi/batch", controller.BatchTransaksi)
+	app.Get("/transaksi", controller.GetTransaksiList)
+
+	return app, mockUsecase
+}
+
+func buatImportBody(fields map[string]string, csv *string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for key, value := range fields {
+		writer.WriteField(key, value)
+	}
+	if csv != nil {
+		part, _ := writer.CreateFormFile("file", "mutasi.csv")
+		part.Write([]byte(*csv))
+	}
+	writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+func TestPreviewImportTransaksi_Success(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	mockUsecase.On("PreviewImportTransaksi", uint(1), mock.MatchedBy(func(req *domain.ImportTransaksiRequest) bool {
+		return req.KantongID == "550e8400-e29b-41d4-a716-446655440001" &&
+			req.KolomTanggal == "Tanggal" &&
+			req.PemisahKolom == "titik_koma"
+	}), mock.Anything).Return(&domain.ImportTransaksiPreview{TotalBaris: 1, BarisSiapImpor: 1}, nil)
+
+	csv := "Tanggal;Jumlah\n2026-10-01;10.000\n"
+	body, contentType := buatImportBody(map[string]string{
+		"kantong_id":    "550e8400-e29b-41d4-a716-446655440001",
+		"kolom_tanggal": "Tanggal",
+		"kolom_jumlah":  "Jumlah",
+		"pemisah_kolom": "titik_koma",
+	}, &csv)
+	req := httptest.NewRequest("POST", "/transaksi/import/preview", body)
+	req.Header.Set("Content-Type", contentType)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestPreviewImportTransaksi_TanpaFile(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	body, contentType := buatImportBody(map[string]string{
+		"kantong_id":    "550e8400-e29b-41d4-a716-446655440001",
+		"kolom_tanggal": "Tanggal",
+		"kolom_jumlah":  "Jumlah",
+	}, nil)
+	req := httptest.NewRequest("POST", "/transaksi/import/preview", body)
+	req.Header.Set("Content-Type", contentType)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "PreviewImportTransaksi", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPreviewImportTransaksi_ValidationError(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	csv := "Tanggal,Jumlah\n2026-10-01,10.000\n"
+	body, contentType := buatImportBody(map[string]string{
+		"kantong_id":    "550e8400-e29b-41d4-a716-446655440001",
+		"kolom_tanggal": "Tanggal",
+	}, &csv)
+	req := httptest.NewRequest("POST", "/transaksi/import/preview", body)
+	req.Header.Set("Content-Type", contentType)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "PreviewImportTransaksi", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportTransaksi_Success(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	mockUsecase.On("ImportTransaksi", uint(1), mock.Anything, mock.Anything).Return(&domain.ImportTransaksiResult{Diimpor: 1}, nil)
+
+	csv := "Tanggal,Jumlah\n2026-10-01,10.000\n"
+	body, contentType := buatImportBody(map[string]string{
+		"kantong_id":    "550e8400-e29b-41d4-a716-446655440001",
+		"kolom_tanggal": "Tanggal",
+		"kolom_jumlah":  "Jumlah",
+	}, &csv)
+	req := httptest.NewRequest("POST", "/transaksi/import", body)
+	req.Header.Set("Content-Type", contentType)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+}
+
+func TestImportTransaksi_ErrorMapping(t *testing.T) {
+	cases := []struct {
+		err      error
+		result   *domain.ImportTransaksiResult
+		expected int
+	}{
+		{errors.New("kantong tidak ditemukan"), nil, fiber.StatusNotFound},
+		{errors.New("kolom tanggal tidak ditemukan"), nil, fiber.StatusBadRequest},
+		{errors.New("saldo tidak mencukupi"), nil, fiber.StatusBadRequest},
+		{errors.New("terdapat baris yang tidak valid"), &domain.ImportTransaksiResult{BarisError: []domain.ImportTransaksiRow{{Baris: 2, Error: "jumlah tidak valid"}}}, fiber.StatusBadRequest},
+		{errors.New("database error"), nil, fiber.StatusInternalServerError},
+	}
+
+	for _, c := range cases {
+		app, mockUsecase := setupTransaksiController()
+		mockUsecase.On("ImportTransaksi", uint(1), mock.Anything, mock.Anything).Return(c.result, c.err)
+
+		csv := "Tanggal,Jumlah\n2026-10-01,10.000\n"
+		body, contentType := buatImportBody(map[string]string{
+			"kantong_id":    "550e8400-e29b-41d4-a716-446655440001",
+			"kolom_tanggal": "Tanggal",
+			"kolom_jumlah":  "Jumlah",
+		}, &csv)
+		req := httptest.NewRequest("POST", "/transaksi/import", body)
+		req.Header.Set("Content-Type", contentType)
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, c.expected, resp.StatusCode, c.err.Error())
+	}
+}
+
+func TestExportTransaksi_StreamXLSX(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	mockUsecase.On("ExportTransaksi", uint(1), mock.MatchedBy(func(req *domain.TransaksiListRequest) bool {
+		return req.Jenis != nil && *req.Jenis == "Pengeluaran"
+	}), domain.FormatExportXLSX).Return(func(w io.Writer) error {
+		_, err := io.WriteString(w, "isi-export")
+		return err
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/transaksi/export?format=xlsx&jenis=Pengeluaran", nil)
+	resp, _ := app.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, domain.ContentTypeExport(domain.FormatExportXLSX), resp.Header.Get(fiber.HeaderContentType))
+	assert.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), ".xlsx")
+	assert.Equal(t, "isi-export", string(body))
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestExportTransaksi_FormatTidakDidukung(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	req := httptest.NewRequest("GET", "/transaksi/export?format=pdf", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "ExportTransaksi", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportTransaksi_GagalSebelumStream(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	mockUsecase.On("ExportTransaksi", uint(1), mock.Anything, domain.FormatExportCSV).Return(nil, errors.New("database error"))
+
+	req := httptest.NewRequest("GET", "/transaksi/export", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	assert.NotEqual(t, domain.ContentTypeExport(domain.FormatExportCSV), resp.Header.Get(fiber.HeaderContentType))
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestCreateTransaksi_SplitTanpaKantongID(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	mockUsecase.On("CreateTransaksi", uint(1), mock.MatchedBy(func(req *domain.CreateTransaksiRequest) bool {
+		return req.KantongID == "" && len(req.Splits) == 2
+	})).Return(&domain.TransaksiDetailResponse{Message: "Transaksi berhasil dibuat"}, nil)
+
+	body := `{"tanggal":"2026-10-01","jenis":"Pengeluaran","jumlah":100000,"splits":[{"kantong_id":"550e8400-e29b-41d4-a716-446655440001","jumlah":60000},{"kantong_id":"550e8400-e29b-41d4-a716-446655440002","jumlah":40000}]}`
+	req := httptest.NewRequest("POST", "/transaksi", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestCreateTransaksi_TanpaKantongDanSplit(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	body := `{"tanggal":"2026-10-01","jenis":"Pengeluaran","jumlah":100000}`
+	req := httptest.NewRequest("POST", "/transaksi", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "CreateTransaksi", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransaksi_SplitError(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	mockUsecase.On("CreateTransaksi", uint(1), mock.Anything).Return((*domain.TransaksiDetailResponse)(nil), errors.New("total split harus sama dengan jumlah transaksi"))
+
+	body := `{"tanggal":"2026-10-01","jenis":"Pengeluaran","jumlah":100000,"splits":[{"kantong_id":"550e8400-e29b-41d4-a716-446655440001","jumlah":60000},{"kantong_id":"550e8400-e29b-41d4-a716-446655440002","jumlah":30000}]}`
+	req := httptest.NewRequest("POST", "/transaksi", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestBatchTransaksi_Success(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	mockUsecase.On("BatchTransaksi", uint(1), mock.MatchedBy(func(req *domain.BatchTransaksiRequest) bool {
+		return req.Mode == domain.ModeBatchSebagian && len(req.Operasi) == 2 && req.Operasi[1].Data == nil
+	})).Return(&domain.BatchTransaksiResult{Mode: domain.ModeBatchSebagian, Total: 2, Berhasil: 2}, nil)
+
+	body := `{"mode":"sebagian","operasi":[{"aksi":"create","data":{"kantong_id":"550e8400-e29b-41d4-a716-446655440001","tanggal":"2026-10-01","jenis":"Pengeluaran","jumlah":50000}},{"aksi":"delete","id":"550e8400-e29b-41d4-a716-446655440051"}]}`
+	req := httptest.NewRequest("POST", "/transaksi/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestBatchTransaksi_ValidationError(t *testing.T) {
+	bodies := []string{
+		`{"operasi":[]}`,
+		`{"mode":"acak","operasi":[{"aksi":"delete","id":"550e8400-e29b-41d4-a716-446655440051"}]}`,
+		`{"operasi":[{"aksi":"update","data":{"kantong_id":"550e8400-e29b-41d4-a716-446655440001","tanggal":"2026-10-01","jenis":"Pengeluaran","jumlah":50000}}]}`,
+		`{"operasi":[{"aksi":"create"}]}`,
+		`{"operasi":[{"aksi":"create","data":{"tanggal":"2026-10-01","jenis":"Pengeluaran","jumlah":50000}}]}`,
+	}
+
+	for _, body := range bodies {
+		app, mockUsecase := setupTransaksiController()
+
+		req := httptest.NewRequest("POST", "/transaksi/batch", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req)
+
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, body)
+		mockUsecase.AssertNotCalled(t, "BatchTransaksi", mock.Anything, mock.Anything)
+	}
+}
+
+func TestBatchTransaksi_Dibatalkan(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	mockUsecase.On("BatchTransaksi", uint(1), mock.Anything).Return(&domain.BatchTransaksiResult{
+		Mode:  domain.ModeBatchAtomik,
+		Total: 1,
+		Gagal: 1,
+		Hasil: []domain.BatchTransaksiHasil{{Aksi: domain.AksiBatchDelete, Status: domain.StatusBatchGagal, Error: "transaksi tidak ditemukan"}},
+	}, errors.New("batch transaksi dibatalkan"))
+
+	body := `{"operasi":[{"aksi":"delete","id":"550e8400-e29b-41d4-a716-446655440051"}]}`
+	req := httptest.NewRequest("POST", "/transaksi/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	respBody, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, string(respBody), "transaksi tidak ditemukan")
+}
+
+func TestGetTransaksiList_Cursor(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	next := "eyJzIjoidGFuZ2dhbCJ9"
+	mockUsecase.On("GetTransaksiList", uint(1), mock.MatchedBy(func(req *domain.TransaksiListRequest) bool {
+		return req.PaginasiCursor() && req.IncludeTotal
+	})).Return(&domain.TransaksiListResponse{
+		Success:    true,
+		Code:       200,
+		Data:       []domain.TransaksiResponse{},
+		CursorMeta: &domain.CursorPaginationMeta{PerPage: 10, NextCursor: &next},
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/transaksi?pagination=cursor&include_total=true", nil)
+	resp, _ := app.Test(req)
+
+	var body struct {
+		Meta map[string]interface{} `json:"meta"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, next, body.Meta["next_cursor"])
+	assert.Nil(t, body.Meta["prev_cursor"])
+	assert.NotContains(t, body.Meta, "total_pages")
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestGetTransaksiList_CursorTidakValid(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	mockUsecase.On("GetTransaksiList", uint(1), mock.Anything).Return((*domain.TransaksiListResponse)(nil), errors.New("cursor tidak valid"))
+
+	req := httptest.NewRequest("GET", "/transaksi?cursor=rusak", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetTransaksiList_PaginationTidakValid(t *testing.T) {
+	app, mockUsecase := setupTransaksiController()
+
+	req := httptest.NewRequest("GET", "/transaksi?pagination=halaman", nil)
+	resp, _ := app.Test(req)
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	mockUsecase.AssertNotCalled(t, "GetTransaksiList", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,176 @@
+package http
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TransaksiBerulangController struct {
+	transaksiBerulangUsecase usecase.TransaksiBerulangUsecase
+}
+
+func NewTransaksiBerulangController(transaksiBerulangUsecase usecase.TransaksiBerulangUsecase) *TransaksiBerulangController {
+	return &TransaksiBerulangController{
+		transaksiBerulangUsecase: transaksiBerulangUsecase,
+	}
+}
+
+func (ctrl *TransaksiBerulangController) GetTransaksiBerulangList(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	req := domain.NewTransaksiBerulangListRequest()
+
+	if status := c.Query("status"); status != "" {
+		req.Status = &status
+	}
+	if kantongID := c.Query("kantong_id"); kantongID != "" {
+		req.KantongID = &kantongID
+	}
+
+	if page, err := strconv.Atoi(c.Query("page", "1")); err == nil && page > 0 {
+		req.Page = page
+	}
+	if perPage, err := strconv.Atoi(c.Query("per_page", "10")); err == nil && perPage > 0 && perPage <= 100 {
+		req.PerPage = perPage
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, meta, err := ctrl.transaksiBerulangUsecase.GetTransaksiBerulangList(userID, req)
+	if err != nil {
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendPaginatedResponse(c, fiber.StatusOK, "Daftar transaksi berulang berhasil diambil", result, *meta)
+}
+
+func (ctrl *TransaksiBerulangController) GetTransaksiBerulangDetail(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	result, err := ctrl.transaksiBerulangUsecase.GetTransaksiBerulangByID(c.Params("id"), userID)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Detail transaksi berulang berhasil diambil", result)
+}
+
+func (ctrl *TransaksiBerulangController) CreateTransaksiBerulang(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	var req domain.CreateTransaksiBerulangRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := ctrl.transaksiBerulangUsecase.CreateTransaksiBerulang(userID, &req)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusCreated, "Transaksi berulang berhasil dibuat", result)
+}
+
+func (ctrl *TransaksiBerulangController) PatchTransaksiBerulang(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	var req domain.PatchTransaksiBerulangRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := ctrl.transaksiBerulangUsecase.PatchTransaksiBerulang(c.Params("id"), userID, &req)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Transaksi berulang berhasil diperbarui", result)
+}
+
+func (ctrl *TransaksiBerulangController) DeleteTransaksiBerulang(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	if err := ctrl.transaksiBerulangUsecase.DeleteTransaksiBerulang(c.Params("id"), userID); err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Transaksi berulang berhasil dihapus", nil)
+}
+
+func (ctrl *TransaksiBerulangController) PauseTransaksiBerulang(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	result, err := ctrl.transaksiBerulangUsecase.PauseTransaksiBerulang(c.Params("id"), userID)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Transaksi berulang berhasil dijeda", result)
+}
+
+func (ctrl *TransaksiBerulangController) ResumeTransaksiBerulang(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	result, err := ctrl.transaksiBerulangUsecase.ResumeTransaksiBerulang(c.Params("id"), userID)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Transaksi berulang berhasil dilanjutkan", result)
+}
+
+func (ctrl *TransaksiBerulangController) SkipKejadian(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	var req domain.LewatiKejadianRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+		}
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := ctrl.transaksiBerulangUsecase.SkipKejadian(c.Params("id"), userID, &req)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Kejadian transaksi berulang berhasil dilewati", result)
+}
+
+func (ctrl *TransaksiBerulangController) handleError(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "transaksi berulang tidak ditemukan", "kantong tidak ditemukan":
+		return helper.SendNotFoundResponse(c, err.Error())
+	case "format tanggal tidak valid",
+		"tanggal mulai tidak boleh sebelum hari ini",
+		"tanggal selesai tidak boleh sebelum tanggal mulai",
+		"tanggal berikutnya tidak boleh sebelum hari ini",
+		"tanggal bukan jadwal transaksi berulang":
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+	case "transaksi berulang tidak aktif",
+		"transaksi berulang tidak sedang dijeda",
+		"transaksi berulang sudah selesai",
+		"kejadian sudah dilewati atau diproses":
+		return helper.SendErrorResponse(c, fiber.StatusConflict, err.Error(), nil)
+	default:
+		return helper.SendInternalServerErrorResponse(c)
+	}
+}
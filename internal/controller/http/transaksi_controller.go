@@ -1,10 +1,15 @@
 package http
 
 import (
+	"bufio"
 	"fiber-boiler-plate/internal/domain"
 	"fiber-boiler-plate/internal/helper"
 	"fiber-boiler-plate/internal/usecase"
+	"fmt"
+	"mime/multipart"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -22,51 +27,23 @@ func NewTransaksiController(transaksiUsecase usecase.TransaksiUsecase) *Transaks
 func (ctrl *TransaksiController) GetTransaksiList(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uint)
 
-	req := &domain.TransaksiListRequest{
-		Page:          1,
-		PerPage:       10,
-		SortBy:        "tanggal",
-		SortDirection: "desc",
-	}
-
-	if search := c.Query("search"); search != "" {
-		req.Search = &search
-	}
-	if jenis := c.Query("jenis"); jenis != "" {
-		req.Jenis = &jenis
-	}
-	if kantongNama := c.Query("kantong_nama"); kantongNama != "" {
-		req.KantongNama = &kantongNama
-	}
-	if tanggalMulai := c.Query("tanggal_mulai"); tanggalMulai != "" {
-		req.TanggalMulai = &tanggalMulai
-	}
-	if tanggalSelesai := c.Query("tanggal_selesai"); tanggalSelesai != "" {
-		req.TanggalSelesai = &tanggalSelesai
-	}
-	if sortBy := c.Query("sort_by"); sortBy != "" {
-		req.SortBy = sortBy
-	}
-	if sortDirection := c.Query("sort_direction"); sortDirection != "" {
-		req.SortDirection = sortDirection
-	}
-
-	if page, err := strconv.Atoi(c.Query("page", "1")); err == nil && page > 0 {
-		req.Page = page
-	}
-	if perPage, err := strconv.Atoi(c.Query("per_page", "10")); err == nil && perPage > 0 && perPage <= 100 {
-		req.PerPage = perPage
-	}
-
+	req := parseTransaksiListRequest(c)
 	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
 		return helper.SendValidationErrorResponse(c, validationErrors)
 	}
 
 	result, err := ctrl.transaksiUsecase.GetTransaksiList(userID, req)
 	if err != nil {
+		if err.Error() == "cursor tidak valid" {
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
+		}
 		return helper.SendInternalServerErrorResponse(c)
 	}
 
+	if result.CursorMeta != nil {
+		return helper.SendCursorPaginatedResponse(c, result.Code, result.Message, result.Data, *result.CursorMeta)
+	}
+
 	return helper.SendPaginatedResponse(c, result.Code, result.Message, result.Data, result.Meta)
 }
 
@@ -109,7 +86,7 @@ func (ctrl *TransaksiController) CreateTransaksi(c *fiber.Ctx) error {
 		if err.Error() == "format tanggal tidak valid" {
 			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
 		}
-		if err.Error() == "saldo tidak mencukupi" {
+		if err.Error() == "saldo tidak mencukupi" || isSplitError(err) {
 			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
 		}
 		return helper.SendInternalServerErrorResponse(c)
@@ -146,7 +123,7 @@ func (ctrl *TransaksiController) UpdateTransaksi(c *fiber.Ctx) error {
 		if err.Error() == "format tanggal tidak valid" {
 			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
 		}
-		if err.Error() == "saldo tidak mencukupi" {
+		if err.Error() == "saldo tidak mencukupi" || isSplitError(err) {
 			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
 		}
 		return helper.SendInternalServerErrorResponse(c)
@@ -183,7 +160,7 @@ func (ctrl *TransaksiController) PatchTransaksi(c *fiber.Ctx) error {
 		if err.Error() == "format tanggal tidak valid" {
 			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
 		}
-		if err.Error() == "saldo tidak mencukupi" {
+		if err.Error() == "saldo tidak mencukupi" || isSplitError(err) {
 			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), nil)
 		}
 		return helper.SendInternalServerErrorResponse(c)
@@ -210,3 +187,197 @@ func (ctrl *TransaksiController) DeleteTransaksi(c *fiber.Ctx) error {
 
 	return helper.SendSuccessResponse(c, fiber.StatusOK, "Transaksi berhasil dihapus", nil)
 }
+
+func (ctrl *TransaksiController) PreviewImportTransaksi(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	req, file, errResponse := ctrl.parseImportRequest(c)
+	if errResponse != nil {
+		return errResponse()
+	}
+	defer file.Close()
+
+	result, err := ctrl.transaksiUsecase.PreviewImportTransaksi(userID, req, file)
+	if err != nil {
+		return ctrl.handleImportError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Pratinjau import transaksi berhasil dibuat", result)
+}
+
+func (ctrl *TransaksiController) ImportTransaksi(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	req, file, errResponse := ctrl.parseImportRequest(c)
+	if errResponse != nil {
+		return errResponse()
+	}
+	defer file.Close()
+
+	result, err := ctrl.transaksiUsecase.ImportTransaksi(userID, req, file)
+	if err != nil {
+		if err.Error() == "terdapat baris yang tidak valid" && result != nil {
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), result.BarisError)
+		}
+		return ctrl.handleImportError(c, err)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusCreated, "Transaksi berhasil diimpor", result)
+}
+
+func (ctrl *TransaksiController) BatchTransaksi(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	var req domain.BatchTransaksiRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	result, err := ctrl.transaksiUsecase.BatchTransaksi(userID, &req)
+	if err != nil {
+		if err.Error() == "batch transaksi dibatalkan" && result != nil {
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, err.Error(), result.Hasil)
+		}
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	return helper.SendSuccessResponse(c, fiber.StatusOK, "Batch transaksi berhasil diproses", result)
+}
+
+func (ctrl *TransaksiController) parseImportRequest(c *fiber.Ctx) (*domain.ImportTransaksiRequest, multipart.File, func() error) {
+	var req domain.ImportTransaksiRequest
+	if err := c.BodyParser(&req); err != nil {
+		return nil, nil, func() error {
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format request tidak valid", nil)
+		}
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, nil, func() error {
+			return helper.SendValidationErrorResponse(c, validationErrors)
+		}
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, nil, func() error {
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, "File CSV wajib diunggah", nil)
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, nil, func() error {
+			return helper.SendErrorResponse(c, fiber.StatusBadRequest, "File CSV tidak dapat dibaca", nil)
+		}
+	}
+
+	return &req, file, nil
+}
+
+func isSplitError(err error) bool {
+	switch err.Error() {
+	case "split minimal terdiri dari 2 kantong",
+		"total split harus sama dengan jumlah transaksi",
+		"kantong split tidak boleh duplikat":
+		return true
+	}
+	return false
+}
+
+func (ctrl *TransaksiController) handleImportError(c *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case message == "kantong tidak ditemukan":
+		return helper.SendErrorResponse(c, fiber.StatusNotFound, message, nil)
+	case message == "format CSV tidak valid",
+		message == "file CSV tidak memiliki baris transaksi",
+		message == "saldo tidak mencukupi",
+		strings.HasPrefix(message, "jumlah baris melebihi batas"),
+		strings.HasPrefix(message, "kolom "):
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, message, nil)
+	}
+	return helper.SendInternalServerErrorResponse(c)
+}
+
+func (ctrl *TransaksiController) ExportTransaksi(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uint)
+
+	format := strings.ToLower(c.Query("format", domain.FormatExportCSV))
+	if !domain.FormatExportValid(format) {
+		return helper.SendErrorResponse(c, fiber.StatusBadRequest, "Format export tidak didukung", nil)
+	}
+
+	req := parseTransaksiListRequest(c)
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.SendValidationErrorResponse(c, validationErrors)
+	}
+
+	tulis, err := ctrl.transaksiUsecase.ExportTransaksi(userID, req, format)
+	if err != nil {
+		return helper.SendInternalServerErrorResponse(c)
+	}
+
+	c.Set(fiber.HeaderContentType, domain.ContentTypeExport(format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="transaksi-%s.%s"`, time.Now().Format("20060102"), format))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		tulis(w)
+		w.Flush()
+	})
+
+	return nil
+}
+
+func parseTransaksiListRequest(c *fiber.Ctx) *domain.TransaksiListRequest {
+	req := &domain.TransaksiListRequest{
+		Page:          1,
+		PerPage:       10,
+		SortBy:        "tanggal",
+		SortDirection: "desc",
+	}
+
+	if search := c.Query("search"); search != "" {
+		req.Search = &search
+	}
+	if jenis := c.Query("jenis"); jenis != "" {
+		req.Jenis = &jenis
+	}
+	if kantongNama := c.Query("kantong_nama"); kantongNama != "" {
+		req.KantongNama = &kantongNama
+	}
+	if tanggalMulai := c.Query("tanggal_mulai"); tanggalMulai != "" {
+		req.TanggalMulai = &tanggalMulai
+	}
+	if tanggalSelesai := c.Query("tanggal_selesai"); tanggalSelesai != "" {
+		req.TanggalSelesai = &tanggalSelesai
+	}
+	if tag := c.Query("tag"); tag != "" {
+		req.Tag = &tag
+	}
+	if sortBy := c.Query("sort_by"); sortBy != "" {
+		req.SortBy = sortBy
+	}
+	if sortDirection := c.Query("sort_direction"); sortDirection != "" {
+		req.SortDirection = sortDirection
+	}
+	if pagination := c.Query("pagination"); pagination != "" {
+		req.Pagination = pagination
+	}
+	if cursor := c.Query("cursor"); cursor != "" {
+		req.Cursor = &cursor
+	}
+	req.IncludeTotal = c.QueryBool("include_total")
+
+	if page, err := strconv.Atoi(c.Query("page", "1")); err == nil && page > 0 {
+		req.Page = page
+	}
+	if perPage, err := strconv.Atoi(c.Query("per_page", "10")); err == nil && perPage > 0 && perPage <= 100 {
+		req.PerPage = perPage
+	}
+
+	return req
+}
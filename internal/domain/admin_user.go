@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+type AdminUserListRequest struct {
+	Search        *string `json:"search" query:"search"`
+	Status        *string `json:"status" query:"status" validate:"omitempty,oneof=active inactive"`
+	RoleID        *string `json:"role_id" query:"role_id" validate:"omitempty,uuid"`
+	SortBy        string  `json:"sort_by" query:"sort_by" validate:"omitempty,oneof=nama email created_at"`
+	SortDirection string  `json:"sort_direction" query:"sort_direction" validate:"omitempty,oneof=asc desc"`
+	Page          int     `json:"page" query:"page" validate:"min=1"`
+	PerPage       int     `json:"per_page" query:"per_page" validate:"min=1,max=100"`
+}
+
+func (r *AdminUserListRequest) GetOffset() int {
+	return (r.Page - 1) * r.PerPage
+}
+
+func (req *AdminUserListRequest) SetDefaults() {
+	if req.SortBy == "" {
+		req.SortBy = "created_at"
+	}
+	if req.SortDirection == "" {
+		req.SortDirection = "desc"
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PerPage < 1 {
+		req.PerPage = 10
+	}
+}
+
+type UpdateUserStatusRequest struct {
+	IsActive *bool `json:"is_active" validate:"required"`
+}
+
+type AssignUserRoleRequest struct {
+	RoleID *string `json:"role_id" validate:"omitempty,uuid"`
+}
+
+type AdminUserResponse struct {
+	ID              uint       `json:"id"`
+	Nama            string     `json:"nama"`
+	Email           string     `json:"email"`
+	RoleID          *string    `json:"role_id"`
+	Role            string     `json:"role"`
+	IsActive        bool       `json:"is_active"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type AdminUserDetailResponse struct {
+	ID              uint                      `json:"id"`
+	Nama            string                    `json:"nama"`
+	Email           string                    `json:"email"`
+	RoleID          *string                   `json:"role_id"`
+	Role            string                    `json:"role"`
+	IsActive        bool                      `json:"is_active"`
+	EmailVerifiedAt *time.Time                `json:"email_verified_at"`
+	Subscription    *UserSubscriptionResponse `json:"subscription"`
+	Invoices        []*InvoiceResponse        `json:"invoices"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
+}
@@ -13,11 +13,11 @@ type Anggaran struct {
 	UserID      uint      `json:"-" gorm:"not null;index:idx_anggarans_kantong_user,idx_anggarans_user_bulan_tahun"`
 	Bulan       int       `json:"bulan" gorm:"not null;index:idx_anggarans_user_bulan_tahun;check:bulan >= 1 AND bulan <= 12"`
 	Tahun       int       `json:"tahun" gorm:"not null;index:idx_anggarans_user_bulan_tahun;check:tahun >= 2020"`
-	Rencana     *float64  `json:"rencana" gorm:"type:decimal(15,2)"`
-	CarryIn     float64   `json:"carry_in" gorm:"type:decimal(15,2);not null;default:0"`
-	Penyesuaian float64   `json:"penyesuaian" gorm:"type:decimal(15,2);not null;default:0"`
-	Terpakai    float64   `json:"terpakai" gorm:"type:decimal(15,2);not null;default:0"`
-	Sisa        float64   `json:"sisa" gorm:"type:decimal(15,2);not null;default:0"`
+	Rencana     *Money    `json:"rencana" gorm:"type:decimal(15,2)"`
+	CarryIn     Money     `json:"carry_in" gorm:"type:decimal(15,2);not null;default:0"`
+	Penyesuaian Money     `json:"penyesuaian" gorm:"type:decimal(15,2);not null;default:0"`
+	Terpakai    Money     `json:"terpakai" gorm:"type:decimal(15,2);not null;default:0"`
+	Sisa        Money     `json:"sisa" gorm:"type:decimal(15,2);not null;default:0"`
 	Progres     float64   `json:"progres" gorm:"type:decimal(5,2);not null;default:0"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
@@ -40,7 +40,7 @@ type PenyesuaianAnggaran struct {
 	ID         string    `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
 	AnggaranID string    `json:"anggaran_id" gorm:"type:uuid;not null;index"`
 	Jenis      string    `json:"jenis" gorm:"type:varchar(10);not null;check:jenis IN ('tambah','kurangi')"`
-	Jumlah     float64   `json:"jumlah" gorm:"type:decimal(15,2);not null;check:jumlah >= 0"`
+	Jumlah     Money     `json:"jumlah" gorm:"type:decimal(15,2);not null;check:jumlah >= 0"`
 	CreatedAt  time.Time `json:"created_at"`
 	Anggaran   Anggaran  `json:"-" gorm:"foreignKey:AnggaranID"`
 }
@@ -59,11 +59,11 @@ func (p *PenyesuaianAnggaran) TableName() string {
 type AnggaranItem struct {
 	KantongID      string            `json:"kantong_id"`
 	NamaKantong    string            `json:"nama_kantong"`
-	Rencana        *float64          `json:"rencana"`
-	CarryIn        float64           `json:"carry_in"`
-	Penyesuaian    float64           `json:"penyesuaian"`
-	Terpakai       float64           `json:"terpakai"`
-	Sisa           float64           `json:"sisa"`
+	Rencana        *Money            `json:"rencana"`
+	CarryIn        Money             `json:"carry_in"`
+	Penyesuaian    Money             `json:"penyesuaian"`
+	Terpakai       Money             `json:"terpakai"`
+	Sisa           Money             `json:"sisa"`
 	Progres        float64           `json:"progres"`
 	DetailKantong  *Kantong          `json:"detail_kantong,omitempty"`
 	StatistikBulan []StatistikHarian `json:"statistik_bulan,omitempty"`
@@ -76,8 +76,8 @@ type AnggaranItem struct {
 type StatistikHarian struct {
 	Tanggal           time.Time `json:"tanggal"`
 	JumlahTransaksi   int       `json:"jumlah_transaksi"`
-	TotalPengeluaran  float64   `json:"total_pengeluaran"`
-	AkumulasiTerpakai float64   `json:"akumulasi_terpakai"`
+	TotalPengeluaran  Money     `json:"total_pengeluaran"`
+	AkumulasiTerpakai Money     `json:"akumulasi_terpakai"`
 }
 
 type AnggaranListRequest struct {
@@ -103,35 +103,35 @@ func NewAnggaranListRequest() *AnggaranListRequest {
 }
 
 type PenyesuaianAnggaranRequest struct {
-	KantongID string  `json:"kantong_id" validate:"required,uuid"`
-	Jenis     string  `json:"jenis" validate:"required,oneof=tambah kurangi"`
-	Jumlah    float64 `json:"jumlah" validate:"required,min=0"`
-	Bulan     int     `json:"bulan" validate:"required,min=1,max=12"`
-	Tahun     int     `json:"tahun" validate:"required,min=2020"`
+	KantongID string `json:"kantong_id" validate:"required,uuid"`
+	Jenis     string `json:"jenis" validate:"required,oneof=tambah kurangi"`
+	Jumlah    Money  `json:"jumlah" validate:"required,min=0"`
+	Bulan     int    `json:"bulan" validate:"required,min=1,max=12"`
+	Tahun     int    `json:"tahun" validate:"required,min=2020"`
 }
 
 type AnggaranResponse struct {
-	KantongID   string   `json:"kantong_id"`
-	NamaKantong string   `json:"nama_kantong"`
-	Rencana     *float64 `json:"rencana"`
-	CarryIn     float64  `json:"carry_in"`
-	Penyesuaian float64  `json:"penyesuaian"`
-	Terpakai    float64  `json:"terpakai"`
-	Sisa        float64  `json:"sisa"`
-	Progres     float64  `json:"progres"`
-	Bulan       int      `json:"bulan"`
-	Tahun       int      `json:"tahun"`
+	KantongID   string  `json:"kantong_id"`
+	NamaKantong string  `json:"nama_kantong"`
+	Rencana     *Money  `json:"rencana"`
+	CarryIn     Money   `json:"carry_in"`
+	Penyesuaian Money   `json:"penyesuaian"`
+	Terpakai    Money   `json:"terpakai"`
+	Sisa        Money   `json:"sisa"`
+	Progres     float64 `json:"progres"`
+	Bulan       int     `json:"bulan"`
+	Tahun       int     `json:"tahun"`
 }
 
 type AnggaranDetailResponse struct {
 	KantongID      string            `json:"kantong_id"`
 	NamaKantong    string            `json:"nama_kantong"`
 	DetailKantong  *KantongResponse  `json:"detail_kantong"`
-	Rencana        *float64          `json:"rencana"`
-	CarryIn        float64           `json:"carry_in"`
-	Penyesuaian    float64           `json:"penyesuaian"`
-	Terpakai       float64           `json:"terpakai"`
-	Sisa           float64           `json:"sisa"`
+	Rencana        *Money            `json:"rencana"`
+	CarryIn        Money             `json:"carry_in"`
+	Penyesuaian    Money             `json:"penyesuaian"`
+	Terpakai       Money             `json:"terpakai"`
+	Sisa           Money             `json:"sisa"`
 	Progres        float64           `json:"progres"`
 	StatistikBulan []StatistikHarian `json:"statistik_bulan"`
 	Bulan          int               `json:"bulan"`
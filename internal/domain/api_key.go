@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAPIKeyInvalid   = errors.New("api key tidak valid")
+	ErrAPIKeyExpired   = errors.New("api key sudah kedaluwarsa")
+	ErrAccountInactive = errors.New("akun dinonaktifkan")
+)
+
+const (
+	APIKeyResourceKantong   = "kantong"
+	APIKeyResourceTransaksi = "transaksi"
+	APIKeyResourceLaporan   = "laporan"
+
+	APIKeyScopeKantongRead    = "kantong:read"
+	APIKeyScopeKantongWrite   = "kantong:write"
+	APIKeyScopeTransaksiRead  = "transaksi:read"
+	APIKeyScopeTransaksiWrite = "transaksi:write"
+	APIKeyScopeLaporanRead    = "laporan:read"
+	APIKeyScopeLaporanWrite   = "laporan:write"
+)
+
+type APIKey struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	UserID     uint       `json:"user_id" gorm:"not null;index"`
+	Nama       string     `json:"nama" gorm:"not null"`
+	Prefix     string     `json:"prefix" gorm:"uniqueIndex;not null;size:16"`
+	KeyHash    string     `json:"-" gorm:"not null;size:64"`
+	Scopes     string     `json:"scopes" gorm:"not null"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (k *APIKey) TableName() string {
+	return "api_keys"
+}
+
+func (k *APIKey) ScopeList() []string {
+	if k.Scopes == "" {
+		return []string{}
+	}
+	return strings.Split(k.Scopes, ",")
+}
+
+func (k *APIKey) HasScope(resource string, write bool) bool {
+	for _, scope := range k.ScopeList() {
+		if scope == resource+":write" {
+			return true
+		}
+		if !write && scope == resource+":read" {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+type CreateAPIKeyRequest struct {
+	Nama      string     `json:"nama" validate:"required,min=2,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,oneof=kantong:read kantong:write transaksi:read transaksi:write laporan:read laporan:write"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	Nama       string     `json:"nama"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
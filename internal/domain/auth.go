@@ -3,13 +3,17 @@ package domain
 import "time"
 
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Email     string    `json:"email" gorm:"uniqueIndex;not null"`
-	Password  string    `json:"-" gorm:"not null"`
-	Name      string    `json:"name" gorm:"not null"`
-	IsActive  bool      `json:"is_active" gorm:"default:true"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                  uint       `json:"id" gorm:"primaryKey"`
+	Email               string     `json:"email" gorm:"uniqueIndex;not null"`
+	Password            string     `json:"-" gorm:"not null"`
+	Name                string     `json:"name" gorm:"not null"`
+	IsActive            bool       `json:"is_active" gorm:"default:true"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at"`
+	TokensInvalidBefore *time.Time `json:"-"`
+	RoleID              *string    `json:"role_id" gorm:"type:uuid;index"`
+	Role                *Role      `json:"role,omitempty" gorm:"foreignKey:RoleID"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
 }
 
 type AuthRequest struct {
@@ -36,12 +40,23 @@ type NewPasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8"`
 }
 
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
 type AuthResponse struct {
-	User         User   `json:"user"`
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	TokenType    string `json:"token_type"`
-	ExpiresIn    int    `json:"expires_in"`
+	User                      User   `json:"user"`
+	AccessToken               string `json:"access_token,omitempty"`
+	RefreshToken              string `json:"refresh_token,omitempty"`
+	TokenType                 string `json:"token_type,omitempty"`
+	ExpiresIn                 int    `json:"expires_in,omitempty"`
+	EmailVerificationRequired bool   `json:"email_verification_required"`
+	MFARequired               bool   `json:"mfa_required"`
+	MFAToken                  string `json:"mfa_token,omitempty"`
 }
 
 type RefreshTokenResponse struct {
@@ -52,21 +67,75 @@ type RefreshTokenResponse struct {
 }
 
 type RefreshToken struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	UserID     uint       `json:"user_id" gorm:"not null"`
+	Token      string     `json:"token" gorm:"uniqueIndex;not null"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	IsRevoked  bool       `json:"is_revoked" gorm:"default:false"`
+	FamilyID   string     `json:"family_id" gorm:"index;size:36"`
+	ParentID   *uint      `json:"parent_id"`
+	RotatedAt  *time.Time `json:"rotated_at"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	DeviceName string     `json:"device_name"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	User       User       `json:"user" gorm:"foreignKey:UserID"`
+}
+
+type RevokedAccessToken struct {
+	RevocationKey string    `json:"revocation_key" gorm:"primaryKey;size:100"`
+	ExpiresAt     time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+const (
+	AttemptIdentifierEmail = "email"
+	AttemptIdentifierIP    = "ip"
+)
+
+type AttemptIdentifier struct {
+	Kind  string
+	Value string
+}
+
+type SessionMeta struct {
+	UserAgent  string
+	IPAddress  string
+	DeviceName string
+}
+
+type SessionResponse struct {
+	ID         uint      `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	IsCurrent  bool      `json:"is_current"`
+}
+
+type PasswordResetToken struct {
 	ID        uint      `json:"id" gorm:"primaryKey"`
-	UserID    uint      `json:"user_id" gorm:"not null"`
+	Email     string    `json:"email" gorm:"not null"`
 	Token     string    `json:"token" gorm:"uniqueIndex;not null"`
 	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
-	IsRevoked bool      `json:"is_revoked" gorm:"default:false"`
+	IsUsed    bool      `json:"is_used" gorm:"default:false"`
 	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	User      User      `json:"user" gorm:"foreignKey:UserID"`
 }
 
-type PasswordResetToken struct {
+type EmailVerificationToken struct {
 	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
 	Email     string    `json:"email" gorm:"not null"`
 	Token     string    `json:"token" gorm:"uniqueIndex;not null"`
 	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
 	IsUsed    bool      `json:"is_used" gorm:"default:false"`
 	CreatedAt time.Time `json:"created_at"`
 }
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
@@ -146,6 +146,7 @@ type SystemMetrics struct {
 	Database DatabaseStatus     `json:"database"`
 	Redis    RedisStatus        `json:"redis"`
 	Http     HttpMetrics        `json:"http"`
+	Jobs     []JobMetrics       `json:"jobs"`
 }
 
 type ApplicationStatus struct {
@@ -11,7 +11,7 @@ type Invoice struct {
 	ID                 string            `json:"invoice_id" gorm:"primaryKey;type:varchar(50)"`
 	UserID             uint              `json:"user_id" gorm:"not null;index"`
 	User               User              `json:"user" gorm:"foreignKey:UserID"`
-	Jumlah             float64           `json:"jumlah" gorm:"not null;check:jumlah > 0"`
+	Jumlah             Money             `json:"jumlah" gorm:"not null;check:jumlah > 0"`
 	Status             string            `json:"status" gorm:"not null;default:'pending';check:status IN ('sukses','gagal','pending')"`
 	DibayarPada        *time.Time        `json:"dibayar_pada" gorm:"index"`
 	MetodePembayaran   *string           `json:"metode_pembayaran"`
@@ -76,7 +76,7 @@ type InvoiceResponse struct {
 	NamaUser             string     `json:"nama_user"`
 	UserID               uint       `json:"user_id"`
 	UserEmail            string     `json:"user_email"`
-	Jumlah               float64    `json:"jumlah"`
+	Jumlah               Money      `json:"jumlah"`
 	Status               string     `json:"status"`
 	DibayarPada          *time.Time `json:"dibayar_pada"`
 	MetodePembayaran     *string    `json:"metode_pembayaran"`
@@ -92,7 +92,7 @@ type InvoiceDetailResponse struct {
 	NamaUser             string     `json:"nama_user"`
 	UserID               uint       `json:"user_id"`
 	UserEmail            string     `json:"user_email"`
-	Jumlah               float64    `json:"jumlah"`
+	Jumlah               Money      `json:"jumlah"`
 	Status               string     `json:"status"`
 	DibayarPada          *time.Time `json:"dibayar_pada"`
 	MetodePembayaran     *string    `json:"metode_pembayaran"`
@@ -108,23 +108,23 @@ type InvoiceStatistics struct {
 	TotalSukses          int64                 `json:"total_sukses"`
 	TotalGagal           int64                 `json:"total_gagal"`
 	TotalPending         int64                 `json:"total_pending"`
-	TotalPendapatan      float64               `json:"total_pendapatan"`
-	RataRataPembayaran   float64               `json:"rata_rata_pembayaran"`
+	TotalPendapatan      Money                 `json:"total_pendapatan"`
+	RataRataPembayaran   Money                 `json:"rata_rata_pembayaran"`
 	InvoiceBulanan       []InvoiceStatsBulanan `json:"invoice_bulanan"`
 	TopSubscriptionPlans []TopSubscriptionPlan `json:"top_subscription_plans"`
 }
 
 type InvoiceStatsBulanan struct {
-	Bulan           string  `json:"bulan"`
-	TotalInvoice    int64   `json:"total_invoice"`
-	TotalSukses     int64   `json:"total_sukses"`
-	TotalPendapatan float64 `json:"total_pendapatan"`
+	Bulan           string `json:"bulan"`
+	TotalInvoice    int64  `json:"total_invoice"`
+	TotalSukses     int64  `json:"total_sukses"`
+	TotalPendapatan Money  `json:"total_pendapatan"`
 }
 
 type TopSubscriptionPlan struct {
-	SubscriptionPlanNama string  `json:"subscription_plan_nama"`
-	JumlahInvoice        int64   `json:"jumlah_invoice"`
-	TotalPendapatan      float64 `json:"total_pendapatan"`
+	SubscriptionPlanNama string `json:"subscription_plan_nama"`
+	JumlahInvoice        int64  `json:"jumlah_invoice"`
+	TotalPendapatan      Money  `json:"total_pendapatan"`
 }
 
 type InvoiceStatisticsRequest struct {
@@ -1,12 +1,15 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+var ErrKantongTidakDitemukan = errors.New("kantong tidak ditemukan")
+
 type Kantong struct {
 	ID        string    `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
 	IDKartu   string    `json:"id_kartu" gorm:"type:varchar(6);uniqueIndex;not null"`
@@ -14,8 +17,8 @@ type Kantong struct {
 	Nama      string    `json:"nama" gorm:"type:varchar(100);not null;index"`
 	Kategori  string    `json:"kategori" gorm:"type:varchar(20);not null;check:kategori IN ('Pengeluaran','Tabungan','Darurat','Transport','Tidak Spesifik')"`
 	Deskripsi *string   `json:"deskripsi" gorm:"type:varchar(500)"`
-	Limit     *float64  `json:"limit" gorm:"column:limit_amount;type:decimal(15,2);check:limit_amount >= 0"`
-	Saldo     float64   `json:"saldo" gorm:"type:decimal(15,2);not null;default:0;check:saldo >= 0"`
+	Limit     *Money    `json:"limit" gorm:"column:limit_amount;type:decimal(15,2);check:limit_amount >= 0"`
+	Saldo     Money     `json:"saldo" gorm:"type:decimal(15,2);not null;default:0;check:saldo >= 0"`
 	Warna     string    `json:"warna" gorm:"type:varchar(10);not null;check:warna IN ('Navy','Glass','Purple','Green','Red')"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -28,8 +31,8 @@ type KantongResponse struct {
 	Nama      string    `json:"nama"`
 	Kategori  string    `json:"kategori"`
 	Deskripsi *string   `json:"deskripsi"`
-	Limit     *float64  `json:"limit"`
-	Saldo     float64   `json:"saldo"`
+	Limit     *Money    `json:"limit"`
+	Saldo     Money     `json:"saldo"`
 	Warna     string    `json:"warna"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -69,30 +72,30 @@ func (k *Kantong) BeforeCreate(tx *gorm.DB) error {
 }
 
 type CreateKantongRequest struct {
-	Nama      string   `json:"nama" validate:"required,min=1,max=100"`
-	Kategori  string   `json:"kategori" validate:"required,oneof=Pengeluaran Tabungan Darurat Transport 'Tidak Spesifik'"`
-	Deskripsi *string  `json:"deskripsi" validate:"omitempty,max=500"`
-	Limit     *float64 `json:"limit" validate:"omitempty,min=0"`
-	Saldo     *float64 `json:"saldo" validate:"omitempty,min=0"`
-	Warna     string   `json:"warna" validate:"required,oneof=Navy Glass Purple Green Red"`
+	Nama      string  `json:"nama" validate:"required,min=1,max=100"`
+	Kategori  string  `json:"kategori" validate:"required,oneof=Pengeluaran Tabungan Darurat Transport 'Tidak Spesifik'"`
+	Deskripsi *string `json:"deskripsi" validate:"omitempty,max=500"`
+	Limit     *Money  `json:"limit" validate:"omitempty,min=0"`
+	Saldo     *Money  `json:"saldo" validate:"omitempty,min=0"`
+	Warna     string  `json:"warna" validate:"required,oneof=Navy Glass Purple Green Red"`
 }
 
 type UpdateKantongRequest struct {
-	Nama      string   `json:"nama" validate:"required,min=1,max=100"`
-	Kategori  string   `json:"kategori" validate:"required,oneof=Pengeluaran Tabungan Darurat Transport 'Tidak Spesifik'"`
-	Deskripsi *string  `json:"deskripsi" validate:"omitempty,max=500"`
-	Limit     *float64 `json:"limit" validate:"omitempty,min=0"`
-	Saldo     float64  `json:"saldo" validate:"min=0"`
-	Warna     string   `json:"warna" validate:"required,oneof=Navy Glass Purple Green Red"`
+	Nama      string  `json:"nama" validate:"required,min=1,max=100"`
+	Kategori  string  `json:"kategori" validate:"required,oneof=Pengeluaran Tabungan Darurat Transport 'Tidak Spesifik'"`
+	Deskripsi *string `json:"deskripsi" validate:"omitempty,max=500"`
+	Limit     *Money  `json:"limit" validate:"omitempty,min=0"`
+	Saldo     Money   `json:"saldo" validate:"min=0"`
+	Warna     string  `json:"warna" validate:"required,oneof=Navy Glass Purple Green Red"`
 }
 
 type PatchKantongRequest struct {
-	Nama      *string  `json:"nama" validate:"omitempty,min=1,max=100"`
-	Kategori  *string  `json:"kategori" validate:"omitempty,oneof=Pengeluaran Tabungan Darurat Transport 'Tidak Spesifik'"`
-	Deskripsi *string  `json:"deskripsi" validate:"omitempty,max=500"`
-	Limit     *float64 `json:"limit" validate:"omitempty,min=0"`
-	Saldo     *float64 `json:"saldo" validate:"omitempty,min=0"`
-	Warna     *string  `json:"warna" validate:"omitempty,oneof=Navy Glass Purple Green Red"`
+	Nama      *string `json:"nama" validate:"omitempty,min=1,max=100"`
+	Kategori  *string `json:"kategori" validate:"omitempty,oneof=Pengeluaran Tabungan Darurat Transport 'Tidak Spesifik'"`
+	Deskripsi *string `json:"deskripsi" validate:"omitempty,max=500"`
+	Limit     *Money  `json:"limit" validate:"omitempty,min=0"`
+	Saldo     *Money  `json:"saldo" validate:"omitempty,min=0"`
+	Warna     *string `json:"warna" validate:"omitempty,oneof=Navy Glass Purple Green Red"`
 }
 
 type KantongListRequest struct {
@@ -115,24 +118,26 @@ func NewKantongListRequest() *KantongListRequest {
 type TransferKantongRequest struct {
 	KantongAsalID   string  `json:"kantong_asal_id" validate:"required,uuid"`
 	KantongTujuanID string  `json:"kantong_tujuan_id" validate:"required,uuid"`
-	Jumlah          float64 `json:"jumlah" validate:"required,gt=0"`
+	Jumlah          Money   `json:"jumlah" validate:"required,gt=0"`
 	Catatan         *string `json:"catatan" validate:"omitempty,max=500"`
 }
 
 type TransferKantongDetail struct {
-	ID           string  `json:"id"`
-	Nama         string  `json:"nama"`
-	SaldoSebelum float64 `json:"saldo_sebelum"`
-	SaldoSesudah float64 `json:"saldo_sesudah"`
+	ID           string `json:"id"`
+	Nama         string `json:"nama"`
+	SaldoSebelum Money  `json:"saldo_sebelum"`
+	SaldoSesudah Money  `json:"saldo_sesudah"`
 }
 
 type TransferResult struct {
 	TransferID      string                `json:"transfer_id"`
 	KantongAsal     TransferKantongDetail `json:"kantong_asal"`
 	KantongTujuan   TransferKantongDetail `json:"kantong_tujuan"`
-	Jumlah          float64               `json:"jumlah"`
+	Jumlah          Money                 `json:"jumlah"`
 	Catatan         *string               `json:"catatan"`
 	TanggalTransfer time.Time             `json:"tanggal_transfer"`
+	ReversalOfID    *string               `json:"reversal_of_id"`
+	ReversedAt      *time.Time            `json:"reversed_at"`
 }
 
 type TransferKantongResponse struct {
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrJumlahLampiranMelebihiBatas = errors.New("jumlah lampiran melebihi batas")
+
+var ContentTypeLampiranDiizinkan = []string{
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+	"image/heic",
+	"application/pdf",
+}
+
+type LampiranTransaksi struct {
+	ID          string    `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TransaksiID string    `json:"transaksi_id" gorm:"type:uuid;not null;index"`
+	UserID      uint      `json:"-" gorm:"not null;index"`
+	NamaFile    string    `json:"nama_file" gorm:"type:varchar(255);not null"`
+	ContentType string    `json:"content_type" gorm:"type:varchar(100);not null"`
+	Ukuran      int64     `json:"ukuran" gorm:"not null"`
+	StorageKey  string    `json:"-" gorm:"type:varchar(500);not null;uniqueIndex"`
+	CreatedAt   time.Time `json:"created_at"`
+	Transaksi   Transaksi `json:"-" gorm:"foreignKey:TransaksiID;constraint:OnDelete:CASCADE"`
+	User        User      `json:"-" gorm:"foreignKey:UserID"`
+}
+
+func (l *LampiranTransaksi) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	return nil
+}
+
+type LampiranTransaksiResponse struct {
+	ID          string    `json:"id"`
+	TransaksiID string    `json:"transaksi_id"`
+	NamaFile    string    `json:"nama_file"`
+	ContentType string    `json:"content_type"`
+	Ukuran      int64     `json:"ukuran"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func ToLampiranTransaksiResponse(lampiran *LampiranTransaksi) *LampiranTransaksiResponse {
+	return &LampiranTransaksiResponse{
+		ID:          lampiran.ID,
+		TransaksiID: lampiran.TransaksiID,
+		NamaFile:    lampiran.NamaFile,
+		ContentType: lampiran.ContentType,
+		Ukuran:      lampiran.Ukuran,
+		CreatedAt:   lampiran.CreatedAt,
+	}
+}
+
+func DeteksiContentTypeLampiran(header []byte) (string, bool) {
+	if len(header) >= 12 && bytes.Equal(header[4:8], []byte("ftyp")) {
+		switch string(header[8:12]) {
+		case "heic", "heix", "mif1", "msf1":
+			return "image/heic", true
+		}
+	}
+
+	contentType := http.DetectContentType(header)
+	if index := bytes.IndexByte([]byte(contentType), ';'); index >= 0 {
+		contentType = contentType[:index]
+	}
+	for _, diizinkan := range ContentTypeLampiranDiizinkan {
+		if contentType == diizinkan {
+			return contentType, true
+		}
+	}
+	return contentType, false
+}
@@ -3,10 +3,10 @@ package domain
 import "time"
 
 type RingkasanLaporan struct {
-	TotalPemasukan            float64        `json:"total_pemasukan"`
-	TotalPengeluaran          float64        `json:"total_pengeluaran"`
-	TotalSaldo                float64        `json:"total_saldo"`
-	RataRataPengeluaranHarian float64        `json:"rata_rata_pengeluaran_harian"`
+	TotalPemasukan            Money          `json:"total_pemasukan"`
+	TotalPengeluaran          Money          `json:"total_pengeluaran"`
+	TotalSaldo                Money          `json:"total_saldo"`
+	RataRataPengeluaranHarian Money          `json:"rata_rata_pengeluaran_harian"`
 	Periode                   PeriodeTanggal `json:"periode"`
 }
 
@@ -18,21 +18,21 @@ type PeriodeTanggal struct {
 type StatistikTahunan struct {
 	Tahun                 int           `json:"tahun"`
 	DataBulanan           []DataBulanan `json:"data_bulanan"`
-	TotalPemasukanTahun   float64       `json:"total_pemasukan_tahun"`
-	TotalPengeluaranTahun float64       `json:"total_pengeluaran_tahun"`
+	TotalPemasukanTahun   Money         `json:"total_pemasukan_tahun"`
+	TotalPengeluaranTahun Money         `json:"total_pengeluaran_tahun"`
 }
 
 type DataBulanan struct {
-	Bulan            int     `json:"bulan"`
-	NamaBulan        string  `json:"nama_bulan"`
-	TotalPemasukan   float64 `json:"total_pemasukan"`
-	TotalPengeluaran float64 `json:"total_pengeluaran"`
+	Bulan            int    `json:"bulan"`
+	NamaBulan        string `json:"nama_bulan"`
+	TotalPemasukan   Money  `json:"total_pemasukan"`
+	TotalPengeluaran Money  `json:"total_pengeluaran"`
 }
 
 type StatistikKantongBulanan struct {
 	Periode          PeriodeBulan         `json:"periode"`
 	DataKantong      []DataKantongBulanan `json:"data_kantong"`
-	TotalPengeluaran float64              `json:"total_pengeluaran"`
+	TotalPengeluaran Money                `json:"total_pengeluaran"`
 	TotalTransaksi   int                  `json:"total_transaksi"`
 }
 
@@ -46,7 +46,7 @@ type DataKantongBulanan struct {
 	KantongID        string  `json:"kantong_id"`
 	KantongNama      string  `json:"kantong_nama"`
 	Kategori         string  `json:"kategori"`
-	TotalPengeluaran float64 `json:"total_pengeluaran"`
+	TotalPengeluaran Money   `json:"total_pengeluaran"`
 	JumlahTransaksi  int     `json:"jumlah_transaksi"`
 	Persentase       float64 `json:"persentase"`
 }
@@ -54,7 +54,7 @@ type DataKantongBulanan struct {
 type TopKantongPengeluaran struct {
 	Periode               PeriodeBulan     `json:"periode"`
 	TopKantong            []DataTopKantong `json:"top_kantong"`
-	TotalPengeluaranSemua float64          `json:"total_pengeluaran_semua"`
+	TotalPengeluaranSemua Money            `json:"total_pengeluaran_semua"`
 	TotalTransaksiSemua   int              `json:"total_transaksi_semua"`
 }
 
@@ -63,10 +63,10 @@ type DataTopKantong struct {
 	KantongID           string  `json:"kantong_id"`
 	KantongNama         string  `json:"kantong_nama"`
 	Kategori            string  `json:"kategori"`
-	TotalPengeluaran    float64 `json:"total_pengeluaran"`
+	TotalPengeluaran    Money   `json:"total_pengeluaran"`
 	JumlahTransaksi     int     `json:"jumlah_transaksi"`
 	PersentaseDariTotal float64 `json:"persentase_dari_total"`
-	RataRataPengeluaran float64 `json:"rata_rata_pengeluaran"`
+	RataRataPengeluaran Money   `json:"rata_rata_pengeluaran"`
 }
 
 type RingkasanLaporanRequest struct {
@@ -124,30 +124,30 @@ type TopKantongPengeluaranResponse struct {
 type StatistikKantongPeriode struct {
 	Periode          PeriodeTanggal       `json:"periode"`
 	DataKantong      []DataKantongPeriode `json:"data_kantong"`
-	TotalPengeluaran float64              `json:"total_pengeluaran"`
+	TotalPengeluaran Money                `json:"total_pengeluaran"`
 }
 
 type DataKantongPeriode struct {
-	KantongID        string  `json:"kantong_id"`
-	KantongNama      string  `json:"kantong_nama"`
-	TotalPengeluaran float64 `json:"total_pengeluaran"`
+	KantongID        string `json:"kantong_id"`
+	KantongNama      string `json:"kantong_nama"`
+	TotalPengeluaran Money  `json:"total_pengeluaran"`
 }
 
 type PengeluaranKantongDetail struct {
 	Periode                PeriodeTanggal      `json:"periode"`
 	DataKantong            []DataKantongDetail `json:"data_kantong"`
-	TotalPengeluaran       float64             `json:"total_pengeluaran"`
-	TotalSaldoSemuaKantong float64             `json:"total_saldo_semua_kantong"`
+	TotalPengeluaran       Money               `json:"total_pengeluaran"`
+	TotalSaldoSemuaKantong Money               `json:"total_saldo_semua_kantong"`
 }
 
 type DataKantongDetail struct {
 	KantongID           string  `json:"kantong_id"`
 	KantongNama         string  `json:"kantong_nama"`
-	TotalPengeluaran    float64 `json:"total_pengeluaran"`
+	TotalPengeluaran    Money   `json:"total_pengeluaran"`
 	PersentaseDariSaldo float64 `json:"persentase_dari_saldo"`
 	JumlahTransaksi     int     `json:"jumlah_transaksi"`
-	RataRataPengeluaran float64 `json:"rata_rata_pengeluaran"`
-	SaldoKantong        float64 `json:"saldo_kantong"`
+	RataRataPengeluaran Money   `json:"rata_rata_pengeluaran"`
+	SaldoKantong        Money   `json:"saldo_kantong"`
 }
 
 type StatistikKantongPeriodeRequest struct {
@@ -179,8 +179,8 @@ type PengeluaranKantongDetailResponse struct {
 type TrenBulanan struct {
 	Tahun                 int           `json:"tahun"`
 	DataTren              []DataBulanan `json:"data_tren"`
-	TotalPemasukanTahun   float64       `json:"total_pemasukan_tahun"`
-	TotalPengeluaranTahun float64       `json:"total_pengeluaran_tahun"`
+	TotalPemasukanTahun   Money         `json:"total_pemasukan_tahun"`
+	TotalPengeluaranTahun Money         `json:"total_pengeluaran_tahun"`
 }
 
 type TrenBulananRequest struct {
@@ -199,15 +199,15 @@ type PerbandinganKantong struct {
 	BulanIni        PeriodeBulan              `json:"bulan_ini"`
 	BulanSebelumnya PeriodeBulan              `json:"bulan_sebelumnya"`
 	DataKantong     []DataPerbandinganKantong `json:"data_kantong"`
-	TotalBulanIni   float64                   `json:"total_bulan_ini"`
-	TotalBulanLalu  float64                   `json:"total_bulan_lalu"`
+	TotalBulanIni   Money                     `json:"total_bulan_ini"`
+	TotalBulanLalu  Money                     `json:"total_bulan_lalu"`
 }
 
 type DataPerbandinganKantong struct {
-	KantongID       string  `json:"kantong_id"`
-	KantongNama     string  `json:"kantong_nama"`
-	JumlahBulanIni  float64 `json:"jumlah_bulan_ini"`
-	JumlahBulanLalu float64 `json:"jumlah_bulan_lalu"`
+	KantongID       string `json:"kantong_id"`
+	KantongNama     string `json:"kantong_nama"`
+	JumlahBulanIni  Money  `json:"jumlah_bulan_ini"`
+	JumlahBulanLalu Money  `json:"jumlah_bulan_lalu"`
 }
 
 type PerbandinganKantongResponse struct {
@@ -222,9 +222,9 @@ type DetailPerbandinganKantong struct {
 	BulanIni        PeriodeBulan                    `json:"bulan_ini"`
 	BulanSebelumnya PeriodeBulan                    `json:"bulan_sebelumnya"`
 	DataKantong     []DataDetailPerbandinganKantong `json:"data_kantong"`
-	TotalBulanIni   float64                         `json:"total_bulan_ini"`
-	TotalBulanLalu  float64                         `json:"total_bulan_lalu"`
-	RataRataTotal   float64                         `json:"rata_rata_total"`
+	TotalBulanIni   Money                           `json:"total_bulan_ini"`
+	TotalBulanLalu  Money                           `json:"total_bulan_lalu"`
+	RataRataTotal   Money                           `json:"rata_rata_total"`
 	PersentaseTotal float64                         `json:"persentase_total"`
 	TrendTotal      string                          `json:"trend_total"`
 }
@@ -232,9 +232,9 @@ type DetailPerbandinganKantong struct {
 type DataDetailPerbandinganKantong struct {
 	KantongID           string  `json:"kantong_id"`
 	KantongNama         string  `json:"kantong_nama"`
-	JumlahBulanIni      float64 `json:"jumlah_bulan_ini"`
-	JumlahBulanLalu     float64 `json:"jumlah_bulan_lalu"`
-	RataRataPengeluaran float64 `json:"rata_rata_pengeluaran"`
+	JumlahBulanIni      Money   `json:"jumlah_bulan_ini"`
+	JumlahBulanLalu     Money   `json:"jumlah_bulan_lalu"`
+	RataRataPengeluaran Money   `json:"rata_rata_pengeluaran"`
 	Persentase          float64 `json:"persentase"`
 	Trend               string  `json:"trend"`
 }
@@ -246,3 +246,32 @@ type DetailPerbandinganKantongResponse struct {
 	Data      DetailPerbandinganKantong `json:"data"`
 	Timestamp time.Time                 `json:"timestamp"`
 }
+
+type PengeluaranPerTag struct {
+	Periode             PeriodeTanggal       `json:"periode"`
+	DataTag             []DataTagPengeluaran `json:"data_tag"`
+	TotalPengeluaran    Money                `json:"total_pengeluaran"`
+	PengeluaranTanpaTag Money                `json:"pengeluaran_tanpa_tag"`
+}
+
+type DataTagPengeluaran struct {
+	TagID               string  `json:"tag_id"`
+	TagNama             string  `json:"tag_nama"`
+	TotalPengeluaran    Money   `json:"total_pengeluaran"`
+	JumlahTransaksi     int     `json:"jumlah_transaksi"`
+	Persentase          float64 `json:"persentase"`
+	RataRataPengeluaran Money   `json:"rata_rata_pengeluaran"`
+}
+
+type PengeluaranPerTagRequest struct {
+	TanggalMulai   *string `json:"tanggal_mulai" query:"tanggal_mulai"`
+	TanggalSelesai *string `json:"tanggal_selesai" query:"tanggal_selesai"`
+}
+
+type PengeluaranPerTagResponse struct {
+	Success   bool              `json:"success"`
+	Message   string            `json:"message"`
+	Code      int               `json:"code"`
+	Data      PengeluaranPerTag `json:"data"`
+	Timestamp time.Time         `json:"timestamp"`
+}
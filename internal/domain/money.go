@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type Money int64
+
+const moneyScale = 100
+
+var errInvalidMoney = errors.New("format nominal uang tidak valid")
+
+func NewMoney(units int64) Money {
+	return Money(units * moneyScale)
+}
+
+func NewMoneyFromFloat(value float64) Money {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0
+	}
+	m, err := ParseMoney(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		return Money(math.Round(value * moneyScale))
+	}
+	return m
+}
+
+func ParseMoney(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, errInvalidMoney
+	}
+
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, errInvalidMoney
+	}
+
+	scaled := new(big.Int).Mul(rat.Num(), big.NewInt(moneyScale))
+	quotient, remainder := new(big.Int).QuoRem(scaled, rat.Denom(), new(big.Int))
+
+	doubled := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+	if doubled.Cmp(rat.Denom()) >= 0 {
+		if scaled.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		return 0, errInvalidMoney
+	}
+
+	return Money(quotient.Int64()), nil
+}
+
+func ParseMoneyLocale(value string, desimalKoma bool) (Money, error) {
+	value = strings.NewReplacer(" ", "", "\u00a0", "", "'", "").Replace(strings.TrimSpace(value))
+
+	negatif := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negatif = true
+		value = strings.TrimSuffix(strings.TrimPrefix(value, "("), ")")
+	}
+	if strings.HasPrefix(value, "-") {
+		negatif = true
+		value = strings.TrimPrefix(value, "-")
+	}
+	if strings.HasSuffix(value, "-") {
+		negatif = true
+		value = strings.TrimSuffix(value, "-")
+	}
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "Rp"), "IDR")
+
+	ribuan, desimal := ".", ","
+	if !desimalKoma {
+		ribuan, desimal = ",", "."
+	}
+
+	value = strings.ReplaceAll(value, ribuan, "")
+	if strings.Count(value, desimal) > 1 || strings.ContainsAny(value, "+-/eE") {
+		return 0, errInvalidMoney
+	}
+	value = strings.Replace(value, desimal, ".", 1)
+
+	m, err := ParseMoney(value)
+	if err != nil {
+		return 0, err
+	}
+	if negatif {
+		m = -m.Abs()
+	}
+	return m, nil
+}
+
+func (m Money) Float64() float64 {
+	return float64(m) / moneyScale
+}
+
+func (m Money) Minor() int64 {
+	return int64(m)
+}
+
+func (m Money) Div(n int64) Money {
+	if n == 0 {
+		return 0
+	}
+	quotient := int64(m) / n
+	remainder := int64(m) % n
+	if remainder < 0 {
+		remainder = -remainder
+	}
+	absN := n
+	if absN < 0 {
+		absN = -absN
+	}
+	if remainder*2 >= absN {
+		if (int64(m) < 0) != (n < 0) {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+	return Money(quotient)
+}
+
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, value/moneyScale, value%moneyScale)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	text := m.String()
+	text = strings.TrimRight(text, "0")
+	text = strings.TrimSuffix(text, ".")
+	return []byte(text), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := strings.TrimSpace(string(data))
+	if text == "null" {
+		return nil
+	}
+	text = strings.Trim(text, `"`)
+
+	parsed, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalText(data []byte) error {
+	parsed, err := ParseMoney(string(data))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func (m *Money) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		parsed, err := ParseMoney(string(value))
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	case string:
+		parsed, err := ParseMoney(value)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	case int64:
+		*m = NewMoney(value)
+	case float64:
+		*m = NewMoneyFromFloat(value)
+	default:
+		return fmt.Errorf("tidak dapat membaca %T sebagai nominal uang", src)
+	}
+	return nil
+}
+
+func (Money) GormDataType() string {
+	return "decimal(15,2)"
+}
+
+func MoneyPtr(m Money) *Money {
+	return &m
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+const (
+	OAuthProviderGoogle = "google"
+	OAuthProviderGitHub = "github"
+	OAuthProviderOIDC   = "oidc"
+	OAuthProviderFake   = "fake"
+)
+
+type OAuthIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type UserIdentity struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	Provider  string    `json:"provider" gorm:"not null;size:50;uniqueIndex:idx_user_identities_provider_subject"`
+	Subject   string    `json:"subject" gorm:"not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OAuthState struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	StateHash    string    `json:"-" gorm:"uniqueIndex;not null;size:64"`
+	Provider     string    `json:"provider" gorm:"not null;size:50"`
+	CodeVerifier string    `json:"-" gorm:"not null"`
+	Nonce        string    `json:"-" gorm:"not null"`
+	ExpiresAt    time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type OAuthCallbackRequest struct {
+	Code  string `query:"code" validate:"required"`
+	State string `query:"state" validate:"required"`
+}
+
+type OAuthStartResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+	ExpiresIn        int    `json:"expires_in"`
+}
+
+type OAuthProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
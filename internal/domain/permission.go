@@ -7,6 +7,48 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	PermissionSubscriptionPlanRead   = "subscription_plan.read"
+	PermissionSubscriptionPlanCreate = "subscription_plan.create"
+	PermissionSubscriptionPlanUpdate = "subscription_plan.update"
+	PermissionSubscriptionPlanDelete = "subscription_plan.delete"
+	PermissionPermissionRead         = "permission.read"
+	PermissionPermissionCreate       = "permission.create"
+	PermissionPermissionUpdate       = "permission.update"
+	PermissionPermissionDelete       = "permission.delete"
+	PermissionRoleRead               = "role.read"
+	PermissionRoleCreate             = "role.create"
+	PermissionRoleUpdate             = "role.update"
+	PermissionRoleDelete             = "role.delete"
+	PermissionUserSubscriptionRead   = "user_subscription.read"
+	PermissionUserSubscriptionUpdate = "user_subscription.update"
+	PermissionInvoiceRead            = "invoice.read"
+	PermissionInvoiceUpdate          = "invoice.update"
+	PermissionUserRead               = "user.read"
+	PermissionUserUpdate             = "user.update"
+)
+
+var AdminPermissions = []string{
+	PermissionSubscriptionPlanRead,
+	PermissionSubscriptionPlanCreate,
+	PermissionSubscriptionPlanUpdate,
+	PermissionSubscriptionPlanDelete,
+	PermissionPermissionRead,
+	PermissionPermissionCreate,
+	PermissionPermissionUpdate,
+	PermissionPermissionDelete,
+	PermissionRoleRead,
+	PermissionRoleCreate,
+	PermissionRoleUpdate,
+	PermissionRoleDelete,
+	PermissionUserSubscriptionRead,
+	PermissionUserSubscriptionUpdate,
+	PermissionInvoiceRead,
+	PermissionInvoiceUpdate,
+	PermissionUserRead,
+	PermissionUserUpdate,
+}
+
 type Permission struct {
 	ID        string    `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
 	Nama      string    `json:"nama" gorm:"type:varchar(100);not null;uniqueIndex;index"`
@@ -4,6 +4,17 @@ type UpdateProfilRequest struct {
 	Name string `json:"name" validate:"required,min=2,max=100"`
 }
 
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8"`
+}
+
+type ChangePasswordResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
 type ProfilResponse struct {
 	ID        uint   `json:"id"`
 	Name      string `json:"name"`
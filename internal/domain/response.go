@@ -32,6 +32,18 @@ type PaginatedResponse struct {
 	Meta PaginationMeta `json:"meta"`
 }
 
+type CursorPaginationMeta struct {
+	PerPage      int     `json:"per_page"`
+	NextCursor   *string `json:"next_cursor"`
+	PrevCursor   *string `json:"prev_cursor"`
+	TotalRecords *int    `json:"total_records,omitempty"`
+}
+
+type CursorPaginatedResponse struct {
+	SuccessResponse
+	Meta CursorPaginationMeta `json:"meta"`
+}
+
 type ValidationError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+const (
+	JobTokenCleanup      = "token_cleanup"
+	JobTransaksiBerulang = "transaksi_berulang"
+)
+
+type JobMetrics struct {
+	Name             string           `json:"name"`
+	Interval         string           `json:"interval"`
+	Timeout          string           `json:"timeout"`
+	Runs             int64            `json:"runs"`
+	Skipped          int64            `json:"skipped"`
+	LockErrors       int64            `json:"lock_errors"`
+	Failures         int64            `json:"failures"`
+	LastRunAt        *time.Time       `json:"last_run_at"`
+	LastDuration     string           `json:"last_duration"`
+	LastError        string           `json:"last_error,omitempty"`
+	LastRowsAffected map[string]int64 `json:"last_rows_affected"`
+	RowsAffected     map[string]int64 `json:"rows_affected"`
+}
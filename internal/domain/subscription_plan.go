@@ -13,7 +13,7 @@ type SubscriptionPlan struct {
 	ID            uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
 	Kode          string    `json:"kode" gorm:"uniqueIndex;not null"`
 	Nama          string    `json:"nama" gorm:"not null"`
-	Harga         float64   `json:"harga" gorm:"not null;default:0"`
+	Harga         Money     `json:"harga" gorm:"not null;default:0"`
 	Interval      string    `json:"interval" gorm:"not null"`
 	HariPercobaan int       `json:"hari_percobaan" gorm:"default:0"`
 	Status        string    `json:"status" gorm:"not null;default:'aktif'"`
@@ -38,27 +38,27 @@ func (s *SubscriptionPlan) generateKode() {
 }
 
 type CreateSubscriptionPlanRequest struct {
-	Nama          string  `json:"nama" validate:"required,min=1,max=100"`
-	Harga         float64 `json:"harga" validate:"gte=0"`
-	Interval      string  `json:"interval" validate:"required,oneof=bulan tahun"`
-	HariPercobaan int     `json:"hari_percobaan" validate:"gte=0"`
-	Status        string  `json:"status" validate:"required,oneof=aktif 'non aktif'"`
+	Nama          string `json:"nama" validate:"required,min=1,max=100"`
+	Harga         Money  `json:"harga" validate:"gte=0"`
+	Interval      string `json:"interval" validate:"required,oneof=bulan tahun"`
+	HariPercobaan int    `json:"hari_percobaan" validate:"gte=0"`
+	Status        string `json:"status" validate:"required,oneof=aktif 'non aktif'"`
 }
 
 type UpdateSubscriptionPlanRequest struct {
-	Nama          string  `json:"nama" validate:"required,min=1,max=100"`
-	Harga         float64 `json:"harga" validate:"required,gte=0"`
-	Interval      string  `json:"interval" validate:"required,oneof=bulan tahun"`
-	HariPercobaan int     `json:"hari_percobaan" validate:"required,gte=0"`
-	Status        string  `json:"status" validate:"required,oneof=aktif 'non aktif'"`
+	Nama          string `json:"nama" validate:"required,min=1,max=100"`
+	Harga         Money  `json:"harga" validate:"required,gte=0"`
+	Interval      string `json:"interval" validate:"required,oneof=bulan tahun"`
+	HariPercobaan int    `json:"hari_percobaan" validate:"required,gte=0"`
+	Status        string `json:"status" validate:"required,oneof=aktif 'non aktif'"`
 }
 
 type PatchSubscriptionPlanRequest struct {
-	Nama          *string  `json:"nama" validate:"omitempty,min=1,max=100"`
-	Harga         *float64 `json:"harga" validate:"omitempty,gte=0"`
-	Interval      *string  `json:"interval" validate:"omitempty,oneof=bulan tahun"`
-	HariPercobaan *int     `json:"hari_percobaan" validate:"omitempty,gte=0"`
-	Status        *string  `json:"status" validate:"omitempty,oneof=aktif 'non aktif'"`
+	Nama          *string `json:"nama" validate:"omitempty,min=1,max=100"`
+	Harga         *Money  `json:"harga" validate:"omitempty,gte=0"`
+	Interval      *string `json:"interval" validate:"omitempty,oneof=bulan tahun"`
+	HariPercobaan *int    `json:"hari_percobaan" validate:"omitempty,gte=0"`
+	Status        *string `json:"status" validate:"omitempty,oneof=aktif 'non aktif'"`
 }
 
 type SubscriptionPlanListRequest struct {
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Tag struct {
+	ID        string    `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID    uint      `json:"-" gorm:"not null;index"`
+	Nama      string    `json:"nama" gorm:"type:varchar(50);not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	User      User      `json:"-" gorm:"foreignKey:UserID"`
+}
+
+func (t *Tag) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return nil
+}
+
+type TransaksiTag struct {
+	TransaksiID string `gorm:"type:uuid;primaryKey"`
+	TagID       string `gorm:"type:uuid;primaryKey"`
+}
+
+type TagResponse struct {
+	ID              string    `json:"id"`
+	Nama            string    `json:"nama"`
+	JumlahTransaksi int       `json:"jumlah_transaksi"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type TransaksiTagResponse struct {
+	ID   string `json:"id"`
+	Nama string `json:"nama"`
+}
+
+type CreateTagRequest struct {
+	Nama string `json:"nama" validate:"required,min=1,max=50"`
+}
+
+type UpdateTagRequest struct {
+	Nama string `json:"nama" validate:"required,min=1,max=50"`
+}
+
+type TagListRequest struct {
+	Search  *string `json:"search" query:"search"`
+	Page    int     `json:"page" query:"page" validate:"min=1"`
+	PerPage int     `json:"per_page" query:"per_page" validate:"min=1,max=100"`
+}
+
+func NewTagListRequest() *TagListRequest {
+	return &TagListRequest{
+		Page:    1,
+		PerPage: 10,
+	}
+}
+
+func NormalisasiNamaTag(nama string) string {
+	return strings.Join(strings.Fields(nama), " ")
+}
+
+func NormalisasiDaftarTag(names []string) []string {
+	if names == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(names))
+	sudahAda := make(map[string]bool, len(names))
+	for _, nama := range names {
+		nama = NormalisasiNamaTag(nama)
+		kunci := strings.ToLower(nama)
+		if nama == "" || sudahAda[kunci] {
+			continue
+		}
+		sudahAda[kunci] = true
+		result = append(result, nama)
+	}
+	return result
+}
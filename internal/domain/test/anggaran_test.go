@@ -13,11 +13,11 @@ func TestAnggaranItem_Structure(t *testing.T) {
 	anggaran := &domain.AnggaranItem{
 		KantongID:   "550e8400-e29b-41d4-a716-446655440001",
 		NamaKantong: "Kantong Test",
-		Rencana:     &[]float64{1000000}[0],
-		CarryIn:     150000,
-		Penyesuaian: -50000,
-		Terpakai:    450000,
-		Sisa:        650000,
+		Rencana:     domain.MoneyPtr(domain.NewMoney(1000000)),
+		CarryIn:     domain.NewMoney(150000),
+		Penyesuaian: domain.NewMoney(-50000),
+		Terpakai:    domain.NewMoney(450000),
+		Sisa:        domain.NewMoney(650000),
 		Progres:     45.0,
 		Bulan:       9,
 		Tahun:       2024,
@@ -28,11 +28,11 @@ func TestAnggaranItem_Structure(t *testing.T) {
 	assert.NotNil(t, anggaran)
 	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440001", anggaran.KantongID)
 	assert.Equal(t, "Kantong Test", anggaran.NamaKantong)
-	assert.Equal(t, float64(1000000), *anggaran.Rencana)
-	assert.Equal(t, float64(150000), anggaran.CarryIn)
-	assert.Equal(t, float64(-50000), anggaran.Penyesuaian)
-	assert.Equal(t, float64(450000), anggaran.Terpakai)
-	assert.Equal(t, float64(650000), anggaran.Sisa)
+	assert.Equal(t, domain.NewMoney(1000000), *anggaran.Rencana)
+	assert.Equal(t, domain.NewMoney(150000), anggaran.CarryIn)
+	assert.Equal(t, domain.NewMoney(-50000), anggaran.Penyesuaian)
+	assert.Equal(t, domain.NewMoney(450000), anggaran.Terpakai)
+	assert.Equal(t, domain.NewMoney(650000), anggaran.Sisa)
 	assert.Equal(t, float64(45.0), anggaran.Progres)
 	assert.Equal(t, 9, anggaran.Bulan)
 	assert.Equal(t, 2024, anggaran.Tahun)
@@ -56,7 +56,7 @@ func TestPenyesuaianAnggaranRequest_Structure(t *testing.T) {
 	req := &domain.PenyesuaianAnggaranRequest{
 		KantongID: "550e8400-e29b-41d4-a716-446655440001",
 		Jenis:     "kurangi",
-		Jumlah:    50000,
+		Jumlah:    domain.NewMoney(50000),
 		Bulan:     9,
 		Tahun:     2024,
 	}
@@ -64,7 +64,7 @@ func TestPenyesuaianAnggaranRequest_Structure(t *testing.T) {
 	assert.NotNil(t, req)
 	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440001", req.KantongID)
 	assert.Equal(t, "kurangi", req.Jenis)
-	assert.Equal(t, float64(50000), req.Jumlah)
+	assert.Equal(t, domain.NewMoney(50000), req.Jumlah)
 	assert.Equal(t, 9, req.Bulan)
 	assert.Equal(t, 2024, req.Tahun)
 }
@@ -74,11 +74,11 @@ func TestToAnggaranResponse_Conversion(t *testing.T) {
 	anggaran := &domain.AnggaranItem{
 		KantongID:   "550e8400-e29b-41d4-a716-446655440001",
 		NamaKantong: "Kantong Test",
-		Rencana:     &[]float64{1000000}[0],
-		CarryIn:     150000,
-		Penyesuaian: -50000,
-		Terpakai:    450000,
-		Sisa:        650000,
+		Rencana:     domain.MoneyPtr(domain.NewMoney(1000000)),
+		CarryIn:     domain.NewMoney(150000),
+		Penyesuaian: domain.NewMoney(-50000),
+		Terpakai:    domain.NewMoney(450000),
+		Sisa:        domain.NewMoney(650000),
 		Progres:     45.0,
 		Bulan:       9,
 		Tahun:       2024,
@@ -113,7 +113,7 @@ func TestToAnggaranDetailResponse_Conversion(t *testing.T) {
 		IDKartu:  "K4N7G1",
 		Nama:     "Kantong Test",
 		Kategori: "Pengeluaran",
-		Saldo:    750000,
+		Saldo:    domain.NewMoney(750000),
 		Warna:    "Navy",
 	}
 
@@ -121,19 +121,19 @@ func TestToAnggaranDetailResponse_Conversion(t *testing.T) {
 		{
 			Tanggal:           now,
 			JumlahTransaksi:   3,
-			TotalPengeluaran:  125000,
-			AkumulasiTerpakai: 125000,
+			TotalPengeluaran:  domain.NewMoney(125000),
+			AkumulasiTerpakai: domain.NewMoney(125000),
 		},
 	}
 
 	anggaran := &domain.AnggaranItem{
 		KantongID:      "550e8400-e29b-41d4-a716-446655440001",
 		NamaKantong:    "Kantong Test",
-		Rencana:        &[]float64{1000000}[0],
-		CarryIn:        150000,
-		Penyesuaian:    -50000,
-		Terpakai:       450000,
-		Sisa:           650000,
+		Rencana:        domain.MoneyPtr(domain.NewMoney(1000000)),
+		CarryIn:        domain.NewMoney(150000),
+		Penyesuaian:    domain.NewMoney(-50000),
+		Terpakai:       domain.NewMoney(450000),
+		Sisa:           domain.NewMoney(650000),
 		Progres:        45.0,
 		DetailKantong:  kantong,
 		StatistikBulan: statistik,
@@ -160,7 +160,7 @@ func TestToAnggaranResponseList_Conversion(t *testing.T) {
 		{
 			KantongID:   "550e8400-e29b-41d4-a716-446655440001",
 			NamaKantong: "Kantong Test 1",
-			Rencana:     &[]float64{1000000}[0],
+			Rencana:     domain.MoneyPtr(domain.NewMoney(1000000)),
 			Bulan:       9,
 			Tahun:       2024,
 			CreatedAt:   now,
@@ -192,13 +192,13 @@ func TestStatistikHarian_Structure(t *testing.T) {
 	statistik := &domain.StatistikHarian{
 		Tanggal:           now,
 		JumlahTransaksi:   5,
-		TotalPengeluaran:  250000,
-		AkumulasiTerpakai: 750000,
+		TotalPengeluaran:  domain.NewMoney(250000),
+		AkumulasiTerpakai: domain.NewMoney(750000),
 	}
 
 	assert.NotNil(t, statistik)
 	assert.Equal(t, now, statistik.Tanggal)
 	assert.Equal(t, 5, statistik.JumlahTransaksi)
-	assert.Equal(t, float64(250000), statistik.TotalPengeluaran)
-	assert.Equal(t, float64(750000), statistik.AkumulasiTerpakai)
+	assert.Equal(t, domain.NewMoney(250000), statistik.TotalPengeluaran)
+	assert.Equal(t, domain.NewMoney(750000), statistik.AkumulasiTerpakai)
 }
@@ -0,0 +1,30 @@
+package domain_test
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeteksiContentTypeLampiran(t *testing.T) {
+	cases := []struct {
+		nama     string
+		header   []byte
+		expected string
+		valid    bool
+	}{
+		{"png", []byte("\x89PNG\r\n\x1a\n0000"), "image/png", true},
+		{"jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF"), "image/jpeg", true},
+		{"pdf", []byte("%PDF-1.7\n"), "application/pdf", true},
+		{"heic", []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00"), "image/heic", true},
+		{"teks", []byte("halo dunia"), "text/plain", false},
+		{"html", []byte("<html><body>"), "text/html", false},
+	}
+
+	for _, c := range cases {
+		contentType, valid := domain.DeteksiContentTypeLampiran(c.header)
+		assert.Equal(t, c.expected, contentType, c.nama)
+		assert.Equal(t, c.valid, valid, c.nama)
+	}
+}
@@ -15,17 +15,17 @@ func TestRingkasanLaporan_Struct(t *testing.T) {
 	}
 
 	ringkasan := domain.RingkasanLaporan{
-		TotalPemasukan:            5000000.0,
-		TotalPengeluaran:          3500000.0,
-		TotalSaldo:                12500000.0,
-		RataRataPengeluaranHarian: 112903.23,
+		TotalPemasukan:            domain.NewMoney(5000000),
+		TotalPengeluaran:          domain.NewMoney(3500000),
+		TotalSaldo:                domain.NewMoney(12500000),
+		RataRataPengeluaranHarian: domain.NewMoneyFromFloat(112903.23),
 		Periode:                   periode,
 	}
 
-	assert.Equal(t, 5000000.0, ringkasan.TotalPemasukan)
-	assert.Equal(t, 3500000.0, ringkasan.TotalPengeluaran)
-	assert.Equal(t, 12500000.0, ringkasan.TotalSaldo)
-	assert.Equal(t, 112903.23, ringkasan.RataRataPengeluaranHarian)
+	assert.Equal(t, domain.NewMoney(5000000), ringkasan.TotalPemasukan)
+	assert.Equal(t, domain.NewMoney(3500000), ringkasan.TotalPengeluaran)
+	assert.Equal(t, domain.NewMoney(12500000), ringkasan.TotalSaldo)
+	assert.Equal(t, domain.NewMoneyFromFloat(112903.23), ringkasan.RataRataPengeluaranHarian)
 	assert.Equal(t, "2024-01-01", ringkasan.Periode.TanggalMulai)
 	assert.Equal(t, "2024-01-31", ringkasan.Periode.TanggalSelesai)
 }
@@ -35,30 +35,30 @@ func TestStatistikTahunan_Struct(t *testing.T) {
 		{
 			Bulan:            1,
 			NamaBulan:        "Januari",
-			TotalPemasukan:   5000000.0,
-			TotalPengeluaran: 3500000.0,
+			TotalPemasukan:   domain.NewMoney(5000000),
+			TotalPengeluaran: domain.NewMoney(3500000),
 		},
 		{
 			Bulan:            2,
 			NamaBulan:        "Februari",
-			TotalPemasukan:   5200000.0,
-			TotalPengeluaran: 3800000.0,
+			TotalPemasukan:   domain.NewMoney(5200000),
+			TotalPengeluaran: domain.NewMoney(3800000),
 		},
 	}
 
 	statistik := domain.StatistikTahunan{
 		Tahun:                 2024,
 		DataBulanan:           dataBulanan,
-		TotalPemasukanTahun:   60000000.0,
-		TotalPengeluaranTahun: 42000000.0,
+		TotalPemasukanTahun:   domain.NewMoney(60000000),
+		TotalPengeluaranTahun: domain.NewMoney(42000000),
 	}
 
 	assert.Equal(t, 2024, statistik.Tahun)
 	assert.Len(t, statistik.DataBulanan, 2)
 	assert.Equal(t, 1, statistik.DataBulanan[0].Bulan)
 	assert.Equal(t, "Januari", statistik.DataBulanan[0].NamaBulan)
-	assert.Equal(t, 60000000.0, statistik.TotalPemasukanTahun)
-	assert.Equal(t, 42000000.0, statistik.TotalPengeluaranTahun)
+	assert.Equal(t, domain.NewMoney(60000000), statistik.TotalPemasukanTahun)
+	assert.Equal(t, domain.NewMoney(42000000), statistik.TotalPengeluaranTahun)
 }
 
 func TestDataKantongBulanan_Struct(t *testing.T) {
@@ -66,7 +66,7 @@ func TestDataKantongBulanan_Struct(t *testing.T) {
 		KantongID:        "550e8400-e29b-41d4-a716-446655440001",
 		KantongNama:      "Kantong Belanja",
 		Kategori:         "Pengeluaran",
-		TotalPengeluaran: 1500000.0,
+		TotalPengeluaran: domain.NewMoney(1500000),
 		JumlahTransaksi:  25,
 		Persentase:       42.86,
 	}
@@ -74,7 +74,7 @@ func TestDataKantongBulanan_Struct(t *testing.T) {
 	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440001", dataKantong.KantongID)
 	assert.Equal(t, "Kantong Belanja", dataKantong.KantongNama)
 	assert.Equal(t, "Pengeluaran", dataKantong.Kategori)
-	assert.Equal(t, 1500000.0, dataKantong.TotalPengeluaran)
+	assert.Equal(t, domain.NewMoney(1500000), dataKantong.TotalPengeluaran)
 	assert.Equal(t, 25, dataKantong.JumlahTransaksi)
 	assert.Equal(t, 42.86, dataKantong.Persentase)
 }
@@ -86,10 +86,10 @@ func TestRingkasanLaporanResponse_Struct(t *testing.T) {
 	}
 
 	ringkasan := domain.RingkasanLaporan{
-		TotalPemasukan:            5000000.0,
-		TotalPengeluaran:          3500000.0,
-		TotalSaldo:                12500000.0,
-		RataRataPengeluaranHarian: 112903.23,
+		TotalPemasukan:            domain.NewMoney(5000000),
+		TotalPengeluaran:          domain.NewMoney(3500000),
+		TotalSaldo:                domain.NewMoney(12500000),
+		RataRataPengeluaranHarian: domain.NewMoneyFromFloat(112903.23),
 		Periode:                   periode,
 	}
 
@@ -118,26 +118,26 @@ func TestStatistikKantongPeriode_Struct(t *testing.T) {
 		{
 			KantongID:        "550e8400-e29b-41d4-a716-446655440001",
 			KantongNama:      "Kantong Belanja",
-			TotalPengeluaran: 1500000.0,
+			TotalPengeluaran: domain.NewMoney(1500000),
 		},
 		{
 			KantongID:        "550e8400-e29b-41d4-a716-446655440002",
 			KantongNama:      "Transport",
-			TotalPengeluaran: 800000.0,
+			TotalPengeluaran: domain.NewMoney(800000),
 		},
 	}
 
 	statistik := domain.StatistikKantongPeriode{
 		Periode:          periode,
 		DataKantong:      dataKantong,
-		TotalPengeluaran: 2300000.0,
+		TotalPengeluaran: domain.NewMoney(2300000),
 	}
 
 	assert.Equal(t, periode, statistik.Periode)
 	assert.Len(t, statistik.DataKantong, 2)
 	assert.Equal(t, "Kantong Belanja", statistik.DataKantong[0].KantongNama)
-	assert.Equal(t, 1500000.0, statistik.DataKantong[0].TotalPengeluaran)
-	assert.Equal(t, 2300000.0, statistik.TotalPengeluaran)
+	assert.Equal(t, domain.NewMoney(1500000), statistik.DataKantong[0].TotalPengeluaran)
+	assert.Equal(t, domain.NewMoney(2300000), statistik.TotalPengeluaran)
 }
 
 func TestPengeluaranKantongDetail_Struct(t *testing.T) {
@@ -150,27 +150,27 @@ func TestPengeluaranKantongDetail_Struct(t *testing.T) {
 		{
 			KantongID:           "550e8400-e29b-41d4-a716-446655440001",
 			KantongNama:         "Kantong Belanja",
-			TotalPengeluaran:    1500000.0,
+			TotalPengeluaran:    domain.NewMoney(1500000),
 			PersentaseDariSaldo: 15.0,
 			JumlahTransaksi:     25,
-			RataRataPengeluaran: 60000.0,
-			SaldoKantong:        10000000.0,
+			RataRataPengeluaran: domain.NewMoney(60000),
+			SaldoKantong:        domain.NewMoney(10000000),
 		},
 	}
 
 	detail := domain.PengeluaranKantongDetail{
 		Periode:                periode,
 		DataKantong:            dataKantong,
-		TotalPengeluaran:       1500000.0,
-		TotalSaldoSemuaKantong: 10000000.0,
+		TotalPengeluaran:       domain.NewMoney(1500000),
+		TotalSaldoSemuaKantong: domain.NewMoney(10000000),
 	}
 
 	assert.Equal(t, periode, detail.Periode)
 	assert.Len(t, detail.DataKantong, 1)
 	assert.Equal(t, "Kantong Belanja", detail.DataKantong[0].KantongNama)
 	assert.Equal(t, 15.0, detail.DataKantong[0].PersentaseDariSaldo)
-	assert.Equal(t, 60000.0, detail.DataKantong[0].RataRataPengeluaran)
-	assert.Equal(t, 10000000.0, detail.TotalSaldoSemuaKantong)
+	assert.Equal(t, domain.NewMoney(60000), detail.DataKantong[0].RataRataPengeluaran)
+	assert.Equal(t, domain.NewMoney(10000000), detail.TotalSaldoSemuaKantong)
 }
 
 func TestStatistikKantongPeriodeRequest_Struct(t *testing.T) {
@@ -209,14 +209,14 @@ func TestStatistikKantongPeriodeResponse_Struct(t *testing.T) {
 		{
 			KantongID:        "550e8400-e29b-41d4-a716-446655440001",
 			KantongNama:      "Kantong Belanja",
-			TotalPengeluaran: 1500000.0,
+			TotalPengeluaran: domain.NewMoney(1500000),
 		},
 	}
 
 	statistik := domain.StatistikKantongPeriode{
 		Periode:          periode,
 		DataKantong:      dataKantong,
-		TotalPengeluaran: 1500000.0,
+		TotalPengeluaran: domain.NewMoney(1500000),
 	}
 
 	response := domain.StatistikKantongPeriodeResponse{
@@ -244,19 +244,19 @@ func TestPengeluaranKantongDetailResponse_Struct(t *testing.T) {
 		{
 			KantongID:           "550e8400-e29b-41d4-a716-446655440001",
 			KantongNama:         "Kantong Belanja",
-			TotalPengeluaran:    1500000.0,
+			TotalPengeluaran:    domain.NewMoney(1500000),
 			PersentaseDariSaldo: 15.0,
 			JumlahTransaksi:     25,
-			RataRataPengeluaran: 60000.0,
-			SaldoKantong:        10000000.0,
+			RataRataPengeluaran: domain.NewMoney(60000),
+			SaldoKantong:        domain.NewMoney(10000000),
 		},
 	}
 
 	detail := domain.PengeluaranKantongDetail{
 		Periode:                periode,
 		DataKantong:            dataKantong,
-		TotalPengeluaran:       1500000.0,
-		TotalSaldoSemuaKantong: 10000000.0,
+		TotalPengeluaran:       domain.NewMoney(1500000),
+		TotalSaldoSemuaKantong: domain.NewMoney(10000000),
 	}
 
 	response := domain.PengeluaranKantongDetailResponse{
@@ -289,14 +289,14 @@ func TestDataPerbandinganKantong_Struct(t *testing.T) {
 	data := domain.DataPerbandinganKantong{
 		KantongID:       "550e8400-e29b-41d4-a716-446655440001",
 		KantongNama:     "Kantong Belanja",
-		JumlahBulanIni:  1500000.0,
-		JumlahBulanLalu: 1200000.0,
+		JumlahBulanIni:  domain.NewMoney(1500000),
+		JumlahBulanLalu: domain.NewMoney(1200000),
 	}
 
 	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440001", data.KantongID)
 	assert.Equal(t, "Kantong Belanja", data.KantongNama)
-	assert.Equal(t, 1500000.0, data.JumlahBulanIni)
-	assert.Equal(t, 1200000.0, data.JumlahBulanLalu)
+	assert.Equal(t, domain.NewMoney(1500000), data.JumlahBulanIni)
+	assert.Equal(t, domain.NewMoney(1200000), data.JumlahBulanLalu)
 }
 
 func TestTrenBulanan_Struct(t *testing.T) {
@@ -304,28 +304,28 @@ func TestTrenBulanan_Struct(t *testing.T) {
 		{
 			Bulan:            11,
 			NamaBulan:        "November",
-			TotalPemasukan:   5000000.0,
-			TotalPengeluaran: 3500000.0,
+			TotalPemasukan:   domain.NewMoney(5000000),
+			TotalPengeluaran: domain.NewMoney(3500000),
 		},
 		{
 			Bulan:            12,
 			NamaBulan:        "Desember",
-			TotalPemasukan:   5200000.0,
-			TotalPengeluaran: 3800000.0,
+			TotalPemasukan:   domain.NewMoney(5200000),
+			TotalPengeluaran: domain.NewMoney(3800000),
 		},
 	}
 
 	tren := domain.TrenBulanan{
 		Tahun:                 2024,
 		DataTren:              dataTren,
-		TotalPemasukanTahun:   62400000.0,
-		TotalPengeluaranTahun: 45600000.0,
+		TotalPemasukanTahun:   domain.NewMoney(62400000),
+		TotalPengeluaranTahun: domain.NewMoney(45600000),
 	}
 
 	assert.Equal(t, 2024, tren.Tahun)
 	assert.Len(t, tren.DataTren, 2)
-	assert.Equal(t, 62400000.0, tren.TotalPemasukanTahun)
-	assert.Equal(t, 45600000.0, tren.TotalPengeluaranTahun)
+	assert.Equal(t, domain.NewMoney(62400000), tren.TotalPemasukanTahun)
+	assert.Equal(t, domain.NewMoney(45600000), tren.TotalPengeluaranTahun)
 }
 
 func TestPerbandinganKantong_Struct(t *testing.T) {
@@ -345,8 +345,8 @@ func TestPerbandinganKantong_Struct(t *testing.T) {
 		{
 			KantongID:       "550e8400-e29b-41d4-a716-446655440001",
 			KantongNama:     "Kantong Belanja",
-			JumlahBulanIni:  1500000.0,
-			JumlahBulanLalu: 1200000.0,
+			JumlahBulanIni:  domain.NewMoney(1500000),
+			JumlahBulanLalu: domain.NewMoney(1200000),
 		},
 	}
 
@@ -354,33 +354,33 @@ func TestPerbandinganKantong_Struct(t *testing.T) {
 		BulanIni:        bulanIni,
 		BulanSebelumnya: bulanSebelumnya,
 		DataKantong:     dataKantong,
-		TotalBulanIni:   1500000.0,
-		TotalBulanLalu:  1200000.0,
+		TotalBulanIni:   domain.NewMoney(1500000),
+		TotalBulanLalu:  domain.NewMoney(1200000),
 	}
 
 	assert.Equal(t, bulanIni, perbandingan.BulanIni)
 	assert.Equal(t, bulanSebelumnya, perbandingan.BulanSebelumnya)
 	assert.Len(t, perbandingan.DataKantong, 1)
-	assert.Equal(t, 1500000.0, perbandingan.TotalBulanIni)
-	assert.Equal(t, 1200000.0, perbandingan.TotalBulanLalu)
+	assert.Equal(t, domain.NewMoney(1500000), perbandingan.TotalBulanIni)
+	assert.Equal(t, domain.NewMoney(1200000), perbandingan.TotalBulanLalu)
 }
 
 func TestDataDetailPerbandinganKantong_Struct(t *testing.T) {
 	data := domain.DataDetailPerbandinganKantong{
 		KantongID:           "550e8400-e29b-41d4-a716-446655440001",
 		KantongNama:         "Kantong Belanja",
-		JumlahBulanIni:      1500000.0,
-		JumlahBulanLalu:     1200000.0,
-		RataRataPengeluaran: 100000.0,
+		JumlahBulanIni:      domain.NewMoney(1500000),
+		JumlahBulanLalu:     domain.NewMoney(1200000),
+		RataRataPengeluaran: domain.NewMoney(100000),
 		Persentase:          25.0,
 		Trend:               "naik",
 	}
 
 	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440001", data.KantongID)
 	assert.Equal(t, "Kantong Belanja", data.KantongNama)
-	assert.Equal(t, 1500000.0, data.JumlahBulanIni)
-	assert.Equal(t, 1200000.0, data.JumlahBulanLalu)
-	assert.Equal(t, 100000.0, data.RataRataPengeluaran)
+	assert.Equal(t, domain.NewMoney(1500000), data.JumlahBulanIni)
+	assert.Equal(t, domain.NewMoney(1200000), data.JumlahBulanLalu)
+	assert.Equal(t, domain.NewMoney(100000), data.RataRataPengeluaran)
 	assert.Equal(t, 25.0, data.Persentase)
 	assert.Equal(t, "naik", data.Trend)
 }
@@ -402,9 +402,9 @@ func TestDetailPerbandinganKantong_Struct(t *testing.T) {
 		{
 			KantongID:           "550e8400-e29b-41d4-a716-446655440001",
 			KantongNama:         "Kantong Belanja",
-			JumlahBulanIni:      1500000.0,
-			JumlahBulanLalu:     1200000.0,
-			RataRataPengeluaran: 100000.0,
+			JumlahBulanIni:      domain.NewMoney(1500000),
+			JumlahBulanLalu:     domain.NewMoney(1200000),
+			RataRataPengeluaran: domain.NewMoney(100000),
 			Persentase:          25.0,
 			Trend:               "naik",
 		},
@@ -414,9 +414,9 @@ func TestDetailPerbandinganKantong_Struct(t *testing.T) {
 		BulanIni:        bulanIni,
 		BulanSebelumnya: bulanSebelumnya,
 		DataKantong:     dataKantong,
-		TotalBulanIni:   1500000.0,
-		TotalBulanLalu:  1200000.0,
-		RataRataTotal:   100000.0,
+		TotalBulanIni:   domain.NewMoney(1500000),
+		TotalBulanLalu:  domain.NewMoney(1200000),
+		RataRataTotal:   domain.NewMoney(100000),
 		PersentaseTotal: 25.0,
 		TrendTotal:      "naik",
 	}
@@ -424,9 +424,9 @@ func TestDetailPerbandinganKantong_Struct(t *testing.T) {
 	assert.Equal(t, bulanIni, detail.BulanIni)
 	assert.Equal(t, bulanSebelumnya, detail.BulanSebelumnya)
 	assert.Len(t, detail.DataKantong, 1)
-	assert.Equal(t, 1500000.0, detail.TotalBulanIni)
-	assert.Equal(t, 1200000.0, detail.TotalBulanLalu)
-	assert.Equal(t, 100000.0, detail.RataRataTotal)
+	assert.Equal(t, domain.NewMoney(1500000), detail.TotalBulanIni)
+	assert.Equal(t, domain.NewMoney(1200000), detail.TotalBulanLalu)
+	assert.Equal(t, domain.NewMoney(100000), detail.RataRataTotal)
 	assert.Equal(t, 25.0, detail.PersentaseTotal)
 	assert.Equal(t, "naik", detail.TrendTotal)
 }
@@ -436,8 +436,8 @@ func TestTrenBulananResponse_Struct(t *testing.T) {
 	tren := domain.TrenBulanan{
 		Tahun:                 2024,
 		DataTren:              []domain.DataBulanan{},
-		TotalPemasukanTahun:   62400000.0,
-		TotalPengeluaranTahun: 45600000.0,
+		TotalPemasukanTahun:   domain.NewMoney(62400000),
+		TotalPengeluaranTahun: domain.NewMoney(45600000),
 	}
 
 	response := domain.TrenBulananResponse{
@@ -460,8 +460,8 @@ func TestPerbandinganKantongResponse_Struct(t *testing.T) {
 		BulanIni:        domain.PeriodeBulan{},
 		BulanSebelumnya: domain.PeriodeBulan{},
 		DataKantong:     []domain.DataPerbandinganKantong{},
-		TotalBulanIni:   1500000.0,
-		TotalBulanLalu:  1200000.0,
+		TotalBulanIni:   domain.NewMoney(1500000),
+		TotalBulanLalu:  domain.NewMoney(1200000),
 	}
 
 	response := domain.PerbandinganKantongResponse{
@@ -484,9 +484,9 @@ func TestDetailPerbandinganKantongResponse_Struct(t *testing.T) {
 		BulanIni:        domain.PeriodeBulan{},
 		BulanSebelumnya: domain.PeriodeBulan{},
 		DataKantong:     []domain.DataDetailPerbandinganKantong{},
-		TotalBulanIni:   1500000.0,
-		TotalBulanLalu:  1200000.0,
-		RataRataTotal:   100000.0,
+		TotalBulanIni:   domain.NewMoney(1500000),
+		TotalBulanLalu:  domain.NewMoney(1200000),
+		RataRataTotal:   domain.NewMoney(100000),
 		PersentaseTotal: 25.0,
 		TrendTotal:      "naik",
 	}
@@ -0,0 +1,160 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"fiber-boiler-plate/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMoney_UsesMinorUnits(t *testing.T) {
+	assert.Equal(t, int64(15000000), domain.NewMoney(150000).Minor())
+	assert.Equal(t, "150000.00", domain.NewMoney(150000).String())
+	assert.Equal(t, float64(150000), domain.NewMoney(150000).Float64())
+}
+
+func TestParseMoney_RoundsHalfAwayFromZero(t *testing.T) {
+	cases := map[string]string{
+		"1234.565":   "1234.57",
+		"1234.564":   "1234.56",
+		"0.005":      "0.01",
+		"0.0049":     "0.00",
+		"-1.005":     "-1.01",
+		"-1.004":     "-1.00",
+		"1e3":        "1000.00",
+		" 250000 ":   "250000.00",
+		"99999.9999": "100000.00",
+	}
+
+	for input, expected := range cases {
+		m, err := domain.ParseMoney(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, m.String(), input)
+	}
+}
+
+func TestParseMoney_RejectsInvalidInput(t *testing.T) {
+	for _, input := range []string{"", "abc", "1.234.567,89", "12,5", "99999999999999999999"} {
+		_, err := domain.ParseMoney(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestParseMoneyLocale_DesimalKoma(t *testing.T) {
+	cases := map[string]string{
+		"1.234.567,89":  "1234567.89",
+		"Rp 1.000":      "1000.00",
+		"-Rp 1.000,50":  "-1000.50",
+		"(250.000)":     "-250000.00",
+		"75.000-":       "-75000.00",
+		"IDR 12,5":      "12.50",
+		"1\u00a0500,00": "1500.00",
+	}
+
+	for input, expected := range cases {
+		m, err := domain.ParseMoneyLocale(input, true)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, m.String(), input)
+	}
+}
+
+func TestParseMoneyLocale_DesimalTitik(t *testing.T) {
+	m, err := domain.ParseMoneyLocale("1,234,567.89", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "1234567.89", m.String())
+}
+
+func TestParseMoneyLocale_RejectsInvalidInput(t *testing.T) {
+	for _, input := range []string{"", "abc", "1,2,3", "1e3", "--5", "+10"} {
+		_, err := domain.ParseMoneyLocale(input, true)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestNewMoneyFromFloat_RoundsDecimalRepresentation(t *testing.T) {
+	assert.Equal(t, "1.01", domain.NewMoneyFromFloat(1.005).String())
+	assert.Equal(t, "0.30", domain.NewMoneyFromFloat(0.1+0.2).String())
+	assert.Equal(t, "-2.50", domain.NewMoneyFromFloat(-2.499).String())
+}
+
+func TestMoney_SumDoesNotDrift(t *testing.T) {
+	var total domain.Money
+	for i := 0; i < 10; i++ {
+		total += domain.NewMoneyFromFloat(0.1)
+	}
+	assert.Equal(t, domain.NewMoney(1), total)
+
+	var floatTotal float64
+	for i := 0; i < 10; i++ {
+		floatTotal += 0.1
+	}
+	assert.NotEqual(t, float64(1), floatTotal)
+}
+
+func TestMoney_DivRoundsHalfAwayFromZero(t *testing.T) {
+	assert.Equal(t, "3.33", domain.NewMoney(10).Div(3).String())
+	assert.Equal(t, "6.67", domain.NewMoney(20).Div(3).String())
+	assert.Equal(t, "0.03", domain.NewMoneyFromFloat(0.05).Div(2).String())
+	assert.Equal(t, "-0.03", domain.NewMoneyFromFloat(-0.05).Div(2).String())
+	assert.Equal(t, domain.Money(0), domain.NewMoney(10).Div(0))
+}
+
+func TestMoney_MarshalJSON(t *testing.T) {
+	payload, err := json.Marshal(map[string]domain.Money{
+		"bulat":   domain.NewMoney(150000),
+		"desimal": domain.NewMoneyFromFloat(1234.5),
+		"negatif": domain.NewMoneyFromFloat(-0.75),
+		"nol":     0,
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"bulat":150000,"desimal":1234.5,"negatif":-0.75,"nol":0}`, string(payload))
+}
+
+func TestMoney_UnmarshalJSON(t *testing.T) {
+	var body struct {
+		Angka   domain.Money  `json:"angka"`
+		Teks    domain.Money  `json:"teks"`
+		Kosong  *domain.Money `json:"kosong"`
+		Panjang domain.Money  `json:"panjang"`
+	}
+
+	err := json.Unmarshal([]byte(`{"angka":100000.5,"teks":"12.345","kosong":null,"panjang":0.1234567890123456789}`), &body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "100000.50", body.Angka.String())
+	assert.Equal(t, "12.35", body.Teks.String())
+	assert.Nil(t, body.Kosong)
+	assert.Equal(t, "0.12", body.Panjang.String())
+}
+
+func TestMoney_UnmarshalJSON_Invalid(t *testing.T) {
+	var m domain.Money
+	assert.Error(t, json.Unmarshal([]byte(`"seratus"`), &m))
+	assert.Error(t, json.Unmarshal([]byte(`true`), &m))
+}
+
+func TestMoney_ValueAndScan(t *testing.T) {
+	value, err := domain.NewMoneyFromFloat(100.5).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "100.50", value)
+
+	var m domain.Money
+	assert.NoError(t, m.Scan([]byte("2500000.75")))
+	assert.Equal(t, "2500000.75", m.String())
+
+	assert.NoError(t, m.Scan("0.10"))
+	assert.Equal(t, domain.NewMoneyFromFloat(0.1), m)
+
+	assert.NoError(t, m.Scan(int64(42)))
+	assert.Equal(t, domain.NewMoney(42), m)
+
+	assert.NoError(t, m.Scan(float64(19.999)))
+	assert.Equal(t, domain.NewMoney(20), m)
+
+	assert.NoError(t, m.Scan(nil))
+	assert.Equal(t, domain.Money(0), m)
+
+	assert.Error(t, m.Scan(true))
+}
@@ -0,0 +1,17 @@
+package domain_test
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalisasiDaftarTag(t *testing.T) {
+	assert.Nil(t, domain.NormalisasiDaftarTag(nil))
+	assert.Equal(t, []string{}, domain.NormalisasiDaftarTag([]string{}))
+	assert.Equal(t,
+		[]string{"Liburan Bali", "kantor"},
+		domain.NormalisasiDaftarTag([]string{"  Liburan   Bali", "kantor", "liburan bali", " ", "KANTOR"}),
+	)
+}
@@ -0,0 +1,147 @@
+package domain_test
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func tanggal(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func TestTransaksiBerulang_TanggalKejadian_BulananMengikutiHariAkhirBulan(t *testing.T) {
+	berulang := &domain.TransaksiBerulang{
+		Frekuensi:    domain.FrekuensiBulanan,
+		Interval:     1,
+		TanggalAcuan: tanggal("2026-01-31"),
+	}
+
+	assert.Equal(t, tanggal("2026-01-31"), berulang.TanggalKejadian(0))
+	assert.Equal(t, tanggal("2026-02-28"), berulang.TanggalKejadian(1))
+	assert.Equal(t, tanggal("2026-03-31"), berulang.TanggalKejadian(2))
+	assert.Equal(t, tanggal("2026-04-30"), berulang.TanggalKejadian(3))
+}
+
+func TestTransaksiBerulang_TanggalKejadian_FrekuensiDanInterval(t *testing.T) {
+	cases := []struct {
+		frekuensi string
+		interval  int
+		expected  string
+	}{
+		{domain.FrekuensiHarian, 3, "2026-01-07"},
+		{domain.FrekuensiMingguan, 2, "2026-01-29"},
+		{domain.FrekuensiBulanan, 6, "2027-01-01"},
+		{domain.FrekuensiTahunan, 1, "2028-01-01"},
+	}
+
+	for _, c := range cases {
+		berulang := &domain.TransaksiBerulang{
+			Frekuensi:    c.frekuensi,
+			Interval:     c.interval,
+			TanggalAcuan: tanggal("2026-01-01"),
+		}
+		assert.Equal(t, tanggal(c.expected), berulang.TanggalKejadian(2), c.frekuensi)
+	}
+}
+
+func TestTransaksiBerulang_TanggalKejadian_TahunanTanggal29Februari(t *testing.T) {
+	berulang := &domain.TransaksiBerulang{
+		Frekuensi:    domain.FrekuensiTahunan,
+		Interval:     1,
+		TanggalAcuan: tanggal("2028-02-29"),
+	}
+
+	assert.Equal(t, tanggal("2029-02-28"), berulang.TanggalKejadian(1))
+	assert.Equal(t, tanggal("2032-02-29"), berulang.TanggalKejadian(4))
+}
+
+func TestTransaksiBerulang_TetapkanAcuan_MempertahankanUrutan(t *testing.T) {
+	berulang := &domain.TransaksiBerulang{
+		Frekuensi:    domain.FrekuensiBulanan,
+		Interval:     1,
+		TanggalAcuan: tanggal("2026-01-10"),
+		Urutan:       3,
+	}
+
+	berulang.TetapkanAcuan(tanggal("2026-04-25"))
+	berulang.Frekuensi = domain.FrekuensiMingguan
+
+	assert.Equal(t, tanggal("2026-04-25"), berulang.TanggalKejadian(3))
+	assert.Equal(t, tanggal("2026-05-02"), berulang.TanggalKejadian(4))
+}
+
+func TestTransaksiBerulang_Jadwalkan_BerakhirBerdasarkanJumlahKejadian(t *testing.T) {
+	jumlahKejadian := 2
+	berulang := &domain.TransaksiBerulang{
+		Frekuensi:      domain.FrekuensiHarian,
+		Interval:       1,
+		TanggalAcuan:   tanggal("2026-01-01"),
+		JumlahKejadian: &jumlahKejadian,
+		Status:         domain.StatusTransaksiBerulangAktif,
+		Urutan:         1,
+	}
+
+	berulang.Jadwalkan()
+	assert.Equal(t, tanggal("2026-01-02"), *berulang.TanggalBerikutnya)
+	assert.Equal(t, domain.StatusTransaksiBerulangAktif, berulang.Status)
+
+	berulang.Urutan = 2
+	berulang.Jadwalkan()
+	assert.Nil(t, berulang.TanggalBerikutnya)
+	assert.Equal(t, domain.StatusTransaksiBerulangSelesai, berulang.Status)
+}
+
+func TestTransaksiBerulang_Jadwalkan_BerakhirBerdasarkanTanggalSelesai(t *testing.T) {
+	tanggalSelesai := tanggal("2026-03-15")
+	berulang := &domain.TransaksiBerulang{
+		Frekuensi:      domain.FrekuensiBulanan,
+		Interval:       1,
+		TanggalAcuan:   tanggal("2026-01-15"),
+		TanggalSelesai: &tanggalSelesai,
+		Status:         domain.StatusTransaksiBerulangAktif,
+	}
+
+	assert.False(t, berulang.Berakhir(2))
+	assert.True(t, berulang.Berakhir(3))
+
+	berulang.Urutan = 3
+	berulang.Jadwalkan()
+	assert.Equal(t, domain.StatusTransaksiBerulangSelesai, berulang.Status)
+}
+
+func TestTransaksiBerulang_Jadwalkan_TidakMengaktifkanYangDijeda(t *testing.T) {
+	berulang := &domain.TransaksiBerulang{
+		Frekuensi:    domain.FrekuensiHarian,
+		Interval:     1,
+		TanggalAcuan: tanggal("2026-01-01"),
+		Status:       domain.StatusTransaksiBerulangDijeda,
+	}
+
+	berulang.Jadwalkan()
+
+	assert.Equal(t, domain.StatusTransaksiBerulangDijeda, berulang.Status)
+	assert.Equal(t, tanggal("2026-01-01"), *berulang.TanggalBerikutnya)
+}
+
+func TestTransaksiBerulang_CariUrutan(t *testing.T) {
+	berulang := &domain.TransaksiBerulang{
+		Frekuensi:    domain.FrekuensiMingguan,
+		Interval:     1,
+		TanggalAcuan: tanggal("2026-01-05"),
+		Urutan:       1,
+	}
+
+	urutan, ditemukan := berulang.CariUrutan(tanggal("2026-01-26"))
+	assert.True(t, ditemukan)
+	assert.Equal(t, 3, urutan)
+
+	_, ditemukan = berulang.CariUrutan(tanggal("2026-01-27"))
+	assert.False(t, ditemukan)
+
+	_, ditemukan = berulang.CariUrutan(tanggal("2026-01-05"))
+	assert.False(t, ditemukan)
+}
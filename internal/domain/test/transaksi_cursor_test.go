@@ -0,0 +1,49 @@
+package domain_test
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaksiCursor_EncodeDecode(t *testing.T) {
+	transaksi := &domain.TransaksiResponse{
+		ID:      "550e8400-e29b-41d4-a716-446655440001",
+		Tanggal: "2026-10-01",
+		Jumlah:  domain.NewMoney(12500),
+	}
+
+	cursor, err := domain.DecodeTransaksiCursor(domain.NewTransaksiCursor("tanggal", "desc", transaksi, true).Encode(), "tanggal", "desc")
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10-01", cursor.Nilai)
+	assert.Equal(t, transaksi.ID, cursor.ID)
+	assert.True(t, cursor.Mundur)
+
+	cursor, err = domain.DecodeTransaksiCursor(domain.NewTransaksiCursor("jumlah", "asc", transaksi, false).Encode(), "jumlah", "asc")
+	assert.NoError(t, err)
+	nilai, err := cursor.NilaiSort()
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(12500), nilai)
+}
+
+func TestDecodeTransaksiCursor_TidakValid(t *testing.T) {
+	transaksi := &domain.TransaksiResponse{ID: "550e8400-e29b-41d4-a716-446655440001", Tanggal: "2026-10-01"}
+
+	cases := []struct {
+		value         string
+		sortBy        string
+		sortDirection string
+	}{
+		{"bukan-cursor!", "tanggal", "desc"},
+		{domain.NewTransaksiCursor("tanggal", "desc", transaksi, false).Encode(), "jumlah", "desc"},
+		{domain.NewTransaksiCursor("tanggal", "desc", transaksi, false).Encode(), "tanggal", "asc"},
+		{domain.TransaksiCursor{SortBy: "tanggal", SortDirection: "desc", Nilai: "2026-10-01", ID: "123"}.Encode(), "tanggal", "desc"},
+		{domain.TransaksiCursor{SortBy: "tanggal", SortDirection: "desc", Nilai: "01/10/2026", ID: transaksi.ID}.Encode(), "tanggal", "desc"},
+	}
+
+	for _, c := range cases {
+		_, err := domain.DecodeTransaksiCursor(c.value, c.sortBy, c.sortDirection)
+		assert.EqualError(t, err, "cursor tidak valid", c.value)
+	}
+}
@@ -0,0 +1,32 @@
+package domain_test
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaksi_MutasiPerKantong(t *testing.T) {
+	tunggal := &domain.Transaksi{KantongID: "a", Jenis: "Pemasukan", Jumlah: domain.NewMoney(1000)}
+	assert.Equal(t, map[string]domain.Money{"a": domain.NewMoney(1000)}, tunggal.MutasiPerKantong())
+
+	split := &domain.Transaksi{
+		KantongID: "a",
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(1000),
+		Splits: []domain.TransaksiSplit{
+			{KantongID: "a", Jumlah: domain.NewMoney(700)},
+			{KantongID: "b", Jumlah: domain.NewMoney(300)},
+		},
+	}
+	assert.Equal(t, map[string]domain.Money{"a": -domain.NewMoney(700), "b": -domain.NewMoney(300)}, split.MutasiPerKantong())
+}
+
+func TestTotalSplit(t *testing.T) {
+	assert.Equal(t, domain.Money(0), domain.TotalSplit(nil))
+	assert.Equal(t, domain.NewMoney(1500), domain.TotalSplit([]domain.TransaksiSplitRequest{
+		{Jumlah: domain.NewMoney(1000)},
+		{Jumlah: domain.NewMoney(500)},
+	}))
+}
@@ -0,0 +1,50 @@
+package domain
+
+const (
+	MaksimalOperasiBatch = 100
+
+	ModeBatchAtomik   = "atomik"
+	ModeBatchSebagian = "sebagian"
+
+	AksiBatchCreate = "create"
+	AksiBatchUpdate = "update"
+	AksiBatchDelete = "delete"
+
+	StatusBatchBerhasil   = "berhasil"
+	StatusBatchGagal      = "gagal"
+	StatusBatchDibatalkan = "dibatalkan"
+)
+
+type BatchTransaksiRequest struct {
+	Mode    string                  `json:"mode" validate:"omitempty,oneof=atomik sebagian"`
+	Operasi []BatchTransaksiOperasi `json:"operasi" validate:"required,min=1,max=100,dive"`
+}
+
+type BatchTransaksiOperasi struct {
+	Aksi string                  `json:"aksi" validate:"required,oneof=create update delete"`
+	ID   string                  `json:"id" validate:"required_unless=Aksi create,omitempty,uuid"`
+	Data *CreateTransaksiRequest `json:"data" validate:"required_unless=Aksi delete"`
+}
+
+type OperasiBatchTransaksi struct {
+	Aksi       string
+	Transaksi  *Transaksi
+	Sebelumnya *Transaksi
+	Err        error
+}
+
+type BatchTransaksiHasil struct {
+	Indeks int    `json:"indeks"`
+	Aksi   string `json:"aksi"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BatchTransaksiResult struct {
+	Mode     string                `json:"mode"`
+	Total    int                   `json:"total"`
+	Berhasil int                   `json:"berhasil"`
+	Gagal    int                   `json:"gagal"`
+	Hasil    []BatchTransaksiHasil `json:"hasil"`
+}
@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	FrekuensiHarian   = "harian"
+	FrekuensiMingguan = "mingguan"
+	FrekuensiBulanan  = "bulanan"
+	FrekuensiTahunan  = "tahunan"
+)
+
+const (
+	StatusTransaksiBerulangAktif   = "aktif"
+	StatusTransaksiBerulangDijeda  = "dijeda"
+	StatusTransaksiBerulangSelesai = "selesai"
+)
+
+const (
+	StatusKejadianDiproses = "diproses"
+	StatusKejadianDibuat   = "dibuat"
+	StatusKejadianDilewati = "dilewati"
+	StatusKejadianGagal    = "gagal"
+)
+
+const batasPencarianKejadian = 5000
+
+type TransaksiBerulang struct {
+	ID                string     `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID            uint       `json:"-" gorm:"not null;index"`
+	KantongID         string     `json:"kantong_id" gorm:"type:uuid;not null;index"`
+	Jenis             string     `json:"jenis" gorm:"type:varchar(20);not null;check:jenis IN ('Pemasukan','Pengeluaran')"`
+	Jumlah            Money      `json:"jumlah" gorm:"type:decimal(15,2);not null;check:jumlah > 0"`
+	Catatan           *string    `json:"catatan" gorm:"type:varchar(500)"`
+	Frekuensi         string     `json:"frekuensi" gorm:"type:varchar(10);not null;check:frekuensi IN ('harian','mingguan','bulanan','tahunan')"`
+	Interval          int        `json:"interval" gorm:"column:interval_frekuensi;not null;default:1;check:interval_frekuensi > 0"`
+	TanggalMulai      time.Time  `json:"tanggal_mulai" gorm:"type:date;not null"`
+	TanggalSelesai    *time.Time `json:"tanggal_selesai" gorm:"type:date"`
+	JumlahKejadian    *int       `json:"jumlah_kejadian" gorm:"check:jumlah_kejadian > 0"`
+	TanggalAcuan      time.Time  `json:"-" gorm:"type:date;not null"`
+	UrutanAcuan       int        `json:"-" gorm:"not null;default:0"`
+	Urutan            int        `json:"-" gorm:"not null;default:0"`
+	TanggalBerikutnya *time.Time `json:"tanggal_berikutnya" gorm:"type:date;index"`
+	Status            string     `json:"status" gorm:"type:varchar(10);not null;default:'aktif';index;check:status IN ('aktif','dijeda','selesai')"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	User              User       `json:"-" gorm:"foreignKey:UserID"`
+	Kantong           Kantong    `json:"-" gorm:"foreignKey:KantongID"`
+}
+
+func (t *TransaksiBerulang) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (t *TransaksiBerulang) TanggalKejadian(urutan int) time.Time {
+	langkah := (urutan - t.UrutanAcuan) * t.Interval
+
+	switch t.Frekuensi {
+	case FrekuensiMingguan:
+		return t.TanggalAcuan.AddDate(0, 0, 7*langkah)
+	case FrekuensiBulanan:
+		return tambahBulan(t.TanggalAcuan, langkah)
+	case FrekuensiTahunan:
+		return tambahBulan(t.TanggalAcuan, 12*langkah)
+	default:
+		return t.TanggalAcuan.AddDate(0, 0, langkah)
+	}
+}
+
+func (t *TransaksiBerulang) Berakhir(urutan int) bool {
+	if t.JumlahKejadian != nil && urutan >= *t.JumlahKejadian {
+		return true
+	}
+	if t.TanggalSelesai != nil && t.TanggalKejadian(urutan).After(*t.TanggalSelesai) {
+		return true
+	}
+	return false
+}
+
+func (t *TransaksiBerulang) Jadwalkan() {
+	if t.Berakhir(t.Urutan) {
+		t.TanggalBerikutnya = nil
+		t.Status = StatusTransaksiBerulangSelesai
+		return
+	}
+
+	berikutnya := t.TanggalKejadian(t.Urutan)
+	t.TanggalBerikutnya = &berikutnya
+	if t.Status == StatusTransaksiBerulangSelesai {
+		t.Status = StatusTransaksiBerulangAktif
+	}
+}
+
+func (t *TransaksiBerulang) TetapkanAcuan(tanggal time.Time) {
+	t.TanggalAcuan = tanggal
+	t.UrutanAcuan = t.Urutan
+}
+
+func (t *TransaksiBerulang) CariUrutan(tanggal time.Time) (int, bool) {
+	for urutan := t.Urutan; urutan < t.Urutan+batasPencarianKejadian && !t.Berakhir(urutan); urutan++ {
+		kejadian := t.TanggalKejadian(urutan)
+		if kejadian.Equal(tanggal) {
+			return urutan, true
+		}
+		if kejadian.After(tanggal) {
+			break
+		}
+	}
+	return 0, false
+}
+
+func tambahBulan(tanggal time.Time, bulan int) time.Time {
+	tahun, bulanAwal, hari := tanggal.Date()
+	awalBulan := time.Date(tahun, bulanAwal+time.Month(bulan), 1, 0, 0, 0, 0, tanggal.Location())
+	hariTerakhir := awalBulan.AddDate(0, 1, -1).Day()
+	if hari > hariTerakhir {
+		hari = hariTerakhir
+	}
+	return time.Date(awalBulan.Year(), awalBulan.Month(), hari, 0, 0, 0, 0, tanggal.Location())
+}
+
+type KejadianTransaksiBerulang struct {
+	ID                  uint      `json:"id" gorm:"primaryKey"`
+	TransaksiBerulangID string    `json:"transaksi_berulang_id" gorm:"type:uuid;not null;uniqueIndex:idx_kejadian_transaksi_berulang_urutan"`
+	Urutan              int       `json:"urutan" gorm:"not null;uniqueIndex:idx_kejadian_transaksi_berulang_urutan"`
+	Tanggal             time.Time `json:"tanggal" gorm:"type:date;not null"`
+	Status              string    `json:"status" gorm:"type:varchar(10);not null;check:status IN ('diproses','dibuat','dilewati','gagal')"`
+	TransaksiID         *string   `json:"transaksi_id" gorm:"type:uuid"`
+	Pesan               *string   `json:"pesan" gorm:"type:varchar(255)"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type TransaksiBerulangResponse struct {
+	ID                string    `json:"id"`
+	KantongID         string    `json:"kantong_id"`
+	KantongNama       string    `json:"kantong_nama"`
+	Jenis             string    `json:"jenis"`
+	Jumlah            Money     `json:"jumlah"`
+	Catatan           *string   `json:"catatan"`
+	Frekuensi         string    `json:"frekuensi"`
+	Interval          int       `json:"interval"`
+	TanggalMulai      string    `json:"tanggal_mulai"`
+	TanggalSelesai    *string   `json:"tanggal_selesai"`
+	JumlahKejadian    *int      `json:"jumlah_kejadian"`
+	KejadianBerjalan  int       `json:"kejadian_berjalan"`
+	TanggalBerikutnya *string   `json:"tanggal_berikutnya"`
+	Status            string    `json:"status"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func ToTransaksiBerulangResponse(t *TransaksiBerulang) *TransaksiBerulangResponse {
+	if t == nil {
+		return nil
+	}
+
+	response := &TransaksiBerulangResponse{
+		ID:               t.ID,
+		KantongID:        t.KantongID,
+		KantongNama:      t.Kantong.Nama,
+		Jenis:            t.Jenis,
+		Jumlah:           t.Jumlah,
+		Catatan:          t.Catatan,
+		Frekuensi:        t.Frekuensi,
+		Interval:         t.Interval,
+		TanggalMulai:     t.TanggalMulai.Format("2006-01-02"),
+		JumlahKejadian:   t.JumlahKejadian,
+		KejadianBerjalan: t.Urutan,
+		Status:           t.Status,
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+	}
+
+	if t.TanggalSelesai != nil {
+		tanggalSelesai := t.TanggalSelesai.Format("2006-01-02")
+		response.TanggalSelesai = &tanggalSelesai
+	}
+	if t.TanggalBerikutnya != nil {
+		tanggalBerikutnya := t.TanggalBerikutnya.Format("2006-01-02")
+		response.TanggalBerikutnya = &tanggalBerikutnya
+	}
+
+	return response
+}
+
+type CreateTransaksiBerulangRequest struct {
+	KantongID      string  `json:"kantong_id" validate:"required,uuid"`
+	Jenis          string  `json:"jenis" validate:"required,oneof=Pemasukan Pengeluaran"`
+	Jumlah         Money   `json:"jumlah" validate:"required,gt=0"`
+	Catatan        *string `json:"catatan" validate:"omitempty,max=500"`
+	Frekuensi      string  `json:"frekuensi" validate:"required,oneof=harian mingguan bulanan tahunan"`
+	Interval       int     `json:"interval" validate:"omitempty,min=1,max=365"`
+	TanggalMulai   string  `json:"tanggal_mulai" validate:"required,datetime=2006-01-02"`
+	TanggalSelesai *string `json:"tanggal_selesai" validate:"omitempty,datetime=2006-01-02"`
+	JumlahKejadian *int    `json:"jumlah_kejadian" validate:"omitempty,min=1"`
+}
+
+type PatchTransaksiBerulangRequest struct {
+	KantongID         *string `json:"kantong_id,omitempty" validate:"omitempty,uuid"`
+	Jenis             *string `json:"jenis,omitempty" validate:"omitempty,oneof=Pemasukan Pengeluaran"`
+	Jumlah            *Money  `json:"jumlah,omitempty" validate:"omitempty,gt=0"`
+	Catatan           *string `json:"catatan,omitempty" validate:"omitempty,max=500"`
+	Frekuensi         *string `json:"frekuensi,omitempty" validate:"omitempty,oneof=harian mingguan bulanan tahunan"`
+	Interval          *int    `json:"interval,omitempty" validate:"omitempty,min=1,max=365"`
+	TanggalBerikutnya *string `json:"tanggal_berikutnya,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	TanggalSelesai    *string `json:"tanggal_selesai,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	JumlahKejadian    *int    `json:"jumlah_kejadian,omitempty" validate:"omitempty,min=1"`
+}
+
+type LewatiKejadianRequest struct {
+	Tanggal *string `json:"tanggal" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type TransaksiBerulangListRequest struct {
+	Status    *string `json:"status" query:"status" validate:"omitempty,oneof=aktif dijeda selesai"`
+	KantongID *string `json:"kantong_id" query:"kantong_id" validate:"omitempty,uuid"`
+	Page      int     `json:"page" query:"page" validate:"min=1"`
+	PerPage   int     `json:"per_page" query:"per_page" validate:"min=1,max=100"`
+}
+
+func NewTransaksiBerulangListRequest() *TransaksiBerulangListRequest {
+	return &TransaksiBerulangListRequest{
+		Page:    1,
+		PerPage: 10,
+	}
+}
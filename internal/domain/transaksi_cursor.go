@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TransaksiCursor struct {
+	SortBy        string `json:"s"`
+	SortDirection string `json:"d"`
+	Nilai         string `json:"v"`
+	ID            string `json:"id"`
+	Mundur        bool   `json:"b,omitempty"`
+}
+
+func NewTransaksiCursor(sortBy, sortDirection string, transaksi *TransaksiResponse, mundur bool) TransaksiCursor {
+	nilai := transaksi.Tanggal
+	if sortBy == "jumlah" {
+		nilai = transaksi.Jumlah.String()
+	}
+	return TransaksiCursor{SortBy: sortBy, SortDirection: sortDirection, Nilai: nilai, ID: transaksi.ID, Mundur: mundur}
+}
+
+func (c TransaksiCursor) Encode() string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func (c TransaksiCursor) NilaiSort() (interface{}, error) {
+	if c.SortBy == "jumlah" {
+		return ParseMoney(c.Nilai)
+	}
+	return time.Parse("2006-01-02", c.Nilai)
+}
+
+func DecodeTransaksiCursor(value, sortBy, sortDirection string) (*TransaksiCursor, error) {
+	errCursor := errors.New("cursor tidak valid")
+
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errCursor
+	}
+
+	var cursor TransaksiCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, errCursor
+	}
+
+	if cursor.SortBy != sortBy || cursor.SortDirection != sortDirection {
+		return nil, errCursor
+	}
+	if _, err := uuid.Parse(cursor.ID); err != nil {
+		return nil, errCursor
+	}
+	if _, err := cursor.NilaiSort(); err != nil {
+		return nil, errCursor
+	}
+
+	return &cursor, nil
+}
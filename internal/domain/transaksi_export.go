@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+const (
+	FormatExportCSV  = "csv"
+	FormatExportXLSX = "xlsx"
+	FormatExportOFX  = "ofx"
+)
+
+var contentTypeExport = map[string]string{
+	FormatExportCSV:  "text/csv; charset=utf-8",
+	FormatExportXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatExportOFX:  "application/x-ofx",
+}
+
+func FormatExportValid(format string) bool {
+	_, ok := contentTypeExport[format]
+	return ok
+}
+
+func ContentTypeExport(format string) string {
+	return contentTypeExport[format]
+}
+
+type TransaksiExportRow struct {
+	ID            string    `gorm:"column:id"`
+	Tanggal       time.Time `gorm:"column:tanggal"`
+	Jenis         string    `gorm:"column:jenis"`
+	Jumlah        Money     `gorm:"column:jumlah"`
+	KantongID     string    `gorm:"column:kantong_id"`
+	KantongNama   string    `gorm:"column:kantong_nama"`
+	Catatan       *string   `gorm:"column:catatan"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	SaldoBerjalan Money     `gorm:"-"`
+}
+
+func (r *TransaksiExportRow) Mutasi() Money {
+	if r.Jenis == "Pengeluaran" {
+		return -r.Jumlah
+	}
+	return r.Jumlah
+}
+
+type MutasiTransferExport struct {
+	KantongID string    `gorm:"column:kantong_id"`
+	Tanggal   time.Time `gorm:"column:tanggal"`
+	Jumlah    Money     `gorm:"column:jumlah"`
+}
+
+func SaldoBerjalanTersedia(req *TransaksiListRequest) bool {
+	return (req.Search == nil || *req.Search == "") &&
+		(req.Jenis == nil || *req.Jenis == "") &&
+		(req.Tag == nil || *req.Tag == "")
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"strings"
+)
+
+const MaksimalBarisImport = 5000
+
+type ImportTransaksiRequest struct {
+	KantongID        string `form:"kantong_id" validate:"required,uuid"`
+	KolomTanggal     string `form:"kolom_tanggal" validate:"required"`
+	KolomJumlah      string `form:"kolom_jumlah" validate:"required_without_all=KolomDebit KolomKredit"`
+	KolomDebit       string `form:"kolom_debit" validate:"required_with=KolomKredit"`
+	KolomKredit      string `form:"kolom_kredit" validate:"required_with=KolomDebit"`
+	KolomJenis       string `form:"kolom_jenis"`
+	KolomKeterangan  string `form:"kolom_keterangan"`
+	FormatTanggal    string `form:"format_tanggal"`
+	PemisahDesimal   string `form:"pemisah_desimal" validate:"omitempty,oneof=koma titik"`
+	PemisahKolom     string `form:"pemisah_kolom" validate:"omitempty,oneof=koma titik_koma tab pipa"`
+	TanpaHeader      bool   `form:"tanpa_header"`
+	SertakanDuplikat bool   `form:"sertakan_duplikat"`
+}
+
+func (r *ImportTransaksiRequest) LayoutTanggal() string {
+	if r.FormatTanggal == "" {
+		return "2006-01-02"
+	}
+
+	layout := r.FormatTanggal
+	replacer := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02")
+	return replacer.Replace(layout)
+}
+
+func (r *ImportTransaksiRequest) DelimiterKolom() rune {
+	switch r.PemisahKolom {
+	case "titik_koma":
+		return ';'
+	case "tab":
+		return '\t'
+	case "pipa":
+		return '|'
+	default:
+		return ','
+	}
+}
+
+func (r *ImportTransaksiRequest) DesimalKoma() bool {
+	return r.PemisahDesimal != "titik"
+}
+
+type ImportTransaksiRow struct {
+	Baris    int     `json:"baris"`
+	Tanggal  string  `json:"tanggal,omitempty"`
+	Jenis    string  `json:"jenis,omitempty"`
+	Jumlah   Money   `json:"jumlah"`
+	Catatan  *string `json:"catatan"`
+	Duplikat bool    `json:"duplikat"`
+	Error    string  `json:"error,omitempty"`
+}
+
+func (r *ImportTransaksiRow) Valid() bool {
+	return r.Error == ""
+}
+
+type ImportTransaksiPreview struct {
+	KantongID        string               `json:"kantong_id"`
+	TotalBaris       int                  `json:"total_baris"`
+	BarisSiapImpor   int                  `json:"baris_siap_impor"`
+	BarisDuplikat    int                  `json:"baris_duplikat"`
+	BarisError       int                  `json:"baris_error"`
+	TotalPemasukan   Money                `json:"total_pemasukan"`
+	TotalPengeluaran Money                `json:"total_pengeluaran"`
+	SaldoSebelum     Money                `json:"saldo_sebelum"`
+	SaldoSesudah     Money                `json:"saldo_sesudah"`
+	Baris            []ImportTransaksiRow `json:"baris"`
+}
+
+type ImportTransaksiResult struct {
+	KantongID        string               `json:"kantong_id"`
+	Diimpor          int                  `json:"diimpor"`
+	DuplikatDilewati int                  `json:"duplikat_dilewati"`
+	TotalPemasukan   Money                `json:"total_pemasukan"`
+	TotalPengeluaran Money                `json:"total_pengeluaran"`
+	BarisError       []ImportTransaksiRow `json:"baris_error,omitempty"`
+}
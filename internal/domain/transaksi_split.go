@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TransaksiSplit struct {
+	ID          string    `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TransaksiID string    `json:"transaksi_id" gorm:"type:uuid;not null;index"`
+	KantongID   string    `json:"kantong_id" gorm:"type:uuid;not null;index"`
+	Jumlah      Money     `json:"jumlah" gorm:"type:decimal(15,2);not null;check:jumlah > 0"`
+	Catatan     *string   `json:"catatan" gorm:"type:varchar(500)"`
+	Urutan      int       `json:"urutan" gorm:"not null;default:0"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Kantong     Kantong   `json:"-" gorm:"foreignKey:KantongID;constraint:OnDelete:RESTRICT"`
+}
+
+func (s *TransaksiSplit) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return nil
+}
+
+type TransaksiSplitRequest struct {
+	KantongID string  `json:"kantong_id" validate:"required,uuid"`
+	Jumlah    Money   `json:"jumlah" validate:"required,gt=0"`
+	Catatan   *string `json:"catatan" validate:"omitempty,max=500"`
+}
+
+type TransaksiSplitResponse struct {
+	ID          string  `json:"id"`
+	KantongID   string  `json:"kantong_id"`
+	KantongNama string  `json:"kantong_nama"`
+	Jumlah      Money   `json:"jumlah"`
+	Catatan     *string `json:"catatan"`
+}
+
+func (t *Transaksi) MutasiPerKantong() map[string]Money {
+	tanda := Money(1)
+	if t.Jenis == "Pengeluaran" {
+		tanda = -1
+	}
+
+	if len(t.Splits) == 0 {
+		return map[string]Money{t.KantongID: t.Jumlah * tanda}
+	}
+
+	mutasi := make(map[string]Money, len(t.Splits))
+	for _, split := range t.Splits {
+		mutasi[split.KantongID] += split.Jumlah * tanda
+	}
+	return mutasi
+}
+
+func TotalSplit(splits []TransaksiSplitRequest) Money {
+	var total Money
+	for _, split := range splits {
+		total += split.Jumlah
+	}
+	return total
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Transfer struct {
+	ID                 string     `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID             uint       `json:"-" gorm:"not null;index"`
+	KantongAsalID      string     `json:"kantong_asal_id" gorm:"type:uuid;not null;index"`
+	KantongTujuanID    string     `json:"kantong_tujuan_id" gorm:"type:uuid;not null;index"`
+	Jumlah             Money      `json:"jumlah" gorm:"type:decimal(15,2);not null;check:jumlah > 0"`
+	Catatan            *string    `json:"catatan" gorm:"type:varchar(500)"`
+	SaldoAsalSebelum   Money      `json:"saldo_asal_sebelum" gorm:"type:decimal(15,2);not null"`
+	SaldoAsalSesudah   Money      `json:"saldo_asal_sesudah" gorm:"type:decimal(15,2);not null"`
+	SaldoTujuanSebelum Money      `json:"saldo_tujuan_sebelum" gorm:"type:decimal(15,2);not null"`
+	SaldoTujuanSesudah Money      `json:"saldo_tujuan_sesudah" gorm:"type:decimal(15,2);not null"`
+	ReversalOfID       *string    `json:"reversal_of_id" gorm:"type:uuid;uniqueIndex"`
+	ReversedAt         *time.Time `json:"reversed_at"`
+	CreatedAt          time.Time  `json:"created_at" gorm:"index"`
+	User               User       `json:"-" gorm:"foreignKey:UserID"`
+	KantongAsal        Kantong    `json:"-" gorm:"foreignKey:KantongAsalID"`
+	KantongTujuan      Kantong    `json:"-" gorm:"foreignKey:KantongTujuanID"`
+}
+
+func (t *Transfer) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (t *Transfer) IsReversal() bool {
+	return t.ReversalOfID != nil
+}
+
+func (t *Transfer) IsReversed() bool {
+	return t.ReversedAt != nil
+}
+
+func ToTransferResult(transfer *Transfer, kantongAsalNama, kantongTujuanNama string) *TransferResult {
+	if transfer == nil {
+		return nil
+	}
+	return &TransferResult{
+		TransferID: transfer.ID,
+		KantongAsal: TransferKantongDetail{
+			ID:           transfer.KantongAsalID,
+			Nama:         kantongAsalNama,
+			SaldoSebelum: transfer.SaldoAsalSebelum,
+			SaldoSesudah: transfer.SaldoAsalSesudah,
+		},
+		KantongTujuan: TransferKantongDetail{
+			ID:           transfer.KantongTujuanID,
+			Nama:         kantongTujuanNama,
+			SaldoSebelum: transfer.SaldoTujuanSebelum,
+			SaldoSesudah: transfer.SaldoTujuanSesudah,
+		},
+		Jumlah:          transfer.Jumlah,
+		Catatan:         transfer.Catatan,
+		TanggalTransfer: transfer.CreatedAt,
+		ReversalOfID:    transfer.ReversalOfID,
+		ReversedAt:      transfer.ReversedAt,
+	}
+}
+
+type TransferListRequest struct {
+	KantongID      *string `json:"kantong_id" query:"kantong_id" validate:"omitempty,uuid"`
+	TanggalMulai   *string `json:"tanggal_mulai" query:"tanggal_mulai" validate:"omitempty,datetime=2006-01-02"`
+	TanggalSelesai *string `json:"tanggal_selesai" query:"tanggal_selesai" validate:"omitempty,datetime=2006-01-02"`
+	Page           int     `json:"page" query:"page" validate:"min=1"`
+	PerPage        int     `json:"per_page" query:"per_page" validate:"min=1,max=100"`
+}
+
+func NewTransferListRequest() *TransferListRequest {
+	return &TransferListRequest{
+		Page:    1,
+		PerPage: 10,
+	}
+}
+
+type ReverseTransferRequest struct {
+	Catatan *string `json:"catatan" validate:"omitempty,max=500"`
+}
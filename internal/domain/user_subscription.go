@@ -119,14 +119,14 @@ type UserInfoDetail struct {
 type SubscriptionPlanInfo struct {
 	ID    uuid.UUID `json:"id"`
 	Nama  string    `json:"nama"`
-	Harga float64   `json:"harga"`
+	Harga Money     `json:"harga"`
 }
 
 type SubscriptionPlanDetail struct {
 	ID            uuid.UUID `json:"id"`
 	Kode          string    `json:"kode"`
 	Nama          string    `json:"nama"`
-	Harga         float64   `json:"harga"`
+	Harga         Money     `json:"harga"`
 	Interval      string    `json:"interval"`
 	HariPercobaan int       `json:"hari_percobaan"`
 	Status        string    `json:"status"`
@@ -138,8 +138,8 @@ type UserSubscriptionStatistics struct {
 	PausedSubscriptions   int64                    `json:"paused_subscriptions"`
 	TrialingSubscriptions int64                    `json:"trialing_subscriptions"`
 	PaymentMethods        []PaymentMethodStatistic `json:"payment_methods"`
-	MonthlyRevenue        float64                  `json:"monthly_revenue"`
-	YearlyRevenue         float64                  `json:"yearly_revenue"`
+	MonthlyRevenue        Money                    `json:"monthly_revenue"`
+	YearlyRevenue         Money                    `json:"yearly_revenue"`
 }
 
 type PaymentMethodStatistic struct {
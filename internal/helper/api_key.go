@@ -0,0 +1,40 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const apiKeyPrefix = "fbk"
+
+func GenerateAPIKey() (string, string, error) {
+	prefixBytes := make([]byte, 6)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", err
+	}
+
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", err
+	}
+
+	prefix := hex.EncodeToString(prefixBytes)
+	key := apiKeyPrefix + "_" + prefix + "_" + hex.EncodeToString(secretBytes)
+
+	return key, prefix, nil
+}
+
+func ParseAPIKeyPrefix(key string) (string, bool) {
+	parts := strings.Split(strings.TrimSpace(key), "_")
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(key)))
+	return hex.EncodeToString(sum[:])
+}
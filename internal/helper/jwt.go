@@ -12,24 +12,31 @@ import (
 type JWTClaims struct {
 	UserID    uint   `json:"user_id"`
 	Email     string `json:"email"`
+	SessionID string `json:"sid,omitempty"`
 	TokenType string `json:"token_type"`
 	jwt.RegisteredClaims
 }
 
-func GenerateAccessToken(userID uint, email, secret string, expireHours int) (string, error) {
+func GenerateAccessToken(userID uint, email, sessionID string, keys *JWTKeySet, expireHours int) (string, error) {
+	tokenID, err := GenerateResetToken()
+	if err != nil {
+		return "", err
+	}
+
 	claims := JWTClaims{
 		UserID:    userID,
 		Email:     email,
+		SessionID: sessionID,
 		TokenType: "access",
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * time.Duration(expireHours))),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	return keys.Sign(claims)
 }
 
 func GenerateRefreshToken() (string, error) {
@@ -48,20 +55,44 @@ func GenerateResetToken() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
-func ValidateAccessToken(tokenString, secret string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("metode signing tidak valid")
-		}
-		return []byte(secret), nil
-	})
+func GenerateMFAToken(userID uint, email string, keys *JWTKeySet, expireMinutes int) (string, error) {
+	tokenID, err := GenerateResetToken()
+	if err != nil {
+		return "", err
+	}
+
+	claims := JWTClaims{
+		UserID:    userID,
+		Email:     email,
+		TokenType: "mfa",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * time.Duration(expireMinutes))),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return keys.Sign(claims)
+}
+
+func ValidateAccessToken(tokenString string, keys *JWTKeySet) (*JWTClaims, error) {
+	return validateToken(tokenString, keys, "access")
+}
+
+func ValidateMFAToken(tokenString string, keys *JWTKeySet) (*JWTClaims, error) {
+	return validateToken(tokenString, keys, "mfa")
+}
+
+func validateToken(tokenString string, keys *JWTKeySet, tokenType string) (*JWTClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, keys.Keyfunc)
 
 	if err != nil {
 		return nil, err
 	}
 
 	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-		if claims.TokenType != "access" {
+		if claims.TokenType != tokenType {
 			return nil, errors.New("tipe token tidak valid")
 		}
 		return claims, nil
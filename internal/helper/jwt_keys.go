@@ -0,0 +1,315 @@
+package helper
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+type JWTKey struct {
+	ID        string
+	Algorithm string
+	SignKey   interface{}
+	VerifyKey interface{}
+}
+
+type JWTKeySet struct {
+	signing *JWTKey
+	keys    map[string]*JWTKey
+	order   []string
+}
+
+type JWTKeyOptions struct {
+	Algorithm            string
+	Secret               string
+	KeyID                string
+	PrivateKeyFile       string
+	VerificationKeyFiles []string
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func NewHMACKeySet(secret string) *JWTKeySet {
+	key := &JWTKey{
+		Algorithm: JWTAlgorithmHS256,
+		SignKey:   []byte(secret),
+		VerifyKey: []byte(secret),
+	}
+	key.ID = hmacKeyID(secret)
+
+	keySet, _ := NewJWTKeySet(key)
+	return keySet
+}
+
+func NewJWTKeySet(signing *JWTKey, verification ...*JWTKey) (*JWTKeySet, error) {
+	if signing == nil || signing.SignKey == nil {
+		return nil, errors.New("kunci signing JWT wajib diisi")
+	}
+
+	keySet := &JWTKeySet{
+		signing: signing,
+		keys:    make(map[string]*JWTKey),
+	}
+
+	for _, key := range append([]*JWTKey{signing}, verification...) {
+		if key.ID == "" {
+			return nil, errors.New("kid JWT wajib diisi")
+		}
+		if _, exists := keySet.keys[key.ID]; exists {
+			return nil, fmt.Errorf("kid JWT duplikat: %s", key.ID)
+		}
+		keySet.keys[key.ID] = key
+		keySet.order = append(keySet.order, key.ID)
+	}
+
+	return keySet, nil
+}
+
+func LoadJWTKeySet(opts JWTKeyOptions) (*JWTKeySet, error) {
+	algorithm := opts.Algorithm
+	if algorithm == "" {
+		algorithm = JWTAlgorithmHS256
+	}
+
+	var signing *JWTKey
+	switch algorithm {
+	case JWTAlgorithmHS256:
+		if opts.Secret == "" {
+			return nil, errors.New("JWT_SECRET wajib diisi untuk HS256")
+		}
+		signing = &JWTKey{
+			ID:        opts.KeyID,
+			Algorithm: JWTAlgorithmHS256,
+			SignKey:   []byte(opts.Secret),
+			VerifyKey: []byte(opts.Secret),
+		}
+		if signing.ID == "" {
+			signing.ID = hmacKeyID(opts.Secret)
+		}
+	case JWTAlgorithmRS256, JWTAlgorithmEdDSA:
+		if opts.PrivateKeyFile == "" {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY_FILE wajib diisi untuk %s", algorithm)
+		}
+		key, err := loadPrivateKey(algorithm, opts.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if opts.KeyID != "" {
+			key.ID = opts.KeyID
+		}
+		signing = key
+	default:
+		return nil, fmt.Errorf("algoritma JWT tidak didukung: %s", algorithm)
+	}
+
+	var verification []*JWTKey
+	for _, entry := range opts.VerificationKeyFiles {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		kid, path := "", entry
+		if i := strings.Index(entry, "="); i > 0 {
+			kid, path = entry[:i], entry[i+1:]
+		}
+
+		key, err := loadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		if kid != "" {
+			key.ID = kid
+		}
+		if key.ID == signing.ID {
+			continue
+		}
+		verification = append(verification, key)
+	}
+
+	return NewJWTKeySet(signing, verification...)
+}
+
+func (ks *JWTKeySet) SigningKey() *JWTKey {
+	return ks.signing
+}
+
+func (ks *JWTKeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(signingMethod(ks.signing.Algorithm), claims)
+	token.Header["kid"] = ks.signing.ID
+	return token.SignedString(ks.signing.SignKey)
+}
+
+func (ks *JWTKeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	key := ks.signing
+	if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+		found, exists := ks.keys[kid]
+		if !exists {
+			return nil, errors.New("kid token tidak dikenal")
+		}
+		key = found
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, errors.New("metode signing tidak valid")
+	}
+
+	return key.VerifyKey, nil
+}
+
+func (ks *JWTKeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, kid := range ks.order {
+		key := ks.keys[kid]
+		if jwk, ok := publicJWK(key); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+	return jwks
+}
+
+func signingMethod(algorithm string) jwt.SigningMethod {
+	switch algorithm {
+	case JWTAlgorithmRS256:
+		return jwt.SigningMethodRS256
+	case JWTAlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
+
+func loadPrivateKey(algorithm, path string) (*JWTKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca private key JWT: %w", err)
+	}
+
+	switch algorithm {
+	case JWTAlgorithmRS256:
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("private key RSA tidak valid: %w", err)
+		}
+		return newPublicJWTKey(JWTAlgorithmRS256, privateKey, &privateKey.PublicKey), nil
+	default:
+		parsed, err := jwt.ParseEdPrivateKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("private key Ed25519 tidak valid: %w", err)
+		}
+		privateKey, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("private key Ed25519 tidak valid")
+		}
+		return newPublicJWTKey(JWTAlgorithmEdDSA, privateKey, privateKey.Public()), nil
+	}
+}
+
+func loadPublicKey(path string) (*JWTKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca public key JWT: %w", err)
+	}
+
+	if publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes); err == nil {
+		return newPublicJWTKey(JWTAlgorithmRS256, nil, publicKey), nil
+	}
+
+	if publicKey, err := jwt.ParseEdPublicKeyFromPEM(pemBytes); err == nil {
+		return newPublicJWTKey(JWTAlgorithmEdDSA, nil, publicKey), nil
+	}
+
+	return nil, fmt.Errorf("public key JWT tidak valid: %s", path)
+}
+
+func newPublicJWTKey(algorithm string, signKey interface{}, verifyKey crypto.PublicKey) *JWTKey {
+	key := &JWTKey{
+		Algorithm: algorithm,
+		SignKey:   signKey,
+		VerifyKey: verifyKey,
+	}
+	if jwk, ok := publicJWK(key); ok {
+		key.ID = jwkThumbprint(jwk)
+	}
+	return key
+}
+
+func publicJWK(key *JWTKey) (JWK, bool) {
+	switch publicKey := key.VerifyKey.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: key.ID,
+			Use: "sig",
+			Alg: key.Algorithm,
+			N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}, true
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Kid: key.ID,
+			Use: "sig",
+			Alg: key.Algorithm,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(publicKey),
+		}, true
+	default:
+		return JWK{}, false
+	}
+}
+
+func jwkThumbprint(jwk JWK) string {
+	var members interface{}
+	if jwk.Kty == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+
+	encoded, _ := json.Marshal(members)
+	sum := sha256.Sum256(encoded)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func hmacKeyID(secret string) string {
+	sum := sha256.Sum256([]byte("kid:" + secret))
+	return "hs256-" + base64.RawURLEncoding.EncodeToString(sum[:6])
+}
@@ -2,12 +2,18 @@ package helper
 
 import (
 	"errors"
+	"fiber-boiler-plate/internal/domain"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func JWTAuthMiddleware(secret string) fiber.Handler {
+type TokenRevocationChecker interface {
+	IsAccessTokenRevoked(tokenID string, userID uint, sessionID string, issuedAt time.Time) (bool, error)
+}
+
+func JWTAuthMiddleware(keys *JWTKeySet, revocation TokenRevocationChecker) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
@@ -19,17 +25,178 @@ func JWTAuthMiddleware(secret string) fiber.Handler {
 			return SendErrorResponse(c, fiber.StatusUnauthorized, "Format token tidak valid", nil)
 		}
 
-		claims, err := ValidateAccessToken(tokenParts[1], secret)
+		claims, err := ValidateAccessToken(tokenParts[1], keys)
 		if err != nil {
 			return SendErrorResponse(c, fiber.StatusUnauthorized, "Token tidak valid", nil)
 		}
 
+		var issuedAt time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = claims.IssuedAt.Time
+		}
+
+		revoked, err := revocation.IsAccessTokenRevoked(claims.ID, claims.UserID, claims.SessionID, issuedAt)
+		if err != nil {
+			return SendInternalServerErrorResponse(c)
+		}
+		if revoked {
+			return SendErrorResponse(c, fiber.StatusUnauthorized, "Token sudah tidak berlaku", nil)
+		}
+
 		c.Locals("user_id", claims.UserID)
 		c.Locals("user_email", claims.Email)
+		c.Locals("session_id", claims.SessionID)
+		c.Locals("token_id", claims.ID)
+		if claims.ExpiresAt != nil {
+			c.Locals("token_expires_at", claims.ExpiresAt.Time)
+		}
 		return c.Next()
 	}
 }
 
+type APIKeyAuthenticator interface {
+	Authenticate(key string) (*domain.APIKey, error)
+}
+
+func AuthMiddleware(keys *JWTKeySet, revocation TokenRevocationChecker, apiKeys APIKeyAuthenticator, resource string) fiber.Handler {
+	jwtAuth := JWTAuthMiddleware(keys, revocation)
+
+	return func(c *fiber.Ctx) error {
+		scheme, credential, found := strings.Cut(c.Get("Authorization"), " ")
+		if !found || scheme != "ApiKey" {
+			return jwtAuth(c)
+		}
+
+		apiKey, err := apiKeys.Authenticate(credential)
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrAPIKeyInvalid), errors.Is(err, domain.ErrAPIKeyExpired):
+				return SendErrorResponse(c, fiber.StatusUnauthorized, "Api key tidak valid atau sudah kedaluwarsa", nil)
+			case errors.Is(err, domain.ErrAccountInactive):
+				return SendErrorResponse(c, fiber.StatusForbidden, err.Error(), nil)
+			default:
+				return SendInternalServerErrorResponse(c)
+			}
+		}
+
+		write := c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead && c.Method() != fiber.MethodOptions
+		if !apiKey.HasScope(resource, write) {
+			return SendErrorResponse(c, fiber.StatusForbidden, "Api key tidak memiliki akses untuk operasi ini", nil)
+		}
+
+		c.Locals("user_id", apiKey.UserID)
+		c.Locals("api_key_id", apiKey.ID)
+		return c.Next()
+	}
+}
+
+type PermissionProvider interface {
+	GetUserPermissions(userID uint) ([]string, error)
+}
+
+func RequirePermission(provider PermissionProvider, permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := GetUserIDFromToken(c)
+		if err != nil {
+			return SendUnauthorizedResponse(c)
+		}
+
+		permissions, err := provider.GetUserPermissions(userID)
+		if err != nil {
+			return SendInternalServerErrorResponse(c)
+		}
+
+		for _, p := range permissions {
+			if p == permission {
+				return c.Next()
+			}
+		}
+
+		return SendForbiddenResponse(c)
+	}
+}
+
+type EmailVerificationProvider interface {
+	IsEmailVerified(userID uint) (bool, error)
+}
+
+func RequireVerifiedEmail(provider EmailVerificationProvider) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead || c.Method() == fiber.MethodOptions {
+			return c.Next()
+		}
+
+		userID, err := GetUserIDFromToken(c)
+		if err != nil {
+			return SendUnauthorizedResponse(c)
+		}
+
+		verified, err := provider.IsEmailVerified(userID)
+		if err != nil {
+			return SendInternalServerErrorResponse(c)
+		}
+
+		if !verified {
+			return SendErrorResponse(c, fiber.StatusForbidden, "Email belum diverifikasi, silakan verifikasi email Anda terlebih dahulu", nil)
+		}
+
+		return c.Next()
+	}
+}
+
+type LoginAttemptGuard interface {
+	Check(scope string, identifiers []domain.AttemptIdentifier) time.Duration
+	RegisterFailure(scope string, identifiers []domain.AttemptIdentifier) time.Duration
+	Reset(scope string, identifiers []domain.AttemptIdentifier)
+}
+
+func BruteForceProtection(guard LoginAttemptGuard, scope string, countEveryAttempt bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		identifiers := attemptIdentifiers(c)
+
+		if retryAfter := guard.Check(scope, identifiers); retryAfter > 0 {
+			return SendTooManyRequestsResponse(c, retryAfter)
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		switch {
+		case countEveryAttempt || status == fiber.StatusUnauthorized || status == fiber.StatusNotFound:
+			guard.RegisterFailure(scope, identifiers)
+		case status >= fiber.StatusOK && status < fiber.StatusMultipleChoices:
+			var emailIdentifiers []domain.AttemptIdentifier
+			for _, identifier := range identifiers {
+				if identifier.Kind == domain.AttemptIdentifierEmail {
+					emailIdentifiers = append(emailIdentifiers, identifier)
+				}
+			}
+			if len(emailIdentifiers) > 0 {
+				guard.Reset(scope, emailIdentifiers)
+			}
+		}
+
+		return nil
+	}
+}
+
+func attemptIdentifiers(c *fiber.Ctx) []domain.AttemptIdentifier {
+	identifiers := []domain.AttemptIdentifier{{Kind: domain.AttemptIdentifierIP, Value: c.IP()}}
+
+	var body struct {
+		Email string `json:"email"`
+	}
+	if err := c.BodyParser(&body); err == nil {
+		if email := strings.ToLower(strings.TrimSpace(body.Email)); email != "" {
+			identifiers = append(identifiers, domain.AttemptIdentifier{Kind: domain.AttemptIdentifierEmail, Value: email})
+		}
+	}
+
+	return identifiers
+}
+
 func GetUserIDFromToken(c *fiber.Ctx) (uint, error) {
 	userID := c.Locals("user_id")
 	if userID == nil {
@@ -42,3 +209,14 @@ func GetUserIDFromToken(c *fiber.Ctx) (uint, error) {
 
 	return 0, errors.New("user ID tidak valid")
 }
+
+func GetAccessTokenFromContext(c *fiber.Ctx) (string, time.Time) {
+	tokenID, _ := c.Locals("token_id").(string)
+	expiresAt, _ := c.Locals("token_expires_at").(time.Time)
+	return tokenID, expiresAt
+}
+
+func GetSessionIDFromToken(c *fiber.Ctx) string {
+	id, _ := c.Locals("session_id").(string)
+	return id
+}
@@ -2,6 +2,8 @@ package helper
 
 import (
 	"fiber-boiler-plate/internal/domain"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -50,6 +52,22 @@ func SendPaginatedResponse(c *fiber.Ctx, code int, message string, data interfac
 	return c.Status(code).JSON(response)
 }
 
+func SendCursorPaginatedResponse(c *fiber.Ctx, code int, message string, data interface{}, meta domain.CursorPaginationMeta) error {
+	response := domain.CursorPaginatedResponse{
+		SuccessResponse: domain.SuccessResponse{
+			BaseResponse: domain.BaseResponse{
+				Success: true,
+				Message: message,
+				Code:    code,
+			},
+			Data:      data,
+			Timestamp: time.Now(),
+		},
+		Meta: meta,
+	}
+	return c.Status(code).JSON(response)
+}
+
 func SendValidationErrorResponse(c *fiber.Ctx, validationErrors []domain.ValidationError) error {
 	return SendErrorResponse(c, fiber.StatusBadRequest, "Data validasi tidak valid", validationErrors)
 }
@@ -79,3 +97,12 @@ func SendNotFoundResponse(c *fiber.Ctx, message string) error {
 	}
 	return SendErrorResponse(c, fiber.StatusNotFound, message, nil)
 }
+
+func SendTooManyRequestsResponse(c *fiber.Ctx, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+	return SendErrorResponse(c, fiber.StatusTooManyRequests, "Terlalu banyak percobaan, silakan coba lagi dalam "+strconv.Itoa(seconds)+" detik", nil)
+}
@@ -0,0 +1,74 @@
+package helper
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const maxDeviceNameLength = 100
+
+func GetSessionMeta(c *fiber.Ctx) domain.SessionMeta {
+	userAgent := c.Get(fiber.HeaderUserAgent)
+
+	deviceName := strings.TrimSpace(c.Get("X-Device-Name"))
+	if deviceName == "" {
+		deviceName = ParseDeviceName(userAgent)
+	}
+	if len(deviceName) > maxDeviceNameLength {
+		deviceName = deviceName[:maxDeviceNameLength]
+	}
+
+	return domain.SessionMeta{
+		UserAgent:  userAgent,
+		IPAddress:  c.IP(),
+		DeviceName: deviceName,
+	}
+}
+
+func ParseDeviceName(userAgent string) string {
+	if userAgent == "" {
+		return "Perangkat tidak dikenal"
+	}
+
+	browser := detectUserAgentPart(userAgent, [][2]string{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	})
+	os := detectUserAgentPart(userAgent, [][2]string{
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Linux", "Linux"},
+	})
+
+	switch {
+	case browser != "" && os != "":
+		return browser + " di " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	}
+
+	name := strings.SplitN(userAgent, " ", 2)[0]
+	if len(name) > maxDeviceNameLength {
+		name = name[:maxDeviceNameLength]
+	}
+	return name
+}
+
+func detectUserAgentPart(userAgent string, candidates [][2]string) string {
+	for _, candidate := range candidates {
+		if strings.Contains(userAgent, candidate[0]) {
+			return candidate[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,29 @@
+package helper_test
+
+import (
+	"fiber-boiler-plate/internal/helper"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, err := helper.GenerateAPIKey()
+
+	assert.NoError(t, err)
+	parsedPrefix, ok := helper.ParseAPIKeyPrefix(key)
+	assert.True(t, ok)
+	assert.Equal(t, prefix, parsedPrefix)
+	assert.Len(t, helper.HashAPIKey(key), 64)
+
+	otherKey, otherPrefix, _ := helper.GenerateAPIKey()
+	assert.NotEqual(t, key, otherKey)
+	assert.NotEqual(t, prefix, otherPrefix)
+}
+
+func TestParseAPIKeyPrefix_Invalid(t *testing.T) {
+	for _, key := range []string{"", "fbk_", "abc_def_ghi", "fbk_onlyprefix", "fbk__secret"} {
+		_, ok := helper.ParseAPIKeyPrefix(key)
+		assert.False(t, ok, key)
+	}
+}
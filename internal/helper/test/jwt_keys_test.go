@@ -0,0 +1,164 @@
+package helper_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fiber-boiler-plate/internal/helper"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeRSAKeyPair(t *testing.T, dir, name string) (string, string) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	privateFile := filepath.Join(dir, name+".pem")
+	assert.NoError(t, os.WriteFile(privateFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}), 0600))
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NoError(t, err)
+	publicFile := filepath.Join(dir, name+".pub.pem")
+	assert.NoError(t, os.WriteFile(publicFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0600))
+
+	return privateFile, publicFile
+}
+
+func writeEd25519KeyPair(t *testing.T, dir, name string) (string, string) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.NoError(t, err)
+	privateFile := filepath.Join(dir, name+".pem")
+	assert.NoError(t, os.WriteFile(privateFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0600))
+
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	assert.NoError(t, err)
+	publicFile := filepath.Join(dir, name+".pub.pem")
+	assert.NoError(t, os.WriteFile(publicFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0600))
+
+	return privateFile, publicFile
+}
+
+func TestLoadJWTKeySet_RS256(t *testing.T) {
+	privateFile, _ := writeRSAKeyPair(t, t.TempDir(), "current")
+
+	keys, err := helper.LoadJWTKeySet(helper.JWTKeyOptions{Algorithm: "RS256", PrivateKeyFile: privateFile, KeyID: "2024-01"})
+	assert.NoError(t, err)
+
+	token, err := helper.GenerateAccessToken(1, "test@example.com", "family-3", keys, 1)
+	assert.NoError(t, err)
+
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, &helper.JWTClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "RS256", parsed.Method.Alg())
+	assert.Equal(t, "2024-01", parsed.Header["kid"])
+
+	claims, err := helper.ValidateAccessToken(token, keys)
+	assert.NoError(t, err)
+	assert.Equal(t, "family-3", claims.SessionID)
+
+	jwks := keys.JWKS()
+	assert.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "RSA", jwks.Keys[0].Kty)
+	assert.Equal(t, "2024-01", jwks.Keys[0].Kid)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+}
+
+func TestLoadJWTKeySet_EdDSA(t *testing.T) {
+	privateFile, _ := writeEd25519KeyPair(t, t.TempDir(), "current")
+
+	keys, err := helper.LoadJWTKeySet(helper.JWTKeyOptions{Algorithm: "EdDSA", PrivateKeyFile: privateFile})
+	assert.NoError(t, err)
+
+	token, err := helper.GenerateAccessToken(1, "test@example.com", "", keys, 1)
+	assert.NoError(t, err)
+
+	_, err = helper.ValidateAccessToken(token, keys)
+	assert.NoError(t, err)
+
+	jwks := keys.JWKS()
+	assert.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "Ed25519", jwks.Keys[0].Crv)
+	assert.Equal(t, keys.SigningKey().ID, jwks.Keys[0].Kid)
+	assert.NotEmpty(t, jwks.Keys[0].Kid)
+}
+
+func TestLoadJWTKeySet_RotationKeepsOldKeyForVerification(t *testing.T) {
+	dir := t.TempDir()
+	oldPrivate, oldPublic := writeRSAKeyPair(t, dir, "old")
+	newPrivate, _ := writeEd25519KeyPair(t, dir, "new")
+
+	oldKeys, err := helper.LoadJWTKeySet(helper.JWTKeyOptions{Algorithm: "RS256", PrivateKeyFile: oldPrivate, KeyID: "old"})
+	assert.NoError(t, err)
+	oldToken, err := helper.GenerateAccessToken(1, "test@example.com", "", oldKeys, 1)
+	assert.NoError(t, err)
+
+	rotatedKeys, err := helper.LoadJWTKeySet(helper.JWTKeyOptions{
+		Algorithm:            "EdDSA",
+		PrivateKeyFile:       newPrivate,
+		KeyID:                "new",
+		VerificationKeyFiles: []string{"old=" + oldPublic},
+	})
+	assert.NoError(t, err)
+
+	_, err = helper.ValidateAccessToken(oldToken, rotatedKeys)
+	assert.NoError(t, err)
+	assert.Len(t, rotatedKeys.JWKS().Keys, 2)
+
+	withoutOldKey, err := helper.LoadJWTKeySet(helper.JWTKeyOptions{Algorithm: "EdDSA", PrivateKeyFile: newPrivate, KeyID: "new"})
+	assert.NoError(t, err)
+
+	_, err = helper.ValidateAccessToken(oldToken, withoutOldKey)
+	assert.Error(t, err)
+}
+
+func TestJWTKeySet_RejectsAlgorithmConfusion(t *testing.T) {
+	_, publicFile := writeRSAKeyPair(t, t.TempDir(), "current")
+	privateFile := publicFile[:len(publicFile)-len(".pub.pem")] + ".pem"
+
+	keys, err := helper.LoadJWTKeySet(helper.JWTKeyOptions{Algorithm: "RS256", PrivateKeyFile: privateFile, KeyID: "rsa"})
+	assert.NoError(t, err)
+
+	publicPEM, err := os.ReadFile(publicFile)
+	assert.NoError(t, err)
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, helper.JWTClaims{UserID: 1, TokenType: "access"})
+	forged.Header["kid"] = "rsa"
+	forgedToken, err := forged.SignedString(publicPEM)
+	assert.NoError(t, err)
+
+	_, err = helper.ValidateAccessToken(forgedToken, keys)
+	assert.Error(t, err)
+}
+
+func TestNewHMACKeySet_AcceptsTokensWithoutKid(t *testing.T) {
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, helper.JWTClaims{UserID: 1, TokenType: "access"})
+	legacyToken, err := legacy.SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+
+	keys := helper.NewHMACKeySet("test-secret")
+
+	_, err = helper.ValidateAccessToken(legacyToken, keys)
+	assert.NoError(t, err)
+	assert.Empty(t, keys.JWKS().Keys)
+}
+
+func TestLoadJWTKeySet_InvalidConfiguration(t *testing.T) {
+	_, err := helper.LoadJWTKeySet(helper.JWTKeyOptions{Algorithm: "RS256"})
+	assert.Error(t, err)
+
+	_, err = helper.LoadJWTKeySet(helper.JWTKeyOptions{Algorithm: "ES256", Secret: "secret"})
+	assert.Error(t, err)
+
+	_, err = helper.LoadJWTKeySet(helper.JWTKeyOptions{Algorithm: "RS256", PrivateKeyFile: "/does/not/exist.pem"})
+	assert.Error(t, err)
+}
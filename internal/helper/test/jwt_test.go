@@ -14,13 +14,38 @@ func TestGenerateAccessToken_Success(t *testing.T) {
 	secret := "test-secret"
 	expireHours := 1
 
-	token, err := helper.GenerateAccessToken(userID, email, secret, expireHours)
+	token, err := helper.GenerateAccessToken(userID, email, "", helper.NewHMACKeySet(secret), expireHours)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
 	assert.Contains(t, token, ".")
 }
 
+func TestGenerateAccessToken_UniqueTokenID(t *testing.T) {
+	keys := helper.NewHMACKeySet("test-secret")
+
+	token1, _ := helper.GenerateAccessToken(1, "test@example.com", "", keys, 1)
+	token2, _ := helper.GenerateAccessToken(1, "test@example.com", "", keys, 1)
+
+	claims1, err1 := helper.ValidateAccessToken(token1, keys)
+	claims2, err2 := helper.ValidateAccessToken(token2, keys)
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NotEmpty(t, claims1.ID)
+	assert.NotEqual(t, claims1.ID, claims2.ID)
+}
+
+func TestValidateAccessToken_SessionID(t *testing.T) {
+	token, err := helper.GenerateAccessToken(1, "test@example.com", "family-42", helper.NewHMACKeySet("test-secret"), 1)
+	assert.NoError(t, err)
+
+	claims, err := helper.ValidateAccessToken(token, helper.NewHMACKeySet("test-secret"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "family-42", claims.SessionID)
+}
+
 func TestGenerateRefreshToken_Success(t *testing.T) {
 	token, err := helper.GenerateRefreshToken()
 
@@ -43,10 +68,10 @@ func TestValidateAccessToken_Success(t *testing.T) {
 	secret := "test-secret"
 	expireHours := 1
 
-	token, err := helper.GenerateAccessToken(userID, email, secret, expireHours)
+	token, err := helper.GenerateAccessToken(userID, email, "", helper.NewHMACKeySet(secret), expireHours)
 	assert.NoError(t, err)
 
-	claims, err := helper.ValidateAccessToken(token, secret)
+	claims, err := helper.ValidateAccessToken(token, helper.NewHMACKeySet(secret))
 
 	assert.NoError(t, err)
 	assert.NotNil(t, claims)
@@ -62,10 +87,10 @@ func TestValidateAccessToken_InvalidSecret(t *testing.T) {
 	wrongSecret := "wrong-secret"
 	expireHours := 1
 
-	token, err := helper.GenerateAccessToken(userID, email, secret, expireHours)
+	token, err := helper.GenerateAccessToken(userID, email, "", helper.NewHMACKeySet(secret), expireHours)
 	assert.NoError(t, err)
 
-	claims, err := helper.ValidateAccessToken(token, wrongSecret)
+	claims, err := helper.ValidateAccessToken(token, helper.NewHMACKeySet(wrongSecret))
 
 	assert.Error(t, err)
 	assert.Nil(t, claims)
@@ -77,12 +102,12 @@ func TestValidateAccessToken_ExpiredToken(t *testing.T) {
 	secret := "test-secret"
 	expireHours := -1
 
-	token, err := helper.GenerateAccessToken(userID, email, secret, expireHours)
+	token, err := helper.GenerateAccessToken(userID, email, "", helper.NewHMACKeySet(secret), expireHours)
 	assert.NoError(t, err)
 
 	time.Sleep(time.Second * 1)
 
-	claims, err := helper.ValidateAccessToken(token, secret)
+	claims, err := helper.ValidateAccessToken(token, helper.NewHMACKeySet(secret))
 
 	assert.Error(t, err)
 	assert.Nil(t, claims)
@@ -104,15 +129,15 @@ func TestGenerateAccessToken_DifferentUsers(t *testing.T) {
 	secret := "test-secret"
 	expireHours := 1
 
-	token1, err1 := helper.GenerateAccessToken(1, "user1@example.com", secret, expireHours)
-	token2, err2 := helper.GenerateAccessToken(2, "user2@example.com", secret, expireHours)
+	token1, err1 := helper.GenerateAccessToken(1, "user1@example.com", "", helper.NewHMACKeySet(secret), expireHours)
+	token2, err2 := helper.GenerateAccessToken(2, "user2@example.com", "", helper.NewHMACKeySet(secret), expireHours)
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
 	assert.NotEqual(t, token1, token2)
 
-	claims1, err1 := helper.ValidateAccessToken(token1, secret)
-	claims2, err2 := helper.ValidateAccessToken(token2, secret)
+	claims1, err1 := helper.ValidateAccessToken(token1, helper.NewHMACKeySet(secret))
+	claims2, err2 := helper.ValidateAccessToken(token2, helper.NewHMACKeySet(secret))
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
@@ -0,0 +1,382 @@
+package helper_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubPermissionProvider struct {
+	permissions []string
+	err         error
+}
+
+func (s *stubPermissionProvider) GetUserPermissions(userID uint) ([]string, error) {
+	return s.permissions, s.err
+}
+
+func newPermissionTestApp(provider helper.PermissionProvider, permission string, withUser bool) *fiber.App {
+	app := fiber.New()
+	app.Get("/admin", func(c *fiber.Ctx) error {
+		if withUser {
+			c.Locals("user_id", uint(1))
+		}
+		return c.Next()
+	}, helper.RequirePermission(provider, permission), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func TestRequirePermission_Granted(t *testing.T) {
+	provider := &stubPermissionProvider{permissions: []string{"invoice.read", "invoice.update"}}
+	app := newPermissionTestApp(provider, "invoice.update", true)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/admin", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestRequirePermission_Forbidden(t *testing.T) {
+	provider := &stubPermissionProvider{permissions: []string{"invoice.read"}}
+	app := newPermissionTestApp(provider, "invoice.update", true)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/admin", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestRequirePermission_NoUser(t *testing.T) {
+	provider := &stubPermissionProvider{permissions: []string{"invoice.update"}}
+	app := newPermissionTestApp(provider, "invoice.update", false)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/admin", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestRequirePermission_ProviderError(t *testing.T) {
+	provider := &stubPermissionProvider{err: errors.New("database error")}
+	app := newPermissionTestApp(provider, "invoice.update", true)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/admin", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
+
+type stubEmailVerificationProvider struct {
+	verified bool
+}
+
+func (s *stubEmailVerificationProvider) IsEmailVerified(userID uint) (bool, error) {
+	return s.verified, nil
+}
+
+func newVerifiedEmailTestApp(provider helper.EmailVerificationProvider) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", uint(1))
+		return c.Next()
+	}, helper.RequireVerifiedEmail(provider))
+	app.Get("/kantong", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Post("/kantong", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+	return app
+}
+
+func TestRequireVerifiedEmail_UnverifiedCanRead(t *testing.T) {
+	app := newVerifiedEmailTestApp(&stubEmailVerificationProvider{verified: false})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/kantong", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestRequireVerifiedEmail_UnverifiedCannotWrite(t *testing.T) {
+	app := newVerifiedEmailTestApp(&stubEmailVerificationProvider{verified: false})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/kantong", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestRequireVerifiedEmail_VerifiedCanWrite(t *testing.T) {
+	app := newVerifiedEmailTestApp(&stubEmailVerificationProvider{verified: true})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/kantong", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+}
+
+type stubLoginAttemptGuard struct {
+	retryAfter time.Duration
+	failures   [][]domain.AttemptIdentifier
+	resets     [][]domain.AttemptIdentifier
+}
+
+func (s *stubLoginAttemptGuard) Check(scope string, identifiers []domain.AttemptIdentifier) time.Duration {
+	return s.retryAfter
+}
+
+func (s *stubLoginAttemptGuard) RegisterFailure(scope string, identifiers []domain.AttemptIdentifier) time.Duration {
+	s.failures = append(s.failures, identifiers)
+	return 0
+}
+
+func (s *stubLoginAttemptGuard) Reset(scope string, identifiers []domain.AttemptIdentifier) {
+	s.resets = append(s.resets, identifiers)
+}
+
+func newBruteForceTestApp(guard helper.LoginAttemptGuard, countEveryAttempt bool, status int) *fiber.App {
+	app := fiber.New()
+	app.Post("/login", helper.BruteForceProtection(guard, "login", countEveryAttempt), func(c *fiber.Ctx) error {
+		return c.SendStatus(status)
+	})
+	return app
+}
+
+func newLoginRequest(email string) *http.Request {
+	req := httptest.NewRequest("POST", "/login", strings.NewReader(`{"email":"`+email+`","password":"secret"}`))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestBruteForceProtection_Blocked(t *testing.T) {
+	guard := &stubLoginAttemptGuard{retryAfter: 1500 * time.Millisecond}
+	app := newBruteForceTestApp(guard, false, fiber.StatusOK)
+
+	resp, err := app.Test(newLoginRequest("user@example.com"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	assert.Empty(t, guard.failures)
+}
+
+func TestBruteForceProtection_RegistersFailure(t *testing.T) {
+	guard := &stubLoginAttemptGuard{}
+	app := newBruteForceTestApp(guard, false, fiber.StatusUnauthorized)
+
+	resp, err := app.Test(newLoginRequest(" User@Example.com "))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	assert.Len(t, guard.failures, 1)
+	assert.Equal(t, domain.AttemptIdentifierIP, guard.failures[0][0].Kind)
+	assert.Equal(t, domain.AttemptIdentifier{Kind: domain.AttemptIdentifierEmail, Value: "user@example.com"}, guard.failures[0][1])
+}
+
+func TestBruteForceProtection_SuccessResetsEmailOnly(t *testing.T) {
+	guard := &stubLoginAttemptGuard{}
+	app := newBruteForceTestApp(guard, false, fiber.StatusOK)
+
+	resp, err := app.Test(newLoginRequest("user@example.com"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, guard.failures)
+	assert.Equal(t, [][]domain.AttemptIdentifier{{{Kind: domain.AttemptIdentifierEmail, Value: "user@example.com"}}}, guard.resets)
+}
+
+func TestBruteForceProtection_CountEveryAttempt(t *testing.T) {
+	guard := &stubLoginAttemptGuard{}
+	app := newBruteForceTestApp(guard, true, fiber.StatusOK)
+
+	_, err := app.Test(newLoginRequest("user@example.com"))
+
+	assert.NoError(t, err)
+	assert.Len(t, guard.failures, 1)
+	assert.Empty(t, guard.resets)
+}
+
+type stubTokenRevocationChecker struct {
+	revoked bool
+	err     error
+	tokenID string
+}
+
+func (s *stubTokenRevocationChecker) IsAccessTokenRevoked(tokenID string, userID uint, sessionID string, issuedAt time.Time) (bool, error) {
+	s.tokenID = tokenID
+	return s.revoked, s.err
+}
+
+func newJWTTestApp(keys *helper.JWTKeySet, checker helper.TokenRevocationChecker) *fiber.App {
+	app := fiber.New()
+	app.Get("/me", helper.JWTAuthMiddleware(keys, checker), func(c *fiber.Ctx) error {
+		tokenID, _ := helper.GetAccessTokenFromContext(c)
+		return c.SendString(tokenID)
+	})
+	return app
+}
+
+func newBearerRequest(token string) *http.Request {
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func TestJWTAuthMiddleware_AcceptsActiveToken(t *testing.T) {
+	keys := helper.NewHMACKeySet("test-secret")
+	checker := &stubTokenRevocationChecker{}
+	token, _ := helper.GenerateAccessToken(1, "test@example.com", "family-2", keys, 1)
+
+	resp, err := newJWTTestApp(keys, checker).Test(newBearerRequest(token))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, checker.tokenID)
+}
+
+func TestJWTAuthMiddleware_RejectsRevokedToken(t *testing.T) {
+	keys := helper.NewHMACKeySet("test-secret")
+	token, _ := helper.GenerateAccessToken(1, "test@example.com", "family-2", keys, 1)
+
+	resp, err := newJWTTestApp(keys, &stubTokenRevocationChecker{revoked: true}).Test(newBearerRequest(token))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestJWTAuthMiddleware_RevocationCheckError(t *testing.T) {
+	keys := helper.NewHMACKeySet("test-secret")
+	token, _ := helper.GenerateAccessToken(1, "test@example.com", "family-2", keys, 1)
+
+	resp, err := newJWTTestApp(keys, &stubTokenRevocationChecker{err: errors.New("db down")}).Test(newBearerRequest(token))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
+
+type stubAPIKeyAuthenticator struct {
+	apiKey *domain.APIKey
+	err    error
+	key    string
+}
+
+func (s *stubAPIKeyAuthenticator) Authenticate(key string) (*domain.APIKey, error) {
+	s.key = key
+	return s.apiKey, s.err
+}
+
+func newAuthTestApp(keys *helper.JWTKeySet, authenticator helper.APIKeyAuthenticator) *fiber.App {
+	app := fiber.New()
+	handler := func(c *fiber.Ctx) error {
+		userID, err := helper.GetUserIDFromToken(c)
+		if err != nil {
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+		return c.JSON(fiber.Map{"user_id": userID})
+	}
+	auth := helper.AuthMiddleware(keys, &stubTokenRevocationChecker{}, authenticator, domain.APIKeyResourceTransaksi)
+	app.Get("/transaksi", auth, handler)
+	app.Post("/transaksi", auth, handler)
+	return app
+}
+
+func newAPIKeyRequest(method, key string) *http.Request {
+	req := httptest.NewRequest(method, "/transaksi", nil)
+	req.Header.Set("Authorization", "ApiKey "+key)
+	return req
+}
+
+func TestAuthMiddleware_APIKeyReadScope(t *testing.T) {
+	authenticator := &stubAPIKeyAuthenticator{apiKey: &domain.APIKey{ID: 3, UserID: 5, Scopes: "transaksi:read"}}
+	app := newAuthTestApp(helper.NewHMACKeySet("test-secret"), authenticator)
+
+	resp, err := app.Test(newAPIKeyRequest("GET", "fbk_abc_def"))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "fbk_abc_def", authenticator.key)
+
+	resp, err = app.Test(newAPIKeyRequest("POST", "fbk_abc_def"))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestAuthMiddleware_APIKeyWriteScope(t *testing.T) {
+	authenticator := &stubAPIKeyAuthenticator{apiKey: &domain.APIKey{ID: 3, UserID: 5, Scopes: "kantong:read,transaksi:write"}}
+	app := newAuthTestApp(helper.NewHMACKeySet("test-secret"), authenticator)
+
+	resp, err := app.Test(newAPIKeyRequest("POST", "fbk_abc_def"))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(newAPIKeyRequest("GET", "fbk_abc_def"))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestAuthMiddleware_APIKeyOtherResource(t *testing.T) {
+	authenticator := &stubAPIKeyAuthenticator{apiKey: &domain.APIKey{ID: 3, UserID: 5, Scopes: "kantong:write"}}
+	app := newAuthTestApp(helper.NewHMACKeySet("test-secret"), authenticator)
+
+	resp, err := app.Test(newAPIKeyRequest("GET", "fbk_abc_def"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestAuthMiddleware_APIKeyInvalid(t *testing.T) {
+	for _, authErr := range []error{domain.ErrAPIKeyInvalid, domain.ErrAPIKeyExpired, fmt.Errorf("autentikasi: %w", domain.ErrAPIKeyExpired)} {
+		authenticator := &stubAPIKeyAuthenticator{err: authErr}
+		app := newAuthTestApp(helper.NewHMACKeySet("test-secret"), authenticator)
+
+		resp, err := app.Test(newAPIKeyRequest("GET", "fbk_abc_def"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode, authErr.Error())
+	}
+}
+
+func TestAuthMiddleware_APIKeyAkunNonaktif(t *testing.T) {
+	authenticator := &stubAPIKeyAuthenticator{err: domain.ErrAccountInactive}
+	app := newAuthTestApp(helper.NewHMACKeySet("test-secret"), authenticator)
+
+	resp, err := app.Test(newAPIKeyRequest("GET", "fbk_abc_def"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestAuthMiddleware_APIKeyErrorLain(t *testing.T) {
+	authenticator := &stubAPIKeyAuthenticator{err: errors.New("api key tidak valid")}
+	app := newAuthTestApp(helper.NewHMACKeySet("test-secret"), authenticator)
+
+	resp, err := app.Test(newAPIKeyRequest("GET", "fbk_abc_def"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestAuthMiddleware_FallsBackToBearer(t *testing.T) {
+	keys := helper.NewHMACKeySet("test-secret")
+	authenticator := &stubAPIKeyAuthenticator{}
+	token, _ := helper.GenerateAccessToken(1, "test@example.com", "family-2", keys, 1)
+
+	req := httptest.NewRequest("GET", "/transaksi", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := newAuthTestApp(keys, authenticator).Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, authenticator.key)
+}
@@ -0,0 +1,46 @@
+package helper_test
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDeviceName(t *testing.T) {
+	cases := map[string]string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36":               "Chrome di Windows",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/604.1": "Safari di iOS",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36 Edg/120.0":     "Edge di Windows",
+		"Mozilla/5.0 (X11; Linux x86_64; rv:121.0) Gecko/20100101 Firefox/121.0":                                                    "Firefox di Linux",
+		"okhttp/4.12.0": "okhttp/4.12.0",
+		"":              "Perangkat tidak dikenal",
+	}
+
+	for userAgent, expected := range cases {
+		assert.Equal(t, expected, helper.ParseDeviceName(userAgent), userAgent)
+	}
+}
+
+func TestGetSessionMeta_DeviceNameHeader(t *testing.T) {
+	var meta domain.SessionMeta
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		meta = helper.GetSessionMeta(c)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("User-Agent", "okhttp/4.12.0")
+	req.Header.Set("X-Device-Name", "  Pixel 8 Budi  ")
+
+	_, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "okhttp/4.12.0", meta.UserAgent)
+	assert.Equal(t, "Pixel 8 Budi", meta.DeviceName)
+	assert.NotEmpty(t, meta.IPAddress)
+}
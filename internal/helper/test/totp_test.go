@@ -0,0 +1,61 @@
+package helper_test
+
+import (
+	"encoding/base32"
+	"fiber-boiler-plate/internal/helper"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateTOTPCode_RFC6238Vectors(t *testing.T) {
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range cases {
+		code, err := helper.GenerateTOTPCode(rfc6238Secret, time.Unix(unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "timestamp %d", unix)
+	}
+}
+
+func TestValidateTOTPCode_AllowsOneStepSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	previous, _ := helper.GenerateTOTPCode(rfc6238Secret, now.Add(-30*time.Second))
+	stale, _ := helper.GenerateTOTPCode(rfc6238Secret, now.Add(-90*time.Second))
+
+	step, ok := helper.ValidateTOTPCode(rfc6238Secret, previous, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/30-1, step)
+
+	_, ok = helper.ValidateTOTPCode(rfc6238Secret, stale, now)
+	assert.False(t, ok)
+
+	_, ok = helper.ValidateTOTPCode(rfc6238Secret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestBuildTOTPURI(t *testing.T) {
+	uri := helper.BuildTOTPURI("Fast Track", "user@example.com", "JBSWY3DPEHPK3PXP")
+
+	assert.Equal(t, "otpauth://totp/Fast%20Track:user@example.com?algorithm=SHA1&digits=6&issuer=Fast+Track&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := helper.GenerateRecoveryCodes(10)
+
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+	for _, code := range codes {
+		assert.Regexp(t, `^[0-9a-f]{5}-[0-9a-f]{5}$`, code)
+	}
+	assert.Equal(t, helper.HashRecoveryCode(codes[0]), helper.HashRecoveryCode(" "+codes[0]+" "))
+}
@@ -54,6 +54,10 @@ func getValidationMessage(err validator.FieldError) string {
 		return err.Field() + " minimal " + err.Param() + " karakter"
 	case "max":
 		return err.Field() + " maksimal " + err.Param() + " karakter"
+	case "len":
+		return err.Field() + " harus " + err.Param() + " karakter"
+	case "numeric":
+		return err.Field() + " harus berupa angka"
 	case "oneof":
 		return err.Field() + " harus berupa salah satu dari: " + err.Param()
 	case "uuid":
@@ -0,0 +1,39 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) Mailer {
+	return &fileMailer{
+		dir:  dir,
+		from: from,
+	}
+}
+
+func (m *fileMailer) Send(msg *Message) error {
+	if len(msg.To) == 0 {
+		return errors.New("penerima email tidak boleh kosong")
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	recipient := unsafeFileChars.ReplaceAllString(msg.To[0], "_")
+	filename := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102T150405.000000000"), recipient)
+
+	return os.WriteFile(filepath.Join(m.dir, filename), buildMIMEMessage(m.from, msg), 0o644)
+}
@@ -0,0 +1,30 @@
+package mailer
+
+import (
+	"fiber-boiler-plate/config"
+	"fmt"
+)
+
+type Message struct {
+	To       []string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+type Mailer interface {
+	Send(msg *Message) error
+}
+
+func New(cfg config.MailConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg), nil
+	case "file", "":
+		return NewFileMailer(cfg.FileDir, cfg.From), nil
+	case "memory":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("driver mail tidak dikenal: %s", cfg.Driver)
+	}
+}
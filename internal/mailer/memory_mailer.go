@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"errors"
+	"sync"
+)
+
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(msg *Message) error {
+	if len(msg.To) == 0 {
+		return errors.New("penerima email tidak boleh kosong")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, *msg)
+	return nil
+}
+
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]Message, len(m.messages))
+	copy(result, m.messages)
+	return result
+}
+
+func (m *MemoryMailer) Last() *Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.messages) == 0 {
+		return nil
+	}
+	msg := m.messages[len(m.messages)-1]
+	return &msg
+}
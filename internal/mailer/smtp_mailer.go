@@ -0,0 +1,79 @@
+package mailer
+
+import (
+	"bytes"
+	"errors"
+	"fiber-boiler-plate/config"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type smtpMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPMailer(cfg config.MailConfig) Mailer {
+	return &smtpMailer{
+		host:     cfg.Host,
+		port:     cfg.Port,
+		username: cfg.Username,
+		password: cfg.Password,
+		from:     cfg.From,
+	}
+}
+
+func (m *smtpMailer) Send(msg *Message) error {
+	if len(msg.To) == 0 {
+		return errors.New("penerima email tidak boleh kosong")
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	addr := fmt.Sprintf("%s:%s", m.host, m.port)
+	return smtp.SendMail(addr, auth, m.from, msg.To, buildMIMEMessage(m.from, msg))
+}
+
+func buildMIMEMessage(from string, msg *Message) []byte {
+	boundary := strings.ReplaceAll(uuid.New().String(), "-", "")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if msg.HTMLBody == "" {
+		buf.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+		buf.WriteString(msg.TextBody)
+		return buf.Bytes()
+	}
+
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=\"%s\"\r\n\r\n", boundary)
+
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	buf.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	buf.WriteString(msg.TextBody)
+	buf.WriteString("\r\n")
+
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	buf.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\n\r\n")
+	buf.WriteString(msg.HTMLBody)
+	buf.WriteString("\r\n")
+
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+
+	return buf.Bytes()
+}
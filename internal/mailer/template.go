@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+var (
+	htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/*.html"))
+	textTemplates = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/*.txt"))
+)
+
+type ResetPasswordEmailData struct {
+	AppName        string
+	Name           string
+	ResetURL       string
+	ExpiresMinutes int
+}
+
+type VerifyEmailData struct {
+	AppName      string
+	Name         string
+	VerifyURL    string
+	ExpiresHours int
+}
+
+func NewTemplateMessage(to, subject, name string, data interface{}) (*Message, error) {
+	var textBody bytes.Buffer
+	if err := textTemplates.ExecuteTemplate(&textBody, name+".txt", data); err != nil {
+		return nil, err
+	}
+
+	var htmlBody bytes.Buffer
+	if err := htmlTemplates.ExecuteTemplate(&htmlBody, name+".html", data); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		To:       []string{to},
+		Subject:  subject,
+		TextBody: textBody.String(),
+		HTMLBody: htmlBody.String(),
+	}, nil
+}
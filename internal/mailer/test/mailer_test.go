@@ -0,0 +1,97 @@
+package mailer_test
+
+import (
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/mailer"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_Drivers(t *testing.T) {
+	m, err := mailer.New(config.MailConfig{Driver: "smtp", Host: "localhost", Port: "587"})
+	assert.NoError(t, err)
+	assert.NotNil(t, m)
+
+	m, err = mailer.New(config.MailConfig{Driver: "file", FileDir: t.TempDir()})
+	assert.NoError(t, err)
+	assert.NotNil(t, m)
+
+	m, err = mailer.New(config.MailConfig{Driver: "memory"})
+	assert.NoError(t, err)
+	assert.IsType(t, &mailer.MemoryMailer{}, m)
+
+	m, err = mailer.New(config.MailConfig{Driver: "pigeon"})
+	assert.Error(t, err)
+	assert.Nil(t, m)
+}
+
+func TestMemoryMailer_Send(t *testing.T) {
+	m := mailer.NewMemoryMailer()
+
+	assert.Nil(t, m.Last())
+
+	err := m.Send(&mailer.Message{To: []string{"user@example.com"}, Subject: "Halo", TextBody: "isi"})
+
+	assert.NoError(t, err)
+	assert.Len(t, m.Messages(), 1)
+	assert.Equal(t, "Halo", m.Last().Subject)
+}
+
+func TestMemoryMailer_Send_NoRecipient(t *testing.T) {
+	m := mailer.NewMemoryMailer()
+
+	err := m.Send(&mailer.Message{Subject: "Halo"})
+
+	assert.Error(t, err)
+	assert.Empty(t, m.Messages())
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := t.TempDir()
+	m := mailer.NewFileMailer(dir, "noreply@example.com")
+
+	err := m.Send(&mailer.Message{
+		To:       []string{"user@example.com"},
+		Subject:  "Reset Password",
+		TextBody: "teks",
+		HTMLBody: "<p>html</p>",
+	})
+	assert.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	content, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "From: noreply@example.com")
+	assert.Contains(t, string(content), "To: user@example.com")
+	assert.Contains(t, string(content), "multipart/alternative")
+	assert.Contains(t, string(content), "<p>html</p>")
+}
+
+func TestNewTemplateMessage_ResetPassword(t *testing.T) {
+	msg, err := mailer.NewTemplateMessage("user@example.com", "Reset Password", "reset_password", mailer.ResetPasswordEmailData{
+		AppName:        "Fast Track",
+		Name:           "Budi <script>",
+		ResetURL:       "https://app.example.com/reset-password?token=abc",
+		ExpiresMinutes: 60,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user@example.com"}, msg.To)
+	assert.Contains(t, msg.TextBody, "https://app.example.com/reset-password?token=abc")
+	assert.Contains(t, msg.TextBody, "60 menit")
+	assert.Contains(t, msg.HTMLBody, "https://app.example.com/reset-password?token=abc")
+	assert.NotContains(t, msg.HTMLBody, "<script>")
+}
+
+func TestNewTemplateMessage_UnknownTemplate(t *testing.T) {
+	msg, err := mailer.NewTemplateMessage("user@example.com", "Subject", "does_not_exist", nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, msg)
+}
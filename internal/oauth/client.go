@@ -0,0 +1,89 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const maxResponseBytes = 1 << 20
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func exchangeCode(ctx context.Context, client *http.Client, tokenURL string, form url.Values) (*tokenResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token tokenResponse
+	status, err := doJSON(client, req, &token)
+	if err != nil {
+		return nil, err
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("token endpoint menolak permintaan: %s %s", token.Error, token.ErrorDescription)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint mengembalikan status %d", status)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token endpoint tidak mengembalikan access token")
+	}
+
+	return &token, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, endpoint, accessToken string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	status, err := doJSON(client, req, target)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("%s mengembalikan status %d", endpoint, status)
+	}
+	return nil
+}
+
+func doJSON(client *http.Client, req *http.Request, target interface{}) (int, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return resp.StatusCode, err
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, nil
+		}
+		return resp.StatusCode, fmt.Errorf("respons dari %s tidak valid: %w", req.URL.Host, err)
+	}
+
+	return resp.StatusCode, nil
+}
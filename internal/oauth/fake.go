@@ -0,0 +1,89 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const fakeCodeTTL = 5 * time.Minute
+
+type fakeAuthorization struct {
+	codeChallenge string
+	nonce         string
+	identity      domain.OAuthIdentity
+	expiresAt     time.Time
+}
+
+type FakeProvider struct {
+	redirectURL string
+	mu          sync.Mutex
+	identity    domain.OAuthIdentity
+	codes       map[string]fakeAuthorization
+}
+
+func NewFakeProvider(redirectURL string, identity domain.OAuthIdentity) *FakeProvider {
+	identity.Provider = domain.OAuthProviderFake
+	return &FakeProvider{
+		redirectURL: redirectURL,
+		identity:    identity,
+		codes:       make(map[string]fakeAuthorization),
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return domain.OAuthProviderFake
+}
+
+func (p *FakeProvider) SetIdentity(identity domain.OAuthIdentity) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	identity.Provider = domain.OAuthProviderFake
+	p.identity = identity
+}
+
+func (p *FakeProvider) AuthCodeURL(ctx context.Context, state, codeChallenge, nonce string) (string, error) {
+	code, err := randomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	p.codes[code] = fakeAuthorization{
+		codeChallenge: codeChallenge,
+		nonce:         nonce,
+		identity:      p.identity,
+		expiresAt:     time.Now().Add(fakeCodeTTL),
+	}
+	p.mu.Unlock()
+
+	query := url.Values{}
+	query.Set("code", code)
+	query.Set("state", state)
+
+	return appendQuery(p.redirectURL, query), nil
+}
+
+func (p *FakeProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.OAuthIdentity, error) {
+	p.mu.Lock()
+	authorization, ok := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+
+	if !ok || time.Now().After(authorization.expiresAt) {
+		return nil, errors.New("kode otorisasi tidak valid")
+	}
+	if CodeChallengeS256(codeVerifier) != authorization.codeChallenge {
+		return nil, errors.New("code verifier tidak sesuai")
+	}
+	if authorization.nonce != nonce {
+		return nil, errors.New("nonce tidak sesuai")
+	}
+
+	identity := authorization.identity
+	return &identity, nil
+}
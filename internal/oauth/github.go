@@ -0,0 +1,144 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	githubAuthURL  = "https://github.com/login/oauth/authorize"
+	githubTokenURL = "https://github.com/login/oauth/access_token"
+	githubAPIURL   = "https://api.github.com"
+)
+
+type GitHubOptions struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	AuthURL      string
+	TokenURL     string
+	APIURL       string
+	HTTPClient   *http.Client
+}
+
+type githubUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+type githubProvider struct {
+	options GitHubOptions
+	client  *http.Client
+}
+
+func NewGitHubProvider(options GitHubOptions) Provider {
+	if options.AuthURL == "" {
+		options.AuthURL = githubAuthURL
+	}
+	if options.TokenURL == "" {
+		options.TokenURL = githubTokenURL
+	}
+	if options.APIURL == "" {
+		options.APIURL = githubAPIURL
+	}
+	options.APIURL = strings.TrimRight(options.APIURL, "/")
+
+	client := options.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &githubProvider{
+		options: options,
+		client:  client,
+	}
+}
+
+func (p *githubProvider) Name() string {
+	return domain.OAuthProviderGitHub
+}
+
+func (p *githubProvider) AuthCodeURL(ctx context.Context, state, codeChallenge, nonce string) (string, error) {
+	query := url.Values{}
+	query.Set("client_id", p.options.ClientID)
+	query.Set("redirect_uri", p.options.RedirectURL)
+	query.Set("scope", "read:user user:email")
+	query.Set("state", state)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	return appendQuery(p.options.AuthURL, query), nil
+}
+
+func (p *githubProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.OAuthIdentity, error) {
+	token, err := exchangeCode(ctx, p.client, p.options.TokenURL, url.Values{
+		"code":          {code},
+		"redirect_uri":  {p.options.RedirectURL},
+		"client_id":     {p.options.ClientID},
+		"client_secret": {p.options.ClientSecret},
+		"code_verifier": {codeVerifier},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var user githubUser
+	if err := getJSON(ctx, p.client, p.options.APIURL+"/user", token.AccessToken, &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, errors.New("respons user GitHub tidak valid")
+	}
+
+	var emails []githubEmail
+	if err := getJSON(ctx, p.client, p.options.APIURL+"/user/emails", token.AccessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &domain.OAuthIdentity{
+		Provider: domain.OAuthProviderGitHub,
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Name:     user.Name,
+	}
+	if identity.Name == "" {
+		identity.Name = user.Login
+	}
+
+	if email, ok := selectGitHubEmail(emails); ok {
+		identity.Email = email.Email
+		identity.EmailVerified = email.Verified
+	}
+
+	return identity, nil
+}
+
+func selectGitHubEmail(emails []githubEmail) (githubEmail, bool) {
+	for _, email := range emails {
+		if email.Primary && email.Verified {
+			return email, true
+		}
+	}
+	for _, email := range emails {
+		if email.Verified {
+			return email, true
+		}
+	}
+	for _, email := range emails {
+		if email.Primary {
+			return email, true
+		}
+	}
+	return githubEmail{}, false
+}
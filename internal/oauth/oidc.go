@@ -0,0 +1,314 @@
+package oauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const googleIssuerURL = "https://accounts.google.com"
+
+var defaultOIDCScopes = []string{"openid", "email", "profile"}
+
+type OIDCOptions struct {
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	HTTPClient   *http.Client
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type oidcClaims struct {
+	Email         string      `json:"email"`
+	EmailVerified interface{} `json:"email_verified"`
+	Name          string      `json:"name"`
+	Nonce         string      `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+type oidcProvider struct {
+	options   OIDCOptions
+	client    *http.Client
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]crypto.PublicKey
+}
+
+func NewOIDCProvider(options OIDCOptions) Provider {
+	if len(options.Scopes) == 0 {
+		options.Scopes = defaultOIDCScopes
+	}
+	client := options.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &oidcProvider{
+		options: options,
+		client:  client,
+		keys:    make(map[string]crypto.PublicKey),
+	}
+}
+
+func (p *oidcProvider) Name() string {
+	return p.options.Name
+}
+
+func (p *oidcProvider) AuthCodeURL(ctx context.Context, state, codeChallenge, nonce string) (string, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.options.ClientID)
+	query.Set("redirect_uri", p.options.RedirectURL)
+	query.Set("scope", strings.Join(p.options.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	return appendQuery(discovery.AuthorizationEndpoint, query), nil
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.OAuthIdentity, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := exchangeCode(ctx, p.client, discovery.TokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.options.RedirectURL},
+		"client_id":     {p.options.ClientID},
+		"client_secret": {p.options.ClientSecret},
+		"code_verifier": {codeVerifier},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("provider tidak mengembalikan id_token")
+	}
+
+	claims, err := p.verifyIDToken(ctx, discovery, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &domain.OAuthIdentity{
+		Provider:      p.options.Name,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: parseEmailVerified(claims.EmailVerified),
+		Name:          claims.Name,
+	}
+
+	if identity.Email == "" && discovery.UserinfoEndpoint != "" {
+		var userinfo oidcClaims
+		if err := getJSON(ctx, p.client, discovery.UserinfoEndpoint, token.AccessToken, &userinfo); err != nil {
+			return nil, err
+		}
+		if userinfo.Subject != "" && userinfo.Subject != identity.Subject {
+			return nil, errors.New("subject userinfo tidak sesuai dengan id_token")
+		}
+		identity.Email = userinfo.Email
+		identity.EmailVerified = parseEmailVerified(userinfo.EmailVerified)
+		if identity.Name == "" {
+			identity.Name = userinfo.Name
+		}
+	}
+
+	return identity, nil
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	issuer := strings.TrimRight(p.options.IssuerURL, "/")
+	var discovery oidcDiscovery
+	if err := getJSON(ctx, p.client, issuer+"/.well-known/openid-configuration", "", &discovery); err != nil {
+		return nil, err
+	}
+	if strings.TrimRight(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("issuer discovery tidak sesuai: %s", discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("dokumen discovery OIDC tidak lengkap")
+	}
+
+	p.discovery = &discovery
+	return p.discovery, nil
+}
+
+func (p *oidcProvider) verifyIDToken(ctx context.Context, discovery *oidcDiscovery, idToken, nonce string) (*oidcClaims, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "ES256", "EdDSA"}))
+
+	claims := &oidcClaims{}
+	_, err := parser.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(ctx, discovery, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("id_token tidak valid: %w", err)
+	}
+
+	if !claims.VerifyIssuer(discovery.Issuer, true) {
+		return nil, errors.New("issuer id_token tidak sesuai")
+	}
+	if !claims.VerifyAudience(p.options.ClientID, true) {
+		return nil, errors.New("audience id_token tidak sesuai")
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.New("nonce id_token tidak sesuai")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("id_token tidak memiliki subject")
+	}
+
+	return claims, nil
+}
+
+func (p *oidcProvider) publicKey(ctx context.Context, discovery *oidcDiscovery, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	var jwks struct {
+		Keys []oidcJWK `json:"keys"`
+	}
+	if err := getJSON(ctx, p.client, discovery.JWKSURI, "", &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		key, err := parseJWK(jwk)
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = keys
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("kunci id_token dengan kid %q tidak ditemukan", kid)
+}
+
+func (p *oidcProvider) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid != "" {
+		key, ok := p.keys[kid]
+		return key, ok
+	}
+	if len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+func parseJWK(jwk oidcJWK) (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("kurva %s tidak didukung", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("kurva %s tidak didukung", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("kunci Ed25519 tidak valid")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("tipe kunci %s tidak didukung", jwk.Kty)
+	}
+}
+
+func parseEmailVerified(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	default:
+		return false
+	}
+}
+
+func appendQuery(endpoint string, query url.Values) string {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+	return endpoint + separator + query.Encode()
+}
@@ -0,0 +1,105 @@
+package oauth
+
+import (
+	"context"
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Provider interface {
+	Name() string
+	AuthCodeURL(ctx context.Context, state, codeChallenge, nonce string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.OAuthIdentity, error)
+}
+
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	registry := &Registry{providers: make(map[string]Provider, len(providers))}
+	for _, provider := range providers {
+		registry.providers[provider.Name()] = provider
+	}
+	return registry
+}
+
+func New(cfg config.OAuthConfig, env string) *Registry {
+	client := &http.Client{Timeout: time.Duration(cfg.HTTPTimeoutSeconds) * time.Second}
+
+	var providers []Provider
+	if cfg.Google.ClientID != "" {
+		providers = append(providers, NewOIDCProvider(OIDCOptions{
+			Name:         domain.OAuthProviderGoogle,
+			IssuerURL:    googleIssuerURL,
+			ClientID:     cfg.Google.ClientID,
+			ClientSecret: cfg.Google.ClientSecret,
+			RedirectURL:  redirectURL(cfg, cfg.Google, domain.OAuthProviderGoogle),
+			HTTPClient:   client,
+		}))
+	}
+	if cfg.GitHub.ClientID != "" {
+		providers = append(providers, NewGitHubProvider(GitHubOptions{
+			ClientID:     cfg.GitHub.ClientID,
+			ClientSecret: cfg.GitHub.ClientSecret,
+			RedirectURL:  redirectURL(cfg, cfg.GitHub, domain.OAuthProviderGitHub),
+			HTTPClient:   client,
+		}))
+	}
+	if cfg.OIDC.ClientID != "" && cfg.OIDC.IssuerURL != "" {
+		providers = append(providers, NewOIDCProvider(OIDCOptions{
+			Name:         domain.OAuthProviderOIDC,
+			IssuerURL:    cfg.OIDC.IssuerURL,
+			ClientID:     cfg.OIDC.ClientID,
+			ClientSecret: cfg.OIDC.ClientSecret,
+			RedirectURL:  redirectURL(cfg, cfg.OIDC, domain.OAuthProviderOIDC),
+			Scopes:       cfg.OIDC.Scopes,
+			HTTPClient:   client,
+		}))
+	}
+	if cfg.Fake.Enabled && env == "production" {
+		helper.Warn("Provider oauth fake tidak didaftarkan di production environment", logrus.Fields{
+			"email": cfg.Fake.Email,
+		})
+	} else if cfg.Fake.Enabled {
+		helper.Warn("Provider oauth fake aktif, jangan gunakan di production", logrus.Fields{
+			"email": cfg.Fake.Email,
+		})
+		providers = append(providers, NewFakeProvider(redirectURL(cfg, config.OAuthProviderConfig{}, domain.OAuthProviderFake), domain.OAuthIdentity{
+			Subject:       "fake-" + strings.ToLower(cfg.Fake.Email),
+			Email:         cfg.Fake.Email,
+			EmailVerified: true,
+			Name:          cfg.Fake.Name,
+		}))
+	}
+
+	return NewRegistry(providers...)
+}
+
+func (r *Registry) Get(name string) (Provider, bool) {
+	provider, ok := r.providers[name]
+	return provider, ok
+}
+
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func redirectURL(cfg config.OAuthConfig, provider config.OAuthProviderConfig, name string) string {
+	if provider.RedirectURL != "" {
+		return provider.RedirectURL
+	}
+	return strings.TrimRight(cfg.RedirectBaseURL, "/") + "/" + name
+}
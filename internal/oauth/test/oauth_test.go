@@ -0,0 +1,277 @@
+package oauth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/oauth"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeChallengeS256_RFC7636Vector(t *testing.T) {
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", oauth.CodeChallengeS256("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func TestNew_RegistersConfiguredProviders(t *testing.T) {
+	registry := oauth.New(config.OAuthConfig{
+		RedirectBaseURL: "http://localhost:3000/oauth/callback",
+		GitHub:          config.OAuthProviderConfig{ClientID: "github-client"},
+		OIDC:            config.OAuthProviderConfig{ClientID: "oidc-client"},
+		Fake:            config.FakeOAuthConfig{Enabled: true, Email: "fake@example.com"},
+	}, "development")
+
+	assert.Equal(t, []string{"fake", "github"}, registry.Names())
+
+	_, ok := registry.Get(domain.OAuthProviderGoogle)
+	assert.False(t, ok)
+}
+
+func TestNew_FakeProviderDitolakDiProduction(t *testing.T) {
+	registry := oauth.New(config.OAuthConfig{
+		RedirectBaseURL: "http://localhost:3000/oauth/callback",
+		GitHub:          config.OAuthProviderConfig{ClientID: "github-client"},
+		Fake:            config.FakeOAuthConfig{Enabled: true, Email: "fake@example.com"},
+	}, "production")
+
+	assert.Equal(t, []string{"github"}, registry.Names())
+}
+
+func TestFakeProvider_FullFlow(t *testing.T) {
+	provider := oauth.NewFakeProvider("http://localhost:3000/oauth/callback/fake", domain.OAuthIdentity{
+		Subject:       "fake-1",
+		Email:         "fake@example.com",
+		EmailVerified: true,
+	})
+	verifier, _ := oauth.GenerateCodeVerifier()
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state-1", oauth.CodeChallengeS256(verifier), "nonce-1")
+	assert.NoError(t, err)
+
+	parsed, _ := url.Parse(authURL)
+	code := parsed.Query().Get("code")
+	assert.Equal(t, "state-1", parsed.Query().Get("state"))
+
+	_, err = provider.Exchange(context.Background(), code, "verifier-lain", "nonce-1")
+	assert.Error(t, err)
+
+	authURL, _ = provider.AuthCodeURL(context.Background(), "state-2", oauth.CodeChallengeS256(verifier), "nonce-2")
+	parsed, _ = url.Parse(authURL)
+	code = parsed.Query().Get("code")
+
+	identity, err := provider.Exchange(context.Background(), code, verifier, "nonce-2")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OAuthProviderFake, identity.Provider)
+	assert.Equal(t, "fake@example.com", identity.Email)
+
+	_, err = provider.Exchange(context.Background(), code, verifier, "nonce-2")
+	assert.Error(t, err)
+}
+
+type oidcTestServer struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	nonce    string
+	audience string
+	verifier string
+}
+
+func newOIDCTestServer(t *testing.T) *oidcTestServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	s := &oidcTestServer{key: key, audience: "client-id"}
+	mux := http.NewServeMux()
+	s.server = httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 s.server.URL,
+			"authorization_endpoint": s.server.URL + "/authorize",
+			"token_endpoint":         s.server.URL + "/token",
+			"jwks_uri":               s.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "kunci-1",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		s.verifier = r.Form.Get("code_verifier")
+		if r.Form.Get("code") != "kode-valid" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":            s.server.URL,
+			"aud":            s.audience,
+			"sub":            "subject-123",
+			"email":          "oidc@example.com",
+			"email_verified": true,
+			"name":           "Pengguna OIDC",
+			"nonce":          s.nonce,
+			"exp":            time.Now().Add(time.Minute).Unix(),
+			"iat":            time.Now().Unix(),
+		})
+		token.Header["kid"] = "kunci-1"
+		idToken, _ := token.SignedString(key)
+
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+
+	return s
+}
+
+func (s *oidcTestServer) provider() oauth.Provider {
+	return oauth.NewOIDCProvider(oauth.OIDCOptions{
+		Name:         domain.OAuthProviderOIDC,
+		IssuerURL:    s.server.URL,
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://localhost:3000/oauth/callback/oidc",
+		HTTPClient:   s.server.Client(),
+	})
+}
+
+func TestOIDCProvider_AuthCodeURL(t *testing.T) {
+	s := newOIDCTestServer(t)
+	defer s.server.Close()
+
+	authURL, err := s.provider().AuthCodeURL(context.Background(), "state-1", "challenge-1", "nonce-1")
+
+	assert.NoError(t, err)
+	parsed, _ := url.Parse(authURL)
+	assert.Equal(t, "/authorize", parsed.Path)
+	assert.Equal(t, "challenge-1", parsed.Query().Get("code_challenge"))
+	assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+	assert.Equal(t, "nonce-1", parsed.Query().Get("nonce"))
+	assert.Equal(t, "openid email profile", parsed.Query().Get("scope"))
+}
+
+func TestOIDCProvider_Exchange_Success(t *testing.T) {
+	s := newOIDCTestServer(t)
+	defer s.server.Close()
+	s.nonce = "nonce-1"
+
+	identity, err := s.provider().Exchange(context.Background(), "kode-valid", "verifier-1", "nonce-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "verifier-1", s.verifier)
+	assert.Equal(t, "subject-123", identity.Subject)
+	assert.Equal(t, "oidc@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "Pengguna OIDC", identity.Name)
+}
+
+func TestOIDCProvider_Exchange_NonceMismatch(t *testing.T) {
+	s := newOIDCTestServer(t)
+	defer s.server.Close()
+	s.nonce = "nonce-penyerang"
+
+	identity, err := s.provider().Exchange(context.Background(), "kode-valid", "verifier-1", "nonce-1")
+
+	assert.Nil(t, identity)
+	assert.Error(t, err)
+}
+
+func TestOIDCProvider_Exchange_WrongAudience(t *testing.T) {
+	s := newOIDCTestServer(t)
+	defer s.server.Close()
+	s.nonce = "nonce-1"
+	s.audience = "client-lain"
+
+	_, err := s.provider().Exchange(context.Background(), "kode-valid", "verifier-1", "nonce-1")
+
+	assert.Error(t, err)
+}
+
+func TestOIDCProvider_Exchange_InvalidCode(t *testing.T) {
+	s := newOIDCTestServer(t)
+	defer s.server.Close()
+
+	_, err := s.provider().Exchange(context.Background(), "kode-salah", "verifier-1", "nonce-1")
+
+	assert.Error(t, err)
+}
+
+func TestGitHubProvider_Exchange(t *testing.T) {
+	var verifier string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		verifier = r.Form.Get("code_verifier")
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "gh-token", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer gh-token", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 42, "login": "octocat", "name": ""})
+	})
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"email": "lama@example.com", "primary": false, "verified": true},
+			{"email": "octocat@example.com", "primary": true, "verified": true},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider := oauth.NewGitHubProvider(oauth.GitHubOptions{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://localhost:3000/oauth/callback/github",
+		AuthURL:      server.URL + "/login/oauth/authorize",
+		TokenURL:     server.URL + "/login/oauth/access_token",
+		APIURL:       server.URL,
+		HTTPClient:   server.Client(),
+	})
+
+	identity, err := provider.Exchange(context.Background(), "kode", "verifier-1", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "verifier-1", verifier)
+	assert.Equal(t, "42", identity.Subject)
+	assert.Equal(t, "octocat", identity.Name)
+	assert.Equal(t, "octocat@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+}
+
+func TestGitHubProvider_Exchange_TokenError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"error": "bad_verification_code"})
+	}))
+	defer server.Close()
+
+	provider := oauth.NewGitHubProvider(oauth.GitHubOptions{
+		TokenURL:   server.URL,
+		APIURL:     server.URL,
+		HTTPClient: server.Client(),
+	})
+
+	_, err := provider.Exchange(context.Background(), "kode", "verifier-1", "")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,222 @@
+package scheduler
+
+import (
+	"context"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type JobFunc func() (map[string]int64, error)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Timeout  time.Duration
+	Run      JobFunc
+}
+
+type Scheduler struct {
+	locker  repo.JobLockRepository
+	mu      sync.RWMutex
+	jobs    []Job
+	metrics map[string]*domain.JobMetrics
+	wg      sync.WaitGroup
+}
+
+func New(locker repo.JobLockRepository) *Scheduler {
+	return &Scheduler{
+		locker:  locker,
+		metrics: make(map[string]*domain.JobMetrics),
+	}
+}
+
+func (s *Scheduler) Register(job Job) {
+	if job.Interval <= 0 {
+		helper.Warn("Job scheduler diabaikan karena interval tidak valid", logrus.Fields{
+			"job":      job.Name,
+			"interval": job.Interval.String(),
+		})
+		return
+	}
+
+	if job.Timeout <= 0 {
+		job.Timeout = job.Interval
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, job)
+	s.metrics[job.Name] = &domain.JobMetrics{
+		Name:             job.Name,
+		Interval:         job.Interval.String(),
+		Timeout:          job.Timeout.String(),
+		LastRowsAffected: map[string]int64{},
+		RowsAffected:     map[string]int64{},
+	}
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.RLock()
+	jobs := append([]Job(nil), s.jobs...)
+	s.mu.RUnlock()
+
+	for _, job := range jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+
+	helper.Info("Scheduler berjalan", logrus.Fields{
+		"jobs": len(jobs),
+	})
+}
+
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) RunOnce(job Job) bool {
+	timeout := job.Timeout
+	if timeout <= 0 {
+		timeout = job.Interval
+	}
+
+	acquired, err := s.locker.Acquire(job.Name, timeout)
+	if err != nil {
+		helper.Error("Gagal mengambil lock job, job dilewati", err, logrus.Fields{
+			"job": job.Name,
+		})
+		s.recordSkip(job.Name, true)
+		return false
+	}
+	if !acquired {
+		s.recordSkip(job.Name, false)
+		return false
+	}
+	defer s.release(job.Name)
+
+	startedAt := time.Now()
+	rows, err := job.Run()
+	duration := time.Since(startedAt)
+
+	if duration > timeout {
+		helper.Warn("Job scheduler melebihi timeout lock", logrus.Fields{
+			"job":      job.Name,
+			"duration": duration.String(),
+			"timeout":  timeout.String(),
+		})
+	}
+
+	s.recordRun(job.Name, startedAt, duration, rows, err)
+
+	fields := logrus.Fields{
+		"job":      job.Name,
+		"duration": duration.String(),
+	}
+	for table, count := range rows {
+		fields[table] = count
+	}
+
+	if err != nil {
+		helper.Error("Job scheduler gagal", err, fields)
+		return true
+	}
+
+	helper.Info("Job scheduler selesai", fields)
+	return true
+}
+
+func (s *Scheduler) JobMetrics() []domain.JobMetrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	metrics := make([]domain.JobMetrics, 0, len(s.metrics))
+	for _, m := range s.metrics {
+		snapshot := *m
+		snapshot.LastRowsAffected = copyCounts(m.LastRowsAffected)
+		snapshot.RowsAffected = copyCounts(m.RowsAffected)
+		metrics = append(metrics, snapshot)
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Name < metrics[j].Name
+	})
+
+	return metrics
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	s.RunOnce(job)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(job)
+		}
+	}
+}
+
+func (s *Scheduler) release(name string) {
+	if err := s.locker.Release(name); err != nil {
+		helper.Error("Gagal melepas lock job", err, logrus.Fields{
+			"job": name,
+		})
+	}
+}
+
+func (s *Scheduler) recordSkip(name string, lockError bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, ok := s.metrics[name]; ok {
+		m.Skipped++
+		if lockError {
+			m.LockErrors++
+		}
+	}
+}
+
+func (s *Scheduler) recordRun(name string, startedAt time.Time, duration time.Duration, rows map[string]int64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.metrics[name]
+	if !ok {
+		return
+	}
+
+	m.Runs++
+	m.LastRunAt = &startedAt
+	m.LastDuration = duration.String()
+	m.LastError = ""
+	if err != nil {
+		m.Failures++
+		m.LastError = err.Error()
+	}
+
+	m.LastRowsAffected = copyCounts(rows)
+	for table, count := range rows {
+		m.RowsAffected[table] += count
+	}
+}
+
+func copyCounts(counts map[string]int64) map[string]int64 {
+	copied := make(map[string]int64, len(counts))
+	for k, v := range counts {
+		copied[k] = v
+	}
+	return copied
+}
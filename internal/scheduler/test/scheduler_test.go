@@ -0,0 +1,144 @@
+package scheduler_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/scheduler"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobLockRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobLockRepository) Acquire(name string, ttl time.Duration) (bool, error) {
+	args := m.Called(name, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockJobLockRepository) Release(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func TestScheduler_RunOnce_LockAcquired(t *testing.T) {
+	mockLocker := new(MockJobLockRepository)
+	mockLocker.On("Acquire", "token_cleanup", time.Hour).Return(true, nil)
+	mockLocker.On("Release", "token_cleanup").Return(nil).Twice()
+
+	job := scheduler.Job{
+		Name:     "token_cleanup",
+		Interval: time.Hour,
+		Run: func() (map[string]int64, error) {
+			return map[string]int64{"refresh_tokens": 4}, nil
+		},
+	}
+
+	s := scheduler.New(mockLocker)
+	s.Register(job)
+
+	assert.True(t, s.RunOnce(job))
+	assert.True(t, s.RunOnce(job))
+
+	metrics := s.JobMetrics()
+	assert.Len(t, metrics, 1)
+	assert.Equal(t, "token_cleanup", metrics[0].Name)
+	assert.Equal(t, int64(2), metrics[0].Runs)
+	assert.Equal(t, int64(0), metrics[0].Failures)
+	assert.NotNil(t, metrics[0].LastRunAt)
+	assert.Equal(t, int64(4), metrics[0].LastRowsAffected["refresh_tokens"])
+	assert.Equal(t, int64(8), metrics[0].RowsAffected["refresh_tokens"])
+	assert.Equal(t, "1h0m0s", metrics[0].Timeout)
+	mockLocker.AssertExpectations(t)
+}
+
+func TestScheduler_RunOnce_LockHeld(t *testing.T) {
+	mockLocker := new(MockJobLockRepository)
+	mockLocker.On("Acquire", "token_cleanup", mock.Anything).Return(false, nil)
+
+	called := false
+	job := scheduler.Job{
+		Name:     "token_cleanup",
+		Interval: time.Hour,
+		Run: func() (map[string]int64, error) {
+			called = true
+			return nil, nil
+		},
+	}
+
+	s := scheduler.New(mockLocker)
+	s.Register(job)
+
+	assert.False(t, s.RunOnce(job))
+	assert.False(t, called)
+
+	metrics := s.JobMetrics()
+	assert.Equal(t, int64(0), metrics[0].Runs)
+	assert.Equal(t, int64(1), metrics[0].Skipped)
+	assert.Equal(t, int64(0), metrics[0].LockErrors)
+	mockLocker.AssertNotCalled(t, "Release", mock.Anything)
+}
+
+func TestScheduler_RunOnce_LockErrorDilewati(t *testing.T) {
+	mockLocker := new(MockJobLockRepository)
+	mockLocker.On("Acquire", "transaksi_berulang", 10*time.Minute).Return(false, errors.New("redis tidak tersedia"))
+
+	called := false
+	job := scheduler.Job{
+		Name:     "transaksi_berulang",
+		Interval: 15 * time.Minute,
+		Timeout:  10 * time.Minute,
+		Run: func() (map[string]int64, error) {
+			called = true
+			return nil, nil
+		},
+	}
+
+	s := scheduler.New(mockLocker)
+	s.Register(job)
+
+	assert.False(t, s.RunOnce(job))
+	assert.False(t, called)
+
+	metrics := s.JobMetrics()
+	assert.Equal(t, int64(0), metrics[0].Runs)
+	assert.Equal(t, int64(1), metrics[0].Skipped)
+	assert.Equal(t, int64(1), metrics[0].LockErrors)
+	assert.Equal(t, "10m0s", metrics[0].Timeout)
+	mockLocker.AssertNotCalled(t, "Release", mock.Anything)
+}
+
+func TestScheduler_RunOnce_JobFailure(t *testing.T) {
+	mockLocker := new(MockJobLockRepository)
+	mockLocker.On("Acquire", "token_cleanup", mock.Anything).Return(true, nil)
+	mockLocker.On("Release", "token_cleanup").Return(nil)
+
+	job := scheduler.Job{
+		Name:     "token_cleanup",
+		Interval: time.Hour,
+		Run: func() (map[string]int64, error) {
+			return map[string]int64{"refresh_tokens": 1}, errors.New("gagal membersihkan sebagian token yang kedaluwarsa")
+		},
+	}
+
+	s := scheduler.New(mockLocker)
+	s.Register(job)
+
+	assert.True(t, s.RunOnce(job))
+
+	metrics := s.JobMetrics()
+	assert.Equal(t, int64(1), metrics[0].Runs)
+	assert.Equal(t, int64(1), metrics[0].Failures)
+	assert.Equal(t, "gagal membersihkan sebagian token yang kedaluwarsa", metrics[0].LastError)
+	assert.Equal(t, int64(1), metrics[0].RowsAffected["refresh_tokens"])
+}
+
+func TestScheduler_Register_InvalidInterval(t *testing.T) {
+	s := scheduler.New(new(MockJobLockRepository))
+	s.Register(scheduler.Job{Name: "token_cleanup", Interval: 0})
+
+	assert.Empty(t, s.JobMetrics())
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+type MemoryObject struct {
+	Data        []byte
+	ContentType string
+}
+
+type MemoryStorage struct {
+	mu      sync.Mutex
+	objects map[string]MemoryObject
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{objects: make(map[string]MemoryObject)}
+}
+
+func (s *MemoryStorage) Put(key string, body io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[key] = MemoryObject{Data: data, ContentType: contentType}
+	return nil
+}
+
+func (s *MemoryStorage) Get(key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(object.Data)), nil
+}
+
+func (s *MemoryStorage) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *MemoryStorage) Object(key string) (MemoryObject, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[key]
+	return object, ok
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"errors"
+	"fiber-boiler-plate/config"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+var ErrNotFound = errors.New("file tidak ditemukan")
+
+type Storage interface {
+	Put(key string, body io.Reader, size int64, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+func New(cfg config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "local", "":
+		return NewLocalStorage(cfg.LocalDir), nil
+	case "s3":
+		if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+			return nil, errors.New("endpoint dan bucket S3 wajib diisi")
+		}
+		client := &http.Client{Timeout: time.Duration(cfg.HTTPTimeoutSeconds) * time.Second}
+		return NewS3Storage(cfg, client), nil
+	case "memory":
+		return NewMemoryStorage(), nil
+	default:
+		return nil, fmt.Errorf("driver storage tidak dikenal: %s", cfg.Driver)
+	}
+}
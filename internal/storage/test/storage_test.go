@@ -0,0 +1,233 @@
+package storage_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/storage"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_Drivers(t *testing.T) {
+	s, err := storage.New(config.StorageConfig{Driver: "local", LocalDir: t.TempDir()})
+	assert.NoError(t, err)
+	assert.NotNil(t, s)
+
+	s, err = storage.New(config.StorageConfig{Driver: "s3", S3Endpoint: "http://localhost:9000", S3Bucket: "lampiran"})
+	assert.NoError(t, err)
+	assert.NotNil(t, s)
+
+	s, err = storage.New(config.StorageConfig{Driver: "memory"})
+	assert.NoError(t, err)
+	assert.IsType(t, &storage.MemoryStorage{}, s)
+
+	s, err = storage.New(config.StorageConfig{Driver: "s3"})
+	assert.Error(t, err)
+	assert.Nil(t, s)
+
+	s, err = storage.New(config.StorageConfig{Driver: "ftp"})
+	assert.Error(t, err)
+	assert.Nil(t, s)
+}
+
+func TestLocalStorage_PutGetDelete(t *testing.T) {
+	dir := t.TempDir()
+	s := storage.NewLocalStorage(dir)
+
+	err := s.Put("transaksi/1/abc/struk", strings.NewReader("isi struk"), 9, "image/png")
+	assert.NoError(t, err)
+
+	reader, err := s.Get("transaksi/1/abc/struk")
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	assert.Equal(t, "isi struk", string(data))
+
+	entries, _ := os.ReadDir(filepath.Join(dir, "transaksi", "1", "abc"))
+	assert.Len(t, entries, 1)
+
+	assert.NoError(t, s.Delete("transaksi/1/abc/struk"))
+	assert.NoError(t, s.Delete("transaksi/1/abc/struk"))
+
+	_, err = s.Get("transaksi/1/abc/struk")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestLocalStorage_UkuranTidakSesuai(t *testing.T) {
+	dir := t.TempDir()
+	s := storage.NewLocalStorage(dir)
+
+	err := s.Put("a/b", strings.NewReader("pendek"), 100, "application/pdf")
+
+	assert.Error(t, err)
+	_, err = s.Get("a/b")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestLocalStorage_TolakPathTraversal(t *testing.T) {
+	s := storage.NewLocalStorage(t.TempDir())
+
+	for _, key := range []string{"", "../rahasia", "a/../../rahasia", "/etc/passwd"} {
+		err := s.Put(key, strings.NewReader("x"), 1, "text/plain")
+		assert.Error(t, err, key)
+	}
+}
+
+func TestMemoryStorage(t *testing.T) {
+	s := storage.NewMemoryStorage()
+
+	assert.NoError(t, s.Put("kunci", strings.NewReader("data"), 4, "application/pdf"))
+
+	object, ok := s.Object("kunci")
+	assert.True(t, ok)
+	assert.Equal(t, "application/pdf", object.ContentType)
+
+	assert.NoError(t, s.Delete("kunci"))
+	_, err := s.Get("kunci")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+type fakeS3 struct {
+	t         *testing.T
+	secretKey string
+	mu        sync.Mutex
+	objects   map[string][]byte
+	types     map[string]string
+}
+
+var authorizationPattern = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=([^/]+)/(\d{8})/([^/]+)/s3/aws4_request, SignedHeaders=([a-z0-9;-]+), Signature=([0-9a-f]{64})$`)
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.verify(r) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = data
+		f.types[key] = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) verify(r *http.Request) bool {
+	match := authorizationPattern.FindStringSubmatch(r.Header.Get("Authorization"))
+	if match == nil {
+		return false
+	}
+	tanggal, region, signedHeaders, signature := match[2], match[3], match[4], match[5]
+
+	names := strings.Split(signedHeaders, ";")
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	assert.Equal(f.t, sorted, names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + value + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		r.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		r.Header.Get("X-Amz-Date"),
+		tanggal + "/" + region + "/s3/aws4_request",
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := []byte("AWS4" + f.secretKey)
+	for _, part := range []string{tanggal, region, "s3", "aws4_request", stringToSign} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+
+	return hex.EncodeToString(key) == signature
+}
+
+func setupS3Storage(t *testing.T, secretKey string) (storage.Storage, *fakeS3) {
+	fake := &fakeS3{t: t, secretKey: "rahasia", objects: map[string][]byte{}, types: map[string]string{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	s := storage.NewS3Storage(config.StorageConfig{
+		S3Endpoint:  server.URL,
+		S3Region:    "ap-southeast-3",
+		S3Bucket:    "lampiran",
+		S3AccessKey: "akses",
+		S3SecretKey: secretKey,
+		S3PathStyle: true,
+	}, server.Client())
+
+	return s, fake
+}
+
+func TestS3Storage_PutGetDelete(t *testing.T) {
+	s, fake := setupS3Storage(t, "rahasia")
+
+	err := s.Put("transaksi/1/abc/struk foto.png", strings.NewReader("gambar"), 6, "image/png")
+	assert.NoError(t, err)
+	assert.Equal(t, "gambar", string(fake.objects["/lampiran/transaksi/1/abc/struk foto.png"]))
+	assert.Equal(t, "image/png", fake.types["/lampiran/transaksi/1/abc/struk foto.png"])
+
+	reader, err := s.Get("transaksi/1/abc/struk foto.png")
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	assert.Equal(t, "gambar", string(data))
+
+	assert.NoError(t, s.Delete("transaksi/1/abc/struk foto.png"))
+
+	_, err = s.Get("transaksi/1/abc/struk foto.png")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestS3Storage_SignatureSalah(t *testing.T) {
+	s, _ := setupS3Storage(t, "kunci-lain")
+
+	err := s.Put("a", strings.NewReader("x"), 1, "text/plain")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+}
@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const adminUserInvoiceLimit = 10
+
+type AdminUserUsecase interface {
+	GetAll(req *domain.AdminUserListRequest) ([]*domain.AdminUserResponse, *domain.PaginationMeta, error)
+	GetByID(id uint) (*domain.AdminUserDetailResponse, error)
+	UpdateStatus(adminID, id uint, req *domain.UpdateUserStatusRequest) (*domain.AdminUserResponse, error)
+	ForceLogout(id uint) error
+	AssignRole(adminID, id uint, req *domain.AssignUserRoleRequest) (*domain.AdminUserResponse, error)
+}
+
+type adminUserUsecase struct {
+	userRepo             repo.UserRepository
+	roleRepo             repo.RoleRepository
+	userSubscriptionRepo repo.UserSubscriptionRepository
+	invoiceRepo          repo.InvoiceRepository
+	refreshTokenRepo     repo.RefreshTokenRepository
+	tokenRevocationRepo  repo.TokenRevocationRepository
+	redisRepo            repo.RedisRepository
+}
+
+func NewAdminUserUsecase(
+	userRepo repo.UserRepository,
+	roleRepo repo.RoleRepository,
+	userSubscriptionRepo repo.UserSubscriptionRepository,
+	invoiceRepo repo.InvoiceRepository,
+	refreshTokenRepo repo.RefreshTokenRepository,
+	tokenRevocationRepo repo.TokenRevocationRepository,
+	redisRepo repo.RedisRepository,
+) AdminUserUsecase {
+	return &adminUserUsecase{
+		userRepo:             userRepo,
+		roleRepo:             roleRepo,
+		userSubscriptionRepo: userSubscriptionRepo,
+		invoiceRepo:          invoiceRepo,
+		refreshTokenRepo:     refreshTokenRepo,
+		tokenRevocationRepo:  tokenRevocationRepo,
+		redisRepo:            redisRepo,
+	}
+}
+
+func (uc *adminUserUsecase) GetAll(req *domain.AdminUserListRequest) ([]*domain.AdminUserResponse, *domain.PaginationMeta, error) {
+	req.SetDefaults()
+
+	users, total, err := uc.userRepo.GetAll(req)
+	if err != nil {
+		return nil, nil, errors.New("gagal mengambil daftar pengguna")
+	}
+
+	totalPages := (total + req.PerPage - 1) / req.PerPage
+	meta := &domain.PaginationMeta{
+		CurrentPage:  req.Page,
+		TotalPages:   totalPages,
+		TotalRecords: total,
+		PerPage:      req.PerPage,
+	}
+
+	responses := make([]*domain.AdminUserResponse, len(users))
+	for i, user := range users {
+		responses[i] = uc.mapToResponse(user)
+	}
+
+	return responses, meta, nil
+}
+
+func (uc *adminUserUsecase) GetByID(id uint) (*domain.AdminUserDetailResponse, error) {
+	user, err := uc.findUser(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.AdminUserDetailResponse{
+		ID:              user.ID,
+		Nama:            user.Name,
+		Email:           user.Email,
+		RoleID:          user.RoleID,
+		Role:            userRoleName(user),
+		IsActive:        user.IsActive,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		Invoices:        []*domain.InvoiceResponse{},
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+
+	subscription, err := uc.userSubscriptionRepo.GetLatestByUserID(id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("gagal mengambil subscription pengguna")
+	}
+	if subscription != nil {
+		response.Subscription = &domain.UserSubscriptionResponse{
+			ID: subscription.ID,
+			User: domain.UserInfo{
+				ID:    user.ID,
+				Nama:  user.Name,
+				Email: user.Email,
+				Role:  userRoleName(user),
+			},
+			SubscriptionPlan: domain.SubscriptionPlanInfo{
+				ID:    subscription.SubscriptionPlan.ID,
+				Nama:  subscription.SubscriptionPlan.Nama,
+				Harga: subscription.SubscriptionPlan.Harga,
+			},
+			Status:             subscription.Status,
+			CurrentPeriodStart: subscription.CurrentPeriodStart,
+			CurrentPeriodEnd:   subscription.CurrentPeriodEnd,
+			PaymentMethod:      subscription.PaymentMethod,
+			CreatedAt:          subscription.CreatedAt,
+			UpdatedAt:          subscription.UpdatedAt,
+		}
+	}
+
+	invoices, err := uc.invoiceRepo.GetByUserID(id, adminUserInvoiceLimit)
+	if err != nil {
+		return nil, errors.New("gagal mengambil invoice pengguna")
+	}
+	for _, invoice := range invoices {
+		invoiceResponse := &domain.InvoiceResponse{
+			InvoiceID:          invoice.ID,
+			NamaUser:           user.Name,
+			UserID:             user.ID,
+			UserEmail:          user.Email,
+			Jumlah:             invoice.Jumlah,
+			Status:             invoice.Status,
+			DibayarPada:        invoice.DibayarPada,
+			MetodePembayaran:   invoice.MetodePembayaran,
+			Keterangan:         invoice.Keterangan,
+			SubscriptionPlanID: invoice.SubscriptionPlanID,
+			CreatedAt:          invoice.CreatedAt,
+			UpdatedAt:          invoice.UpdatedAt,
+		}
+		if invoice.SubscriptionPlan != nil {
+			invoiceResponse.SubscriptionPlanNama = &invoice.SubscriptionPlan.Nama
+		}
+		response.Invoices = append(response.Invoices, invoiceResponse)
+	}
+
+	return response, nil
+}
+
+func (uc *adminUserUsecase) UpdateStatus(adminID, id uint, req *domain.UpdateUserStatusRequest) (*domain.AdminUserResponse, error) {
+	if adminID == id && !*req.IsActive {
+		return nil, errors.New("tidak dapat menonaktifkan akun sendiri")
+	}
+
+	user, err := uc.findUser(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsActive != *req.IsActive {
+		if err := uc.userRepo.UpdateStatus(id, *req.IsActive); err != nil {
+			return nil, errors.New("gagal mengubah status pengguna")
+		}
+		user.IsActive = *req.IsActive
+		uc.invalidatePermissionCache(id)
+	}
+
+	if !user.IsActive {
+		if err := uc.revokeAllTokens(id); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.mapToResponse(user), nil
+}
+
+func (uc *adminUserUsecase) ForceLogout(id uint) error {
+	if _, err := uc.findUser(id); err != nil {
+		return err
+	}
+
+	return uc.revokeAllTokens(id)
+}
+
+func (uc *adminUserUsecase) AssignRole(adminID, id uint, req *domain.AssignUserRoleRequest) (*domain.AdminUserResponse, error) {
+	if adminID == id {
+		return nil, errors.New("tidak dapat mengubah role akun sendiri")
+	}
+
+	if _, err := uc.findUser(id); err != nil {
+		return nil, err
+	}
+
+	if req.RoleID != nil {
+		role, err := uc.roleRepo.GetByID(*req.RoleID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("role tidak ditemukan")
+			}
+			return nil, errors.New("gagal mengambil data role")
+		}
+		if role.Status != "aktif" {
+			return nil, errors.New("role tidak aktif")
+		}
+	}
+
+	if err := uc.userRepo.UpdateRole(id, req.RoleID); err != nil {
+		return nil, errors.New("gagal mengubah role pengguna")
+	}
+	uc.invalidatePermissionCache(id)
+
+	user, err := uc.findUser(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.mapToResponse(user), nil
+}
+
+func (uc *adminUserUsecase) findUser(id uint) (*domain.User, error) {
+	user, err := uc.userRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("pengguna tidak ditemukan")
+		}
+		return nil, errors.New("gagal mengambil data pengguna")
+	}
+	return user, nil
+}
+
+func (uc *adminUserUsecase) revokeAllTokens(id uint) error {
+	if err := uc.refreshTokenRepo.RevokeAllUserTokens(id); err != nil {
+		return errors.New("gagal mengakhiri sesi pengguna")
+	}
+
+	if err := uc.tokenRevocationRepo.InvalidateUserTokens(id, time.Now()); err != nil {
+		return errors.New("gagal mengakhiri sesi pengguna")
+	}
+
+	return nil
+}
+
+func (uc *adminUserUsecase) invalidatePermissionCache(id uint) {
+	uc.redisRepo.Delete(fmt.Sprintf("role:user_permissions:%d", id))
+}
+
+func (uc *adminUserUsecase) mapToResponse(user *domain.User) *domain.AdminUserResponse {
+	return &domain.AdminUserResponse{
+		ID:              user.ID,
+		Nama:            user.Name,
+		Email:           user.Email,
+		RoleID:          user.RoleID,
+		Role:            userRoleName(user),
+		IsActive:        user.IsActive,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+}
@@ -14,6 +14,7 @@ type AnggaranUsecase interface {
 	CreatePenyesuaianAnggaran(userID uint, req *domain.PenyesuaianAnggaranRequest) (*domain.AnggaranResponse, error)
 	CreateAnggaranForNewKantong(kantong *domain.Kantong) error
 	UpdateAnggaranAfterTransaction(kantongID string, userID uint) error
+	RecalculateAnggaranBulan(kantongID string, userID uint, bulan, tahun int) error
 }
 
 type anggaranUsecase struct {
@@ -125,3 +126,8 @@ func (uc *anggaranUsecase) CreateAnggaranForNewKantong(kantong *domain.Kantong)
 func (uc *anggaranUsecase) UpdateAnggaranAfterTransaction(kantongID string, userID uint) error {
 	return uc.anggaranRepo.UpdateAnggaranAfterTransaksi(kantongID, userID)
 }
+
+func (uc *anggaranUsecase) RecalculateAnggaranBulan(kantongID string, userID uint, bulan, tahun int) error {
+	_, err := uc.anggaranRepo.RecalculateAnggaran(kantongID, userID, bulan, tahun)
+	return err
+}
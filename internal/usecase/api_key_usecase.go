@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	maxAPIKeysPerUser        = 20
+	apiKeyLastUsedResolution = time.Minute
+)
+
+type APIKeyUsecase interface {
+	GetAll(userID uint) ([]*domain.APIKeyResponse, error)
+	Create(userID uint, req *domain.CreateAPIKeyRequest) (*domain.CreateAPIKeyResponse, error)
+	Delete(userID, id uint) error
+	Authenticate(key string) (*domain.APIKey, error)
+}
+
+type apiKeyUsecase struct {
+	apiKeyRepo repo.APIKeyRepository
+	userRepo   repo.UserRepository
+}
+
+func NewAPIKeyUsecase(apiKeyRepo repo.APIKeyRepository, userRepo repo.UserRepository) APIKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (uc *apiKeyUsecase) GetAll(userID uint) ([]*domain.APIKeyResponse, error) {
+	apiKeys, err := uc.apiKeyRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, errors.New("gagal mengambil daftar api key")
+	}
+
+	responses := make([]*domain.APIKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		responses[i] = uc.mapToResponse(apiKey)
+	}
+
+	return responses, nil
+}
+
+func (uc *apiKeyUsecase) Create(userID uint, req *domain.CreateAPIKeyRequest) (*domain.CreateAPIKeyResponse, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("tanggal kedaluwarsa harus di masa depan")
+	}
+
+	existing, err := uc.apiKeyRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, errors.New("gagal membuat api key")
+	}
+	if len(existing) >= maxAPIKeysPerUser {
+		return nil, errors.New("jumlah api key sudah mencapai batas maksimal")
+	}
+
+	key, prefix, err := helper.GenerateAPIKey()
+	if err != nil {
+		return nil, errors.New("gagal membuat api key")
+	}
+
+	apiKey := &domain.APIKey{
+		UserID:    userID,
+		Nama:      strings.TrimSpace(req.Nama),
+		Prefix:    prefix,
+		KeyHash:   helper.HashAPIKey(key),
+		Scopes:    strings.Join(uniqueScopes(req.Scopes), ","),
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	if err := uc.apiKeyRepo.Create(apiKey); err != nil {
+		return nil, errors.New("gagal membuat api key")
+	}
+
+	return &domain.CreateAPIKeyResponse{
+		APIKeyResponse: *uc.mapToResponse(apiKey),
+		Key:            key,
+	}, nil
+}
+
+func (uc *apiKeyUsecase) Delete(userID, id uint) error {
+	if err := uc.apiKeyRepo.Delete(id, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("api key tidak ditemukan")
+		}
+		return errors.New("gagal menghapus api key")
+	}
+	return nil
+}
+
+func (uc *apiKeyUsecase) Authenticate(key string) (*domain.APIKey, error) {
+	prefix, ok := helper.ParseAPIKeyPrefix(key)
+	if !ok {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+
+	apiKey, err := uc.apiKeyRepo.GetByPrefix(prefix)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyInvalid
+		}
+		return nil, errors.New("gagal memvalidasi api key")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(helper.HashAPIKey(key))) != 1 {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+
+	now := time.Now()
+	if apiKey.IsExpired(now) {
+		return nil, domain.ErrAPIKeyExpired
+	}
+
+	user, err := uc.userRepo.FindByID(apiKey.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyInvalid
+		}
+		return nil, errors.New("gagal memvalidasi api key")
+	}
+	if !user.IsActive {
+		return nil, domain.ErrAccountInactive
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedResolution {
+		if err := uc.apiKeyRepo.UpdateLastUsed(apiKey.ID, now); err != nil {
+			helper.Error("Gagal memperbarui waktu penggunaan api key", err, logrus.Fields{
+				"api_key_id": apiKey.ID,
+			})
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	return apiKey, nil
+}
+
+func (uc *apiKeyUsecase) mapToResponse(apiKey *domain.APIKey) *domain.APIKeyResponse {
+	return &domain.APIKeyResponse{
+		ID:         apiKey.ID,
+		Nama:       apiKey.Nama,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.ScopeList(),
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		result = append(result, scope)
+	}
+	return result
+}
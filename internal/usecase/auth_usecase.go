@@ -5,44 +5,72 @@ import (
 	"fiber-boiler-plate/config"
 	"fiber-boiler-plate/internal/domain"
 	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/mailer"
 	"fiber-boiler-plate/internal/usecase/repo"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type AuthUsecase interface {
-	Register(req domain.RegisterRequest) (*domain.AuthResponse, error)
-	Login(req domain.AuthRequest) (*domain.AuthResponse, error)
-	RefreshToken(req domain.RefreshTokenRequest) (*domain.RefreshTokenResponse, error)
+	Register(req domain.RegisterRequest, meta domain.SessionMeta) (*domain.AuthResponse, error)
+	Login(req domain.AuthRequest, meta domain.SessionMeta) (*domain.AuthResponse, error)
+	CompleteLogin(user *domain.User, meta domain.SessionMeta) (*domain.AuthResponse, error)
+	VerifyMFA(req domain.MFAVerifyRequest, meta domain.SessionMeta) (*domain.AuthResponse, error)
+	RefreshToken(req domain.RefreshTokenRequest, meta domain.SessionMeta) (*domain.RefreshTokenResponse, error)
 	ResetPassword(req domain.ResetPasswordRequest) error
 	ConfirmResetPassword(req domain.NewPasswordRequest) error
-	Logout(token string) error
+	VerifyEmail(req domain.VerifyEmailRequest) error
+	ResendVerification(req domain.ResendVerificationRequest) error
+	Logout(refreshToken, accessTokenID string, accessTokenExpiresAt time.Time) error
 }
 
 type authUsecase struct {
-	userRepo         repo.UserRepository
-	refreshTokenRepo repo.RefreshTokenRepository
-	resetTokenRepo   repo.PasswordResetTokenRepository
-	config           *config.Config
+	userRepo              repo.UserRepository
+	refreshTokenRepo      repo.RefreshTokenRepository
+	resetTokenRepo        repo.PasswordResetTokenRepository
+	verificationTokenRepo repo.EmailVerificationTokenRepository
+	mfaRepo               repo.MFARepository
+	tokenRevocationRepo   repo.TokenRevocationRepository
+	mailer                mailer.Mailer
+	tokenKeys             *helper.JWTKeySet
+	config                *config.Config
 }
 
+const (
+	resetTokenExpireMinutes = 60
+	mfaTokenExpireMinutes   = 5
+)
+
 func NewAuthUsecase(
 	userRepo repo.UserRepository,
 	refreshTokenRepo repo.RefreshTokenRepository,
 	resetTokenRepo repo.PasswordResetTokenRepository,
+	verificationTokenRepo repo.EmailVerificationTokenRepository,
+	mfaRepo repo.MFARepository,
+	tokenRevocationRepo repo.TokenRevocationRepository,
+	mailer mailer.Mailer,
+	tokenKeys *helper.JWTKeySet,
 	config *config.Config,
 ) AuthUsecase {
 	return &authUsecase{
-		userRepo:         userRepo,
-		refreshTokenRepo: refreshTokenRepo,
-		resetTokenRepo:   resetTokenRepo,
-		config:           config,
+		userRepo:              userRepo,
+		refreshTokenRepo:      refreshTokenRepo,
+		resetTokenRepo:        resetTokenRepo,
+		verificationTokenRepo: verificationTokenRepo,
+		mfaRepo:               mfaRepo,
+		tokenRevocationRepo:   tokenRevocationRepo,
+		mailer:                mailer,
+		tokenKeys:             tokenKeys,
+		config:                config,
 	}
 }
 
-func (uc *authUsecase) Register(req domain.RegisterRequest) (*domain.AuthResponse, error) {
+func (uc *authUsecase) Register(req domain.RegisterRequest, meta domain.SessionMeta) (*domain.AuthResponse, error) {
 	existingUser, _ := uc.userRepo.GetByEmail(req.Email)
 	if existingUser != nil {
 		return nil, errors.New("email sudah terdaftar")
@@ -64,10 +92,24 @@ func (uc *authUsecase) Register(req domain.RegisterRequest) (*domain.AuthRespons
 		return nil, errors.New("gagal membuat user")
 	}
 
-	return uc.generateAuthResponse(user)
+	if err := uc.sendVerificationEmail(user); err != nil {
+		helper.Warn("Gagal mengirim email verifikasi", logrus.Fields{
+			"user_id": user.ID,
+			"error":   err.Error(),
+		})
+	}
+
+	if uc.config.Auth.EmailVerificationPolicy == config.EmailVerificationPolicyBlockLogin {
+		return &domain.AuthResponse{
+			User:                      *user,
+			EmailVerificationRequired: true,
+		}, nil
+	}
+
+	return uc.generateAuthResponse(user, meta)
 }
 
-func (uc *authUsecase) Login(req domain.AuthRequest) (*domain.AuthResponse, error) {
+func (uc *authUsecase) Login(req domain.AuthRequest, meta domain.SessionMeta) (*domain.AuthResponse, error) {
 	user, err := uc.userRepo.GetByEmail(req.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -80,27 +122,89 @@ func (uc *authUsecase) Login(req domain.AuthRequest) (*domain.AuthResponse, erro
 		return nil, errors.New("email atau password salah")
 	}
 
-	return uc.generateAuthResponse(user)
+	return uc.CompleteLogin(user, meta)
 }
 
-func (uc *authUsecase) RefreshToken(req domain.RefreshTokenRequest) (*domain.RefreshTokenResponse, error) {
+func (uc *authUsecase) CompleteLogin(user *domain.User, meta domain.SessionMeta) (*domain.AuthResponse, error) {
+	if !user.IsActive {
+		return nil, domain.ErrAccountInactive
+	}
+
+	if uc.config.Auth.EmailVerificationPolicy == config.EmailVerificationPolicyBlockLogin && !user.IsEmailVerified() {
+		return nil, errors.New("email belum diverifikasi")
+	}
+
+	mfa, err := uc.mfaRepo.GetByUserID(user.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("gagal mengambil data 2FA")
+	}
+
+	if mfa != nil && mfa.IsEnabled {
+		mfaToken, err := helper.GenerateMFAToken(user.ID, user.Email, uc.tokenKeys, mfaTokenExpireMinutes)
+		if err != nil {
+			return nil, errors.New("gagal generate token 2FA")
+		}
+
+		return &domain.AuthResponse{
+			User:        *user,
+			ExpiresIn:   mfaTokenExpireMinutes * 60,
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		}, nil
+	}
+
+	return uc.generateAuthResponse(user, meta)
+}
+
+func (uc *authUsecase) VerifyMFA(req domain.MFAVerifyRequest, meta domain.SessionMeta) (*domain.AuthResponse, error) {
+	claims, err := helper.ValidateMFAToken(req.MFAToken, uc.tokenKeys)
+	if err != nil {
+		return nil, errors.New("token 2FA tidak valid atau sudah expired")
+	}
+
+	user, err := uc.userRepo.GetByID(claims.UserID)
+	if err != nil {
+		return nil, errors.New("token 2FA tidak valid atau sudah expired")
+	}
+
+	mfa, err := uc.mfaRepo.GetByUserID(user.ID)
+	if err != nil || !mfa.IsEnabled {
+		return nil, errors.New("token 2FA tidak valid atau sudah expired")
+	}
+
+	valid, err := verifyMFACode(uc.mfaRepo, mfa, req.Code)
+	if err != nil {
+		return nil, errors.New("gagal memverifikasi kode 2FA")
+	}
+	if !valid {
+		return nil, errors.New("kode 2FA tidak valid")
+	}
+
+	return uc.generateAuthResponse(user, meta)
+}
+
+func (uc *authUsecase) RefreshToken(req domain.RefreshTokenRequest, meta domain.SessionMeta) (*domain.RefreshTokenResponse, error) {
 	refreshToken, err := uc.refreshTokenRepo.GetByToken(req.RefreshToken)
 	if err != nil {
 		return nil, errors.New("refresh token tidak valid atau sudah expired")
 	}
 
-	user, err := uc.userRepo.GetByID(refreshToken.UserID)
-	if err != nil {
-		return nil, errors.New("user tidak ditemukan")
+	if refreshToken.RotatedAt != nil {
+		uc.handleRefreshTokenReuse(refreshToken, meta)
+		return nil, errors.New("refresh token tidak valid atau sudah expired")
 	}
 
-	if err := uc.refreshTokenRepo.RevokeToken(req.RefreshToken); err != nil {
-		return nil, errors.New("gagal revoke refresh token lama")
+	if refreshToken.IsRevoked || !refreshToken.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("refresh token tidak valid atau sudah expired")
 	}
 
-	accessToken, err := helper.GenerateAccessToken(user.ID, user.Email, uc.config.JWT.Secret, uc.config.JWT.ExpireHours)
+	user, err := uc.userRepo.FindByID(refreshToken.UserID)
 	if err != nil {
-		return nil, errors.New("gagal generate access token")
+		return nil, errors.New("user tidak ditemukan")
+	}
+
+	if !user.IsActive {
+		return nil, domain.ErrAccountInactive
 	}
 
 	newRefreshToken, err := helper.GenerateRefreshToken()
@@ -108,11 +212,25 @@ func (uc *authUsecase) RefreshToken(req domain.RefreshTokenRequest) (*domain.Ref
 		return nil, errors.New("gagal generate refresh token")
 	}
 
+	if meta.DeviceName == "" {
+		meta.DeviceName = refreshToken.DeviceName
+	}
+
 	refreshTokenExpiry := time.Now().Add(time.Hour * time.Duration(uc.config.JWT.RefreshTokenExpireHours))
-	if _, err := uc.refreshTokenRepo.Create(user.ID, newRefreshToken, refreshTokenExpiry); err != nil {
+	session, err := uc.refreshTokenRepo.Rotate(refreshToken, newRefreshToken, refreshTokenExpiry, meta)
+	if err != nil {
+		if errors.Is(err, repo.ErrRefreshTokenReused) {
+			uc.handleRefreshTokenReuse(refreshToken, meta)
+			return nil, errors.New("refresh token tidak valid atau sudah expired")
+		}
 		return nil, errors.New("gagal simpan refresh token")
 	}
 
+	accessToken, err := helper.GenerateAccessToken(user.ID, user.Email, session.FamilyID, uc.tokenKeys, uc.config.JWT.ExpireHours)
+	if err != nil {
+		return nil, errors.New("gagal generate access token")
+	}
+
 	return &domain.RefreshTokenResponse{
 		AccessToken:  accessToken,
 		RefreshToken: newRefreshToken,
@@ -130,16 +248,34 @@ func (uc *authUsecase) ResetPassword(req domain.ResetPasswordRequest) error {
 		return errors.New("gagal mengambil data user")
 	}
 
+	if !user.IsActive {
+		return errors.New("email tidak ditemukan")
+	}
+
 	resetToken, err := helper.GenerateResetToken()
 	if err != nil {
 		return errors.New("gagal generate reset token")
 	}
 
-	expiresAt := time.Now().Add(time.Hour * 1)
+	expiresAt := time.Now().Add(time.Minute * resetTokenExpireMinutes)
 	if _, err := uc.resetTokenRepo.Create(user.Email, resetToken, expiresAt); err != nil {
 		return errors.New("gagal simpan reset token")
 	}
 
+	msg, err := mailer.NewTemplateMessage(user.Email, "Reset Password "+uc.config.App.Name, "reset_password", mailer.ResetPasswordEmailData{
+		AppName:        uc.config.App.Name,
+		Name:           user.Name,
+		ResetURL:       uc.buildFrontendURL("/reset-password", resetToken),
+		ExpiresMinutes: resetTokenExpireMinutes,
+	})
+	if err != nil {
+		return errors.New("gagal membuat email reset password")
+	}
+
+	if err := uc.mailer.Send(msg); err != nil {
+		return errors.New("gagal mengirim email reset password")
+	}
+
 	return nil
 }
 
@@ -162,34 +298,155 @@ func (uc *authUsecase) ConfirmResetPassword(req domain.NewPasswordRequest) error
 		return errors.New("gagal mark reset token sebagai used")
 	}
 
+	user, err := uc.userRepo.GetByEmail(resetToken.Email)
+	if err != nil {
+		return errors.New("gagal mengambil data user")
+	}
+
+	if err := uc.tokenRevocationRepo.InvalidateUserTokens(user.ID, time.Now()); err != nil {
+		return errors.New("gagal mencabut token yang aktif")
+	}
+
+	if err := uc.refreshTokenRepo.RevokeAllUserTokens(user.ID); err != nil {
+		return errors.New("gagal mencabut token yang aktif")
+	}
+
 	return nil
 }
 
-func (uc *authUsecase) Logout(token string) error {
-	return uc.refreshTokenRepo.RevokeToken(token)
+func (uc *authUsecase) VerifyEmail(req domain.VerifyEmailRequest) error {
+	verificationToken, err := uc.verificationTokenRepo.GetByToken(req.Token)
+	if err != nil {
+		return errors.New("token verifikasi email tidak valid atau sudah expired")
+	}
+
+	if err := uc.userRepo.MarkEmailVerified(verificationToken.UserID); err != nil {
+		return errors.New("gagal verifikasi email")
+	}
+
+	if err := uc.verificationTokenRepo.MarkAsUsed(req.Token); err != nil {
+		return errors.New("gagal mark token verifikasi sebagai used")
+	}
+
+	return nil
 }
 
-func (uc *authUsecase) generateAuthResponse(user *domain.User) (*domain.AuthResponse, error) {
-	accessToken, err := helper.GenerateAccessToken(user.ID, user.Email, uc.config.JWT.Secret, uc.config.JWT.ExpireHours)
+func (uc *authUsecase) ResendVerification(req domain.ResendVerificationRequest) error {
+	user, err := uc.userRepo.GetByEmail(req.Email)
 	if err != nil {
-		return nil, errors.New("gagal generate access token")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("email tidak ditemukan")
+		}
+		return errors.New("gagal mengambil data user")
+	}
+
+	if !user.IsActive {
+		return errors.New("email tidak ditemukan")
+	}
+
+	if user.IsEmailVerified() {
+		return errors.New("email sudah diverifikasi")
+	}
+
+	return uc.sendVerificationEmail(user)
+}
+
+func (uc *authUsecase) Logout(refreshToken, accessTokenID string, accessTokenExpiresAt time.Time) error {
+	if err := uc.refreshTokenRepo.RevokeToken(refreshToken); err != nil {
+		return err
+	}
+
+	return uc.tokenRevocationRepo.RevokeAccessToken(accessTokenID, accessTokenExpiresAt)
+}
+
+func (uc *authUsecase) handleRefreshTokenReuse(refreshToken *domain.RefreshToken, meta domain.SessionMeta) {
+	fields := logrus.Fields{
+		"event":      "refresh_token_reuse",
+		"user_id":    refreshToken.UserID,
+		"token_id":   refreshToken.ID,
+		"family_id":  refreshToken.FamilyID,
+		"ip_address": meta.IPAddress,
+		"user_agent": meta.UserAgent,
+	}
+
+	if err := uc.refreshTokenRepo.RevokeFamily(refreshToken.FamilyID); err != nil {
+		helper.Error("Gagal mencabut keluarga refresh token setelah reuse terdeteksi", err, fields)
+		return
 	}
 
+	accessTokenTTL := time.Duration(uc.config.JWT.ExpireHours) * time.Hour
+	if err := uc.tokenRevocationRepo.RevokeSessionAccess(refreshToken.FamilyID, accessTokenTTL); err != nil {
+		helper.Error("Gagal mencabut access token keluarga refresh token setelah reuse terdeteksi", err, fields)
+		return
+	}
+
+	helper.Warn("Refresh token yang sudah dirotasi digunakan kembali, seluruh sesi dalam keluarga token dicabut", fields)
+}
+
+func (uc *authUsecase) sendVerificationEmail(user *domain.User) error {
+	verificationToken, err := helper.GenerateResetToken()
+	if err != nil {
+		return errors.New("gagal generate token verifikasi")
+	}
+
+	expireHours := uc.config.Auth.EmailVerificationExpireHours
+	expiresAt := time.Now().Add(time.Hour * time.Duration(expireHours))
+	if _, err := uc.verificationTokenRepo.Create(user.ID, user.Email, verificationToken, expiresAt); err != nil {
+		return errors.New("gagal simpan token verifikasi")
+	}
+
+	msg, err := mailer.NewTemplateMessage(user.Email, "Verifikasi Email "+uc.config.App.Name, "verify_email", mailer.VerifyEmailData{
+		AppName:      uc.config.App.Name,
+		Name:         user.Name,
+		VerifyURL:    uc.buildFrontendURL("/verify-email", verificationToken),
+		ExpiresHours: expireHours,
+	})
+	if err != nil {
+		return errors.New("gagal membuat email verifikasi")
+	}
+
+	if err := uc.mailer.Send(msg); err != nil {
+		return errors.New("gagal mengirim email verifikasi")
+	}
+
+	return nil
+}
+
+func (uc *authUsecase) requiresEmailVerification(user *domain.User) bool {
+	switch uc.config.Auth.EmailVerificationPolicy {
+	case config.EmailVerificationPolicyBlockLogin, config.EmailVerificationPolicyLimited:
+		return !user.IsEmailVerified()
+	}
+	return false
+}
+
+func (uc *authUsecase) buildFrontendURL(path, token string) string {
+	return strings.TrimRight(uc.config.App.FrontendURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+func (uc *authUsecase) generateAuthResponse(user *domain.User, meta domain.SessionMeta) (*domain.AuthResponse, error) {
 	refreshToken, err := helper.GenerateRefreshToken()
 	if err != nil {
 		return nil, errors.New("gagal generate refresh token")
 	}
 
 	refreshTokenExpiry := time.Now().Add(time.Hour * time.Duration(uc.config.JWT.RefreshTokenExpireHours))
-	if _, err := uc.refreshTokenRepo.Create(user.ID, refreshToken, refreshTokenExpiry); err != nil {
+	session, err := uc.refreshTokenRepo.Create(user.ID, refreshToken, refreshTokenExpiry, meta)
+	if err != nil {
 		return nil, errors.New("gagal simpan refresh token")
 	}
 
+	accessToken, err := helper.GenerateAccessToken(user.ID, user.Email, session.FamilyID, uc.tokenKeys, uc.config.JWT.ExpireHours)
+	if err != nil {
+		return nil, errors.New("gagal generate access token")
+	}
+
 	return &domain.AuthResponse{
-		User:         *user,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		TokenType:    "Bearer",
-		ExpiresIn:    uc.config.JWT.ExpireHours * 3600,
+		User:                      *user,
+		AccessToken:               accessToken,
+		RefreshToken:              refreshToken,
+		TokenType:                 "Bearer",
+		ExpiresIn:                 uc.config.JWT.ExpireHours * 3600,
+		EmailVerificationRequired: uc.requiresEmailVerification(user),
 	}, nil
 }
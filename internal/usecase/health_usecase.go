@@ -17,13 +17,19 @@ type HealthUsecase interface {
 	GetComprehensiveHealth() *domain.ComprehensiveHealthCheck
 	GetSystemMetrics() *domain.SystemMetrics
 	GetApplicationStatus() *domain.ApplicationStatus
+	SetJobMetricsProvider(provider JobMetricsProvider)
+}
+
+type JobMetricsProvider interface {
+	JobMetrics() []domain.JobMetrics
 }
 
 type healthUsecase struct {
-	db        *gorm.DB
-	rdb       *redis.Client
-	config    *config.Config
-	startTime time.Time
+	db         *gorm.DB
+	rdb        *redis.Client
+	config     *config.Config
+	startTime  time.Time
+	jobMetrics JobMetricsProvider
 }
 
 func NewHealthUsecase(db *gorm.DB, rdb *redis.Client, config *config.Config) HealthUsecase {
@@ -35,6 +41,10 @@ func NewHealthUsecase(db *gorm.DB, rdb *redis.Client, config *config.Config) Hea
 	}
 }
 
+func (uc *healthUsecase) SetJobMetricsProvider(provider JobMetricsProvider) {
+	uc.jobMetrics = provider
+}
+
 func (uc *healthUsecase) GetBasicHealth() *domain.BasicHealthCheck {
 	return &domain.BasicHealthCheck{
 		Status:    domain.HealthStatusHealthy,
@@ -77,6 +87,7 @@ func (uc *healthUsecase) GetSystemMetrics() *domain.SystemMetrics {
 		Database: uc.getDetailedDatabaseStatus(),
 		Redis:    uc.getRedisStatus(),
 		Http:     uc.getHttpMetrics(),
+		Jobs:     uc.getJobMetrics(),
 	}
 }
 
@@ -237,6 +248,13 @@ func (uc *healthUsecase) getHttpMetrics() domain.HttpMetrics {
 	}
 }
 
+func (uc *healthUsecase) getJobMetrics() []domain.JobMetrics {
+	if uc.jobMetrics == nil {
+		return []domain.JobMetrics{}
+	}
+	return uc.jobMetrics.JobMetrics()
+}
+
 func (uc *healthUsecase) getServicesStatus() domain.ServicesStatus {
 	dbStatus := uc.getDatabaseStatus()
 	redisStatus := uc.getRedisStatus()
@@ -19,6 +19,9 @@ type KantongUsecase interface {
 	PatchKantong(id string, req *domain.PatchKantongRequest, userID uint) (*domain.KantongResponse, error)
 	DeleteKantong(id string, userID uint) error
 	TransferKantong(req *domain.TransferKantongRequest, userID uint) (*domain.TransferKantongResponse, error)
+	GetTransferList(userID uint, req *domain.TransferListRequest) ([]*domain.TransferResult, *domain.PaginationMeta, error)
+	GetTransferByID(id string, userID uint) (*domain.TransferResult, error)
+	ReverseTransfer(id string, req *domain.ReverseTransferRequest, userID uint) (*domain.TransferResult, error)
 	SetAnggaranUsecase(anggaranUsecase AnggaranUsecase)
 }
 
@@ -107,7 +110,7 @@ func (u *kantongUsecase) CreateKantong(req *domain.CreateKantongRequest, userID
 		return nil, err
 	}
 
-	saldo := float64(0)
+	saldo := domain.Money(0)
 	if req.Saldo != nil {
 		saldo = *req.Saldo
 	}
@@ -231,6 +234,14 @@ func (u *kantongUsecase) DeleteKantong(id string, userID uint) error {
 		return errors.New("kantong tidak dapat dihapus karena masih memiliki saldo")
 	}
 
+	dipakaiSplit, err := u.kantongRepo.HasTransaksiSplit(id)
+	if err != nil {
+		return err
+	}
+	if dipakaiSplit {
+		return errors.New("kantong tidak dapat dihapus karena masih dipakai pada rincian transaksi")
+	}
+
 	return u.kantongRepo.Delete(id, userID)
 }
 
@@ -259,42 +270,85 @@ func (u *kantongUsecase) TransferKantong(req *domain.TransferKantongRequest, use
 		return nil, errors.New("saldo kantong asal tidak mencukupi untuk transfer")
 	}
 
-	saldoAsalSebelum := kantongAsal.Saldo
-	saldoTujuanSebelum := kantongTujuan.Saldo
+	transfer := &domain.Transfer{
+		UserID:          userID,
+		KantongAsalID:   kantongAsal.ID,
+		KantongTujuanID: kantongTujuan.ID,
+		Jumlah:          req.Jumlah,
+		Catatan:         req.Catatan,
+	}
 
-	kantongAsalAfter, kantongTujuanAfter, err := u.kantongRepo.Transfer(req.KantongAsalID, req.KantongTujuanID, req.Jumlah, userID)
+	kantongAsalAfter, kantongTujuanAfter, err := u.kantongRepo.Transfer(transfer)
 	if err != nil {
 		return nil, err
 	}
 
-	transferResult := &domain.TransferResult{
-		TransferID: generateTransferID(),
-		KantongAsal: domain.TransferKantongDetail{
-			ID:           kantongAsalAfter.ID,
-			Nama:         kantongAsalAfter.Nama,
-			SaldoSebelum: saldoAsalSebelum,
-			SaldoSesudah: kantongAsalAfter.Saldo,
-		},
-		KantongTujuan: domain.TransferKantongDetail{
-			ID:           kantongTujuanAfter.ID,
-			Nama:         kantongTujuanAfter.Nama,
-			SaldoSebelum: saldoTujuanSebelum,
-			SaldoSesudah: kantongTujuanAfter.Saldo,
-		},
-		Jumlah:          req.Jumlah,
-		Catatan:         req.Catatan,
-		TanggalTransfer: time.Now(),
-	}
-
 	return &domain.TransferKantongResponse{
 		Success:   true,
 		Message:   "Transfer antar kantong berhasil dilakukan",
 		Code:      200,
-		Data:      transferResult,
+		Data:      domain.ToTransferResult(transfer, kantongAsalAfter.Nama, kantongTujuanAfter.Nama),
 		Timestamp: time.Now(),
 	}, nil
 }
 
-func generateTransferID() string {
-	return uuid.New().String()
+func (u *kantongUsecase) GetTransferList(userID uint, req *domain.TransferListRequest) ([]*domain.TransferResult, *domain.PaginationMeta, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PerPage <= 0 {
+		req.PerPage = 10
+	}
+	if req.PerPage > 100 {
+		req.PerPage = 100
+	}
+
+	transfers, total, err := u.kantongRepo.GetTransfers(userID, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(req.PerPage)))
+
+	meta := &domain.PaginationMeta{
+		CurrentPage:  req.Page,
+		TotalPages:   totalPages,
+		TotalRecords: total,
+		PerPage:      req.PerPage,
+	}
+
+	return transfers, meta, nil
+}
+
+func (u *kantongUsecase) GetTransferByID(id string, userID uint) (*domain.TransferResult, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("transfer tidak ditemukan")
+	}
+
+	transfer, err := u.kantongRepo.GetTransferByID(id, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("transfer tidak ditemukan")
+		}
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (u *kantongUsecase) ReverseTransfer(id string, req *domain.ReverseTransferRequest, userID uint) (*domain.TransferResult, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("transfer tidak ditemukan")
+	}
+
+	reversal := &domain.Transfer{
+		Catatan: req.Catatan,
+	}
+
+	kantongAsal, kantongTujuan, err := u.kantongRepo.ReverseTransfer(id, userID, reversal)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.ToTransferResult(reversal, kantongAsal.Nama, kantongTujuan.Nama), nil
 }
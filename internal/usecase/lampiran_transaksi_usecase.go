@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/storage"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type LampiranTransaksiUsecase interface {
+	GetLampiranList(transaksiID string, userID uint) ([]*domain.LampiranTransaksiResponse, error)
+	UploadLampiran(transaksiID string, userID uint, namaFile string, ukuran int64, file io.Reader) (*domain.LampiranTransaksiResponse, error)
+	DownloadLampiran(id, transaksiID string, userID uint) (*domain.LampiranTransaksiResponse, io.ReadCloser, error)
+	DeleteLampiran(id, transaksiID string, userID uint) error
+}
+
+type lampiranTransaksiUsecase struct {
+	lampiranRepo  repo.LampiranTransaksiRepository
+	transaksiRepo repo.TransaksiRepository
+	blobStorage   storage.Storage
+	maxUkuran     int64
+	maxJumlah     int
+}
+
+func NewLampiranTransaksiUsecase(
+	lampiranRepo repo.LampiranTransaksiRepository,
+	transaksiRepo repo.TransaksiRepository,
+	blobStorage storage.Storage,
+	maxUkuran int64,
+	maxJumlah int,
+) LampiranTransaksiUsecase {
+	return &lampiranTransaksiUsecase{
+		lampiranRepo:  lampiranRepo,
+		transaksiRepo: transaksiRepo,
+		blobStorage:   blobStorage,
+		maxUkuran:     maxUkuran,
+		maxJumlah:     maxJumlah,
+	}
+}
+
+func (uc *lampiranTransaksiUsecase) GetLampiranList(transaksiID string, userID uint) ([]*domain.LampiranTransaksiResponse, error) {
+	if _, err := uc.transaksiRepo.GetByID(transaksiID, userID); err != nil {
+		return nil, err
+	}
+
+	list, err := uc.lampiranRepo.GetByTransaksiID(transaksiID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.LampiranTransaksiResponse, 0, len(list))
+	for _, lampiran := range list {
+		result = append(result, domain.ToLampiranTransaksiResponse(lampiran))
+	}
+	return result, nil
+}
+
+func (uc *lampiranTransaksiUsecase) UploadLampiran(transaksiID string, userID uint, namaFile string, ukuran int64, file io.Reader) (*domain.LampiranTransaksiResponse, error) {
+	if ukuran <= 0 {
+		return nil, errors.New("file lampiran kosong")
+	}
+	if ukuran > uc.maxUkuran {
+		return nil, errors.New("ukuran file melebihi batas")
+	}
+
+	if _, err := uc.transaksiRepo.GetByID(transaksiID, userID); err != nil {
+		return nil, err
+	}
+
+	jumlah, err := uc.lampiranRepo.CountByTransaksiID(transaksiID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if jumlah >= uc.maxJumlah {
+		return nil, domain.ErrJumlahLampiranMelebihiBatas
+	}
+
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	contentType, ok := domain.DeteksiContentTypeLampiran(header[:n])
+	if !ok {
+		return nil, errors.New("tipe file tidak didukung")
+	}
+
+	lampiran := &domain.LampiranTransaksi{
+		ID:          uuid.New().String(),
+		TransaksiID: transaksiID,
+		UserID:      userID,
+		NamaFile:    namaFileLampiran(namaFile),
+		ContentType: contentType,
+		Ukuran:      ukuran,
+	}
+	lampiran.StorageKey = fmt.Sprintf("transaksi/%d/%s/%s", userID, transaksiID, lampiran.ID)
+
+	body := io.MultiReader(bytes.NewReader(header[:n]), file)
+	if err := uc.blobStorage.Put(lampiran.StorageKey, body, ukuran, contentType); err != nil {
+		return nil, err
+	}
+
+	if err := uc.lampiranRepo.Create(lampiran, uc.maxJumlah); err != nil {
+		uc.hapusBlob(lampiran.StorageKey)
+		return nil, err
+	}
+
+	return domain.ToLampiranTransaksiResponse(lampiran), nil
+}
+
+func (uc *lampiranTransaksiUsecase) DownloadLampiran(id, transaksiID string, userID uint) (*domain.LampiranTransaksiResponse, io.ReadCloser, error) {
+	lampiran, err := uc.lampiranRepo.GetByID(id, transaksiID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader, err := uc.blobStorage.Get(lampiran.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, errors.New("lampiran tidak ditemukan")
+		}
+		return nil, nil, err
+	}
+
+	return domain.ToLampiranTransaksiResponse(lampiran), reader, nil
+}
+
+func (uc *lampiranTransaksiUsecase) DeleteLampiran(id, transaksiID string, userID uint) error {
+	lampiran, err := uc.lampiranRepo.GetByID(id, transaksiID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.lampiranRepo.Delete(lampiran.ID, userID); err != nil {
+		return err
+	}
+
+	uc.hapusBlob(lampiran.StorageKey)
+	return nil
+}
+
+func (uc *lampiranTransaksiUsecase) hapusBlob(key string) {
+	if err := uc.blobStorage.Delete(key); err != nil {
+		helper.Warn("Gagal menghapus file lampiran transaksi", logrus.Fields{
+			"storage_key": key,
+			"error":       err.Error(),
+		})
+	}
+}
+
+func namaFileLampiran(namaFile string) string {
+	namaFile = strings.TrimSpace(filepath.Base(strings.ReplaceAll(namaFile, "\\", "/")))
+	if namaFile == "" || namaFile == "." || namaFile == "/" {
+		return "lampiran"
+	}
+	return potongTeks(namaFile, 255)
+}
@@ -17,6 +17,7 @@ type LaporanUsecase interface {
 	GetTrenBulanan(userID uint, req *domain.TrenBulananRequest) (*domain.TrenBulananResponse, error)
 	GetPerbandinganKantong(userID uint) (*domain.PerbandinganKantongResponse, error)
 	GetDetailPerbandinganKantong(userID uint) (*domain.DetailPerbandinganKantongResponse, error)
+	GetPengeluaranPerTag(userID uint, req *domain.PengeluaranPerTagRequest) (*domain.PengeluaranPerTagResponse, error)
 }
 
 type laporanUsecase struct {
@@ -360,3 +361,32 @@ func (uc *laporanUsecase) GetDetailPerbandinganKantong(userID uint) (*domain.Det
 
 	return response, nil
 }
+
+func (uc *laporanUsecase) GetPengeluaranPerTag(userID uint, req *domain.PengeluaranPerTagRequest) (*domain.PengeluaranPerTagResponse, error) {
+	tanggalMulai, tanggalSelesai := uc.getDefaultDateRange(req.TanggalMulai, req.TanggalSelesai)
+
+	cacheKey := fmt.Sprintf("pengeluaran_per_tag:%d:%s:%s", userID, tanggalMulai.Format("2006-01-02"), tanggalSelesai.Format("2006-01-02"))
+
+	var response *domain.PengeluaranPerTagResponse
+	err := uc.redisRepo.GetJSON(cacheKey, &response)
+	if err == nil && response != nil {
+		return response, nil
+	}
+
+	data, err := uc.laporanRepo.GetPengeluaranPerTag(userID, tanggalMulai, tanggalSelesai)
+	if err != nil {
+		return nil, err
+	}
+
+	response = &domain.PengeluaranPerTagResponse{
+		Success:   true,
+		Message:   "Pengeluaran per tag berhasil diambil",
+		Code:      200,
+		Data:      *data,
+		Timestamp: time.Now(),
+	}
+
+	uc.redisRepo.SetJSON(cacheKey, response, 15*time.Minute)
+
+	return response, nil
+}
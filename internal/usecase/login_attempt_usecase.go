@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type LoginAttemptUsecase interface {
+	Check(scope string, identifiers []domain.AttemptIdentifier) time.Duration
+	RegisterFailure(scope string, identifiers []domain.AttemptIdentifier) time.Duration
+	Reset(scope string, identifiers []domain.AttemptIdentifier)
+}
+
+type loginAttemptUsecase struct {
+	attemptRepo repo.LoginAttemptRepository
+	config      config.BruteForceConfig
+}
+
+func NewLoginAttemptUsecase(attemptRepo repo.LoginAttemptRepository, cfg *config.Config) LoginAttemptUsecase {
+	return &loginAttemptUsecase{
+		attemptRepo: attemptRepo,
+		config:      cfg.Auth.BruteForce,
+	}
+}
+
+func (uc *loginAttemptUsecase) Check(scope string, identifiers []domain.AttemptIdentifier) time.Duration {
+	var retryAfter time.Duration
+	for _, identifier := range identifiers {
+		for _, key := range []string{lockoutKey(scope, identifier), delayKey(scope, identifier)} {
+			ttl, err := uc.attemptRepo.BlockedFor(key)
+			if err == nil && ttl > retryAfter {
+				retryAfter = ttl
+			}
+		}
+	}
+	return retryAfter
+}
+
+func (uc *loginAttemptUsecase) RegisterFailure(scope string, identifiers []domain.AttemptIdentifier) time.Duration {
+	window := time.Duration(uc.config.WindowMinutes) * time.Minute
+	lockout := time.Duration(uc.config.LockoutMinutes) * time.Minute
+
+	var retryAfter time.Duration
+	for _, identifier := range identifiers {
+		count, err := uc.attemptRepo.Increment(attemptKey(scope, identifier), window)
+		if err != nil {
+			continue
+		}
+
+		maxAttempts := uc.maxAttempts(identifier)
+		if maxAttempts > 0 && count >= int64(maxAttempts) {
+			uc.attemptRepo.Block(lockoutKey(scope, identifier), lockout)
+			uc.attemptRepo.Reset(attemptKey(scope, identifier), delayKey(scope, identifier))
+
+			helper.Warn("Percobaan autentikasi berulang terdeteksi, akses dikunci sementara", logrus.Fields{
+				"event":      "auth_lockout",
+				"scope":      scope,
+				"identifier": identifier.Kind,
+				"value":      identifier.Value,
+				"attempts":   count,
+				"lockout":    lockout.String(),
+			})
+
+			if lockout > retryAfter {
+				retryAfter = lockout
+			}
+			continue
+		}
+
+		if uc.config.DelayAfterAttempts > 0 && count >= int64(uc.config.DelayAfterAttempts) {
+			delay := uc.progressiveDelay(count, lockout)
+			uc.attemptRepo.Block(delayKey(scope, identifier), delay)
+			if delay > retryAfter {
+				retryAfter = delay
+			}
+		}
+	}
+	return retryAfter
+}
+
+func (uc *loginAttemptUsecase) Reset(scope string, identifiers []domain.AttemptIdentifier) {
+	for _, identifier := range identifiers {
+		uc.attemptRepo.Reset(attemptKey(scope, identifier), delayKey(scope, identifier))
+	}
+}
+
+func (uc *loginAttemptUsecase) maxAttempts(identifier domain.AttemptIdentifier) int {
+	if identifier.Kind == domain.AttemptIdentifierIP {
+		return uc.config.MaxAttemptsPerIP
+	}
+	return uc.config.MaxAttemptsPerEmail
+}
+
+func (uc *loginAttemptUsecase) progressiveDelay(count int64, limit time.Duration) time.Duration {
+	delay := time.Duration(uc.config.DelayBaseSeconds) * time.Second
+	for i := int64(uc.config.DelayAfterAttempts); i < count; i++ {
+		delay *= 2
+		if limit > 0 && delay >= limit {
+			return limit
+		}
+	}
+	return delay
+}
+
+func attemptKey(scope string, identifier domain.AttemptIdentifier) string {
+	return fmt.Sprintf("auth:attempts:%s:%s:%s", scope, identifier.Kind, strings.ToLower(identifier.Value))
+}
+
+func delayKey(scope string, identifier domain.AttemptIdentifier) string {
+	return fmt.Sprintf("auth:delay:%s:%s:%s", scope, identifier.Kind, strings.ToLower(identifier.Value))
+}
+
+func lockoutKey(scope string, identifier domain.AttemptIdentifier) string {
+	return fmt.Sprintf("auth:lockout:%s:%s:%s", scope, identifier.Kind, strings.ToLower(identifier.Value))
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"errors"
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type MFAUsecase interface {
+	Setup(userID uint) (*domain.MFASetupResponse, error)
+	Confirm(userID uint, req domain.MFAConfirmRequest) (*domain.MFARecoveryCodesResponse, error)
+	Disable(userID uint, req domain.MFADisableRequest) error
+	RegenerateRecoveryCodes(userID uint, req domain.MFARegenerateRecoveryCodesRequest) (*domain.MFARecoveryCodesResponse, error)
+}
+
+type mfaUsecase struct {
+	mfaRepo  repo.MFARepository
+	userRepo repo.UserRepository
+	config   *config.Config
+}
+
+const mfaRecoveryCodeCount = 10
+
+func NewMFAUsecase(
+	mfaRepo repo.MFARepository,
+	userRepo repo.UserRepository,
+	config *config.Config,
+) MFAUsecase {
+	return &mfaUsecase{
+		mfaRepo:  mfaRepo,
+		userRepo: userRepo,
+		config:   config,
+	}
+}
+
+func (uc *mfaUsecase) Setup(userID uint) (*domain.MFASetupResponse, error) {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user tidak ditemukan")
+		}
+		return nil, errors.New("gagal mengambil data user")
+	}
+
+	mfa, err := uc.mfaRepo.GetByUserID(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("gagal mengambil data 2FA")
+	}
+	if mfa != nil && mfa.IsEnabled {
+		return nil, errors.New("2FA sudah aktif")
+	}
+
+	secret, err := helper.GenerateTOTPSecret()
+	if err != nil {
+		return nil, errors.New("gagal generate secret 2FA")
+	}
+
+	if mfa == nil {
+		mfa = &domain.UserMFA{UserID: userID}
+	}
+	mfa.Secret = secret
+	mfa.LastUsedStep = 0
+
+	if err := uc.mfaRepo.Save(mfa); err != nil {
+		return nil, errors.New("gagal menyimpan data 2FA")
+	}
+
+	return &domain.MFASetupResponse{
+		Secret:     secret,
+		OTPAuthURI: helper.BuildTOTPURI(uc.config.App.Name, user.Email, secret),
+	}, nil
+}
+
+func (uc *mfaUsecase) Confirm(userID uint, req domain.MFAConfirmRequest) (*domain.MFARecoveryCodesResponse, error) {
+	mfa, err := uc.mfaRepo.GetByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("2FA belum disiapkan")
+		}
+		return nil, errors.New("gagal mengambil data 2FA")
+	}
+
+	if mfa.IsEnabled {
+		return nil, errors.New("2FA sudah aktif")
+	}
+
+	step, ok := helper.ValidateTOTPCode(mfa.Secret, req.Code, time.Now())
+	if !ok {
+		return nil, errors.New("kode 2FA tidak valid")
+	}
+
+	now := time.Now()
+	mfa.IsEnabled = true
+	mfa.EnabledAt = &now
+	mfa.LastUsedStep = step
+
+	if err := uc.mfaRepo.Save(mfa); err != nil {
+		return nil, errors.New("gagal menyimpan data 2FA")
+	}
+
+	return uc.issueRecoveryCodes(userID)
+}
+
+func (uc *mfaUsecase) Disable(userID uint, req domain.MFADisableRequest) error {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user tidak ditemukan")
+		}
+		return errors.New("gagal mengambil data user")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return errors.New("password salah")
+	}
+
+	mfa, err := uc.getEnabledMFA(userID)
+	if err != nil {
+		return err
+	}
+
+	valid, err := verifyMFACode(uc.mfaRepo, mfa, req.Code)
+	if err != nil {
+		return errors.New("gagal memverifikasi kode 2FA")
+	}
+	if !valid {
+		return errors.New("kode 2FA tidak valid")
+	}
+
+	if err := uc.mfaRepo.Delete(userID); err != nil {
+		return errors.New("gagal menonaktifkan 2FA")
+	}
+
+	return nil
+}
+
+func (uc *mfaUsecase) RegenerateRecoveryCodes(userID uint, req domain.MFARegenerateRecoveryCodesRequest) (*domain.MFARecoveryCodesResponse, error) {
+	mfa, err := uc.getEnabledMFA(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := verifyMFACode(uc.mfaRepo, mfa, req.Code)
+	if err != nil {
+		return nil, errors.New("gagal memverifikasi kode 2FA")
+	}
+	if !valid {
+		return nil, errors.New("kode 2FA tidak valid")
+	}
+
+	return uc.issueRecoveryCodes(userID)
+}
+
+func (uc *mfaUsecase) getEnabledMFA(userID uint) (*domain.UserMFA, error) {
+	mfa, err := uc.mfaRepo.GetByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("2FA belum aktif")
+		}
+		return nil, errors.New("gagal mengambil data 2FA")
+	}
+
+	if !mfa.IsEnabled {
+		return nil, errors.New("2FA belum aktif")
+	}
+
+	return mfa, nil
+}
+
+func (uc *mfaUsecase) issueRecoveryCodes(userID uint) (*domain.MFARecoveryCodesResponse, error) {
+	codes, err := helper.GenerateRecoveryCodes(mfaRecoveryCodeCount)
+	if err != nil {
+		return nil, errors.New("gagal generate recovery code")
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, helper.HashRecoveryCode(code))
+	}
+
+	if err := uc.mfaRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, errors.New("gagal menyimpan recovery code")
+	}
+
+	return &domain.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func verifyMFACode(mfaRepo repo.MFARepository, mfa *domain.UserMFA, code string) (bool, error) {
+	if step, ok := helper.ValidateTOTPCode(mfa.Secret, code, time.Now()); ok {
+		return mfaRepo.UpdateLastUsedStep(mfa.UserID, step)
+	}
+
+	return mfaRepo.UseRecoveryCode(mfa.UserID, helper.HashRecoveryCode(code))
+}
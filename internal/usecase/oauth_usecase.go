@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/oauth"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type OAuthUsecase interface {
+	GetProviders() *domain.OAuthProvidersResponse
+	Start(provider string) (*domain.OAuthStartResponse, error)
+	Callback(provider string, req *domain.OAuthCallbackRequest, meta domain.SessionMeta) (*domain.AuthResponse, error)
+}
+
+type LoginCompleter interface {
+	CompleteLogin(user *domain.User, meta domain.SessionMeta) (*domain.AuthResponse, error)
+}
+
+type oauthUsecase struct {
+	providers    *oauth.Registry
+	stateRepo    repo.OAuthStateRepository
+	identityRepo repo.UserIdentityRepository
+	userRepo     repo.UserRepository
+	login        LoginCompleter
+	config       *config.Config
+}
+
+func NewOAuthUsecase(
+	providers *oauth.Registry,
+	stateRepo repo.OAuthStateRepository,
+	identityRepo repo.UserIdentityRepository,
+	userRepo repo.UserRepository,
+	login LoginCompleter,
+	config *config.Config,
+) OAuthUsecase {
+	return &oauthUsecase{
+		providers:    providers,
+		stateRepo:    stateRepo,
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		login:        login,
+		config:       config,
+	}
+}
+
+func (uc *oauthUsecase) GetProviders() *domain.OAuthProvidersResponse {
+	return &domain.OAuthProvidersResponse{Providers: uc.providers.Names()}
+}
+
+func (uc *oauthUsecase) Start(providerName string) (*domain.OAuthStartResponse, error) {
+	provider, ok := uc.providers.Get(providerName)
+	if !ok {
+		return nil, errors.New("provider oauth tidak didukung")
+	}
+
+	state, err := oauth.GenerateState()
+	if err != nil {
+		return nil, errors.New("gagal memulai login oauth")
+	}
+	codeVerifier, err := oauth.GenerateCodeVerifier()
+	if err != nil {
+		return nil, errors.New("gagal memulai login oauth")
+	}
+	nonce, err := oauth.GenerateNonce()
+	if err != nil {
+		return nil, errors.New("gagal memulai login oauth")
+	}
+
+	ttl := time.Duration(uc.config.OAuth.StateTTLMinutes) * time.Minute
+	if err := uc.stateRepo.Create(&domain.OAuthState{
+		StateHash:    oauth.HashState(state),
+		Provider:     provider.Name(),
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(ttl),
+	}); err != nil {
+		return nil, errors.New("gagal memulai login oauth")
+	}
+
+	ctx, cancel := uc.context()
+	defer cancel()
+
+	authorizationURL, err := provider.AuthCodeURL(ctx, state, oauth.CodeChallengeS256(codeVerifier), nonce)
+	if err != nil {
+		helper.Error("Gagal membuat URL otorisasi oauth", err, logrus.Fields{
+			"provider": provider.Name(),
+		})
+		return nil, errors.New("provider oauth tidak dapat dihubungi")
+	}
+
+	return &domain.OAuthStartResponse{
+		AuthorizationURL: authorizationURL,
+		State:            state,
+		ExpiresIn:        int(ttl.Seconds()),
+	}, nil
+}
+
+func (uc *oauthUsecase) Callback(providerName string, req *domain.OAuthCallbackRequest, meta domain.SessionMeta) (*domain.AuthResponse, error) {
+	provider, ok := uc.providers.Get(providerName)
+	if !ok {
+		return nil, errors.New("provider oauth tidak didukung")
+	}
+
+	state, err := uc.stateRepo.Consume(oauth.HashState(req.State))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("state oauth tidak valid atau sudah kedaluwarsa")
+		}
+		return nil, errors.New("gagal memverifikasi state oauth")
+	}
+	if state.Provider != provider.Name() {
+		return nil, errors.New("state oauth tidak valid atau sudah kedaluwarsa")
+	}
+
+	ctx, cancel := uc.context()
+	defer cancel()
+
+	identity, err := provider.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		helper.Error("Gagal menukar kode otorisasi oauth", err, logrus.Fields{
+			"provider": provider.Name(),
+		})
+		return nil, errors.New("gagal memverifikasi akun provider")
+	}
+	identity.Provider = provider.Name()
+
+	user, err := uc.resolveUser(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.login.CompleteLogin(user, meta)
+}
+
+func (uc *oauthUsecase) resolveUser(identity *domain.OAuthIdentity) (*domain.User, error) {
+	linked, err := uc.identityRepo.GetByProviderSubject(identity.Provider, identity.Subject)
+	if err == nil {
+		user, err := uc.userRepo.FindByID(linked.UserID)
+		if err != nil {
+			return nil, errors.New("gagal mengambil data user")
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("gagal mengambil data akun oauth")
+	}
+
+	email := strings.TrimSpace(identity.Email)
+	if email == "" || !identity.EmailVerified {
+		return nil, errors.New("email akun provider belum terverifikasi")
+	}
+
+	user, err := uc.userRepo.GetByEmail(email)
+	switch {
+	case err == nil:
+		if !user.IsEmailVerified() {
+			return nil, errors.New("email sudah terdaftar dan belum diverifikasi, silakan login dengan password lalu verifikasi email")
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		user, err = uc.createUser(identity, email)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("gagal mengambil data user")
+	}
+
+	if err := uc.identityRepo.Create(&domain.UserIdentity{
+		UserID:   user.ID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    email,
+	}); err != nil {
+		return nil, errors.New("gagal menautkan akun oauth")
+	}
+
+	helper.Info("Akun oauth ditautkan", logrus.Fields{
+		"user_id":  user.ID,
+		"provider": identity.Provider,
+	})
+
+	return user, nil
+}
+
+func (uc *oauthUsecase) createUser(identity *domain.OAuthIdentity, email string) (*domain.User, error) {
+	randomPassword, err := helper.GenerateRefreshToken()
+	if err != nil {
+		return nil, errors.New("gagal membuat user")
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(randomPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.New("gagal mengenkripsi password")
+	}
+
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name = strings.Split(email, "@")[0]
+	}
+
+	now := time.Now()
+	user := &domain.User{
+		Name:            name,
+		Email:           email,
+		Password:        string(hashedPassword),
+		IsActive:        true,
+		EmailVerifiedAt: &now,
+	}
+	if err := uc.userRepo.Create(user); err != nil {
+		return nil, errors.New("gagal membuat user")
+	}
+
+	return user, nil
+}
+
+func (uc *oauthUsecase) context() (context.Context, context.CancelFunc) {
+	timeout := time.Duration(uc.config.OAuth.HTTPTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
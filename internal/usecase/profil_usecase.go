@@ -2,31 +2,47 @@ package usecase
 
 import (
 	"errors"
+	"fiber-boiler-plate/config"
 	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
 	"fiber-boiler-plate/internal/usecase/repo"
 	"fmt"
 	"time"
 
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type ProfilUsecase interface {
 	GetProfil(userID uint) (*domain.ProfilResponse, error)
 	UpdateProfil(userID uint, req domain.UpdateProfilRequest) (*domain.ProfilResponse, error)
+	ChangePassword(userID uint, sessionID string, req domain.ChangePasswordRequest) (*domain.ChangePasswordResponse, error)
 }
 
 type profilUsecase struct {
-	userRepo  repo.UserRepository
-	redisRepo repo.RedisRepository
+	userRepo            repo.UserRepository
+	redisRepo           repo.RedisRepository
+	refreshTokenRepo    repo.RefreshTokenRepository
+	tokenRevocationRepo repo.TokenRevocationRepository
+	tokenKeys           *helper.JWTKeySet
+	config              *config.Config
 }
 
 func NewProfilUsecase(
 	userRepo repo.UserRepository,
 	redisRepo repo.RedisRepository,
+	refreshTokenRepo repo.RefreshTokenRepository,
+	tokenRevocationRepo repo.TokenRevocationRepository,
+	tokenKeys *helper.JWTKeySet,
+	config *config.Config,
 ) ProfilUsecase {
 	return &profilUsecase{
-		userRepo:  userRepo,
-		redisRepo: redisRepo,
+		userRepo:            userRepo,
+		redisRepo:           redisRepo,
+		refreshTokenRepo:    refreshTokenRepo,
+		tokenRevocationRepo: tokenRevocationRepo,
+		tokenKeys:           tokenKeys,
+		config:              config,
 	}
 }
 
@@ -91,3 +107,55 @@ func (uc *profilUsecase) UpdateProfil(userID uint, req domain.UpdateProfilReques
 
 	return profil, nil
 }
+
+func (uc *profilUsecase) ChangePassword(userID uint, sessionID string, req domain.ChangePasswordRequest) (*domain.ChangePasswordResponse, error) {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("profil tidak ditemukan")
+		}
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		return nil, errors.New("password saat ini salah")
+	}
+
+	if req.CurrentPassword == req.NewPassword {
+		return nil, errors.New("password baru tidak boleh sama dengan password saat ini")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.New("gagal mengenkripsi password")
+	}
+
+	if err := uc.userRepo.UpdatePassword(user.Email, string(hashedPassword)); err != nil {
+		return nil, errors.New("gagal update password")
+	}
+
+	var keepSessions []string
+	if sessionID != "" {
+		keepSessions = append(keepSessions, sessionID)
+	}
+	if err := uc.refreshTokenRepo.RevokeAllUserTokens(user.ID, keepSessions...); err != nil {
+		return nil, errors.New("gagal mencabut sesi lain")
+	}
+
+	invalidBefore := time.Now()
+	if err := uc.tokenRevocationRepo.InvalidateUserTokens(user.ID, invalidBefore); err != nil {
+		return nil, errors.New("gagal mencabut sesi lain")
+	}
+	time.Sleep(time.Until(invalidBefore.Truncate(time.Second).Add(time.Second)))
+
+	accessToken, err := helper.GenerateAccessToken(user.ID, user.Email, sessionID, uc.tokenKeys, uc.config.JWT.ExpireHours)
+	if err != nil {
+		return nil, errors.New("gagal generate access token")
+	}
+
+	return &domain.ChangePasswordResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   uc.config.JWT.ExpireHours * 3600,
+	}, nil
+}
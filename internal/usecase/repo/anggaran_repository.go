@@ -158,7 +158,7 @@ func (r *anggaranRepository) CreatePenyesuaian(userID uint, req *domain.Penyesua
 		return nil, err
 	}
 
-	var totalPenyesuaian float64
+	var totalPenyesuaian domain.Money
 	err = r.db.Model(&domain.PenyesuaianAnggaran{}).
 		Select("SUM(CASE WHEN jenis = 'tambah' THEN jumlah WHEN jenis = 'kurangi' THEN -jumlah ELSE 0 END)").
 		Where("anggaran_id = ?", anggaranDB.ID).Scan(&totalPenyesuaian).Error
@@ -187,14 +187,14 @@ func (r *anggaranRepository) GetStatistikBulan(kantongID string, userID uint, bu
 	var results []struct {
 		Tanggal          time.Time
 		JumlahTransaksi  int64
-		TotalPengeluaran float64
+		TotalPengeluaran domain.Money
 	}
 
-	err := r.db.Table("transaksis").
-		Select("DATE(created_at) as tanggal, COUNT(*) as jumlah_transaksi, SUM(jumlah) as total_pengeluaran").
-		Where("kantong_id = ? AND user_id = ? AND created_at >= ? AND created_at <= ?",
+	err := r.db.Table("transaksi_kantongs").
+		Select("tanggal, COUNT(*) as jumlah_transaksi, SUM(jumlah) as total_pengeluaran").
+		Where("kantong_id = ? AND user_id = ? AND tanggal >= ? AND tanggal <= ?",
 			kantongID, userID, startDate, endDate).
-		Group("DATE(created_at)").
+		Group("tanggal").
 		Order("tanggal").
 		Scan(&results).Error
 
@@ -203,7 +203,7 @@ func (r *anggaranRepository) GetStatistikBulan(kantongID string, userID uint, bu
 	}
 
 	statistik := make([]domain.StatistikHarian, len(results))
-	var akumulasi float64
+	var akumulasi domain.Money
 
 	for i, result := range results {
 		akumulasi += result.TotalPengeluaran
@@ -226,9 +226,9 @@ func (r *anggaranRepository) RecalculateAnggaran(kantongID string, userID uint,
 		return nil, err
 	}
 
-	var totalTransaksi float64
-	err = r.db.Model(&domain.Transaksi{}).
-		Where("kantong_id = ? AND user_id = ? AND EXTRACT(MONTH FROM created_at) = ? AND EXTRACT(YEAR FROM created_at) = ?",
+	var totalTransaksi domain.Money
+	err = r.db.Table("transaksi_kantongs").
+		Where("kantong_id = ? AND user_id = ? AND EXTRACT(MONTH FROM tanggal) = ? AND EXTRACT(YEAR FROM tanggal) = ?",
 			kantongID, userID, bulan, tahun).
 		Select("COALESCE(SUM(jumlah), 0)").Scan(&totalTransaksi).Error
 	if err != nil {
@@ -333,9 +333,9 @@ func (r *anggaranRepository) toAnggaranItem(anggaran *domain.Anggaran) *domain.A
 }
 
 func (r *anggaranRepository) calculateAnggaranValues(item *domain.AnggaranItem, userID uint) (*domain.AnggaranItem, error) {
-	var totalTransaksi float64
-	err := r.db.Model(&domain.Transaksi{}).
-		Where("kantong_id = ? AND user_id = ? AND EXTRACT(MONTH FROM created_at) = ? AND EXTRACT(YEAR FROM created_at) = ?",
+	var totalTransaksi domain.Money
+	err := r.db.Table("transaksi_kantongs").
+		Where("kantong_id = ? AND user_id = ? AND EXTRACT(MONTH FROM tanggal) = ? AND EXTRACT(YEAR FROM tanggal) = ?",
 			item.KantongID, userID, item.Bulan, item.Tahun).
 		Select("COALESCE(SUM(jumlah), 0)").Scan(&totalTransaksi).Error
 	if err != nil {
@@ -349,18 +349,18 @@ func (r *anggaranRepository) calculateAnggaranValues(item *domain.AnggaranItem,
 	return item, nil
 }
 
-func (r *anggaranRepository) calculateSisa(rencana *float64, carryIn, penyesuaian, terpakai float64) float64 {
+func (r *anggaranRepository) calculateSisa(rencana *domain.Money, carryIn, penyesuaian, terpakai domain.Money) domain.Money {
 	if rencana == nil {
 		return carryIn + penyesuaian - terpakai
 	}
 	return *rencana + carryIn + penyesuaian - terpakai
 }
 
-func (r *anggaranRepository) calculateProgres(rencana *float64, penyesuaian, terpakai float64) float64 {
+func (r *anggaranRepository) calculateProgres(rencana *domain.Money, penyesuaian, terpakai domain.Money) float64 {
 	if rencana == nil || *rencana+penyesuaian <= 0 {
 		return 0
 	}
-	progres := (terpakai / (*rencana + penyesuaian)) * 100
+	progres := (terpakai.Float64() / (*rencana + penyesuaian).Float64()) * 100
 	return math.Round(progres*100) / 100
 }
 
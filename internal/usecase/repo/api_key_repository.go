@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(apiKey *domain.APIKey) error {
+	return r.db.Create(apiKey).Error
+}
+
+func (r *apiKeyRepository) GetByUserID(userID uint) ([]*domain.APIKey, error) {
+	var apiKeys []*domain.APIKey
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&apiKeys).Error
+	return apiKeys, err
+}
+
+func (r *apiKeyRepository) GetByPrefix(prefix string) (*domain.APIKey, error) {
+	var apiKey domain.APIKey
+	err := r.db.Where("prefix = ?", prefix).First(&apiKey).Error
+	if err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+func (r *apiKeyRepository) Delete(id, userID uint) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&domain.APIKey{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) UpdateLastUsed(id uint, usedAt time.Time) error {
+	return r.db.Model(&domain.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", usedAt).Error
+}
@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type emailVerificationTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationTokenRepository(db *gorm.DB) EmailVerificationTokenRepository {
+	return &emailVerificationTokenRepository{db: db}
+}
+
+func (r *emailVerificationTokenRepository) Create(userID uint, email, token string, expiresAt time.Time) (*domain.EmailVerificationToken, error) {
+	verificationToken := &domain.EmailVerificationToken{
+		UserID:    userID,
+		Email:     email,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}
+	err := r.db.Create(verificationToken).Error
+	if err != nil {
+		return nil, err
+	}
+	return verificationToken, nil
+}
+
+func (r *emailVerificationTokenRepository) GetByToken(token string) (*domain.EmailVerificationToken, error) {
+	var verificationToken domain.EmailVerificationToken
+	err := r.db.Where("token = ? AND is_used = ? AND expires_at > ?", token, false, time.Now()).First(&verificationToken).Error
+	if err != nil {
+		return nil, err
+	}
+	return &verificationToken, nil
+}
+
+func (r *emailVerificationTokenRepository) MarkAsUsed(token string) error {
+	return r.db.Model(&domain.EmailVerificationToken{}).Where("token = ?", token).Update("is_used", true).Error
+}
+
+func (r *emailVerificationTokenRepository) CleanupExpired() (int64, error) {
+	result := r.db.Where("expires_at < ? OR is_used = ?", time.Now(), true).Delete(&domain.EmailVerificationToken{})
+	return result.RowsAffected, result.Error
+}
@@ -11,21 +11,86 @@ type UserRepository interface {
 	Create(user *domain.User) error
 	UpdatePassword(email, hashedPassword string) error
 	Update(user *domain.User) error
+	MarkEmailVerified(id uint) error
+	IsEmailVerified(id uint) (bool, error)
+	GetAll(req *domain.AdminUserListRequest) ([]*domain.User, int, error)
+	FindByID(id uint) (*domain.User, error)
+	UpdateStatus(id uint, isActive bool) error
+	UpdateRole(id uint, roleID *string) error
 }
 
 type RefreshTokenRepository interface {
-	Create(userID uint, token string, expiresAt time.Time) (*domain.RefreshToken, error)
+	Create(userID uint, token string, expiresAt time.Time, meta domain.SessionMeta) (*domain.RefreshToken, error)
 	GetByToken(token string) (*domain.RefreshToken, error)
+	GetActiveByUserID(userID uint) ([]*domain.RefreshToken, error)
+	Rotate(parent *domain.RefreshToken, token string, expiresAt time.Time, meta domain.SessionMeta) (*domain.RefreshToken, error)
 	RevokeToken(token string) error
-	RevokeAllUserTokens(userID uint) error
-	CleanupExpired() error
+	RevokeFamily(familyID string) error
+	RevokeUserToken(userID, id uint) (string, error)
+	RevokeAllUserTokens(userID uint, exceptFamilyIDs ...string) error
+	CleanupExpired() (int64, error)
+}
+
+type LoginAttemptRepository interface {
+	Increment(key string, window time.Duration) (int64, error)
+	Block(key string, ttl time.Duration) error
+	BlockedFor(key string) (time.Duration, error)
+	Reset(keys ...string) error
+}
+
+type JobLockRepository interface {
+	Acquire(name string, ttl time.Duration) (bool, error)
+	Release(name string) error
+}
+
+type TokenRevocationRepository interface {
+	RevokeAccessToken(tokenID string, expiresAt time.Time) error
+	RevokeSessionAccess(sessionID string, ttl time.Duration) error
+	InvalidateUserTokens(userID uint, before time.Time) error
+	IsAccessTokenRevoked(tokenID string, userID uint, sessionID string, issuedAt time.Time) (bool, error)
 }
 
 type PasswordResetTokenRepository interface {
 	Create(email, token string, expiresAt time.Time) (*domain.PasswordResetToken, error)
 	GetByToken(token string) (*domain.PasswordResetToken, error)
 	MarkAsUsed(token string) error
-	CleanupExpired() error
+	CleanupExpired() (int64, error)
+}
+
+type EmailVerificationTokenRepository interface {
+	Create(userID uint, email, token string, expiresAt time.Time) (*domain.EmailVerificationToken, error)
+	GetByToken(token string) (*domain.EmailVerificationToken, error)
+	MarkAsUsed(token string) error
+	CleanupExpired() (int64, error)
+}
+
+type MFARepository interface {
+	GetByUserID(userID uint) (*domain.UserMFA, error)
+	Save(mfa *domain.UserMFA) error
+	Delete(userID uint) error
+	UpdateLastUsedStep(userID uint, step int64) (bool, error)
+	ReplaceRecoveryCodes(userID uint, codeHashes []string) error
+	UseRecoveryCode(userID uint, codeHash string) (bool, error)
+}
+
+type OAuthStateRepository interface {
+	Create(state *domain.OAuthState) error
+	Consume(stateHash string) (*domain.OAuthState, error)
+	CleanupExpired() (int64, error)
+}
+
+type UserIdentityRepository interface {
+	GetByProviderSubject(provider, subject string) (*domain.UserIdentity, error)
+	GetByUserID(userID uint) ([]*domain.UserIdentity, error)
+	Create(identity *domain.UserIdentity) error
+}
+
+type APIKeyRepository interface {
+	Create(apiKey *domain.APIKey) error
+	GetByUserID(userID uint) ([]*domain.APIKey, error)
+	GetByPrefix(prefix string) (*domain.APIKey, error)
+	Delete(id, userID uint) error
+	UpdateLastUsed(id uint, usedAt time.Time) error
 }
 
 type KantongRepository interface {
@@ -36,16 +101,61 @@ type KantongRepository interface {
 	Update(kantong *domain.Kantong) error
 	Delete(id string, userID uint) error
 	IsNameExistForUser(nama string, userID uint, excludeID ...string) (bool, error)
+	HasTransaksiSplit(id string) (bool, error)
 	GenerateUniqueIDKartu() (string, error)
-	Transfer(kantongAsalID, kantongTujuanID string, jumlah float64, userID uint) (*domain.Kantong, *domain.Kantong, error)
+	Transfer(transfer *domain.Transfer) (*domain.Kantong, *domain.Kantong, error)
+	ReverseTransfer(id string, userID uint, reversal *domain.Transfer) (*domain.Kantong, *domain.Kantong, error)
+	GetTransfers(userID uint, req *domain.TransferListRequest) ([]*domain.TransferResult, int, error)
+	GetTransferByID(id string, userID uint) (*domain.TransferResult, error)
 }
 
 type TransaksiRepository interface {
 	GetByUserID(userID uint, req *domain.TransaksiListRequest) ([]*domain.TransaksiResponse, int, error)
+	GetByUserIDCursor(userID uint, req *domain.TransaksiListRequest, cursor *domain.TransaksiCursor, limit int) ([]*domain.TransaksiResponse, error)
+	CountByUserID(userID uint, req *domain.TransaksiListRequest) (int, error)
 	GetByID(id string, userID uint) (*domain.TransaksiResponse, error)
+	GetByKantongAndPeriod(kantongID string, userID uint, tanggalMulai, tanggalSelesai time.Time) ([]*domain.Transaksi, error)
+	StreamForExport(userID uint, req *domain.TransaksiListRequest, kelompokkanKantong bool, fn func(row *domain.TransaksiExportRow) error) error
+	GetSaldoAwalKantong(userID uint, tanggalMulai *string) (map[string]domain.Money, error)
+	GetMutasiTransfer(userID uint, tanggalMulai, tanggalSelesai *string) ([]domain.MutasiTransferExport, error)
 	Create(transaksi *domain.Transaksi) error
+	CreateBatch(transaksis []*domain.Transaksi) error
 	Update(transaksi *domain.Transaksi) error
 	Delete(id string, userID uint) error
+	ExecuteBatch(userID uint, operasi []*domain.OperasiBatchTransaksi, atomik bool) error
+}
+
+type TagRepository interface {
+	GetByUserID(userID uint, req *domain.TagListRequest) ([]*domain.TagResponse, int, error)
+	GetByID(id string, userID uint) (*domain.Tag, error)
+	CountTransaksi(id string) (int, error)
+	Create(tag *domain.Tag) error
+	Update(tag *domain.Tag) error
+	Delete(id string, userID uint) error
+	IsNamaExists(userID uint, nama string, excludeID ...string) (bool, error)
+}
+
+type LampiranTransaksiRepository interface {
+	Create(lampiran *domain.LampiranTransaksi, maxJumlah int) error
+	GetByID(id, transaksiID string, userID uint) (*domain.LampiranTransaksi, error)
+	GetByTransaksiID(transaksiID string, userID uint) ([]*domain.LampiranTransaksi, error)
+	CountByTransaksiID(transaksiID string, userID uint) (int, error)
+	Delete(id string, userID uint) error
+}
+
+type TransaksiBerulangRepository interface {
+	Create(transaksiBerulang *domain.TransaksiBerulang) error
+	GetByID(id string, userID uint) (*domain.TransaksiBerulang, error)
+	GetByUserID(userID uint, req *domain.TransaksiBerulangListRequest) ([]*domain.TransaksiBerulang, int, error)
+	Update(transaksiBerulang *domain.TransaksiBerulang) error
+	Delete(id string, userID uint) error
+	GetDue(tanggal time.Time, limit int) ([]*domain.TransaksiBerulang, error)
+	AdvanceSchedule(transaksiBerulang *domain.TransaksiBerulang, urutanSebelum int) (bool, error)
+	CreateKejadian(kejadian *domain.KejadianTransaksiBerulang) (bool, error)
+	ReclaimKejadian(kejadian *domain.KejadianTransaksiBerulang, staleBefore time.Time) (bool, error)
+	UpdateKejadian(kejadian *domain.KejadianTransaksiBerulang) error
+	DeleteKejadian(id uint) error
+	DeleteKejadianDilewati(transaksiBerulangID string, urutanMulai int) error
 }
 
 type AnggaranRepository interface {
@@ -69,6 +179,7 @@ type LaporanRepository interface {
 	GetTrenBulanan(userID uint, tahun int) (*domain.TrenBulanan, error)
 	GetPerbandinganKantong(userID uint, bulanIni, tahunIni, bulanLalu, tahunLalu int) (*domain.PerbandinganKantong, error)
 	GetDetailPerbandinganKantong(userID uint, bulanIni, tahunIni, bulanLalu, tahunLalu int) (*domain.DetailPerbandinganKantong, error)
+	GetPengeluaranPerTag(userID uint, tanggalMulai, tanggalSelesai time.Time) (*domain.PengeluaranPerTag, error)
 }
 
 type SubscriptionPlanRepository interface {
@@ -107,6 +218,7 @@ type RoleRepository interface {
 	GetRolePermissions(roleID string, req *domain.RolePermissionListRequest) ([]*domain.Permission, int, error)
 	UpdateRolePermissions(roleID string, permissionIDs []string) error
 	ValidatePermissions(permissionIDs []string) ([]string, error)
+	GetUserPermissions(userID uint) ([]string, error)
 }
 
 type UserSubscriptionRepository interface {
@@ -115,6 +227,7 @@ type UserSubscriptionRepository interface {
 	UpdateStatus(id string, status string, reason *string) error
 	UpdatePaymentMethod(id string, paymentMethod string) error
 	GetStatistics() (*domain.UserSubscriptionStatistics, error)
+	GetLatestByUserID(userID uint) (*domain.UserSubscription, error)
 	Create(subscription *domain.UserSubscription) error
 	Update(subscription *domain.UserSubscription) error
 	Delete(id string) error
@@ -126,8 +239,11 @@ type RedisRepository interface {
 	GetJSON(key string, dest interface{}) error
 	SetJSON(key string, value interface{}, ttl time.Duration) error
 	Delete(key string) error
+	SetNX(key string, value interface{}, ttl time.Duration) (bool, error)
+	DeleteIfEqual(key, value string) (bool, error)
 	Exists(key string) (bool, error)
 	Increment(key string) (int64, error)
+	IncrementWithExpire(key string, ttl time.Duration) (int64, error)
 	Decrement(key string) (int64, error)
 	SetExpire(key string, ttl time.Duration) error
 	GetTTL(key string) (time.Duration, error)
@@ -1,7 +1,6 @@
 package repo
 
 import (
-	"database/sql"
 	"fiber-boiler-plate/internal/domain"
 	"fmt"
 	"strings"
@@ -13,6 +12,7 @@ import (
 type InvoiceRepository interface {
 	GetAll(req *domain.InvoiceListRequest) ([]*domain.Invoice, int, error)
 	GetByID(id string) (*domain.Invoice, error)
+	GetByUserID(userID uint, limit int) ([]*domain.Invoice, error)
 	UpdateStatus(id string, status string, keterangan *string) error
 	GetStatistics(req *domain.InvoiceStatisticsRequest) (*domain.InvoiceStatistics, error)
 }
@@ -131,6 +131,20 @@ func (r *invoiceRepository) GetByID(id string) (*domain.Invoice, error) {
 	return invoice, nil
 }
 
+func (r *invoiceRepository) GetByUserID(userID uint, limit int) ([]*domain.Invoice, error) {
+	var invoices []*domain.Invoice
+	if err := r.db.
+		Preload("SubscriptionPlan").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&invoices).Error; err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
 func (r *invoiceRepository) UpdateStatus(id string, status string, keterangan *string) error {
 	updates := map[string]interface{}{
 		"status":     status,
@@ -207,18 +221,16 @@ func (r *invoiceRepository) GetStatistics(req *domain.InvoiceStatisticsRequest)
 	stats.TotalGagal = totalGagal
 	stats.TotalPending = totalPending
 
-	var totalPendapatan sql.NullFloat64
+	var totalPendapatan domain.Money
 	r.db.Model(&domain.Invoice{}).
 		Where("status = ?", "sukses").
 		Select("SUM(jumlah)").
 		Scan(&totalPendapatan)
 
-	if totalPendapatan.Valid {
-		stats.TotalPendapatan = totalPendapatan.Float64
-	}
+	stats.TotalPendapatan = totalPendapatan
 
 	if stats.TotalSukses > 0 {
-		stats.RataRataPembayaran = stats.TotalPendapatan / float64(stats.TotalSukses)
+		stats.RataRataPembayaran = stats.TotalPendapatan.Div(stats.TotalSukses)
 	}
 
 	r.getMonthlyStats(stats, req)
@@ -260,7 +272,7 @@ func (r *invoiceRepository) getMonthlyStats(stats *domain.InvoiceStatistics, req
 		var month int
 		var monthName string
 		var totalInvoice, totalSukses int64
-		var totalPendapatan float64
+		var totalPendapatan domain.Money
 
 		rows.Scan(&month, &monthName, &totalInvoice, &totalSukses, &totalPendapatan)
 
@@ -306,7 +318,7 @@ func (r *invoiceRepository) getTopSubscriptionPlans(stats *domain.InvoiceStatist
 	for rows.Next() {
 		var subscriptionPlanNama string
 		var jumlahInvoice int64
-		var totalPendapatan float64
+		var totalPendapatan domain.Money
 
 		rows.Scan(&subscriptionPlanNama, &jumlahInvoice, &totalPendapatan)
 
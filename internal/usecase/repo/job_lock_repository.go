@@ -0,0 +1,53 @@
+package repo
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type jobLockRepository struct {
+	redis  RedisRepository
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+func NewJobLockRepository(redis RedisRepository) JobLockRepository {
+	return &jobLockRepository{
+		redis:  redis,
+		tokens: make(map[string]string),
+	}
+}
+
+func (r *jobLockRepository) Acquire(name string, ttl time.Duration) (bool, error) {
+	key := "scheduler:lock:" + name
+	token := uuid.New().String()
+
+	acquired, err := r.redis.SetNX(key, token, ttl)
+	if err != nil || !acquired {
+		return false, err
+	}
+
+	r.mu.Lock()
+	r.tokens[key] = token
+	r.mu.Unlock()
+
+	return true, nil
+}
+
+func (r *jobLockRepository) Release(name string) error {
+	key := "scheduler:lock:" + name
+
+	r.mu.Lock()
+	token, ok := r.tokens[key]
+	delete(r.tokens, key)
+	r.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	_, err := r.redis.DeleteIfEqual(key, token)
+	return err
+}
@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func lockKantongs(tx *gorm.DB, userID uint, ids ...string) (map[string]*domain.Kantong, error) {
+	uniqueIDs := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+	sort.Strings(uniqueIDs)
+
+	kantongs := make(map[string]*domain.Kantong, len(uniqueIDs))
+	for _, id := range uniqueIDs {
+		var kantong domain.Kantong
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", id, userID).
+			First(&kantong).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		kantongs[id] = &kantong
+	}
+
+	return kantongs, nil
+}
+
+func kantongIDMutasi(mutasi ...map[string]domain.Money) []string {
+	var ids []string
+	for _, m := range mutasi {
+		for id := range m {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func terapkanMutasi(kantongs map[string]*domain.Kantong, mutasi map[string]domain.Money, balik bool, errTidakDitemukan error) error {
+	for id, jumlah := range mutasi {
+		kantong, ok := kantongs[id]
+		if !ok {
+			return errTidakDitemukan
+		}
+		if balik {
+			jumlah = -jumlah
+		}
+		kantong.Saldo += jumlah
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"crypto/rand"
+	"errors"
 	"fiber-boiler-plate/internal/domain"
 	"fmt"
 	"strings"
@@ -145,6 +146,15 @@ func (r *kantongRepository) Delete(id string, userID uint) error {
 	return nil
 }
 
+func (r *kantongRepository) HasTransaksiSplit(id string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&domain.TransaksiSplit{}).Where("kantong_id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 func (r *kantongRepository) IsNameExistForUser(nama string, userID uint, excludeID ...string) (bool, error) {
 	query := r.db.Where("nama = ? AND user_id = ?", nama, userID)
 
@@ -214,55 +224,188 @@ func (r *kantongRepository) clearUserListCache(userID uint) {
 	}
 }
 
-func (r *kantongRepository) Transfer(kantongAsalID, kantongTujuanID string, jumlah float64, userID uint) (*domain.Kantong, *domain.Kantong, error) {
-	tx := r.db.Begin()
-	if tx.Error != nil {
-		return nil, nil, tx.Error
+func (r *kantongRepository) refreshKantongCache(kantongs ...*domain.Kantong) {
+	if r.redis == nil {
+		return
 	}
 
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	for _, kantong := range kantongs {
+		cacheKey := fmt.Sprintf("kantong:id:%s:user:%d", kantong.ID, kantong.UserID)
+		_ = r.redis.Set(cacheKey, kantong, 10*time.Minute)
+	}
+}
+
+func (r *kantongRepository) Transfer(transfer *domain.Transfer) (*domain.Kantong, *domain.Kantong, error) {
+	var kantongAsal, kantongTujuan *domain.Kantong
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		kantongs, err := lockKantongs(tx, transfer.UserID, transfer.KantongAsalID, transfer.KantongTujuanID)
+		if err != nil {
+			return err
 		}
-	}()
 
-	var kantongAsal, kantongTujuan domain.Kantong
+		var ok bool
+		if kantongAsal, ok = kantongs[transfer.KantongAsalID]; !ok {
+			return errors.New("kantong asal tidak ditemukan")
+		}
+		if kantongTujuan, ok = kantongs[transfer.KantongTujuanID]; !ok {
+			return errors.New("kantong tujuan tidak ditemukan")
+		}
+
+		if kantongAsal.Saldo < transfer.Jumlah {
+			return errors.New("saldo kantong asal tidak mencukupi untuk transfer")
+		}
 
-	if err := tx.Where("id = ? AND user_id = ?", kantongAsalID, userID).First(&kantongAsal).Error; err != nil {
-		tx.Rollback()
+		return r.applyTransfer(tx, transfer, kantongAsal, kantongTujuan)
+	})
+	if err != nil {
 		return nil, nil, err
 	}
 
-	if err := tx.Where("id = ? AND user_id = ?", kantongTujuanID, userID).First(&kantongTujuan).Error; err != nil {
-		tx.Rollback()
+	r.refreshKantongCache(kantongAsal, kantongTujuan)
+	r.clearUserListCache(transfer.UserID)
+
+	return kantongAsal, kantongTujuan, nil
+}
+
+func (r *kantongRepository) ReverseTransfer(id string, userID uint, reversal *domain.Transfer) (*domain.Kantong, *domain.Kantong, error) {
+	var kantongAsal, kantongTujuan *domain.Kantong
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var original domain.Transfer
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&original).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("transfer tidak ditemukan")
+			}
+			return err
+		}
+
+		if original.IsReversal() {
+			return errors.New("transfer pembatalan tidak dapat dibatalkan")
+		}
+
+		now := time.Now()
+		result := tx.Model(&domain.Transfer{}).
+			Where("id = ? AND reversed_at IS NULL", original.ID).
+			Update("reversed_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("transfer sudah dibatalkan")
+		}
+
+		kantongs, err := lockKantongs(tx, userID, original.KantongTujuanID, original.KantongAsalID)
+		if err != nil {
+			return err
+		}
+
+		var ok bool
+		if kantongAsal, ok = kantongs[original.KantongTujuanID]; !ok {
+			return errors.New("kantong tidak ditemukan")
+		}
+		if kantongTujuan, ok = kantongs[original.KantongAsalID]; !ok {
+			return errors.New("kantong tidak ditemukan")
+		}
+
+		if kantongAsal.Saldo < original.Jumlah {
+			return errors.New("saldo kantong tujuan tidak mencukupi untuk membatalkan transfer")
+		}
+
+		reversal.UserID = userID
+		reversal.KantongAsalID = original.KantongTujuanID
+		reversal.KantongTujuanID = original.KantongAsalID
+		reversal.Jumlah = original.Jumlah
+		reversal.ReversalOfID = &original.ID
+
+		return r.applyTransfer(tx, reversal, kantongAsal, kantongTujuan)
+	})
+	if err != nil {
 		return nil, nil, err
 	}
 
-	if kantongAsal.Saldo < jumlah {
-		tx.Rollback()
-		return nil, nil, fmt.Errorf("saldo tidak mencukupi")
-	}
+	r.refreshKantongCache(kantongAsal, kantongTujuan)
+	r.clearUserListCache(userID)
+
+	return kantongAsal, kantongTujuan, nil
+}
+
+func (r *kantongRepository) applyTransfer(tx *gorm.DB, transfer *domain.Transfer, kantongAsal, kantongTujuan *domain.Kantong) error {
+	transfer.SaldoAsalSebelum = kantongAsal.Saldo
+	transfer.SaldoTujuanSebelum = kantongTujuan.Saldo
 
-	kantongAsal.Saldo -= jumlah
-	kantongTujuan.Saldo += jumlah
+	kantongAsal.Saldo -= transfer.Jumlah
+	kantongTujuan.Saldo += transfer.Jumlah
 	kantongAsal.UpdatedAt = time.Now()
 	kantongTujuan.UpdatedAt = time.Now()
 
-	if err := tx.Save(&kantongAsal).Error; err != nil {
-		tx.Rollback()
-		return nil, nil, err
+	transfer.SaldoAsalSesudah = kantongAsal.Saldo
+	transfer.SaldoTujuanSesudah = kantongTujuan.Saldo
+
+	if err := tx.Save(kantongAsal).Error; err != nil {
+		return err
 	}
 
-	if err := tx.Save(&kantongTujuan).Error; err != nil {
-		tx.Rollback()
-		return nil, nil, err
+	if err := tx.Save(kantongTujuan).Error; err != nil {
+		return err
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return nil, nil, err
+	return tx.Omit("User", "KantongAsal", "KantongTujuan").Create(transfer).Error
+}
+
+type transferRow struct {
+	domain.Transfer
+	KantongAsalNama   string
+	KantongTujuanNama string
+}
+
+func (r *kantongRepository) transferQuery(userID uint) *gorm.DB {
+	return r.db.Table("transfers t").
+		Select("t.*, ka.nama as kantong_asal_nama, kt.nama as kantong_tujuan_nama").
+		Joins("LEFT JOIN kantongs ka ON t.kantong_asal_id = ka.id").
+		Joins("LEFT JOIN kantongs kt ON t.kantong_tujuan_id = kt.id").
+		Where("t.user_id = ?", userID)
+}
+
+func (r *kantongRepository) GetTransfers(userID uint, req *domain.TransferListRequest) ([]*domain.TransferResult, int, error) {
+	query := r.transferQuery(userID)
+
+	if req.KantongID != nil && *req.KantongID != "" {
+		query = query.Where("t.kantong_asal_id = ? OR t.kantong_tujuan_id = ?", *req.KantongID, *req.KantongID)
 	}
 
-	r.clearUserListCache(userID)
+	if req.TanggalMulai != nil && *req.TanggalMulai != "" {
+		query = query.Where("DATE(t.created_at) >= ?", *req.TanggalMulai)
+	}
+
+	if req.TanggalSelesai != nil && *req.TanggalSelesai != "" {
+		query = query.Where("DATE(t.created_at) <= ?", *req.TanggalSelesai)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []transferRow
+	offset := (req.Page - 1) * req.PerPage
+	if err := query.Order("t.created_at DESC").Offset(offset).Limit(req.PerPage).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*domain.TransferResult, 0, len(rows))
+	for i := range rows {
+		result = append(result, domain.ToTransferResult(&rows[i].Transfer, rows[i].KantongAsalNama, rows[i].KantongTujuanNama))
+	}
+
+	return result, int(total), nil
+}
+
+func (r *kantongRepository) GetTransferByID(id string, userID uint) (*domain.TransferResult, error) {
+	var row transferRow
+	if err := r.transferQuery(userID).Where("t.id = ?", id).First(&row).Error; err != nil {
+		return nil, err
+	}
 
-	return &kantongAsal, &kantongTujuan, nil
+	return domain.ToTransferResult(&row.Transfer, row.KantongAsalNama, row.KantongTujuanNama), nil
 }
@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type lampiranTransaksiRepository struct {
+	db *gorm.DB
+}
+
+func NewLampiranTransaksiRepository(db *gorm.DB) LampiranTransaksiRepository {
+	return &lampiranTransaksiRepository{db: db}
+}
+
+func (r *lampiranTransaksiRepository) Create(lampiran *domain.LampiranTransaksi, maxJumlah int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var transaksi domain.Transaksi
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ? AND user_id = ?", lampiran.TransaksiID, lampiran.UserID).
+			First(&transaksi).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrTransaksiTidakDitemukan
+			}
+			return err
+		}
+
+		var total int64
+		err = tx.Model(&domain.LampiranTransaksi{}).
+			Where("transaksi_id = ? AND user_id = ?", lampiran.TransaksiID, lampiran.UserID).
+			Count(&total).Error
+		if err != nil {
+			return err
+		}
+		if int(total) >= maxJumlah {
+			return domain.ErrJumlahLampiranMelebihiBatas
+		}
+
+		return tx.Omit("Transaksi", "User").Create(lampiran).Error
+	})
+}
+
+func (r *lampiranTransaksiRepository) GetByID(id, transaksiID string, userID uint) (*domain.LampiranTransaksi, error) {
+	var lampiran domain.LampiranTransaksi
+	err := r.db.Where("id = ? AND transaksi_id = ? AND user_id = ?", id, transaksiID, userID).First(&lampiran).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("lampiran tidak ditemukan")
+		}
+		return nil, err
+	}
+	return &lampiran, nil
+}
+
+func (r *lampiranTransaksiRepository) GetByTransaksiID(transaksiID string, userID uint) ([]*domain.LampiranTransaksi, error) {
+	var list []*domain.LampiranTransaksi
+	err := r.db.Where("transaksi_id = ? AND user_id = ?", transaksiID, userID).
+		Order("created_at ASC").
+		Find(&list).Error
+	return list, err
+}
+
+func (r *lampiranTransaksiRepository) CountByTransaksiID(transaksiID string, userID uint) (int, error) {
+	var total int64
+	err := r.db.Model(&domain.LampiranTransaksi{}).
+		Where("transaksi_id = ? AND user_id = ?", transaksiID, userID).
+		Count(&total).Error
+	return int(total), err
+}
+
+func (r *lampiranTransaksiRepository) Delete(id string, userID uint) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&domain.LampiranTransaksi{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("lampiran tidak ditemukan")
+	}
+	return nil
+}
@@ -16,7 +16,7 @@ func NewLaporanRepository(db *gorm.DB) LaporanRepository {
 }
 
 func (r *laporanRepository) GetRingkasanLaporan(userID uint, tanggalMulai, tanggalSelesai time.Time) (*domain.RingkasanLaporan, error) {
-	var totalPemasukan, totalPengeluaran float64
+	var totalPemasukan, totalPengeluaran domain.Money
 
 	err := r.db.Table("transaksis").
 		Where("user_id = ? AND tanggal BETWEEN ? AND ? AND jenis = ?", userID, tanggalMulai, tanggalSelesai, "Pemasukan").
@@ -36,7 +36,7 @@ func (r *laporanRepository) GetRingkasanLaporan(userID uint, tanggalMulai, tangg
 		return nil, err
 	}
 
-	var totalSaldo float64
+	var totalSaldo domain.Money
 	err = r.db.Table("kantongs").
 		Where("user_id = ?", userID).
 		Select("COALESCE(SUM(saldo), 0)").
@@ -47,9 +47,9 @@ func (r *laporanRepository) GetRingkasanLaporan(userID uint, tanggalMulai, tangg
 	}
 
 	days := int(tanggalSelesai.Sub(tanggalMulai).Hours()/24) + 1
-	rataRataPengeluaranHarian := float64(0)
+	rataRataPengeluaranHarian := domain.Money(0)
 	if days > 0 {
-		rataRataPengeluaranHarian = totalPengeluaran / float64(days)
+		rataRataPengeluaranHarian = totalPengeluaran.Div(int64(days))
 	}
 
 	return &domain.RingkasanLaporan{
@@ -66,9 +66,9 @@ func (r *laporanRepository) GetRingkasanLaporan(userID uint, tanggalMulai, tangg
 
 func (r *laporanRepository) GetStatistikTahunan(userID uint, tahun int) (*domain.StatistikTahunan, error) {
 	var results []struct {
-		Bulan            int     `json:"bulan"`
-		TotalPemasukan   float64 `json:"total_pemasukan"`
-		TotalPengeluaran float64 `json:"total_pengeluaran"`
+		Bulan            int          `json:"bulan"`
+		TotalPemasukan   domain.Money `json:"total_pemasukan"`
+		TotalPengeluaran domain.Money `json:"total_pengeluaran"`
 	}
 
 	query := `
@@ -93,14 +93,14 @@ func (r *laporanRepository) GetStatistikTahunan(userID uint, tahun int) (*domain
 	}
 
 	monthlyData := make(map[int]struct {
-		TotalPemasukan   float64
-		TotalPengeluaran float64
+		TotalPemasukan   domain.Money
+		TotalPengeluaran domain.Money
 	})
 
 	for _, result := range results {
 		monthlyData[result.Bulan] = struct {
-			TotalPemasukan   float64
-			TotalPengeluaran float64
+			TotalPemasukan   domain.Money
+			TotalPengeluaran domain.Money
 		}{
 			TotalPemasukan:   result.TotalPemasukan,
 			TotalPengeluaran: result.TotalPengeluaran,
@@ -108,7 +108,7 @@ func (r *laporanRepository) GetStatistikTahunan(userID uint, tahun int) (*domain
 	}
 
 	var dataBulanan []domain.DataBulanan
-	var totalPemasukanTahun, totalPengeluaranTahun float64
+	var totalPemasukanTahun, totalPengeluaranTahun domain.Money
 
 	for bulan := 1; bulan <= 12; bulan++ {
 		data := monthlyData[bulan]
@@ -132,11 +132,11 @@ func (r *laporanRepository) GetStatistikTahunan(userID uint, tahun int) (*domain
 
 func (r *laporanRepository) GetStatistikKantongBulanan(userID uint, bulan, tahun int) (*domain.StatistikKantongBulanan, error) {
 	var results []struct {
-		KantongID        string  `json:"kantong_id"`
-		KantongNama      string  `json:"kantong_nama"`
-		Kategori         string  `json:"kategori"`
-		TotalPengeluaran float64 `json:"total_pengeluaran"`
-		JumlahTransaksi  int     `json:"jumlah_transaksi"`
+		KantongID        string       `json:"kantong_id"`
+		KantongNama      string       `json:"kantong_nama"`
+		Kategori         string       `json:"kategori"`
+		TotalPengeluaran domain.Money `json:"total_pengeluaran"`
+		JumlahTransaksi  int          `json:"jumlah_transaksi"`
 	}
 
 	query := `
@@ -147,7 +147,7 @@ func (r *laporanRepository) GetStatistikKantongBulanan(userID uint, bulan, tahun
 			COALESCE(SUM(t.jumlah), 0) as total_pengeluaran,
 			COUNT(t.id) as jumlah_transaksi
 		FROM kantongs k
-		LEFT JOIN transaksis t ON k.id = t.kantong_id 
+		LEFT JOIN transaksi_kantongs t ON k.id = t.kantong_id 
 			AND t.jenis = 'Pengeluaran' 
 			AND EXTRACT(MONTH FROM t.tanggal) = ? 
 			AND EXTRACT(YEAR FROM t.tanggal) = ?
@@ -162,7 +162,7 @@ func (r *laporanRepository) GetStatistikKantongBulanan(userID uint, bulan, tahun
 		return nil, err
 	}
 
-	var totalPengeluaran float64
+	var totalPengeluaran domain.Money
 	var totalTransaksi int
 
 	for _, result := range results {
@@ -174,7 +174,7 @@ func (r *laporanRepository) GetStatistikKantongBulanan(userID uint, bulan, tahun
 	for _, result := range results {
 		persentase := float64(0)
 		if totalPengeluaran > 0 {
-			persentase = (result.TotalPengeluaran / totalPengeluaran) * 100
+			persentase = (result.TotalPengeluaran.Float64() / totalPengeluaran.Float64()) * 100
 		}
 
 		dataKantong = append(dataKantong, domain.DataKantongBulanan{
@@ -206,11 +206,11 @@ func (r *laporanRepository) GetStatistikKantongBulanan(userID uint, bulan, tahun
 
 func (r *laporanRepository) GetTopKantongPengeluaran(userID uint, bulan, tahun, limit int) (*domain.TopKantongPengeluaran, error) {
 	var results []struct {
-		KantongID        string  `json:"kantong_id"`
-		KantongNama      string  `json:"kantong_nama"`
-		Kategori         string  `json:"kategori"`
-		TotalPengeluaran float64 `json:"total_pengeluaran"`
-		JumlahTransaksi  int     `json:"jumlah_transaksi"`
+		KantongID        string       `json:"kantong_id"`
+		KantongNama      string       `json:"kantong_nama"`
+		Kategori         string       `json:"kategori"`
+		TotalPengeluaran domain.Money `json:"total_pengeluaran"`
+		JumlahTransaksi  int          `json:"jumlah_transaksi"`
 	}
 
 	query := `
@@ -221,7 +221,7 @@ func (r *laporanRepository) GetTopKantongPengeluaran(userID uint, bulan, tahun,
 			COALESCE(SUM(t.jumlah), 0) as total_pengeluaran,
 			COUNT(t.id) as jumlah_transaksi
 		FROM kantongs k
-		LEFT JOIN transaksis t ON k.id = t.kantong_id 
+		LEFT JOIN transaksi_kantongs t ON k.id = t.kantong_id 
 			AND t.jenis = 'Pengeluaran' 
 			AND EXTRACT(MONTH FROM t.tanggal) = ? 
 			AND EXTRACT(YEAR FROM t.tanggal) = ?
@@ -237,14 +237,14 @@ func (r *laporanRepository) GetTopKantongPengeluaran(userID uint, bulan, tahun,
 		return nil, err
 	}
 
-	var totalPengeluaranSemua float64
+	var totalPengeluaranSemua domain.Money
 	var totalTransaksiSemua int
 
 	queryTotal := `
 		SELECT 
 			COALESCE(SUM(t.jumlah), 0) as total_pengeluaran,
-			COUNT(t.id) as total_transaksi
-		FROM transaksis t
+			COUNT(DISTINCT t.id) as total_transaksi
+		FROM transaksi_kantongs t
 		JOIN kantongs k ON t.kantong_id = k.id
 		WHERE k.user_id = ? 
 			AND t.jenis = 'Pengeluaran' 
@@ -261,12 +261,12 @@ func (r *laporanRepository) GetTopKantongPengeluaran(userID uint, bulan, tahun,
 	for i, result := range results {
 		persentaseDariTotal := float64(0)
 		if totalPengeluaranSemua > 0 {
-			persentaseDariTotal = (result.TotalPengeluaran / totalPengeluaranSemua) * 100
+			persentaseDariTotal = (result.TotalPengeluaran.Float64() / totalPengeluaranSemua.Float64()) * 100
 		}
 
-		rataRataPengeluaran := float64(0)
+		rataRataPengeluaran := domain.Money(0)
 		if result.JumlahTransaksi > 0 {
-			rataRataPengeluaran = result.TotalPengeluaran / float64(result.JumlahTransaksi)
+			rataRataPengeluaran = result.TotalPengeluaran.Div(int64(result.JumlahTransaksi))
 		}
 
 		topKantong = append(topKantong, domain.DataTopKantong{
@@ -300,9 +300,9 @@ func (r *laporanRepository) GetTopKantongPengeluaran(userID uint, bulan, tahun,
 
 func (r *laporanRepository) GetStatistikKantongPeriode(userID uint, tanggalMulai, tanggalSelesai time.Time) (*domain.StatistikKantongPeriode, error) {
 	type queryResult struct {
-		KantongID        string  `json:"kantong_id"`
-		KantongNama      string  `json:"kantong_nama"`
-		TotalPengeluaran float64 `json:"total_pengeluaran"`
+		KantongID        string       `json:"kantong_id"`
+		KantongNama      string       `json:"kantong_nama"`
+		TotalPengeluaran domain.Money `json:"total_pengeluaran"`
 	}
 
 	var results []queryResult
@@ -312,7 +312,7 @@ func (r *laporanRepository) GetStatistikKantongPeriode(userID uint, tanggalMulai
 			k.nama as kantong_nama,
 			COALESCE(SUM(t.jumlah), 0) as total_pengeluaran
 		FROM kantongs k
-		LEFT JOIN transaksis t ON k.id = t.kantong_id 
+		LEFT JOIN transaksi_kantongs t ON k.id = t.kantong_id 
 			AND t.jenis = 'Pengeluaran' 
 			AND t.tanggal BETWEEN ? AND ?
 		WHERE k.user_id = ?
@@ -325,7 +325,7 @@ func (r *laporanRepository) GetStatistikKantongPeriode(userID uint, tanggalMulai
 		return nil, err
 	}
 
-	var totalPengeluaran float64
+	var totalPengeluaran domain.Money
 	var dataKantong []domain.DataKantongPeriode
 
 	for _, result := range results {
@@ -349,11 +349,11 @@ func (r *laporanRepository) GetStatistikKantongPeriode(userID uint, tanggalMulai
 
 func (r *laporanRepository) GetPengeluaranKantongDetail(userID uint, tanggalMulai, tanggalSelesai time.Time) (*domain.PengeluaranKantongDetail, error) {
 	type queryResult struct {
-		KantongID        string  `json:"kantong_id"`
-		KantongNama      string  `json:"kantong_nama"`
-		TotalPengeluaran float64 `json:"total_pengeluaran"`
-		JumlahTransaksi  int     `json:"jumlah_transaksi"`
-		SaldoKantong     float64 `json:"saldo_kantong"`
+		KantongID        string       `json:"kantong_id"`
+		KantongNama      string       `json:"kantong_nama"`
+		TotalPengeluaran domain.Money `json:"total_pengeluaran"`
+		JumlahTransaksi  int          `json:"jumlah_transaksi"`
+		SaldoKantong     domain.Money `json:"saldo_kantong"`
 	}
 
 	var results []queryResult
@@ -365,7 +365,7 @@ func (r *laporanRepository) GetPengeluaranKantongDetail(userID uint, tanggalMula
 			COUNT(CASE WHEN t.jenis = 'Pengeluaran' THEN 1 END) as jumlah_transaksi,
 			k.saldo as saldo_kantong
 		FROM kantongs k
-		LEFT JOIN transaksis t ON k.id = t.kantong_id 
+		LEFT JOIN transaksi_kantongs t ON k.id = t.kantong_id 
 			AND t.tanggal BETWEEN ? AND ?
 		WHERE k.user_id = ?
 		GROUP BY k.id, k.nama, k.saldo
@@ -377,7 +377,7 @@ func (r *laporanRepository) GetPengeluaranKantongDetail(userID uint, tanggalMula
 		return nil, err
 	}
 
-	var totalPengeluaran, totalSaldoSemuaKantong float64
+	var totalPengeluaran, totalSaldoSemuaKantong domain.Money
 	var dataKantong []domain.DataKantongDetail
 
 	for _, result := range results {
@@ -386,12 +386,12 @@ func (r *laporanRepository) GetPengeluaranKantongDetail(userID uint, tanggalMula
 
 		persentaseDariSaldo := float64(0)
 		if result.SaldoKantong > 0 {
-			persentaseDariSaldo = (result.TotalPengeluaran / result.SaldoKantong) * 100
+			persentaseDariSaldo = (result.TotalPengeluaran.Float64() / result.SaldoKantong.Float64()) * 100
 		}
 
-		rataRataPengeluaran := float64(0)
+		rataRataPengeluaran := domain.Money(0)
 		if result.JumlahTransaksi > 0 {
-			rataRataPengeluaran = result.TotalPengeluaran / float64(result.JumlahTransaksi)
+			rataRataPengeluaran = result.TotalPengeluaran.Div(int64(result.JumlahTransaksi))
 		}
 
 		dataKantong = append(dataKantong, domain.DataKantongDetail{
@@ -418,7 +418,7 @@ func (r *laporanRepository) GetPengeluaranKantongDetail(userID uint, tanggalMula
 
 func (r *laporanRepository) GetTrenBulanan(userID uint, tahun int) (*domain.TrenBulanan, error) {
 	var dataTren []domain.DataBulanan
-	var totalPemasukanTahun, totalPengeluaranTahun float64
+	var totalPemasukanTahun, totalPengeluaranTahun domain.Money
 
 	namaBulan := []string{
 		"Januari", "Februari", "Maret", "April", "Mei", "Juni",
@@ -426,7 +426,7 @@ func (r *laporanRepository) GetTrenBulanan(userID uint, tahun int) (*domain.Tren
 	}
 
 	for bulan := 1; bulan <= 12; bulan++ {
-		var totalPemasukan, totalPengeluaran float64
+		var totalPemasukan, totalPengeluaran domain.Money
 
 		err := r.db.Table("transaksis").
 			Where("user_id = ? AND EXTRACT(year FROM tanggal) = ? AND EXTRACT(month FROM tanggal) = ? AND jenis = ?",
@@ -469,10 +469,10 @@ func (r *laporanRepository) GetTrenBulanan(userID uint, tahun int) (*domain.Tren
 
 func (r *laporanRepository) GetPerbandinganKantong(userID uint, bulanIni, tahunIni, bulanLalu, tahunLalu int) (*domain.PerbandinganKantong, error) {
 	type KantongResult struct {
-		KantongID       string  `gorm:"column:kantong_id"`
-		KantongNama     string  `gorm:"column:kantong_nama"`
-		JumlahBulanIni  float64 `gorm:"column:jumlah_bulan_ini"`
-		JumlahBulanLalu float64 `gorm:"column:jumlah_bulan_lalu"`
+		KantongID       string       `gorm:"column:kantong_id"`
+		KantongNama     string       `gorm:"column:kantong_nama"`
+		JumlahBulanIni  domain.Money `gorm:"column:jumlah_bulan_ini"`
+		JumlahBulanLalu domain.Money `gorm:"column:jumlah_bulan_lalu"`
 	}
 
 	var results []KantongResult
@@ -484,7 +484,7 @@ func (r *laporanRepository) GetPerbandinganKantong(userID uint, bulanIni, tahunI
 				k.nama as kantong_nama,
 				COALESCE(SUM(t.jumlah), 0) as jumlah_bulan_ini
 			FROM kantongs k
-			LEFT JOIN transaksis t ON k.id = t.kantong_id 
+			LEFT JOIN transaksi_kantongs t ON k.id = t.kantong_id 
 				AND t.jenis = 'Pengeluaran'
 				AND EXTRACT(year FROM t.tanggal) = ?
 				AND EXTRACT(month FROM t.tanggal) = ?
@@ -496,7 +496,7 @@ func (r *laporanRepository) GetPerbandinganKantong(userID uint, bulanIni, tahunI
 				k.id as kantong_id,
 				COALESCE(SUM(t.jumlah), 0) as jumlah_bulan_lalu
 			FROM kantongs k
-			LEFT JOIN transaksis t ON k.id = t.kantong_id 
+			LEFT JOIN transaksi_kantongs t ON k.id = t.kantong_id 
 				AND t.jenis = 'Pengeluaran'
 				AND EXTRACT(year FROM t.tanggal) = ?
 				AND EXTRACT(month FROM t.tanggal) = ?
@@ -518,7 +518,7 @@ func (r *laporanRepository) GetPerbandinganKantong(userID uint, bulanIni, tahunI
 	}
 
 	var dataKantong []domain.DataPerbandinganKantong
-	var totalBulanIni, totalBulanLalu float64
+	var totalBulanIni, totalBulanLalu domain.Money
 
 	for _, result := range results {
 		dataKantong = append(dataKantong, domain.DataPerbandinganKantong{
@@ -562,13 +562,13 @@ func (r *laporanRepository) GetDetailPerbandinganKantong(userID uint, bulanIni,
 	var dataKantong []domain.DataDetailPerbandinganKantong
 
 	for _, kantong := range perbandinganKantong.DataKantong {
-		rataRata := (kantong.JumlahBulanIni + kantong.JumlahBulanLalu) / 2
+		rataRata := (kantong.JumlahBulanIni + kantong.JumlahBulanLalu).Div(2)
 
 		var persentase float64
 		var trend string
 
 		if kantong.JumlahBulanLalu > 0 {
-			persentase = ((kantong.JumlahBulanIni - kantong.JumlahBulanLalu) / kantong.JumlahBulanLalu) * 100
+			persentase = ((kantong.JumlahBulanIni - kantong.JumlahBulanLalu).Float64() / kantong.JumlahBulanLalu.Float64()) * 100
 		} else if kantong.JumlahBulanIni > 0 {
 			persentase = 100
 		}
@@ -592,13 +592,13 @@ func (r *laporanRepository) GetDetailPerbandinganKantong(userID uint, bulanIni,
 		})
 	}
 
-	rataRataTotal := (perbandinganKantong.TotalBulanIni + perbandinganKantong.TotalBulanLalu) / 2
+	rataRataTotal := (perbandinganKantong.TotalBulanIni + perbandinganKantong.TotalBulanLalu).Div(2)
 
 	var persentaseTotal float64
 	var trendTotal string
 
 	if perbandinganKantong.TotalBulanLalu > 0 {
-		persentaseTotal = ((perbandinganKantong.TotalBulanIni - perbandinganKantong.TotalBulanLalu) / perbandinganKantong.TotalBulanLalu) * 100
+		persentaseTotal = ((perbandinganKantong.TotalBulanIni - perbandinganKantong.TotalBulanLalu).Float64() / perbandinganKantong.TotalBulanLalu.Float64()) * 100
 	} else if perbandinganKantong.TotalBulanIni > 0 {
 		persentaseTotal = 100
 	}
@@ -622,3 +622,87 @@ func (r *laporanRepository) GetDetailPerbandinganKantong(userID uint, bulanIni,
 		TrendTotal:      trendTotal,
 	}, nil
 }
+
+func (r *laporanRepository) GetPengeluaranPerTag(userID uint, tanggalMulai, tanggalSelesai time.Time) (*domain.PengeluaranPerTag, error) {
+	var results []struct {
+		TagID            string       `json:"tag_id"`
+		TagNama          string       `json:"tag_nama"`
+		TotalPengeluaran domain.Money `json:"total_pengeluaran"`
+		JumlahTransaksi  int          `json:"jumlah_transaksi"`
+	}
+
+	query := `
+		SELECT 
+			tg.id as tag_id,
+			tg.nama as tag_nama,
+			COALESCE(SUM(t.jumlah), 0) as total_pengeluaran,
+			COUNT(t.id) as jumlah_transaksi
+		FROM tags tg
+		JOIN transaksi_tags tt ON tt.tag_id = tg.id
+		JOIN transaksis t ON t.id = tt.transaksi_id 
+			AND t.jenis = 'Pengeluaran' 
+			AND t.tanggal BETWEEN ? AND ?
+		WHERE tg.user_id = ?
+		GROUP BY tg.id, tg.nama
+		ORDER BY total_pengeluaran DESC, tg.nama ASC
+	`
+
+	err := r.db.Raw(query, tanggalMulai, tanggalSelesai, userID).Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var total struct {
+		TotalPengeluaran    domain.Money `json:"total_pengeluaran"`
+		PengeluaranTanpaTag domain.Money `json:"pengeluaran_tanpa_tag"`
+	}
+
+	totalQuery := `
+		SELECT 
+			COALESCE(SUM(t.jumlah), 0) as total_pengeluaran,
+			COALESCE(SUM(CASE WHEN NOT EXISTS (
+				SELECT 1 FROM transaksi_tags tt WHERE tt.transaksi_id = t.id
+			) THEN t.jumlah ELSE 0 END), 0) as pengeluaran_tanpa_tag
+		FROM transaksis t
+		WHERE t.user_id = ? 
+			AND t.jenis = 'Pengeluaran' 
+			AND t.tanggal BETWEEN ? AND ?
+	`
+
+	err = r.db.Raw(totalQuery, userID, tanggalMulai, tanggalSelesai).Scan(&total).Error
+	if err != nil {
+		return nil, err
+	}
+
+	dataTag := make([]domain.DataTagPengeluaran, 0, len(results))
+	for _, result := range results {
+		persentase := float64(0)
+		if total.TotalPengeluaran > 0 {
+			persentase = (result.TotalPengeluaran.Float64() / total.TotalPengeluaran.Float64()) * 100
+		}
+
+		rataRataPengeluaran := domain.Money(0)
+		if result.JumlahTransaksi > 0 {
+			rataRataPengeluaran = result.TotalPengeluaran.Div(int64(result.JumlahTransaksi))
+		}
+
+		dataTag = append(dataTag, domain.DataTagPengeluaran{
+			TagID:               result.TagID,
+			TagNama:             result.TagNama,
+			TotalPengeluaran:    result.TotalPengeluaran,
+			JumlahTransaksi:     result.JumlahTransaksi,
+			Persentase:          persentase,
+			RataRataPengeluaran: rataRataPengeluaran,
+		})
+	}
+
+	return &domain.PengeluaranPerTag{
+		Periode: domain.PeriodeTanggal{
+			TanggalMulai:   tanggalMulai.Format("2006-01-02"),
+			TanggalSelesai: tanggalSelesai.Format("2006-01-02"),
+		},
+		DataTag:             dataTag,
+		TotalPengeluaran:    total.TotalPengeluaran,
+		PengeluaranTanpaTag: total.PengeluaranTanpaTag,
+	}, nil
+}
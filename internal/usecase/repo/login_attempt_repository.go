@@ -0,0 +1,94 @@
+package repo
+
+import (
+	"sync"
+	"time"
+)
+
+type memoryAttempt struct {
+	count     int64
+	expiresAt time.Time
+}
+
+type loginAttemptRepository struct {
+	redis   RedisRepository
+	mu      sync.Mutex
+	entries map[string]*memoryAttempt
+}
+
+func NewLoginAttemptRepository(redis RedisRepository) LoginAttemptRepository {
+	return &loginAttemptRepository{
+		redis:   redis,
+		entries: make(map[string]*memoryAttempt),
+	}
+}
+
+func (r *loginAttemptRepository) Increment(key string, window time.Duration) (int64, error) {
+	if count, err := r.redis.IncrementWithExpire(key, window); err == nil {
+		return count, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.pruneExpired(now)
+
+	entry, ok := r.entries[key]
+	if !ok {
+		entry = &memoryAttempt{expiresAt: now.Add(window)}
+		r.entries[key] = entry
+	}
+	entry.count++
+
+	return entry.count, nil
+}
+
+func (r *loginAttemptRepository) Block(key string, ttl time.Duration) error {
+	if err := r.redis.Set(key, 1, ttl); err == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[key] = &memoryAttempt{count: 1, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+func (r *loginAttemptRepository) BlockedFor(key string) (time.Duration, error) {
+	var remaining time.Duration
+	if ttl, err := r.redis.GetTTL(key); err == nil && ttl > 0 {
+		remaining = ttl
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.entries[key]; ok {
+		if ttl := time.Until(entry.expiresAt); ttl > remaining {
+			remaining = ttl
+		}
+	}
+
+	return remaining, nil
+}
+
+func (r *loginAttemptRepository) Reset(keys ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		r.redis.Delete(key)
+		delete(r.entries, key)
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) pruneExpired(now time.Time) {
+	for key, entry := range r.entries {
+		if !entry.expiresAt.After(now) {
+			delete(r.entries, key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type mfaRepository struct {
+	db *gorm.DB
+}
+
+func NewMFARepository(db *gorm.DB) MFARepository {
+	return &mfaRepository{db: db}
+}
+
+func (r *mfaRepository) GetByUserID(userID uint) (*domain.UserMFA, error) {
+	var mfa domain.UserMFA
+	err := r.db.Where("user_id = ?", userID).First(&mfa).Error
+	if err != nil {
+		return nil, err
+	}
+	return &mfa, nil
+}
+
+func (r *mfaRepository) Save(mfa *domain.UserMFA) error {
+	return r.db.Save(mfa).Error
+}
+
+func (r *mfaRepository) Delete(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&domain.UserMFA{}).Error
+	})
+}
+
+func (r *mfaRepository) UpdateLastUsedStep(userID uint, step int64) (bool, error) {
+	result := r.db.Model(&domain.UserMFA{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *mfaRepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]domain.MFARecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, domain.MFARecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *mfaRepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := r.db.Model(&domain.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type oauthStateRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthStateRepository(db *gorm.DB) OAuthStateRepository {
+	return &oauthStateRepository{db: db}
+}
+
+func (r *oauthStateRepository) Create(state *domain.OAuthState) error {
+	return r.db.Create(state).Error
+}
+
+func (r *oauthStateRepository) Consume(stateHash string) (*domain.OAuthState, error) {
+	var states []domain.OAuthState
+	err := r.db.Clauses(clause.Returning{}).
+		Where("state_hash = ? AND expires_at > ?", stateHash, time.Now()).
+		Delete(&states).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(states) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &states[0], nil
+}
+
+func (r *oauthStateRepository) CleanupExpired() (int64, error) {
+	result := r.db.Where("expires_at < ?", time.Now()).Delete(&domain.OAuthState{})
+	return result.RowsAffected, result.Error
+}
+
+type userIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) UserIdentityRepository {
+	return &userIdentityRepository{db: db}
+}
+
+func (r *userIdentityRepository) GetByProviderSubject(provider, subject string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+	err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *userIdentityRepository) GetByUserID(userID uint) ([]*domain.UserIdentity, error) {
+	var identities []*domain.UserIdentity
+	err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&identities).Error
+	return identities, err
+}
+
+func (r *userIdentityRepository) Create(identity *domain.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
@@ -41,6 +41,7 @@ func (r *passwordResetTokenRepository) MarkAsUsed(token string) error {
 	return r.db.Model(&domain.PasswordResetToken{}).Where("token = ?", token).Update("is_used", true).Error
 }
 
-func (r *passwordResetTokenRepository) CleanupExpired() error {
-	return r.db.Where("expires_at < ? OR is_used = ?", time.Now(), true).Delete(&domain.PasswordResetToken{}).Error
+func (r *passwordResetTokenRepository) CleanupExpired() (int64, error) {
+	result := r.db.Where("expires_at < ? OR is_used = ?", time.Now(), true).Delete(&domain.PasswordResetToken{})
+	return result.RowsAffected, result.Error
 }
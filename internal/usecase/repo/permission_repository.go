@@ -180,4 +180,9 @@ func (r *permissionRepository) invalidateCache() {
 	for _, key := range keys {
 		r.redis.Delete(key)
 	}
+
+	userPermissionKeys, _ := r.redis.GetKeys("role:user_permissions:*")
+	for _, key := range userPermissionKeys {
+		r.redis.Delete(key)
+	}
 }
@@ -8,6 +8,10 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+var deleteIfEqualScript = redis.NewScript(`if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) end return 0`)
+
+var incrementWithExpireScript = redis.NewScript(`local count = redis.call("incr", KEYS[1]) if redis.call("pttl", KEYS[1]) < 0 then redis.call("pexpire", KEYS[1], ARGV[1]) end return count`)
+
 type redisRepository struct {
 	rdb *redis.Client
 }
@@ -85,6 +89,32 @@ func (r *redisRepository) Delete(key string) error {
 	return r.rdb.Del(ctx, key).Err()
 }
 
+func (r *redisRepository) SetNX(key string, value interface{}, ttl time.Duration) (bool, error) {
+	if r.rdb == nil {
+		return false, redis.Nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return r.rdb.SetNX(ctx, key, value, ttl).Result()
+}
+
+func (r *redisRepository) DeleteIfEqual(key, value string) (bool, error) {
+	if r.rdb == nil {
+		return false, redis.Nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	deleted, err := deleteIfEqualScript.Run(ctx, r.rdb, []string{key}, value).Int64()
+	if err != nil {
+		return false, err
+	}
+	return deleted == 1, nil
+}
+
 func (r *redisRepository) Exists(key string) (bool, error) {
 	if r.rdb == nil {
 		return false, redis.Nil
@@ -108,6 +138,17 @@ func (r *redisRepository) Increment(key string) (int64, error) {
 	return r.rdb.Incr(ctx, key).Result()
 }
 
+func (r *redisRepository) IncrementWithExpire(key string, ttl time.Duration) (int64, error) {
+	if r.rdb == nil {
+		return 0, redis.Nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return incrementWithExpireScript.Run(ctx, r.rdb, []string{key}, ttl.Milliseconds()).Int64()
+}
+
 func (r *redisRepository) Decrement(key string) (int64, error) {
 	if r.rdb == nil {
 		return 0, redis.Nil
@@ -1,12 +1,16 @@
 package repo
 
 import (
+	"errors"
 	"fiber-boiler-plate/internal/domain"
 	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+var ErrRefreshTokenReused = errors.New("refresh token sudah pernah digunakan")
+
 type refreshTokenRepository struct {
 	db *gorm.DB
 }
@@ -15,11 +19,16 @@ func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
 	return &refreshTokenRepository{db: db}
 }
 
-func (r *refreshTokenRepository) Create(userID uint, token string, expiresAt time.Time) (*domain.RefreshToken, error) {
+func (r *refreshTokenRepository) Create(userID uint, token string, expiresAt time.Time, meta domain.SessionMeta) (*domain.RefreshToken, error) {
 	refreshToken := &domain.RefreshToken{
-		UserID:    userID,
-		Token:     token,
-		ExpiresAt: expiresAt,
+		UserID:     userID,
+		Token:      token,
+		ExpiresAt:  expiresAt,
+		FamilyID:   uuid.New().String(),
+		UserAgent:  meta.UserAgent,
+		IPAddress:  meta.IPAddress,
+		DeviceName: meta.DeviceName,
+		LastUsedAt: time.Now(),
 	}
 	err := r.db.Create(refreshToken).Error
 	if err != nil {
@@ -30,21 +39,89 @@ func (r *refreshTokenRepository) Create(userID uint, token string, expiresAt tim
 
 func (r *refreshTokenRepository) GetByToken(token string) (*domain.RefreshToken, error) {
 	var refreshToken domain.RefreshToken
-	err := r.db.Where("token = ? AND is_revoked = ? AND expires_at > ?", token, false, time.Now()).First(&refreshToken).Error
+	err := r.db.Where("token = ?", token).First(&refreshToken).Error
 	if err != nil {
 		return nil, err
 	}
 	return &refreshToken, nil
 }
 
+func (r *refreshTokenRepository) GetActiveByUserID(userID uint) ([]*domain.RefreshToken, error) {
+	var refreshTokens []*domain.RefreshToken
+	err := r.db.Where("user_id = ? AND is_revoked = ? AND expires_at > ?", userID, false, time.Now()).
+		Order("last_used_at DESC").
+		Find(&refreshTokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return refreshTokens, nil
+}
+
+func (r *refreshTokenRepository) Rotate(parent *domain.RefreshToken, token string, expiresAt time.Time, meta domain.SessionMeta) (*domain.RefreshToken, error) {
+	now := time.Now()
+	refreshToken := &domain.RefreshToken{
+		UserID:     parent.UserID,
+		Token:      token,
+		ExpiresAt:  expiresAt,
+		FamilyID:   parent.FamilyID,
+		ParentID:   &parent.ID,
+		UserAgent:  meta.UserAgent,
+		IPAddress:  meta.IPAddress,
+		DeviceName: meta.DeviceName,
+		LastUsedAt: now,
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.RefreshToken{}).
+			Where("id = ? AND is_revoked = ?", parent.ID, false).
+			Updates(map[string]interface{}{"is_revoked": true, "rotated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenReused
+		}
+
+		return tx.Create(refreshToken).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return refreshToken, nil
+}
+
 func (r *refreshTokenRepository) RevokeToken(token string) error {
 	return r.db.Model(&domain.RefreshToken{}).Where("token = ?", token).Update("is_revoked", true).Error
 }
 
-func (r *refreshTokenRepository) RevokeAllUserTokens(userID uint) error {
-	return r.db.Model(&domain.RefreshToken{}).Where("user_id = ?", userID).Update("is_revoked", true).Error
+func (r *refreshTokenRepository) RevokeFamily(familyID string) error {
+	return r.db.Model(&domain.RefreshToken{}).Where("family_id = ? AND is_revoked = ?", familyID, false).Update("is_revoked", true).Error
+}
+
+func (r *refreshTokenRepository) RevokeUserToken(userID, id uint) (string, error) {
+	var refreshToken domain.RefreshToken
+	err := r.db.Select("id", "family_id").
+		Where("id = ? AND user_id = ? AND is_revoked = ?", id, userID, false).
+		First(&refreshToken).Error
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.RevokeFamily(refreshToken.FamilyID); err != nil {
+		return "", err
+	}
+	return refreshToken.FamilyID, nil
+}
+
+func (r *refreshTokenRepository) RevokeAllUserTokens(userID uint, exceptFamilyIDs ...string) error {
+	query := r.db.Model(&domain.RefreshToken{}).Where("user_id = ?", userID)
+	if len(exceptFamilyIDs) > 0 {
+		query = query.Where("family_id NOT IN ?", exceptFamilyIDs)
+	}
+	return query.Update("is_revoked", true).Error
 }
 
-func (r *refreshTokenRepository) CleanupExpired() error {
-	return r.db.Where("expires_at < ? OR is_revoked = ?", time.Now(), true).Delete(&domain.RefreshToken{}).Error
+func (r *refreshTokenRepository) CleanupExpired() (int64, error) {
+	result := r.db.Where("expires_at < ?", time.Now()).Delete(&domain.RefreshToken{})
+	return result.RowsAffected, result.Error
 }
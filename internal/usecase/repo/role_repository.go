@@ -258,9 +258,17 @@ func (r *roleRepository) GetRolePermissions(roleID string, req *domain.RolePermi
 }
 
 func (r *roleRepository) UpdateRolePermissions(roleID string, permissionIDs []string) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
 		return r.updateRolePermissionsInTx(tx, roleID, permissionIDs)
 	})
+
+	if err != nil {
+		return err
+	}
+
+	r.invalidateCache()
+	r.redis.Delete(fmt.Sprintf("role:id:%s", roleID))
+	return nil
 }
 
 func (r *roleRepository) updateRolePermissionsInTx(tx *gorm.DB, roleID string, permissionIDs []string) error {
@@ -310,6 +318,35 @@ func (r *roleRepository) ValidatePermissions(permissionIDs []string) ([]string,
 	return invalidIDs, nil
 }
 
+func (r *roleRepository) GetUserPermissions(userID uint) ([]string, error) {
+	cacheKey := fmt.Sprintf("role:user_permissions:%d", userID)
+
+	var permissions []string
+	if exists, _ := r.redis.Exists(cacheKey); exists {
+		if err := r.redis.GetJSON(cacheKey, &permissions); err == nil {
+			return permissions, nil
+		}
+	}
+
+	if err := r.db.Table("permissions").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Joins("JOIN users ON users.role_id = roles.id").
+		Where("users.id = ? AND users.is_active = ? AND roles.status = ?", userID, true, "aktif").
+		Order("permissions.nama ASC").
+		Pluck("permissions.nama", &permissions).Error; err != nil {
+		return nil, err
+	}
+
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	r.redis.SetJSON(cacheKey, permissions, 10*time.Minute)
+
+	return permissions, nil
+}
+
 func (r *roleRepository) invalidateCache() {
 	keys, _ := r.redis.GetKeys("role:list:*")
 	for _, key := range keys {
@@ -320,4 +357,9 @@ func (r *roleRepository) invalidateCache() {
 	for _, key := range permissionKeys {
 		r.redis.Delete(key)
 	}
+
+	userPermissionKeys, _ := r.redis.GetKeys("role:user_permissions:*")
+	for _, key := range userPermissionKeys {
+		r.redis.Delete(key)
+	}
 }
@@ -0,0 +1,131 @@
+package repo
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) GetByUserID(userID uint, req *domain.TagListRequest) ([]*domain.TagResponse, int, error) {
+	query := r.db.Table("tags tg").Where("tg.user_id = ?", userID)
+
+	if req.Search != nil && *req.Search != "" {
+		query = query.Where("tg.nama ILIKE ?", "%"+*req.Search+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []*domain.TagResponse
+	offset := (req.Page - 1) * req.PerPage
+	err := query.
+		Select("tg.id, tg.nama, tg.created_at, tg.updated_at, (SELECT COUNT(*) FROM transaksi_tags tt WHERE tt.tag_id = tg.id) as jumlah_transaksi").
+		Order("LOWER(tg.nama) ASC").
+		Offset(offset).
+		Limit(req.PerPage).
+		Scan(&list).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, int(total), nil
+}
+
+func (r *tagRepository) GetByID(id string, userID uint) (*domain.Tag, error) {
+	var tag domain.Tag
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) CountTransaksi(id string) (int, error) {
+	var count int64
+	err := r.db.Model(&domain.TransaksiTag{}).Where("tag_id = ?", id).Count(&count).Error
+	return int(count), err
+}
+
+func getOrCreateTags(tx *gorm.DB, userID uint, names []string) ([]domain.Tag, error) {
+	if len(names) == 0 {
+		return []domain.Tag{}, nil
+	}
+
+	baru := make([]domain.Tag, 0, len(names))
+	lowerNames := make([]string, 0, len(names))
+	for _, nama := range names {
+		baru = append(baru, domain.Tag{UserID: userID, Nama: nama})
+		lowerNames = append(lowerNames, strings.ToLower(nama))
+	}
+
+	if err := tx.Omit("User").Clauses(clause.OnConflict{DoNothing: true}).Create(&baru).Error; err != nil {
+		return nil, err
+	}
+
+	var existing []domain.Tag
+	if err := tx.Where("user_id = ? AND LOWER(nama) IN ?", userID, lowerNames).Find(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	byNama := make(map[string]domain.Tag, len(existing))
+	for _, tag := range existing {
+		byNama[strings.ToLower(tag.Nama)] = tag
+	}
+
+	tags := make([]domain.Tag, 0, len(lowerNames))
+	for _, nama := range lowerNames {
+		if tag, ok := byNama[nama]; ok {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+func (r *tagRepository) Create(tag *domain.Tag) error {
+	return r.db.Omit("User").Create(tag).Error
+}
+
+func (r *tagRepository) Update(tag *domain.Tag) error {
+	return r.db.Omit("User").Save(tag).Error
+}
+
+func (r *tagRepository) Delete(id string, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&domain.Tag{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("tag_id = ?", id).Delete(&domain.TransaksiTag{}).Error
+	})
+}
+
+func (r *tagRepository) IsNamaExists(userID uint, nama string, excludeID ...string) (bool, error) {
+	query := r.db.Model(&domain.Tag{}).Where("user_id = ? AND LOWER(nama) = LOWER(?)", userID, nama)
+
+	if len(excludeID) > 0 && excludeID[0] != "" {
+		query = query.Where("id != ?", excludeID[0])
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
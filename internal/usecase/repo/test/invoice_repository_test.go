@@ -38,6 +38,11 @@ func (m *MockRedisRepository) Delete(key string) error {
 	return args.Error(0)
 }
 
+func (m *MockRedisRepository) SetNX(key string, value interface{}, ttl time.Duration) (bool, error) {
+	args := m.Called(key, value, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockRedisRepository) Exists(key string) (bool, error) {
 	args := m.Called(key)
 	return args.Bool(0), args.Error(1)
@@ -48,6 +53,11 @@ func (m *MockRedisRepository) Increment(key string) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockRedisRepository) IncrementWithExpire(key string, ttl time.Duration) (int64, error) {
+	args := m.Called(key, ttl)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockRedisRepository) Decrement(key string) (int64, error) {
 	args := m.Called(key)
 	return args.Get(0).(int64), args.Error(1)
@@ -73,6 +83,11 @@ func (m *MockRedisRepository) FlushAll() error {
 	return args.Error(0)
 }
 
+func (m *MockRedisRepository) DeleteIfEqual(key, value string) (bool, error) {
+	args := m.Called(key, value)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockRedisRepository) Ping() error {
 	args := m.Called()
 	return args.Error(0)
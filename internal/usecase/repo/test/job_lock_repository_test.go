@@ -0,0 +1,45 @@
+package repo_test
+
+import (
+	"fiber-boiler-plate/internal/usecase/repo"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestJobLockRepository_Acquire_GagalTanpaRedis(t *testing.T) {
+	lockRepo := repo.NewJobLockRepository(repo.NewRedisRepository(nil))
+
+	acquired, err := lockRepo.Acquire("token_cleanup", time.Minute)
+	assert.Error(t, err)
+	assert.False(t, acquired)
+
+	acquired, err = lockRepo.Acquire("token_cleanup", time.Minute)
+	assert.Error(t, err)
+	assert.False(t, acquired)
+
+	assert.NoError(t, lockRepo.Release("token_cleanup"))
+}
+
+func TestJobLockRepository_Release_HanyaMenghapusTokenSendiri(t *testing.T) {
+	mockRedis := new(MockRedisRepository)
+	lockRepo := repo.NewJobLockRepository(mockRedis)
+
+	var token string
+	mockRedis.On("SetNX", "scheduler:lock:token_cleanup", mock.AnythingOfType("string"), time.Minute).Run(func(args mock.Arguments) {
+		token = args.String(1)
+	}).Return(true, nil).Once()
+
+	acquired, err := lockRepo.Acquire("token_cleanup", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	mockRedis.On("DeleteIfEqual", "scheduler:lock:token_cleanup", token).Return(false, nil).Once()
+
+	assert.NoError(t, lockRepo.Release("token_cleanup"))
+	assert.NoError(t, lockRepo.Release("token_cleanup"))
+	mockRedis.AssertExpectations(t)
+	mockRedis.AssertNotCalled(t, "Delete", mock.Anything)
+}
@@ -13,29 +13,29 @@ func TestLaporanRepository_TrenBulananDataStructure(t *testing.T) {
 		{
 			Bulan:            1,
 			NamaBulan:        "Januari",
-			TotalPemasukan:   5000000.0,
-			TotalPengeluaran: 3500000.0,
+			TotalPemasukan:   domain.NewMoney(5000000),
+			TotalPengeluaran: domain.NewMoney(3500000),
 		},
 		{
 			Bulan:            2,
 			NamaBulan:        "Februari",
-			TotalPemasukan:   5200000.0,
-			TotalPengeluaran: 3800000.0,
+			TotalPemasukan:   domain.NewMoney(5200000),
+			TotalPengeluaran: domain.NewMoney(3800000),
 		},
 	}
 
 	trenBulanan := domain.TrenBulanan{
 		Tahun:                 2024,
 		DataTren:              expectedData,
-		TotalPemasukanTahun:   62400000.0,
-		TotalPengeluaranTahun: 45600000.0,
+		TotalPemasukanTahun:   domain.NewMoney(62400000),
+		TotalPengeluaranTahun: domain.NewMoney(45600000),
 	}
 
 	assert.Equal(t, 2024, trenBulanan.Tahun)
 	assert.Len(t, trenBulanan.DataTren, 2)
 	assert.Equal(t, "Januari", trenBulanan.DataTren[0].NamaBulan)
-	assert.Equal(t, 5000000.0, trenBulanan.DataTren[0].TotalPemasukan)
-	assert.Equal(t, 62400000.0, trenBulanan.TotalPemasukanTahun)
+	assert.Equal(t, domain.NewMoney(5000000), trenBulanan.DataTren[0].TotalPemasukan)
+	assert.Equal(t, domain.NewMoney(62400000), trenBulanan.TotalPemasukanTahun)
 }
 
 func TestLaporanRepository_PerbandinganKantongDataStructure(t *testing.T) {
@@ -43,14 +43,14 @@ func TestLaporanRepository_PerbandinganKantongDataStructure(t *testing.T) {
 		{
 			KantongID:       "550e8400-e29b-41d4-a716-446655440001",
 			KantongNama:     "Kantong Belanja",
-			JumlahBulanIni:  1500000.0,
-			JumlahBulanLalu: 1200000.0,
+			JumlahBulanIni:  domain.NewMoney(1500000),
+			JumlahBulanLalu: domain.NewMoney(1200000),
 		},
 		{
 			KantongID:       "550e8400-e29b-41d4-a716-446655440002",
 			KantongNama:     "Transport",
-			JumlahBulanIni:  800000.0,
-			JumlahBulanLalu: 700000.0,
+			JumlahBulanIni:  domain.NewMoney(800000),
+			JumlahBulanLalu: domain.NewMoney(700000),
 		},
 	}
 
@@ -70,8 +70,8 @@ func TestLaporanRepository_PerbandinganKantongDataStructure(t *testing.T) {
 		BulanIni:        bulanIni,
 		BulanSebelumnya: bulanSebelumnya,
 		DataKantong:     expectedData,
-		TotalBulanIni:   2300000.0,
-		TotalBulanLalu:  1900000.0,
+		TotalBulanIni:   domain.NewMoney(2300000),
+		TotalBulanLalu:  domain.NewMoney(1900000),
 	}
 
 	assert.Equal(t, 12, perbandingan.BulanIni.Bulan)
@@ -79,8 +79,8 @@ func TestLaporanRepository_PerbandinganKantongDataStructure(t *testing.T) {
 	assert.Equal(t, "Desember", perbandingan.BulanIni.NamaBulan)
 	assert.Len(t, perbandingan.DataKantong, 2)
 	assert.Equal(t, "Kantong Belanja", perbandingan.DataKantong[0].KantongNama)
-	assert.Equal(t, 1500000.0, perbandingan.DataKantong[0].JumlahBulanIni)
-	assert.Equal(t, 2300000.0, perbandingan.TotalBulanIni)
+	assert.Equal(t, domain.NewMoney(1500000), perbandingan.DataKantong[0].JumlahBulanIni)
+	assert.Equal(t, domain.NewMoney(2300000), perbandingan.TotalBulanIni)
 }
 
 func TestLaporanRepository_DetailPerbandinganKantongDataStructure(t *testing.T) {
@@ -88,18 +88,18 @@ func TestLaporanRepository_DetailPerbandinganKantongDataStructure(t *testing.T)
 		{
 			KantongID:           "550e8400-e29b-41d4-a716-446655440001",
 			KantongNama:         "Kantong Belanja",
-			JumlahBulanIni:      1500000.0,
-			JumlahBulanLalu:     1200000.0,
-			RataRataPengeluaran: 100000.0,
+			JumlahBulanIni:      domain.NewMoney(1500000),
+			JumlahBulanLalu:     domain.NewMoney(1200000),
+			RataRataPengeluaran: domain.NewMoney(100000),
 			Persentase:          25.0,
 			Trend:               "naik",
 		},
 		{
 			KantongID:           "550e8400-e29b-41d4-a716-446655440002",
 			KantongNama:         "Transport",
-			JumlahBulanIni:      800000.0,
-			JumlahBulanLalu:     700000.0,
-			RataRataPengeluaran: 66666.67,
+			JumlahBulanIni:      domain.NewMoney(800000),
+			JumlahBulanLalu:     domain.NewMoney(700000),
+			RataRataPengeluaran: domain.NewMoneyFromFloat(66666.67),
 			Persentase:          14.29,
 			Trend:               "naik",
 		},
@@ -121,9 +121,9 @@ func TestLaporanRepository_DetailPerbandinganKantongDataStructure(t *testing.T)
 		BulanIni:        bulanIni,
 		BulanSebelumnya: bulanSebelumnya,
 		DataKantong:     expectedData,
-		TotalBulanIni:   2300000.0,
-		TotalBulanLalu:  1900000.0,
-		RataRataTotal:   100000.0,
+		TotalBulanIni:   domain.NewMoney(2300000),
+		TotalBulanLalu:  domain.NewMoney(1900000),
+		RataRataTotal:   domain.NewMoney(100000),
 		PersentaseTotal: 21.05,
 		TrendTotal:      "naik",
 	}
@@ -133,10 +133,10 @@ func TestLaporanRepository_DetailPerbandinganKantongDataStructure(t *testing.T)
 	assert.Equal(t, "Desember", detail.BulanIni.NamaBulan)
 	assert.Len(t, detail.DataKantong, 2)
 	assert.Equal(t, "Kantong Belanja", detail.DataKantong[0].KantongNama)
-	assert.Equal(t, 1500000.0, detail.DataKantong[0].JumlahBulanIni)
+	assert.Equal(t, domain.NewMoney(1500000), detail.DataKantong[0].JumlahBulanIni)
 	assert.Equal(t, 25.0, detail.DataKantong[0].Persentase)
 	assert.Equal(t, "naik", detail.DataKantong[0].Trend)
-	assert.Equal(t, 2300000.0, detail.TotalBulanIni)
+	assert.Equal(t, domain.NewMoney(2300000), detail.TotalBulanIni)
 	assert.Equal(t, 21.05, detail.PersentaseTotal)
 	assert.Equal(t, "naik", detail.TrendTotal)
 }
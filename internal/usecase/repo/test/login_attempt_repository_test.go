@@ -0,0 +1,55 @@
+package repo_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLoginAttemptRepository_IncrementUsesRedis(t *testing.T) {
+	mockRedis := new(MockRedisRepository)
+	attemptRepo := repo.NewLoginAttemptRepository(mockRedis)
+
+	mockRedis.On("IncrementWithExpire", "auth:attempts:login:ip:10.0.0.1", 15*time.Minute).Return(int64(1), nil).Once()
+
+	count, err := attemptRepo.Increment("auth:attempts:login:ip:10.0.0.1", 15*time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	mockRedis.AssertExpectations(t)
+}
+
+func TestLoginAttemptRepository_FallsBackToMemory(t *testing.T) {
+	mockRedis := new(MockRedisRepository)
+	attemptRepo := repo.NewLoginAttemptRepository(mockRedis)
+
+	redisDown := errors.New("redis: connection refused")
+	mockRedis.On("IncrementWithExpire", mock.Anything, mock.Anything).Return(int64(0), redisDown)
+	mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(redisDown)
+	mockRedis.On("GetTTL", mock.Anything).Return(time.Duration(0), redisDown)
+	mockRedis.On("Delete", mock.Anything).Return(redisDown)
+
+	for i := 1; i <= 3; i++ {
+		count, err := attemptRepo.Increment("auth:attempts:login:email:a@example.com", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(i), count)
+	}
+
+	assert.NoError(t, attemptRepo.Block("auth:lockout:login:email:a@example.com", time.Minute))
+
+	ttl, err := attemptRepo.BlockedFor("auth:lockout:login:email:a@example.com")
+	assert.NoError(t, err)
+	assert.Greater(t, ttl, 50*time.Second)
+
+	assert.NoError(t, attemptRepo.Reset("auth:attempts:login:email:a@example.com", "auth:lockout:login:email:a@example.com"))
+
+	ttl, _ = attemptRepo.BlockedFor("auth:lockout:login:email:a@example.com")
+	assert.Equal(t, time.Duration(0), ttl)
+
+	count, _ := attemptRepo.Increment("auth:attempts:login:email:a@example.com", time.Minute)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,557 @@
+package repo_test
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupSaldoTestDB(t *testing.T) (*gorm.DB, *domain.User) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL tidak diset, test konkurensi saldo dilewati")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	sqlDB, err := db.DB()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	sqlDB.SetMaxOpenConns(20)
+
+	err = db.AutoMigrate(
+		&domain.Permission{},
+		&domain.Role{},
+		&domain.RolePermission{},
+		&domain.User{},
+		&domain.Kantong{},
+		&domain.Transaksi{},
+		&domain.Transfer{},
+		&domain.LampiranTransaksi{},
+		&domain.Tag{},
+		&domain.TransaksiTag{},
+		&domain.TransaksiSplit{},
+	)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	user := &domain.User{
+		Email:    fmt.Sprintf("saldo-%d@example.com", time.Now().UnixNano()),
+		Password: "hashed",
+		Name:     "Saldo Test",
+		IsActive: true,
+	}
+	if !assert.NoError(t, db.Create(user).Error) {
+		t.FailNow()
+	}
+
+	t.Cleanup(func() {
+		db.Where("user_id = ?", user.ID).Delete(&domain.Transfer{})
+		db.Where("user_id = ?", user.ID).Delete(&domain.Transaksi{})
+		db.Where("user_id = ?", user.ID).Delete(&domain.Tag{})
+		db.Where("user_id = ?", user.ID).Delete(&domain.Kantong{})
+		db.Delete(user)
+		sqlDB.Close()
+	})
+
+	return db, user
+}
+
+func createSaldoTestKantong(t *testing.T, db *gorm.DB, userID uint, nama string, saldo domain.Money) *domain.Kantong {
+	idKartu, err := repo.NewKantongRepository(db, nil).GenerateUniqueIDKartu()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	kantong := &domain.Kantong{
+		IDKartu:  idKartu,
+		UserID:   userID,
+		Nama:     nama,
+		Kategori: "Pengeluaran",
+		Saldo:    saldo,
+		Warna:    "Navy",
+	}
+	if !assert.NoError(t, db.Create(kantong).Error) {
+		t.FailNow()
+	}
+	return kantong
+}
+
+func currentSaldo(t *testing.T, db *gorm.DB, id string) domain.Money {
+	var kantong domain.Kantong
+	if !assert.NoError(t, db.Where("id = ?", id).First(&kantong).Error) {
+		t.FailNow()
+	}
+	return kantong.Saldo
+}
+
+func TestTransaksiRepository_Create_ConcurrentPemasukan(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	transaksiRepo := repo.NewTransaksiRepository(db, nil)
+	kantong := createSaldoTestKantong(t, db, user.ID, "Konkuren Pemasukan", domain.NewMoney(0))
+
+	const workers = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- transaksiRepo.Create(&domain.Transaksi{
+				UserID:    user.ID,
+				KantongID: kantong.ID,
+				Tanggal:   time.Now(),
+				Jenis:     "Pemasukan",
+				Jumlah:    domain.NewMoney(1000),
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, domain.NewMoney(workers*1000), currentSaldo(t, db, kantong.ID))
+}
+
+func TestTransaksiRepository_Create_ConcurrentPengeluaranNeverOverdraws(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	transaksiRepo := repo.NewTransaksiRepository(db, nil)
+	kantong := createSaldoTestKantong(t, db, user.ID, "Konkuren Pengeluaran", domain.NewMoney(10000))
+
+	const workers = 30
+	var wg sync.WaitGroup
+	var succeeded, rejected int64
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := transaksiRepo.Create(&domain.Transaksi{
+				UserID:    user.ID,
+				KantongID: kantong.ID,
+				Tanggal:   time.Now(),
+				Jenis:     "Pengeluaran",
+				Jumlah:    domain.NewMoney(1000),
+			})
+			if err == nil {
+				atomic.AddInt64(&succeeded, 1)
+			} else if err.Error() == "saldo tidak mencukupi" {
+				atomic.AddInt64(&rejected, 1)
+			} else {
+				t.Errorf("error tidak terduga: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(10), succeeded)
+	assert.Equal(t, int64(workers-10), rejected)
+	assert.Equal(t, domain.NewMoney(0), currentSaldo(t, db, kantong.ID))
+}
+
+func TestTransaksiRepository_UpdateDelete_ConcurrentKeepsSaldoConsistent(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	transaksiRepo := repo.NewTransaksiRepository(db, nil)
+	kantong := createSaldoTestKantong(t, db, user.ID, "Konkuren Update", domain.NewMoney(0))
+
+	const workers = 20
+	transaksiList := make([]*domain.Transaksi, workers)
+	for i := range transaksiList {
+		transaksiList[i] = &domain.Transaksi{
+			UserID:    user.ID,
+			KantongID: kantong.ID,
+			Tanggal:   time.Now(),
+			Jenis:     "Pemasukan",
+			Jumlah:    domain.NewMoney(1000),
+		}
+		if !assert.NoError(t, transaksiRepo.Create(transaksiList[i])) {
+			t.FailNow()
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, transaksi := range transaksiList {
+		wg.Add(1)
+		go func(i int, transaksi *domain.Transaksi) {
+			defer wg.Done()
+			if i%2 == 0 {
+				assert.NoError(t, transaksiRepo.Delete(transaksi.ID, user.ID))
+				return
+			}
+			assert.NoError(t, transaksiRepo.Update(&domain.Transaksi{
+				ID:        transaksi.ID,
+				UserID:    user.ID,
+				KantongID: kantong.ID,
+				Tanggal:   transaksi.Tanggal,
+				Jenis:     "Pemasukan",
+				Jumlah:    domain.NewMoney(2500),
+			}))
+		}(i, transaksi)
+	}
+	wg.Wait()
+
+	assert.Equal(t, domain.NewMoney(workers/2*2500), currentSaldo(t, db, kantong.ID))
+}
+
+func TestTransaksiRepository_Update_SameKantongAppliesNewJumlah(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	transaksiRepo := repo.NewTransaksiRepository(db, nil)
+	kantong := createSaldoTestKantong(t, db, user.ID, "Update Kantong Sama", domain.NewMoney(10000))
+
+	transaksi := &domain.Transaksi{
+		UserID:    user.ID,
+		KantongID: kantong.ID,
+		Tanggal:   time.Now(),
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(2000),
+	}
+	assert.NoError(t, transaksiRepo.Create(transaksi))
+	assert.Equal(t, domain.NewMoney(8000), currentSaldo(t, db, kantong.ID))
+
+	assert.NoError(t, transaksiRepo.Update(&domain.Transaksi{
+		ID:        transaksi.ID,
+		UserID:    user.ID,
+		KantongID: kantong.ID,
+		Tanggal:   transaksi.Tanggal,
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(5000),
+	}))
+	assert.Equal(t, domain.NewMoney(5000), currentSaldo(t, db, kantong.ID))
+}
+
+func TestTransaksiRepository_Split_AppliesAndRevertsEveryKantong(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	transaksiRepo := repo.NewTransaksiRepository(db, nil)
+	kantongA := createSaldoTestKantong(t, db, user.ID, "Split A", domain.NewMoney(10000))
+	kantongB := createSaldoTestKantong(t, db, user.ID, "Split B", domain.NewMoney(10000))
+
+	transaksi := &domain.Transaksi{
+		UserID:    user.ID,
+		KantongID: kantongA.ID,
+		Tanggal:   time.Now(),
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(9000),
+		Splits: []domain.TransaksiSplit{
+			{KantongID: kantongA.ID, Jumlah: domain.NewMoney(6000)},
+			{KantongID: kantongB.ID, Jumlah: domain.NewMoney(3000)},
+		},
+	}
+	assert.NoError(t, transaksiRepo.Create(transaksi))
+	assert.Equal(t, domain.NewMoney(4000), currentSaldo(t, db, kantongA.ID))
+	assert.Equal(t, domain.NewMoney(7000), currentSaldo(t, db, kantongB.ID))
+
+	err := transaksiRepo.Create(&domain.Transaksi{
+		UserID:    user.ID,
+		KantongID: kantongA.ID,
+		Tanggal:   time.Now(),
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(6000),
+		Splits: []domain.TransaksiSplit{
+			{KantongID: kantongA.ID, Jumlah: domain.NewMoney(5000)},
+			{KantongID: kantongB.ID, Jumlah: domain.NewMoney(1000)},
+		},
+	})
+	assert.EqualError(t, err, "saldo tidak mencukupi")
+	assert.Equal(t, domain.NewMoney(4000), currentSaldo(t, db, kantongA.ID))
+	assert.Equal(t, domain.NewMoney(7000), currentSaldo(t, db, kantongB.ID))
+
+	assert.NoError(t, transaksiRepo.Update(&domain.Transaksi{
+		ID:        transaksi.ID,
+		UserID:    user.ID,
+		KantongID: kantongB.ID,
+		Tanggal:   transaksi.Tanggal,
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(2000),
+	}))
+	assert.Equal(t, domain.NewMoney(10000), currentSaldo(t, db, kantongA.ID))
+	assert.Equal(t, domain.NewMoney(8000), currentSaldo(t, db, kantongB.ID))
+
+	assert.NoError(t, transaksiRepo.Delete(transaksi.ID, user.ID))
+	assert.Equal(t, domain.NewMoney(10000), currentSaldo(t, db, kantongB.ID))
+}
+
+func TestTransaksiRepository_Split_FilterDanNamaSemuaKantong(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	transaksiRepo := repo.NewTransaksiRepository(db, nil)
+	kantongA := createSaldoTestKantong(t, db, user.ID, "Split Utama", domain.NewMoney(10000))
+	kantongB := createSaldoTestKantong(t, db, user.ID, "Split Dapur", domain.NewMoney(10000))
+
+	transaksi := &domain.Transaksi{
+		UserID:    user.ID,
+		KantongID: kantongA.ID,
+		Tanggal:   time.Now(),
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(9000),
+		Splits: []domain.TransaksiSplit{
+			{KantongID: kantongA.ID, Jumlah: domain.NewMoney(6000)},
+			{KantongID: kantongB.ID, Jumlah: domain.NewMoney(3000)},
+		},
+	}
+	if !assert.NoError(t, transaksiRepo.Create(transaksi)) {
+		t.FailNow()
+	}
+
+	kantongNama := "dapur"
+	req := &domain.TransaksiListRequest{KantongNama: &kantongNama, SortBy: "tanggal", SortDirection: "desc", Page: 1, PerPage: 10}
+	list, total, err := transaksiRepo.GetByUserID(user.ID, req)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, transaksi.ID, list[0].ID)
+		assert.Equal(t, []string{"Split Utama", "Split Dapur"}, list[0].DaftarKantongNama)
+	}
+
+	detail, err := transaksiRepo.GetByID(transaksi.ID, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Split Utama", "Split Dapur"}, detail.DaftarKantongNama)
+
+	var rows []*domain.TransaksiExportRow
+	err = transaksiRepo.StreamForExport(user.ID, req, false, func(row *domain.TransaksiExportRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 1) {
+		assert.Equal(t, kantongB.ID, rows[0].KantongID)
+	}
+}
+
+func TestTransaksiRepository_ExecuteBatch_SebagianMelewatiOperasiGagal(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	transaksiRepo := repo.NewTransaksiRepository(db, nil)
+	kantong := createSaldoTestKantong(t, db, user.ID, "Batch Sebagian", domain.NewMoney(10000))
+
+	existing := &domain.Transaksi{
+		UserID:    user.ID,
+		KantongID: kantong.ID,
+		Tanggal:   time.Now(),
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(1000),
+	}
+	assert.NoError(t, transaksiRepo.Create(existing))
+
+	operasi := []*domain.OperasiBatchTransaksi{
+		{Aksi: domain.AksiBatchCreate, Transaksi: &domain.Transaksi{UserID: user.ID, KantongID: kantong.ID, Tanggal: time.Now(), Jenis: "Pengeluaran", Jumlah: domain.NewMoney(4000)}},
+		{Aksi: domain.AksiBatchCreate, Transaksi: &domain.Transaksi{UserID: user.ID, KantongID: kantong.ID, Tanggal: time.Now(), Jenis: "Pengeluaran", Jumlah: domain.NewMoney(9000)}},
+		{Aksi: domain.AksiBatchDelete, Transaksi: &domain.Transaksi{ID: existing.ID, UserID: user.ID}},
+		{Aksi: domain.AksiBatchDelete, Transaksi: &domain.Transaksi{ID: existing.ID, UserID: user.ID}},
+	}
+	assert.NoError(t, transaksiRepo.ExecuteBatch(user.ID, operasi, false))
+
+	assert.NoError(t, operasi[0].Err)
+	assert.EqualError(t, operasi[1].Err, "saldo tidak mencukupi")
+	assert.NoError(t, operasi[2].Err)
+	assert.EqualError(t, operasi[3].Err, "transaksi tidak ditemukan")
+	assert.Equal(t, domain.NewMoney(6000), currentSaldo(t, db, kantong.ID))
+}
+
+func TestTransaksiRepository_ExecuteBatch_AtomikMembatalkanSemua(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	transaksiRepo := repo.NewTransaksiRepository(db, nil)
+	kantong := createSaldoTestKantong(t, db, user.ID, "Batch Atomik", domain.NewMoney(10000))
+
+	operasi := []*domain.OperasiBatchTransaksi{
+		{Aksi: domain.AksiBatchCreate, Transaksi: &domain.Transaksi{UserID: user.ID, KantongID: kantong.ID, Tanggal: time.Now(), Jenis: "Pengeluaran", Jumlah: domain.NewMoney(4000), Tags: []domain.Tag{{UserID: user.ID, Nama: "Batch"}}}},
+		{Aksi: domain.AksiBatchCreate, Transaksi: &domain.Transaksi{UserID: user.ID, KantongID: kantong.ID, Tanggal: time.Now(), Jenis: "Pengeluaran", Jumlah: domain.NewMoney(9000)}},
+	}
+	assert.EqualError(t, transaksiRepo.ExecuteBatch(user.ID, operasi, true), "saldo tidak mencukupi")
+
+	var jumlah, jumlahTag int64
+	db.Model(&domain.Transaksi{}).Where("user_id = ?", user.ID).Count(&jumlah)
+	db.Model(&domain.Tag{}).Where("user_id = ?", user.ID).Count(&jumlahTag)
+	assert.Equal(t, int64(0), jumlah)
+	assert.Equal(t, int64(0), jumlahTag)
+	assert.Equal(t, domain.NewMoney(10000), currentSaldo(t, db, kantong.ID))
+}
+
+func TestTransaksiRepository_Create_GagalTidakMeninggalkanTag(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	transaksiRepo := repo.NewTransaksiRepository(db, nil)
+	kantong := createSaldoTestKantong(t, db, user.ID, "Tag Gagal", domain.NewMoney(1000))
+
+	err := transaksiRepo.Create(&domain.Transaksi{
+		UserID:    user.ID,
+		KantongID: kantong.ID,
+		Tanggal:   time.Now(),
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(5000),
+		Tags:      []domain.Tag{{UserID: user.ID, Nama: "Liburan"}},
+	})
+	assert.EqualError(t, err, "saldo tidak mencukupi")
+
+	var jumlahTag int64
+	db.Model(&domain.Tag{}).Where("user_id = ?", user.ID).Count(&jumlahTag)
+	assert.Equal(t, int64(0), jumlahTag)
+
+	transaksi := &domain.Transaksi{
+		UserID:    user.ID,
+		KantongID: kantong.ID,
+		Tanggal:   time.Now(),
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(500),
+		Tags:      []domain.Tag{{UserID: user.ID, Nama: "Liburan"}},
+	}
+	if !assert.NoError(t, transaksiRepo.Create(transaksi)) {
+		t.FailNow()
+	}
+
+	detail, err := transaksiRepo.GetByID(transaksi.ID, user.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, detail.Tags, 1) {
+		assert.Equal(t, "Liburan", detail.Tags[0].Nama)
+	}
+}
+
+func TestKantongRepository_Transfer_ConcurrentOppositeDirections(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	kantongRepo := repo.NewKantongRepository(db, nil)
+	kantongA := createSaldoTestKantong(t, db, user.ID, "Konkuren A", domain.NewMoney(100000))
+	kantongB := createSaldoTestKantong(t, db, user.ID, "Konkuren B", domain.NewMoney(100000))
+
+	const workers = 40
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			asal, tujuan := kantongA.ID, kantongB.ID
+			jumlah := domain.NewMoney(1000)
+			if i%2 == 1 {
+				asal, tujuan = kantongB.ID, kantongA.ID
+				jumlah = domain.NewMoney(500)
+			}
+			_, _, err := kantongRepo.Transfer(&domain.Transfer{
+				UserID:          user.ID,
+				KantongAsalID:   asal,
+				KantongTujuanID: tujuan,
+				Jumlah:          jumlah,
+			})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, domain.NewMoney(100000-20*1000+20*500), currentSaldo(t, db, kantongA.ID))
+	assert.Equal(t, domain.NewMoney(100000+20*1000-20*500), currentSaldo(t, db, kantongB.ID))
+
+	var count int64
+	db.Model(&domain.Transfer{}).Where("user_id = ?", user.ID).Count(&count)
+	assert.Equal(t, int64(workers), count)
+}
+
+func TestKantongRepository_ReverseTransfer_ConcurrentOnlyOnce(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	kantongRepo := repo.NewKantongRepository(db, nil)
+	kantongA := createSaldoTestKantong(t, db, user.ID, "Reversal A", domain.NewMoney(50000))
+	kantongB := createSaldoTestKantong(t, db, user.ID, "Reversal B", domain.NewMoney(0))
+
+	transfer := &domain.Transfer{
+		UserID:          user.ID,
+		KantongAsalID:   kantongA.ID,
+		KantongTujuanID: kantongB.ID,
+		Jumlah:          domain.NewMoney(20000),
+	}
+	_, _, err := kantongRepo.Transfer(transfer)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	const workers = 10
+	var wg sync.WaitGroup
+	var succeeded int64
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := kantongRepo.ReverseTransfer(transfer.ID, user.ID, &domain.Transfer{})
+			if err == nil {
+				atomic.AddInt64(&succeeded, 1)
+			} else if err.Error() != "transfer sudah dibatalkan" {
+				t.Errorf("error tidak terduga: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(1), succeeded)
+	assert.Equal(t, domain.NewMoney(50000), currentSaldo(t, db, kantongA.ID))
+	assert.Equal(t, domain.NewMoney(0), currentSaldo(t, db, kantongB.ID))
+}
+
+func TestLampiranTransaksiRepository_Create_ConcurrentTidakMelebihiBatas(t *testing.T) {
+	db, user := setupSaldoTestDB(t)
+	transaksiRepo := repo.NewTransaksiRepository(db, nil)
+	lampiranRepo := repo.NewLampiranTransaksiRepository(db)
+	kantong := createSaldoTestKantong(t, db, user.ID, "Konkuren Lampiran", domain.NewMoney(0))
+
+	transaksi := &domain.Transaksi{
+		UserID:    user.ID,
+		KantongID: kantong.ID,
+		Tanggal:   time.Now(),
+		Jenis:     "Pemasukan",
+		Jumlah:    domain.NewMoney(1000),
+	}
+	if !assert.NoError(t, transaksiRepo.Create(transaksi)) {
+		t.FailNow()
+	}
+
+	const workers = 20
+	const maxJumlah = 5
+	var wg sync.WaitGroup
+	var succeeded, rejected int64
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := lampiranRepo.Create(&domain.LampiranTransaksi{
+				TransaksiID: transaksi.ID,
+				UserID:      user.ID,
+				NamaFile:    "struk.png",
+				ContentType: "image/png",
+				Ukuran:      10,
+				StorageKey:  fmt.Sprintf("transaksi/%d/%s/%d-%d", user.ID, transaksi.ID, time.Now().UnixNano(), i),
+			}, maxJumlah)
+			if err == nil {
+				atomic.AddInt64(&succeeded, 1)
+			} else if err == domain.ErrJumlahLampiranMelebihiBatas {
+				atomic.AddInt64(&rejected, 1)
+			} else {
+				t.Errorf("error tidak terduga: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	total, err := lampiranRepo.CountByTransaksiID(transaksi.ID, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(maxJumlah), succeeded)
+	assert.Equal(t, int64(workers-maxJumlah), rejected)
+	assert.Equal(t, maxJumlah, total)
+}
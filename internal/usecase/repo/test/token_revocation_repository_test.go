@@ -0,0 +1,119 @@
+package repo_test
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type redisSelaluMiss struct {
+	repo.RedisRepository
+}
+
+func (r *redisSelaluMiss) Exists(key string) (bool, error) {
+	return false, nil
+}
+
+func (r *redisSelaluMiss) Set(key string, value interface{}, ttl time.Duration) error {
+	return nil
+}
+
+func (r *redisSelaluMiss) Get(key string) (string, error) {
+	return "", redis.Nil
+}
+
+func (r *redisSelaluMiss) Delete(key string) error {
+	return nil
+}
+
+func setupTokenRevocationTestDB(t *testing.T) (*gorm.DB, *domain.User) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL tidak diset, test revocation token dilewati")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if !assert.NoError(t, db.AutoMigrate(&domain.Permission{}, &domain.Role{}, &domain.RolePermission{}, &domain.User{}, &domain.RevokedAccessToken{})) {
+		t.FailNow()
+	}
+
+	user := &domain.User{
+		Email:    fmt.Sprintf("revoke-%d@example.com", time.Now().UnixNano()),
+		Password: "hashed",
+		Name:     "Revoke Test",
+		IsActive: true,
+	}
+	if !assert.NoError(t, db.Create(user).Error) {
+		t.FailNow()
+	}
+	t.Cleanup(func() {
+		db.Unscoped().Delete(user)
+	})
+
+	return db, user
+}
+
+func TestTokenRevocationRepository_RevokeTerlihatAntarInstanceTanpaRedis(t *testing.T) {
+	db, user := setupTokenRevocationTestDB(t)
+
+	instanceA := repo.NewTokenRevocationRepository(db, repo.NewRedisRepository(nil))
+	instanceB := repo.NewTokenRevocationRepository(db, repo.NewRedisRepository(nil))
+
+	tokenID := fmt.Sprintf("jti-%d", time.Now().UnixNano())
+	issuedAt := time.Now()
+
+	revoked, err := instanceB.IsAccessTokenRevoked(tokenID, user.ID, "", issuedAt)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	assert.NoError(t, instanceA.RevokeAccessToken(tokenID, time.Now().Add(time.Hour)))
+
+	revoked, err = instanceB.IsAccessTokenRevoked(tokenID, user.ID, "", issuedAt)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	assert.NoError(t, instanceA.RevokeSessionAccess("family-987654", time.Hour))
+
+	revoked, err = instanceB.IsAccessTokenRevoked("", user.ID, "family-987654", issuedAt)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestTokenRevocationRepository_RedisMissTetapMembacaDatabase(t *testing.T) {
+	db, user := setupTokenRevocationTestDB(t)
+
+	tokenID := fmt.Sprintf("jti-miss-%d", time.Now().UnixNano())
+	assert.NoError(t, repo.NewTokenRevocationRepository(db, repo.NewRedisRepository(nil)).RevokeAccessToken(tokenID, time.Now().Add(time.Hour)))
+
+	revoked, err := repo.NewTokenRevocationRepository(db, &redisSelaluMiss{}).IsAccessTokenRevoked(tokenID, user.ID, "", time.Now())
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestTokenRevocationRepository_TokenDiDetikYangSamaIkutDicabut(t *testing.T) {
+	db, user := setupTokenRevocationTestDB(t)
+	revocationRepo := repo.NewTokenRevocationRepository(db, repo.NewRedisRepository(nil))
+
+	now := time.Now()
+	assert.NoError(t, revocationRepo.InvalidateUserTokens(user.ID, now))
+
+	revoked, err := revocationRepo.IsAccessTokenRevoked("", user.ID, "", now.Truncate(time.Second))
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = revocationRepo.IsAccessTokenRevoked("", user.ID, "", now.Truncate(time.Second).Add(time.Second))
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
@@ -0,0 +1,155 @@
+package repo
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tokenRevocationRepository struct {
+	db    *gorm.DB
+	redis RedisRepository
+}
+
+func NewTokenRevocationRepository(db *gorm.DB, redis RedisRepository) TokenRevocationRepository {
+	return &tokenRevocationRepository{
+		db:    db,
+		redis: redis,
+	}
+}
+
+func (r *tokenRevocationRepository) RevokeAccessToken(tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if tokenID == "" || ttl <= 0 {
+		return nil
+	}
+	return r.deny(fmt.Sprintf("auth:revoked_jti:%s", tokenID), ttl)
+}
+
+func (r *tokenRevocationRepository) RevokeSessionAccess(sessionID string, ttl time.Duration) error {
+	if sessionID == "" || ttl <= 0 {
+		return nil
+	}
+	return r.deny(fmt.Sprintf("auth:revoked_sid:%s", sessionID), ttl)
+}
+
+func (r *tokenRevocationRepository) InvalidateUserTokens(userID uint, before time.Time) error {
+	before = before.Truncate(time.Second)
+	if err := r.db.Model(&domain.User{}).Where("id = ?", userID).Update("tokens_invalid_before", before).Error; err != nil {
+		return err
+	}
+
+	cacheKey := fmt.Sprintf("auth:tokens_invalid_before:%d", userID)
+	r.redis.Delete(cacheKey)
+	r.redis.Set(cacheKey, strconv.FormatInt(before.Unix(), 10), 10*time.Minute)
+
+	return nil
+}
+
+func (r *tokenRevocationRepository) IsAccessTokenRevoked(tokenID string, userID uint, sessionID string, issuedAt time.Time) (bool, error) {
+	var keys []string
+	if tokenID != "" {
+		keys = append(keys, fmt.Sprintf("auth:revoked_jti:%s", tokenID))
+	}
+	if sessionID != "" {
+		keys = append(keys, fmt.Sprintf("auth:revoked_sid:%s", sessionID))
+	}
+	if len(keys) > 0 {
+		denied, err := r.isDenied(keys...)
+		if err != nil {
+			return false, err
+		}
+		if denied {
+			return true, nil
+		}
+	}
+
+	before, err := r.tokensInvalidBefore(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return before != nil && !issuedAt.After(*before), nil
+}
+
+func (r *tokenRevocationRepository) tokensInvalidBefore(userID uint) (*time.Time, error) {
+	cacheKey := fmt.Sprintf("auth:tokens_invalid_before:%d", userID)
+	if cached, err := r.redis.Get(cacheKey); err == nil && cached != "" {
+		if unix, err := strconv.ParseInt(cached, 10, 64); err == nil {
+			if unix == 0 {
+				return nil, nil
+			}
+			before := time.Unix(unix, 0)
+			return &before, nil
+		}
+	}
+
+	var user domain.User
+	if err := r.db.Select("id", "tokens_invalid_before").Where("id = ?", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			now := time.Now().Add(time.Second)
+			return &now, nil
+		}
+		return nil, err
+	}
+
+	var unix int64
+	if user.TokensInvalidBefore != nil {
+		unix = user.TokensInvalidBefore.Unix()
+	}
+	r.redis.Set(cacheKey, strconv.FormatInt(unix, 10), 10*time.Minute)
+
+	return user.TokensInvalidBefore, nil
+}
+
+func (r *tokenRevocationRepository) deny(key string, ttl time.Duration) error {
+	now := time.Now()
+	if err := r.db.Where("expires_at <= ?", now).Delete(&domain.RevokedAccessToken{}).Error; err != nil {
+		return err
+	}
+
+	revoked := domain.RevokedAccessToken{RevocationKey: key, ExpiresAt: now.Add(ttl)}
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "revocation_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+	}).Create(&revoked).Error; err != nil {
+		return err
+	}
+
+	if err := r.redis.Set(key, 1, ttl); err != nil && !errors.Is(err, redis.Nil) {
+		helper.Warn("Gagal menyimpan revocation token ke redis, database tetap menjadi acuan", logrus.Fields{
+			"revocation_key": key,
+			"error":          err.Error(),
+		})
+	}
+
+	return nil
+}
+
+func (r *tokenRevocationRepository) isDenied(keys ...string) (bool, error) {
+	for _, key := range keys {
+		if exists, err := r.redis.Exists(key); err == nil && exists {
+			return true, nil
+		}
+	}
+
+	var revoked []domain.RevokedAccessToken
+	if err := r.db.Where("revocation_key IN ? AND expires_at > ?", keys, time.Now()).
+		Find(&revoked).Error; err != nil {
+		return false, err
+	}
+
+	for _, row := range revoked {
+		r.redis.Set(row.RevocationKey, 1, time.Until(row.ExpiresAt))
+	}
+
+	return len(revoked) > 0, nil
+}
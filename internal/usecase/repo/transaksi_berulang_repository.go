@@ -0,0 +1,138 @@
+package repo
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type transaksiBerulangRepository struct {
+	db *gorm.DB
+}
+
+func NewTransaksiBerulangRepository(db *gorm.DB) TransaksiBerulangRepository {
+	return &transaksiBerulangRepository{db: db}
+}
+
+func (r *transaksiBerulangRepository) Create(transaksiBerulang *domain.TransaksiBerulang) error {
+	return r.db.Omit("User", "Kantong").Create(transaksiBerulang).Error
+}
+
+func (r *transaksiBerulangRepository) GetByID(id string, userID uint) (*domain.TransaksiBerulang, error) {
+	var transaksiBerulang domain.TransaksiBerulang
+	err := r.db.Preload("Kantong").Where("id = ? AND user_id = ?", id, userID).First(&transaksiBerulang).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transaksiBerulang, nil
+}
+
+func (r *transaksiBerulangRepository) GetByUserID(userID uint, req *domain.TransaksiBerulangListRequest) ([]*domain.TransaksiBerulang, int, error) {
+	query := r.db.Model(&domain.TransaksiBerulang{}).Where("user_id = ?", userID)
+
+	if req.Status != nil && *req.Status != "" {
+		query = query.Where("status = ?", *req.Status)
+	}
+
+	if req.KantongID != nil && *req.KantongID != "" {
+		query = query.Where("kantong_id = ?", *req.KantongID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []*domain.TransaksiBerulang
+	offset := (req.Page - 1) * req.PerPage
+	err := query.Preload("Kantong").
+		Order("tanggal_berikutnya ASC NULLS LAST").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(req.PerPage).
+		Find(&list).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, int(total), nil
+}
+
+func (r *transaksiBerulangRepository) Update(transaksiBerulang *domain.TransaksiBerulang) error {
+	return r.db.Omit("User", "Kantong").Save(transaksiBerulang).Error
+}
+
+func (r *transaksiBerulangRepository) Delete(id string, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&domain.TransaksiBerulang{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("transaksi_berulang_id = ?", id).Delete(&domain.KejadianTransaksiBerulang{}).Error
+	})
+}
+
+func (r *transaksiBerulangRepository) GetDue(tanggal time.Time, limit int) ([]*domain.TransaksiBerulang, error) {
+	var list []*domain.TransaksiBerulang
+	err := r.db.Where("status = ? AND tanggal_berikutnya <= ?", domain.StatusTransaksiBerulangAktif, tanggal).
+		Order("tanggal_berikutnya ASC").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+func (r *transaksiBerulangRepository) AdvanceSchedule(transaksiBerulang *domain.TransaksiBerulang, urutanSebelum int) (bool, error) {
+	result := r.db.Model(&domain.TransaksiBerulang{}).
+		Where("id = ? AND urutan = ? AND status = ?", transaksiBerulang.ID, urutanSebelum, domain.StatusTransaksiBerulangAktif).
+		Updates(map[string]interface{}{
+			"urutan":             transaksiBerulang.Urutan,
+			"tanggal_berikutnya": transaksiBerulang.TanggalBerikutnya,
+			"status":             transaksiBerulang.Status,
+			"updated_at":         time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *transaksiBerulangRepository) CreateKejadian(kejadian *domain.KejadianTransaksiBerulang) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(kejadian)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *transaksiBerulangRepository) ReclaimKejadian(kejadian *domain.KejadianTransaksiBerulang, staleBefore time.Time) (bool, error) {
+	result := r.db.Model(&domain.KejadianTransaksiBerulang{}).
+		Where("transaksi_berulang_id = ? AND urutan = ? AND status = ? AND updated_at < ?", kejadian.TransaksiBerulangID, kejadian.Urutan, domain.StatusKejadianDiproses, staleBefore).
+		Update("updated_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	err := r.db.Where("transaksi_berulang_id = ? AND urutan = ?", kejadian.TransaksiBerulangID, kejadian.Urutan).First(kejadian).Error
+	return err == nil, err
+}
+
+func (r *transaksiBerulangRepository) UpdateKejadian(kejadian *domain.KejadianTransaksiBerulang) error {
+	return r.db.Save(kejadian).Error
+}
+
+func (r *transaksiBerulangRepository) DeleteKejadian(id uint) error {
+	return r.db.Delete(&domain.KejadianTransaksiBerulang{}, id).Error
+}
+
+func (r *transaksiBerulangRepository) DeleteKejadianDilewati(transaksiBerulangID string, urutanMulai int) error {
+	return r.db.Where("transaksi_berulang_id = ? AND urutan >= ? AND status = ?", transaksiBerulangID, urutanMulai, domain.StatusKejadianDilewati).
+		Delete(&domain.KejadianTransaksiBerulang{}).Error
+}
@@ -3,51 +3,41 @@ package repo
 import (
 	"errors"
 	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/storage"
 	"fmt"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type transaksiRepository struct {
-	db *gorm.DB
+	db          *gorm.DB
+	blobStorage storage.Storage
 }
 
-func NewTransaksiRepository(db *gorm.DB) TransaksiRepository {
-	return &transaksiRepository{db: db}
+func NewTransaksiRepository(db *gorm.DB, blobStorage storage.Storage) TransaksiRepository {
+	return &transaksiRepository{
+		db:          db,
+		blobStorage: blobStorage,
+	}
+}
+
+type transaksiDenganKantong struct {
+	domain.Transaksi
+	KantongNama string `json:"kantong_nama"`
 }
 
 func (r *transaksiRepository) GetByUserID(userID uint, req *domain.TransaksiListRequest) ([]*domain.TransaksiResponse, int, error) {
-	var transaksiList []struct {
-		domain.Transaksi
-		KantongNama string `json:"kantong_nama"`
-	}
+	var transaksiList []transaksiDenganKantong
 
 	query := r.db.Table("transaksis t").
 		Select("t.*, k.nama as kantong_nama").
 		Joins("LEFT JOIN kantongs k ON t.kantong_id = k.id").
 		Where("t.user_id = ?", userID)
-
-	if req.Search != nil && *req.Search != "" {
-		searchTerm := "%" + *req.Search + "%"
-		query = query.Where("k.nama ILIKE ? OR t.catatan ILIKE ?", searchTerm, searchTerm)
-	}
-
-	if req.Jenis != nil && *req.Jenis != "" {
-		query = query.Where("t.jenis = ?", *req.Jenis)
-	}
-
-	if req.KantongNama != nil && *req.KantongNama != "" {
-		query = query.Where("k.nama ILIKE ?", "%"+*req.KantongNama+"%")
-	}
-
-	if req.TanggalMulai != nil && *req.TanggalMulai != "" {
-		query = query.Where("t.tanggal >= ?", *req.TanggalMulai)
-	}
-
-	if req.TanggalSelesai != nil && *req.TanggalSelesai != "" {
-		query = query.Where("t.tanggal <= ?", *req.TanggalSelesai)
-	}
+	query = applyTransaksiFilter(query, req)
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -72,6 +62,89 @@ func (r *transaksiRepository) GetByUserID(userID uint, req *domain.TransaksiList
 		return nil, 0, err
 	}
 
+	result := toTransaksiResponses(transaksiList)
+
+	if err := r.loadTags(result); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.loadSplits(result); err != nil {
+		return nil, 0, err
+	}
+
+	return result, int(total), nil
+}
+
+func (r *transaksiRepository) GetByUserIDCursor(userID uint, req *domain.TransaksiListRequest, cursor *domain.TransaksiCursor, limit int) ([]*domain.TransaksiResponse, error) {
+	query := r.db.Table("transaksis t").
+		Select("t.*, k.nama as kantong_nama").
+		Joins("LEFT JOIN kantongs k ON t.kantong_id = k.id").
+		Where("t.user_id = ?", userID)
+	query = applyTransaksiFilter(query, req)
+
+	kolom, tipe := "t.tanggal", "date"
+	if req.SortBy == "jumlah" {
+		kolom, tipe = "t.jumlah", "decimal"
+	}
+
+	descending := req.SortDirection != "asc"
+	if cursor != nil && cursor.Mundur {
+		descending = !descending
+	}
+
+	direction, operator := "ASC", ">"
+	if descending {
+		direction, operator = "DESC", "<"
+	}
+
+	if cursor != nil {
+		nilai, err := cursor.NilaiSort()
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(fmt.Sprintf("(%s, t.id) %s (CAST(? AS %s), CAST(? AS uuid))", kolom, operator, tipe), nilai, cursor.ID)
+	}
+
+	var transaksiList []transaksiDenganKantong
+	if err := query.Order(fmt.Sprintf("%s %s, t.id %s", kolom, direction, direction)).
+		Limit(limit).
+		Find(&transaksiList).Error; err != nil {
+		return nil, err
+	}
+
+	result := toTransaksiResponses(transaksiList)
+	if cursor != nil && cursor.Mundur {
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
+
+	if err := r.loadTags(result); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadSplits(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *transaksiRepository) CountByUserID(userID uint, req *domain.TransaksiListRequest) (int, error) {
+	query := r.db.Table("transaksis t").
+		Joins("LEFT JOIN kantongs k ON t.kantong_id = k.id").
+		Where("t.user_id = ?", userID)
+	query = applyTransaksiFilter(query, req)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return int(total), nil
+}
+
+func toTransaksiResponses(transaksiList []transaksiDenganKantong) []*domain.TransaksiResponse {
 	var result []*domain.TransaksiResponse
 	for _, t := range transaksiList {
 		result = append(result, &domain.TransaksiResponse{
@@ -86,8 +159,91 @@ func (r *transaksiRepository) GetByUserID(userID uint, req *domain.TransaksiList
 			UpdatedAt:   t.UpdatedAt,
 		})
 	}
+	return result
+}
 
-	return result, int(total), nil
+func (r *transaksiRepository) StreamForExport(userID uint, req *domain.TransaksiListRequest, kelompokkanKantong bool, fn func(row *domain.TransaksiExportRow) error) error {
+	query := r.db.Table("transaksis t").
+		Select("t.id, t.tanggal, t.jenis, tk.jumlah, tk.kantong_id, k.nama as kantong_nama, tk.catatan, t.created_at").
+		Joins("JOIN transaksi_kantongs tk ON tk.id = t.id").
+		Joins("LEFT JOIN kantongs k ON tk.kantong_id = k.id").
+		Where("t.user_id = ?", userID)
+	query = applyTransaksiFilter(query, req)
+	if req.KantongNama != nil && *req.KantongNama != "" {
+		query = query.Where("k.nama ILIKE ?", "%"+*req.KantongNama+"%")
+	}
+
+	if kelompokkanKantong {
+		query = query.Order("tk.kantong_id ASC")
+	}
+
+	rows, err := query.Order("t.tanggal ASC").Order("t.created_at ASC").Order("t.id ASC").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row domain.TransaksiExportRow
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *transaksiRepository) GetSaldoAwalKantong(userID uint, tanggalMulai *string) (map[string]domain.Money, error) {
+	filterTransaksi, filterTransfer := "TRUE", "TRUE"
+	var args []interface{}
+	if tanggalMulai != nil && *tanggalMulai != "" {
+		filterTransaksi, filterTransfer = "tk.tanggal >= ?", "DATE(tf.created_at) >= ?"
+		args = append(args, *tanggalMulai, *tanggalMulai)
+	}
+	args = append(args, userID)
+
+	var rows []struct {
+		KantongID string       `gorm:"column:kantong_id"`
+		Saldo     domain.Money `gorm:"column:saldo"`
+	}
+	err := r.db.Raw(fmt.Sprintf(`SELECT k.id AS kantong_id,
+		k.saldo
+		- COALESCE((SELECT SUM(CASE WHEN tk.jenis = 'Pengeluaran' THEN -tk.jumlah ELSE tk.jumlah END)
+			FROM transaksi_kantongs tk WHERE tk.kantong_id = k.id AND %s), 0)
+		- COALESCE((SELECT SUM(CASE WHEN tf.kantong_tujuan_id = k.id THEN tf.jumlah ELSE -tf.jumlah END)
+			FROM transfers tf WHERE (tf.kantong_asal_id = k.id OR tf.kantong_tujuan_id = k.id) AND %s), 0) AS saldo
+		FROM kantongs k
+		WHERE k.user_id = ?`, filterTransaksi, filterTransfer), args...).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]domain.Money, len(rows))
+	for _, row := range rows {
+		result[row.KantongID] = row.Saldo
+	}
+	return result, nil
+}
+
+func (r *transaksiRepository) GetMutasiTransfer(userID uint, tanggalMulai, tanggalSelesai *string) ([]domain.MutasiTransferExport, error) {
+	transfers := r.db.Table("transfers").Where("user_id = ?", userID)
+	if tanggalMulai != nil && *tanggalMulai != "" {
+		transfers = transfers.Where("DATE(created_at) >= ?", *tanggalMulai)
+	}
+	if tanggalSelesai != nil && *tanggalSelesai != "" {
+		transfers = transfers.Where("DATE(created_at) <= ?", *tanggalSelesai)
+	}
+
+	masuk := transfers.Session(&gorm.Session{}).Select("kantong_tujuan_id AS kantong_id, DATE(created_at) AS tanggal, jumlah")
+	keluar := transfers.Session(&gorm.Session{}).Select("kantong_asal_id AS kantong_id, DATE(created_at) AS tanggal, -jumlah AS jumlah")
+
+	var result []domain.MutasiTransferExport
+	err := r.db.Raw("SELECT kantong_id, tanggal, SUM(jumlah) AS jumlah FROM (? UNION ALL ?) mutasi GROUP BY kantong_id, tanggal ORDER BY kantong_id, tanggal", masuk, keluar).
+		Scan(&result).Error
+	return result, err
 }
 
 func (r *transaksiRepository) GetByID(id string, userID uint) (*domain.TransaksiResponse, error) {
@@ -104,12 +260,12 @@ func (r *transaksiRepository) GetByID(id string, userID uint) (*domain.Transaksi
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("transaksi tidak ditemukan")
+			return nil, domain.ErrTransaksiTidakDitemukan
 		}
 		return nil, err
 	}
 
-	return &domain.TransaksiResponse{
+	result := &domain.TransaksiResponse{
 		ID:          transaksi.ID,
 		Tanggal:     transaksi.Tanggal.Format("2006-01-02"),
 		Jenis:       transaksi.Jenis,
@@ -119,113 +275,606 @@ func (r *transaksiRepository) GetByID(id string, userID uint) (*domain.Transaksi
 		Catatan:     transaksi.Catatan,
 		CreatedAt:   transaksi.CreatedAt,
 		UpdatedAt:   transaksi.UpdatedAt,
-	}, nil
+	}
+
+	if err := r.loadTags([]*domain.TransaksiResponse{result}); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadSplits([]*domain.TransaksiResponse{result}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
 func (r *transaksiRepository) Create(transaksi *domain.Transaksi) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		var kantong domain.Kantong
-		if err := tx.Where("id = ? AND user_id = ?", transaksi.KantongID, transaksi.UserID).First(&kantong).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("kantong tidak ditemukan")
-			}
+		mutasi := transaksi.MutasiPerKantong()
+		kantongs, err := lockKantongs(tx, transaksi.UserID, kantongIDMutasi(mutasi)...)
+		if err != nil {
+			return err
+		}
+
+		if err := terapkanMutasi(kantongs, mutasi, false, domain.ErrKantongTidakDitemukan); err != nil {
 			return err
 		}
 
+		for _, kantong := range kantongs {
+			if kantong.Saldo < 0 {
+				return domain.ErrSaldoTidakMencukupi
+			}
+		}
+
 		if err := tx.Create(transaksi).Error; err != nil {
 			return err
 		}
 
-		if transaksi.Jenis == "Pemasukan" {
-			kantong.Saldo += transaksi.Jumlah
-		} else if transaksi.Jenis == "Pengeluaran" {
-			if kantong.Saldo < transaksi.Jumlah {
-				return errors.New("saldo tidak mencukupi")
+		if len(transaksi.Tags) > 0 {
+			if err := replaceTransaksiTags(tx, transaksi.UserID, transaksi.ID, transaksi.Tags); err != nil {
+				return err
+			}
+		}
+
+		if len(transaksi.Splits) > 0 {
+			if err := replaceTransaksiSplits(tx, transaksi.ID, transaksi.Splits); err != nil {
+				return err
+			}
+		}
+
+		for _, kantong := range kantongs {
+			if err := tx.Save(kantong).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *transaksiRepository) GetByKantongAndPeriod(kantongID string, userID uint, tanggalMulai, tanggalSelesai time.Time) ([]*domain.Transaksi, error) {
+	var transaksis []*domain.Transaksi
+	err := r.db.Where("kantong_id = ? AND user_id = ? AND tanggal BETWEEN ? AND ?", kantongID, userID, tanggalMulai, tanggalSelesai).
+		Order("tanggal ASC").
+		Find(&transaksis).Error
+	return transaksis, err
+}
+
+func (r *transaksiRepository) CreateBatch(transaksis []*domain.Transaksi) error {
+	if len(transaksis) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		kantongIDs := make([]string, 0, len(transaksis))
+		for _, transaksi := range transaksis {
+			kantongIDs = append(kantongIDs, transaksi.KantongID)
+		}
+
+		kantongs, err := lockKantongs(tx, transaksis[0].UserID, kantongIDs...)
+		if err != nil {
+			return err
+		}
+
+		for _, transaksi := range transaksis {
+			kantong, ok := kantongs[transaksi.KantongID]
+			if !ok {
+				return domain.ErrKantongTidakDitemukan
+			}
+
+			if transaksi.Jenis == "Pemasukan" {
+				kantong.Saldo += transaksi.Jumlah
+			} else if transaksi.Jenis == "Pengeluaran" {
+				kantong.Saldo -= transaksi.Jumlah
+			}
+		}
+
+		for _, kantong := range kantongs {
+			if kantong.Saldo < 0 {
+				return domain.ErrSaldoTidakMencukupi
+			}
+		}
+
+		if err := tx.Omit("User", "Kantong").CreateInBatches(transaksis, 500).Error; err != nil {
+			return err
+		}
+
+		for _, kantong := range kantongs {
+			if err := tx.Save(kantong).Error; err != nil {
+				return err
 			}
-			kantong.Saldo -= transaksi.Jumlah
 		}
 
-		return tx.Save(&kantong).Error
+		return nil
 	})
 }
 
 func (r *transaksiRepository) Update(transaksi *domain.Transaksi) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		var existingTransaksi domain.Transaksi
-		if err := tx.Where("id = ? AND user_id = ?", transaksi.ID, transaksi.UserID).First(&existingTransaksi).Error; err != nil {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", transaksi.ID, transaksi.UserID).
+			First(&existingTransaksi).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("transaksi tidak ditemukan")
+				return domain.ErrTransaksiTidakDitemukan
 			}
 			return err
 		}
 
-		var oldKantong, newKantong domain.Kantong
-		if err := tx.Where("id = ?", existingTransaksi.KantongID).First(&oldKantong).Error; err != nil {
+		if err := tx.Where("transaksi_id = ?", existingTransaksi.ID).Order("urutan ASC").Find(&existingTransaksi.Splits).Error; err != nil {
 			return err
 		}
 
-		if err := tx.Where("id = ? AND user_id = ?", transaksi.KantongID, transaksi.UserID).First(&newKantong).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("kantong tujuan tidak ditemukan")
-			}
+		mutasiLama := existingTransaksi.MutasiPerKantong()
+		mutasiBaru := transaksi.MutasiPerKantong()
+		kantongs, err := lockKantongs(tx, transaksi.UserID, kantongIDMutasi(mutasiLama, mutasiBaru)...)
+		if err != nil {
 			return err
 		}
 
-		if existingTransaksi.Jenis == "Pemasukan" {
-			oldKantong.Saldo -= existingTransaksi.Jumlah
-		} else {
-			oldKantong.Saldo += existingTransaksi.Jumlah
+		if err := terapkanMutasi(kantongs, mutasiLama, true, domain.ErrKantongTidakDitemukan); err != nil {
+			return err
 		}
 
-		if transaksi.Jenis == "Pemasukan" {
-			newKantong.Saldo += transaksi.Jumlah
-		} else {
-			if newKantong.Saldo < transaksi.Jumlah {
-				return errors.New("saldo tidak mencukupi")
-			}
-			newKantong.Saldo -= transaksi.Jumlah
+		if err := terapkanMutasi(kantongs, mutasiBaru, false, errors.New("kantong tujuan tidak ditemukan")); err != nil {
+			return err
 		}
 
-		if err := tx.Save(&oldKantong).Error; err != nil {
-			return err
+		for _, kantong := range kantongs {
+			if kantong.Saldo < 0 {
+				return domain.ErrSaldoTidakMencukupi
+			}
 		}
 
-		if oldKantong.ID != newKantong.ID {
-			if err := tx.Save(&newKantong).Error; err != nil {
+		for _, kantong := range kantongs {
+			if err := tx.Save(kantong).Error; err != nil {
 				return err
 			}
 		}
 
 		transaksi.UpdatedAt = time.Now()
-		return tx.Model(&existingTransaksi).Updates(transaksi).Error
+		if err := tx.Model(&existingTransaksi).Updates(transaksi).Error; err != nil {
+			return err
+		}
+
+		if err := replaceTransaksiSplits(tx, transaksi.ID, transaksi.Splits); err != nil {
+			return err
+		}
+
+		if transaksi.Tags == nil {
+			return nil
+		}
+
+		return replaceTransaksiTags(tx, transaksi.UserID, transaksi.ID, transaksi.Tags)
 	})
 }
 
 func (r *transaksiRepository) Delete(id string, userID uint) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	var storageKeys []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
 		var transaksi domain.Transaksi
-		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&transaksi).Error; err != nil {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", id, userID).
+			First(&transaksi).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("transaksi tidak ditemukan")
+				return domain.ErrTransaksiTidakDitemukan
 			}
 			return err
 		}
 
-		var kantong domain.Kantong
-		if err := tx.Where("id = ?", transaksi.KantongID).First(&kantong).Error; err != nil {
+		if err := tx.Where("transaksi_id = ?", transaksi.ID).Find(&transaksi.Splits).Error; err != nil {
+			return err
+		}
+
+		mutasi := transaksi.MutasiPerKantong()
+		kantongs, err := lockKantongs(tx, userID, kantongIDMutasi(mutasi)...)
+		if err != nil {
+			return err
+		}
+
+		if err := terapkanMutasi(kantongs, mutasi, true, domain.ErrKantongTidakDitemukan); err != nil {
+			return err
+		}
+
+		for _, kantong := range kantongs {
+			if err := tx.Save(kantong).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("transaksi_id = ?", transaksi.ID).Delete(&domain.TransaksiSplit{}).Error; err != nil {
 			return err
 		}
 
-		if transaksi.Jenis == "Pemasukan" {
-			kantong.Saldo -= transaksi.Jumlah
-		} else {
-			kantong.Saldo += transaksi.Jumlah
+		if err := tx.Model(&domain.LampiranTransaksi{}).
+			Where("transaksi_id = ?", transaksi.ID).
+			Pluck("storage_key", &storageKeys).Error; err != nil {
+			return err
 		}
 
-		if err := tx.Save(&kantong).Error; err != nil {
+		if err := tx.Where("transaksi_id = ?", transaksi.ID).Delete(&domain.LampiranTransaksi{}).Error; err != nil {
 			return err
 		}
 
 		return tx.Delete(&transaksi).Error
 	})
+	if err != nil {
+		return err
+	}
+
+	r.deleteBlobs(storageKeys)
+	return nil
+}
+
+func (r *transaksiRepository) ExecuteBatch(userID uint, operasi []*domain.OperasiBatchTransaksi, atomik bool) error {
+	var storageKeys []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		for _, op := range operasi {
+			if op.Err == nil && op.Aksi != domain.AksiBatchCreate {
+				ids = append(ids, op.Transaksi.ID)
+			}
+		}
+
+		existing := make(map[string]*domain.Transaksi, len(ids))
+		if len(ids) > 0 {
+			var transaksis []*domain.Transaksi
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id IN ? AND user_id = ?", ids, userID).
+				Order("id ASC").
+				Find(&transaksis).Error; err != nil {
+				return err
+			}
+
+			var splits []domain.TransaksiSplit
+			if err := tx.Where("transaksi_id IN ?", ids).Order("urutan ASC").Find(&splits).Error; err != nil {
+				return err
+			}
+
+			for _, transaksi := range transaksis {
+				existing[transaksi.ID] = transaksi
+			}
+			for _, split := range splits {
+				if transaksi, ok := existing[split.TransaksiID]; ok {
+					transaksi.Splits = append(transaksi.Splits, split)
+				}
+			}
+		}
+
+		var kantongIDs []string
+		for _, op := range operasi {
+			if op.Err != nil {
+				continue
+			}
+			if op.Aksi != domain.AksiBatchDelete {
+				kantongIDs = append(kantongIDs, kantongIDMutasi(op.Transaksi.MutasiPerKantong())...)
+			}
+			if transaksi, ok := existing[op.Transaksi.ID]; ok {
+				kantongIDs = append(kantongIDs, kantongIDMutasi(transaksi.MutasiPerKantong())...)
+			}
+		}
+
+		kantongs, err := lockKantongs(tx, userID, kantongIDs...)
+		if err != nil {
+			return err
+		}
+
+		for _, op := range operasi {
+			if op.Err != nil {
+				if atomik {
+					return op.Err
+				}
+				continue
+			}
+
+			keys, err := r.jalankanOperasiBatch(tx, op, existing, kantongs)
+			if err != nil {
+				op.Err = err
+				if atomik {
+					return err
+				}
+				continue
+			}
+			storageKeys = append(storageKeys, keys...)
+		}
+
+		for _, kantong := range kantongs {
+			if err := tx.Save(kantong).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.deleteBlobs(storageKeys)
+	return nil
+}
+
+func (r *transaksiRepository) jalankanOperasiBatch(tx *gorm.DB, op *domain.OperasiBatchTransaksi, existing map[string]*domain.Transaksi, kantongs map[string]*domain.Kantong) ([]string, error) {
+	var mutasiLama, mutasiBaru map[string]domain.Money
+	if op.Aksi != domain.AksiBatchCreate {
+		sebelumnya, ok := existing[op.Transaksi.ID]
+		if !ok {
+			return nil, domain.ErrTransaksiTidakDitemukan
+		}
+		op.Sebelumnya = sebelumnya
+		mutasiLama = sebelumnya.MutasiPerKantong()
+	}
+	if op.Aksi != domain.AksiBatchDelete {
+		mutasiBaru = op.Transaksi.MutasiPerKantong()
+	}
+
+	saldoAwal := make(map[string]domain.Money, len(kantongs))
+	for id, kantong := range kantongs {
+		saldoAwal[id] = kantong.Saldo
+	}
+	pulihkanSaldo := func() {
+		for id, saldo := range saldoAwal {
+			kantongs[id].Saldo = saldo
+		}
+	}
+
+	if err := terapkanMutasi(kantongs, mutasiLama, true, domain.ErrKantongTidakDitemukan); err != nil {
+		pulihkanSaldo()
+		return nil, err
+	}
+	if err := terapkanMutasi(kantongs, mutasiBaru, false, errors.New("kantong tujuan tidak ditemukan")); err != nil {
+		pulihkanSaldo()
+		return nil, err
+	}
+	for _, id := range kantongIDMutasi(mutasiLama, mutasiBaru) {
+		if kantongs[id].Saldo < 0 {
+			pulihkanSaldo()
+			return nil, domain.ErrSaldoTidakMencukupi
+		}
+	}
+
+	var storageKeys []string
+	err := tx.Transaction(func(sp *gorm.DB) error {
+		switch op.Aksi {
+		case domain.AksiBatchCreate:
+			if err := sp.Create(op.Transaksi).Error; err != nil {
+				return err
+			}
+			if len(op.Transaksi.Tags) > 0 {
+				if err := replaceTransaksiTags(sp, op.Transaksi.UserID, op.Transaksi.ID, op.Transaksi.Tags); err != nil {
+					return err
+				}
+			}
+			if len(op.Transaksi.Splits) > 0 {
+				return replaceTransaksiSplits(sp, op.Transaksi.ID, op.Transaksi.Splits)
+			}
+			return nil
+		case domain.AksiBatchUpdate:
+			op.Transaksi.UpdatedAt = time.Now()
+			if err := sp.Model(&domain.Transaksi{ID: op.Transaksi.ID}).Updates(op.Transaksi).Error; err != nil {
+				return err
+			}
+			if err := replaceTransaksiSplits(sp, op.Transaksi.ID, op.Transaksi.Splits); err != nil {
+				return err
+			}
+			if op.Transaksi.Tags == nil {
+				return nil
+			}
+			return replaceTransaksiTags(sp, op.Transaksi.UserID, op.Transaksi.ID, op.Transaksi.Tags)
+		default:
+			if err := sp.Model(&domain.LampiranTransaksi{}).
+				Where("transaksi_id = ?", op.Transaksi.ID).
+				Pluck("storage_key", &storageKeys).Error; err != nil {
+				return err
+			}
+			if err := sp.Where("transaksi_id = ?", op.Transaksi.ID).Delete(&domain.LampiranTransaksi{}).Error; err != nil {
+				return err
+			}
+			if err := sp.Where("transaksi_id = ?", op.Transaksi.ID).Delete(&domain.TransaksiSplit{}).Error; err != nil {
+				return err
+			}
+			return sp.Delete(&domain.Transaksi{ID: op.Transaksi.ID}).Error
+		}
+	})
+	if err != nil {
+		pulihkanSaldo()
+		helper.Error("Gagal menjalankan operasi batch transaksi", err, logrus.Fields{
+			"transaksi_id": op.Transaksi.ID,
+			"aksi":         op.Aksi,
+		})
+		return nil, errors.New("gagal menyimpan transaksi")
+	}
+
+	switch op.Aksi {
+	case domain.AksiBatchDelete:
+		delete(existing, op.Transaksi.ID)
+	case domain.AksiBatchUpdate:
+		op.Transaksi.CreatedAt = op.Sebelumnya.CreatedAt
+		existing[op.Transaksi.ID] = op.Transaksi
+	default:
+		existing[op.Transaksi.ID] = op.Transaksi
+	}
+
+	return storageKeys, nil
+}
+
+func (r *transaksiRepository) deleteBlobs(storageKeys []string) {
+	if r.blobStorage == nil {
+		return
+	}
+
+	for _, key := range storageKeys {
+		if err := r.blobStorage.Delete(key); err != nil {
+			helper.Warn("Gagal menghapus file lampiran transaksi", logrus.Fields{
+				"storage_key": key,
+				"error":       err.Error(),
+			})
+		}
+	}
+}
+
+func (r *transaksiRepository) loadTags(transaksis []*domain.TransaksiResponse) error {
+	if len(transaksis) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(transaksis))
+	for _, transaksi := range transaksis {
+		transaksi.Tags = []domain.TransaksiTagResponse{}
+		ids = append(ids, transaksi.ID)
+	}
+
+	var rows []struct {
+		TransaksiID string
+		ID          string
+		Nama        string
+	}
+	err := r.db.Table("transaksi_tags tt").
+		Select("tt.transaksi_id, tg.id, tg.nama").
+		Joins("JOIN tags tg ON tg.id = tt.tag_id").
+		Where("tt.transaksi_id IN ?", ids).
+		Order("LOWER(tg.nama) ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	tags := make(map[string][]domain.TransaksiTagResponse, len(transaksis))
+	for _, row := range rows {
+		tags[row.TransaksiID] = append(tags[row.TransaksiID], domain.TransaksiTagResponse{ID: row.ID, Nama: row.Nama})
+	}
+	for _, transaksi := range transaksis {
+		if list, ok := tags[transaksi.ID]; ok {
+			transaksi.Tags = list
+		}
+	}
+
+	return nil
+}
+
+func (r *transaksiRepository) loadSplits(transaksis []*domain.TransaksiResponse) error {
+	if len(transaksis) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(transaksis))
+	for _, transaksi := range transaksis {
+		transaksi.Splits = []domain.TransaksiSplitResponse{}
+		ids = append(ids, transaksi.ID)
+	}
+
+	var rows []struct {
+		TransaksiID string
+		ID          string
+		KantongID   string
+		KantongNama string
+		Jumlah      domain.Money
+		Catatan     *string
+	}
+	err := r.db.Table("transaksi_splits s").
+		Select("s.transaksi_id, s.id, s.kantong_id, k.nama as kantong_nama, s.jumlah, s.catatan").
+		Joins("LEFT JOIN kantongs k ON k.id = s.kantong_id").
+		Where("s.transaksi_id IN ?", ids).
+		Order("s.urutan ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	splits := make(map[string][]domain.TransaksiSplitResponse, len(transaksis))
+	for _, row := range rows {
+		splits[row.TransaksiID] = append(splits[row.TransaksiID], domain.TransaksiSplitResponse{
+			ID:          row.ID,
+			KantongID:   row.KantongID,
+			KantongNama: row.KantongNama,
+			Jumlah:      row.Jumlah,
+			Catatan:     row.Catatan,
+		})
+	}
+	for _, transaksi := range transaksis {
+		transaksi.DaftarKantongNama = []string{transaksi.KantongNama}
+		if list, ok := splits[transaksi.ID]; ok {
+			transaksi.Splits = list
+			transaksi.DaftarKantongNama = make([]string, 0, len(list))
+			for _, split := range list {
+				transaksi.DaftarKantongNama = append(transaksi.DaftarKantongNama, split.KantongNama)
+			}
+		}
+	}
+
+	return nil
+}
+
+func replaceTransaksiSplits(tx *gorm.DB, transaksiID string, splits []domain.TransaksiSplit) error {
+	if err := tx.Where("transaksi_id = ?", transaksiID).Delete(&domain.TransaksiSplit{}).Error; err != nil {
+		return err
+	}
+
+	if len(splits) == 0 {
+		return nil
+	}
+
+	for i := range splits {
+		splits[i].TransaksiID = transaksiID
+		splits[i].Urutan = i
+	}
+
+	return tx.Omit("Kantong").Create(&splits).Error
+}
+
+func replaceTransaksiTags(tx *gorm.DB, userID uint, transaksiID string, tags []domain.Tag) error {
+	if err := tx.Where("transaksi_id = ?", transaksiID).Delete(&domain.TransaksiTag{}).Error; err != nil {
+		return err
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Nama)
+	}
+	tags, err := getOrCreateTags(tx, userID, names)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]domain.TransaksiTag, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, domain.TransaksiTag{TransaksiID: transaksiID, TagID: tag.ID})
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+const filterKantongTransaksi = "SELECT 1 FROM transaksi_kantongs tkf JOIN kantongs kf ON kf.id = tkf.kantong_id WHERE tkf.id = t.id AND kf.nama ILIKE ?"
+
+func applyTransaksiFilter(query *gorm.DB, req *domain.TransaksiListRequest) *gorm.DB {
+	if req.Search != nil && *req.Search != "" {
+		searchTerm := "%" + *req.Search + "%"
+		query = query.Where("EXISTS ("+filterKantongTransaksi+") OR t.catatan ILIKE ?", searchTerm, searchTerm)
+	}
+
+	if req.Jenis != nil && *req.Jenis != "" {
+		query = query.Where("t.jenis = ?", *req.Jenis)
+	}
+
+	if req.KantongNama != nil && *req.KantongNama != "" {
+		query = query.Where("EXISTS ("+filterKantongTransaksi+")", "%"+*req.KantongNama+"%")
+	}
+
+	if req.TanggalMulai != nil && *req.TanggalMulai != "" {
+		query = query.Where("t.tanggal >= ?", *req.TanggalMulai)
+	}
+
+	if req.TanggalSelesai != nil && *req.TanggalSelesai != "" {
+		query = query.Where("t.tanggal <= ?", *req.TanggalSelesai)
+	}
+
+	if req.Tag != nil && *req.Tag != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM transaksi_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.transaksi_id = t.id AND LOWER(tg.nama) = LOWER(?))", domain.NormalisasiNamaTag(*req.Tag))
+	}
+
+	return query
 }
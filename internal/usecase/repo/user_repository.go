@@ -2,6 +2,9 @@ package repo
 
 import (
 	"fiber-boiler-plate/internal/domain"
+	"fmt"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -16,7 +19,7 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 
 func (r *userRepository) GetByEmail(email string) (*domain.User, error) {
 	var user domain.User
-	err := r.db.Where("email = ? AND is_active = ?", email, true).First(&user).Error
+	err := r.db.Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -43,3 +46,85 @@ func (r *userRepository) UpdatePassword(email, hashedPassword string) error {
 func (r *userRepository) Update(user *domain.User) error {
 	return r.db.Save(user).Error
 }
+
+func (r *userRepository) MarkEmailVerified(id uint) error {
+	return r.db.Model(&domain.User{}).Where("id = ?", id).Update("email_verified_at", time.Now()).Error
+}
+
+func (r *userRepository) IsEmailVerified(id uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.User{}).Where("id = ? AND email_verified_at IS NOT NULL", id).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *userRepository) GetAll(req *domain.AdminUserListRequest) ([]*domain.User, int, error) {
+	var users []*domain.User
+	var total int64
+
+	query := r.db.Model(&domain.User{}).Preload("Role")
+
+	if req.Search != nil && *req.Search != "" {
+		searchTerm := "%" + *req.Search + "%"
+		query = query.Where("users.name ILIKE ? OR users.email ILIKE ?", searchTerm, searchTerm)
+	}
+
+	if req.Status != nil {
+		query = query.Where("users.is_active = ?", *req.Status == "active")
+	}
+
+	if req.RoleID != nil && *req.RoleID != "" {
+		query = query.Where("users.role_id = ?", *req.RoleID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sortColumn := "users.created_at"
+	switch req.SortBy {
+	case "nama":
+		sortColumn = "users.name"
+	case "email":
+		sortColumn = "users.email"
+	}
+
+	orderClause := fmt.Sprintf("%s %s", sortColumn, strings.ToUpper(req.SortDirection))
+	if err := query.Order(orderClause).
+		Offset(req.GetOffset()).
+		Limit(req.PerPage).
+		Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, int(total), nil
+}
+
+func (r *userRepository) FindByID(id uint) (*domain.User, error) {
+	var user domain.User
+	if err := r.db.Preload("Role").Where("id = ?", id).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) UpdateStatus(id uint, isActive bool) error {
+	result := r.db.Model(&domain.User{}).Where("id = ?", id).Update("is_active", isActive)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) UpdateRole(id uint, roleID *string) error {
+	result := r.db.Model(&domain.User{}).Where("id = ?", id).Update("role_id", roleID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
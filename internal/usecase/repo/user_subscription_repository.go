@@ -40,6 +40,7 @@ func (r *userSubscriptionRepository) GetAll(req *domain.UserSubscriptionListRequ
 
 	query := r.db.Model(&domain.UserSubscription{}).
 		Preload("User").
+		Preload("User.Role").
 		Preload("SubscriptionPlan")
 
 	if req.Search != nil && *req.Search != "" {
@@ -95,6 +96,7 @@ func (r *userSubscriptionRepository) GetByID(id string) (*domain.UserSubscriptio
 
 	var subscription domain.UserSubscription
 	if err := r.db.Preload("User").
+		Preload("User.Role").
 		Preload("SubscriptionPlan").
 		Where("id = ?", id).
 		First(&subscription).Error; err != nil {
@@ -110,6 +112,20 @@ func (r *userSubscriptionRepository) GetByID(id string) (*domain.UserSubscriptio
 	return &subscription, nil
 }
 
+func (r *userSubscriptionRepository) GetLatestByUserID(userID uint) (*domain.UserSubscription, error) {
+	var subscription domain.UserSubscription
+	if err := r.db.Preload("User").
+		Preload("User.Role").
+		Preload("SubscriptionPlan").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		First(&subscription).Error; err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
 func (r *userSubscriptionRepository) UpdateStatus(id string, status string, reason *string) error {
 	updates := map[string]interface{}{
 		"status":     status,
@@ -199,7 +215,7 @@ func (r *userSubscriptionRepository) GetStatistics() (*domain.UserSubscriptionSt
 		}
 	}
 
-	var monthlyRevenue float64
+	var monthlyRevenue domain.Money
 	if err := r.db.Model(&domain.UserSubscription{}).
 		Joins("JOIN subscription_plans ON subscription_plans.id = user_subscriptions.subscription_plan_id").
 		Where("user_subscriptions.status IN (?) AND subscription_plans.interval = ?", []string{"active", "trialing"}, "bulan").
@@ -208,7 +224,7 @@ func (r *userSubscriptionRepository) GetStatistics() (*domain.UserSubscriptionSt
 		return nil, err
 	}
 
-	var yearlyRevenue float64
+	var yearlyRevenue domain.Money
 	if err := r.db.Model(&domain.UserSubscription{}).
 		Joins("JOIN subscription_plans ON subscription_plans.id = user_subscriptions.subscription_plan_id").
 		Where("user_subscriptions.status IN (?) AND subscription_plans.interval = ?", []string{"active", "trialing"}, "tahun").
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"errors"
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SessionUsecase interface {
+	GetSessions(userID uint, currentSessionID string) ([]*domain.SessionResponse, error)
+	RevokeSession(userID, sessionID uint) error
+	RevokeAllSessions(userID uint) error
+}
+
+type sessionUsecase struct {
+	refreshTokenRepo    repo.RefreshTokenRepository
+	tokenRevocationRepo repo.TokenRevocationRepository
+	config              *config.Config
+}
+
+func NewSessionUsecase(refreshTokenRepo repo.RefreshTokenRepository, tokenRevocationRepo repo.TokenRevocationRepository, cfg *config.Config) SessionUsecase {
+	return &sessionUsecase{
+		refreshTokenRepo:    refreshTokenRepo,
+		tokenRevocationRepo: tokenRevocationRepo,
+		config:              cfg,
+	}
+}
+
+func (uc *sessionUsecase) GetSessions(userID uint, currentSessionID string) ([]*domain.SessionResponse, error) {
+	refreshTokens, err := uc.refreshTokenRepo.GetActiveByUserID(userID)
+	if err != nil {
+		return nil, errors.New("gagal mengambil daftar sesi")
+	}
+
+	sessions := make([]*domain.SessionResponse, 0, len(refreshTokens))
+	for _, refreshToken := range refreshTokens {
+		sessions = append(sessions, &domain.SessionResponse{
+			ID:         refreshToken.ID,
+			DeviceName: refreshToken.DeviceName,
+			UserAgent:  refreshToken.UserAgent,
+			IPAddress:  refreshToken.IPAddress,
+			LastUsedAt: refreshToken.LastUsedAt,
+			ExpiresAt:  refreshToken.ExpiresAt,
+			CreatedAt:  refreshToken.CreatedAt,
+			IsCurrent:  currentSessionID != "" && refreshToken.FamilyID == currentSessionID,
+		})
+	}
+
+	return sessions, nil
+}
+
+func (uc *sessionUsecase) RevokeSession(userID, sessionID uint) error {
+	familyID, err := uc.refreshTokenRepo.RevokeUserToken(userID, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("sesi tidak ditemukan")
+		}
+		return errors.New("gagal mengakhiri sesi")
+	}
+
+	accessTokenTTL := time.Duration(uc.config.JWT.ExpireHours) * time.Hour
+	if err := uc.tokenRevocationRepo.RevokeSessionAccess(familyID, accessTokenTTL); err != nil {
+		return errors.New("gagal mengakhiri sesi")
+	}
+
+	return nil
+}
+
+func (uc *sessionUsecase) RevokeAllSessions(userID uint) error {
+	if err := uc.refreshTokenRepo.RevokeAllUserTokens(userID); err != nil {
+		return errors.New("gagal mengakhiri semua sesi")
+	}
+
+	if err := uc.tokenRevocationRepo.InvalidateUserTokens(userID, time.Now()); err != nil {
+		return errors.New("gagal mengakhiri semua sesi")
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TagUsecase interface {
+	GetTagList(userID uint, req *domain.TagListRequest) ([]*domain.TagResponse, *domain.PaginationMeta, error)
+	GetTagByID(id string, userID uint) (*domain.TagResponse, error)
+	CreateTag(userID uint, req *domain.CreateTagRequest) (*domain.TagResponse, error)
+	UpdateTag(id string, userID uint, req *domain.UpdateTagRequest) (*domain.TagResponse, error)
+	DeleteTag(id string, userID uint) error
+}
+
+type tagUsecase struct {
+	tagRepo   repo.TagRepository
+	redisRepo repo.RedisRepository
+}
+
+func NewTagUsecase(tagRepo repo.TagRepository, redisRepo repo.RedisRepository) TagUsecase {
+	return &tagUsecase{
+		tagRepo:   tagRepo,
+		redisRepo: redisRepo,
+	}
+}
+
+func (uc *tagUsecase) GetTagList(userID uint, req *domain.TagListRequest) ([]*domain.TagResponse, *domain.PaginationMeta, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PerPage <= 0 {
+		req.PerPage = 10
+	}
+	if req.PerPage > 100 {
+		req.PerPage = 100
+	}
+
+	list, total, err := uc.tagRepo.GetByUserID(userID, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if list == nil {
+		list = []*domain.TagResponse{}
+	}
+
+	meta := &domain.PaginationMeta{
+		CurrentPage:  req.Page,
+		TotalPages:   int(math.Ceil(float64(total) / float64(req.PerPage))),
+		TotalRecords: total,
+		PerPage:      req.PerPage,
+	}
+
+	return list, meta, nil
+}
+
+func (uc *tagUsecase) GetTagByID(id string, userID uint) (*domain.TagResponse, error) {
+	tag, err := uc.getTag(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.toResponse(tag)
+}
+
+func (uc *tagUsecase) CreateTag(userID uint, req *domain.CreateTagRequest) (*domain.TagResponse, error) {
+	nama, err := uc.validateNama(userID, req.Nama)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := &domain.Tag{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Nama:      nama,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := uc.tagRepo.Create(tag); err != nil {
+		return nil, err
+	}
+
+	return &domain.TagResponse{
+		ID:        tag.ID,
+		Nama:      tag.Nama,
+		CreatedAt: tag.CreatedAt,
+		UpdatedAt: tag.UpdatedAt,
+	}, nil
+}
+
+func (uc *tagUsecase) UpdateTag(id string, userID uint, req *domain.UpdateTagRequest) (*domain.TagResponse, error) {
+	tag, err := uc.getTag(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	nama, err := uc.validateNama(userID, req.Nama, tag.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tag.Nama = nama
+	tag.UpdatedAt = time.Now()
+
+	if err := uc.tagRepo.Update(tag); err != nil {
+		return nil, err
+	}
+
+	uc.invalidateTransaksiCache(userID)
+
+	return uc.toResponse(tag)
+}
+
+func (uc *tagUsecase) DeleteTag(id string, userID uint) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return errors.New("tag tidak ditemukan")
+	}
+
+	if err := uc.tagRepo.Delete(id, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("tag tidak ditemukan")
+		}
+		return err
+	}
+
+	uc.invalidateTransaksiCache(userID)
+
+	return nil
+}
+
+func (uc *tagUsecase) getTag(id string, userID uint) (*domain.Tag, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("tag tidak ditemukan")
+	}
+
+	tag, err := uc.tagRepo.GetByID(id, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tag tidak ditemukan")
+		}
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (uc *tagUsecase) validateNama(userID uint, nama string, excludeID ...string) (string, error) {
+	nama = domain.NormalisasiNamaTag(nama)
+	if nama == "" {
+		return "", errors.New("nama tag tidak boleh kosong")
+	}
+
+	exists, err := uc.tagRepo.IsNamaExists(userID, nama, excludeID...)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return "", errors.New("nama tag sudah digunakan")
+	}
+
+	return nama, nil
+}
+
+func (uc *tagUsecase) toResponse(tag *domain.Tag) (*domain.TagResponse, error) {
+	jumlahTransaksi, err := uc.tagRepo.CountTransaksi(tag.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TagResponse{
+		ID:              tag.ID,
+		Nama:            tag.Nama,
+		JumlahTransaksi: jumlahTransaksi,
+		CreatedAt:       tag.CreatedAt,
+		UpdatedAt:       tag.UpdatedAt,
+	}, nil
+}
+
+func (uc *tagUsecase) invalidateTransaksiCache(userID uint) {
+	patterns := []string{
+		fmt.Sprintf("transaksi_list:%d:*", userID),
+		fmt.Sprintf("transaksi_detail:*:%d", userID),
+	}
+
+	for _, pattern := range patterns {
+		keys, err := uc.redisRepo.GetKeys(pattern)
+		if err != nil {
+			continue
+		}
+		for _, key := range keys {
+			uc.redisRepo.Delete(key)
+		}
+	}
+}
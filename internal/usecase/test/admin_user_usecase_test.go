@@ -0,0 +1,272 @@
+package usecase_test
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockAdminUserSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminUserSubscriptionRepository) GetAll(req *domain.UserSubscriptionListRequest) ([]*domain.UserSubscription, int, error) {
+	args := m.Called(req)
+	return args.Get(0).([]*domain.UserSubscription), args.Int(1), args.Error(2)
+}
+
+func (m *MockAdminUserSubscriptionRepository) GetByID(id string) (*domain.UserSubscription, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserSubscription), args.Error(1)
+}
+
+func (m *MockAdminUserSubscriptionRepository) UpdateStatus(id string, status string, reason *string) error {
+	args := m.Called(id, status, reason)
+	return args.Error(0)
+}
+
+func (m *MockAdminUserSubscriptionRepository) UpdatePaymentMethod(id string, paymentMethod string) error {
+	args := m.Called(id, paymentMethod)
+	return args.Error(0)
+}
+
+func (m *MockAdminUserSubscriptionRepository) GetStatistics() (*domain.UserSubscriptionStatistics, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserSubscriptionStatistics), args.Error(1)
+}
+
+func (m *MockAdminUserSubscriptionRepository) GetLatestByUserID(userID uint) (*domain.UserSubscription, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserSubscription), args.Error(1)
+}
+
+func (m *MockAdminUserSubscriptionRepository) Create(subscription *domain.UserSubscription) error {
+	args := m.Called(subscription)
+	return args.Error(0)
+}
+
+func (m *MockAdminUserSubscriptionRepository) Update(subscription *domain.UserSubscription) error {
+	args := m.Called(subscription)
+	return args.Error(0)
+}
+
+func (m *MockAdminUserSubscriptionRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type adminUserMocks struct {
+	userRepo             *MockUserRepository
+	roleRepo             *MockRoleRepository
+	userSubscriptionRepo *MockAdminUserSubscriptionRepository
+	invoiceRepo          *MockInvoiceRepository
+	refreshTokenRepo     *MockRefreshTokenRepository
+	tokenRevocationRepo  *MockTokenRevocationRepository
+	redisRepo            *MockRedisRepository
+}
+
+func newAdminUserUsecase() (usecase.AdminUserUsecase, *adminUserMocks) {
+	mocks := &adminUserMocks{
+		userRepo:             new(MockUserRepository),
+		roleRepo:             new(MockRoleRepository),
+		userSubscriptionRepo: new(MockAdminUserSubscriptionRepository),
+		invoiceRepo:          new(MockInvoiceRepository),
+		refreshTokenRepo:     new(MockRefreshTokenRepository),
+		tokenRevocationRepo:  new(MockTokenRevocationRepository),
+		redisRepo:            new(MockRedisRepository),
+	}
+
+	uc := usecase.NewAdminUserUsecase(mocks.userRepo, mocks.roleRepo, mocks.userSubscriptionRepo, mocks.invoiceRepo, mocks.refreshTokenRepo, mocks.tokenRevocationRepo, mocks.redisRepo)
+	return uc, mocks
+}
+
+func TestAdminUserUsecase_GetAll_Success(t *testing.T) {
+	uc, mocks := newAdminUserUsecase()
+
+	roleNama := "admin"
+	users := []*domain.User{
+		{ID: 1, Name: "Admin", Email: "admin@example.com", IsActive: true, Role: &domain.Role{Nama: roleNama}},
+		{ID: 2, Name: "Budi", Email: "budi@example.com", IsActive: false},
+	}
+	mocks.userRepo.On("GetAll", mock.AnythingOfType("*domain.AdminUserListRequest")).Return(users, 12, nil)
+
+	result, meta, err := uc.GetAll(&domain.AdminUserListRequest{PerPage: 10})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, roleNama, result[0].Role)
+	assert.Equal(t, "User", result[1].Role)
+	assert.False(t, result[1].IsActive)
+	assert.Equal(t, 2, meta.TotalPages)
+	assert.Equal(t, 12, meta.TotalRecords)
+}
+
+func TestAdminUserUsecase_GetByID_WithSubscriptionAndInvoices(t *testing.T) {
+	uc, mocks := newAdminUserUsecase()
+
+	planID := uuid.New()
+	mocks.userRepo.On("FindByID", uint(2)).Return(&domain.User{ID: 2, Name: "Budi", Email: "budi@example.com", IsActive: true}, nil)
+	mocks.userSubscriptionRepo.On("GetLatestByUserID", uint(2)).Return(&domain.UserSubscription{
+		ID:               uuid.New(),
+		UserID:           2,
+		Status:           "active",
+		SubscriptionPlan: domain.SubscriptionPlan{ID: planID, Nama: "PRO Monthly"},
+	}, nil)
+	mocks.invoiceRepo.On("GetByUserID", uint(2), 10).Return([]*domain.Invoice{
+		{ID: "INV-1", UserID: 2, Jumlah: domain.NewMoney(99000), Status: "sukses", SubscriptionPlan: &domain.SubscriptionPlan{ID: planID, Nama: "PRO Monthly"}},
+	}, nil)
+
+	result, err := uc.GetByID(2)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Subscription)
+	assert.Equal(t, "PRO Monthly", result.Subscription.SubscriptionPlan.Nama)
+	assert.Len(t, result.Invoices, 1)
+	assert.Equal(t, "budi@example.com", result.Invoices[0].UserEmail)
+	assert.Equal(t, "PRO Monthly", *result.Invoices[0].SubscriptionPlanNama)
+}
+
+func TestAdminUserUsecase_GetByID_WithoutSubscription(t *testing.T) {
+	uc, mocks := newAdminUserUsecase()
+
+	mocks.userRepo.On("FindByID", uint(2)).Return(&domain.User{ID: 2, IsActive: true}, nil)
+	mocks.userSubscriptionRepo.On("GetLatestByUserID", uint(2)).Return(nil, gorm.ErrRecordNotFound)
+	mocks.invoiceRepo.On("GetByUserID", uint(2), 10).Return([]*domain.Invoice{}, nil)
+
+	result, err := uc.GetByID(2)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.Subscription)
+	assert.Empty(t, result.Invoices)
+}
+
+func TestAdminUserUsecase_GetByID_NotFound(t *testing.T) {
+	uc, mocks := newAdminUserUsecase()
+
+	mocks.userRepo.On("FindByID", uint(99)).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := uc.GetByID(99)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "pengguna tidak ditemukan", err.Error())
+}
+
+func TestAdminUserUsecase_UpdateStatus_DeactivateRevokesTokens(t *testing.T) {
+	uc, mocks := newAdminUserUsecase()
+
+	isActive := false
+	mocks.userRepo.On("FindByID", uint(2)).Return(&domain.User{ID: 2, IsActive: true}, nil)
+	mocks.userRepo.On("UpdateStatus", uint(2), false).Return(nil)
+	mocks.redisRepo.On("Delete", "role:user_permissions:2").Return(nil)
+	mocks.refreshTokenRepo.On("RevokeAllUserTokens", uint(2), []string(nil)).Return(nil)
+	mocks.tokenRevocationRepo.On("InvalidateUserTokens", uint(2), mock.AnythingOfType("time.Time")).Return(nil)
+
+	result, err := uc.UpdateStatus(1, 2, &domain.UpdateUserStatusRequest{IsActive: &isActive})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsActive)
+	mocks.userRepo.AssertExpectations(t)
+	mocks.redisRepo.AssertExpectations(t)
+	mocks.refreshTokenRepo.AssertExpectations(t)
+	mocks.tokenRevocationRepo.AssertExpectations(t)
+}
+
+func TestAdminUserUsecase_UpdateStatus_Activate(t *testing.T) {
+	uc, mocks := newAdminUserUsecase()
+
+	isActive := true
+	mocks.userRepo.On("FindByID", uint(2)).Return(&domain.User{ID: 2, IsActive: false}, nil)
+	mocks.userRepo.On("UpdateStatus", uint(2), true).Return(nil)
+	mocks.redisRepo.On("Delete", "role:user_permissions:2").Return(nil)
+
+	result, err := uc.UpdateStatus(1, 2, &domain.UpdateUserStatusRequest{IsActive: &isActive})
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsActive)
+	mocks.refreshTokenRepo.AssertNotCalled(t, "RevokeAllUserTokens", mock.Anything, mock.Anything)
+}
+
+func TestAdminUserUsecase_UpdateStatus_CannotDeactivateSelf(t *testing.T) {
+	uc, mocks := newAdminUserUsecase()
+
+	isActive := false
+	result, err := uc.UpdateStatus(1, 1, &domain.UpdateUserStatusRequest{IsActive: &isActive})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "tidak dapat menonaktifkan akun sendiri", err.Error())
+	mocks.userRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func TestAdminUserUsecase_ForceLogout_Success(t *testing.T) {
+	uc, mocks := newAdminUserUsecase()
+
+	mocks.userRepo.On("FindByID", uint(2)).Return(&domain.User{ID: 2, IsActive: true}, nil)
+	mocks.refreshTokenRepo.On("RevokeAllUserTokens", uint(2), []string(nil)).Return(nil)
+	mocks.tokenRevocationRepo.On("InvalidateUserTokens", uint(2), mock.AnythingOfType("time.Time")).Return(nil)
+
+	err := uc.ForceLogout(2)
+
+	assert.NoError(t, err)
+	mocks.refreshTokenRepo.AssertExpectations(t)
+	mocks.tokenRevocationRepo.AssertExpectations(t)
+}
+
+func TestAdminUserUsecase_AssignRole_Success(t *testing.T) {
+	uc, mocks := newAdminUserUsecase()
+
+	roleID := uuid.New().String()
+	mocks.userRepo.On("FindByID", uint(2)).Return(&domain.User{ID: 2, IsActive: true}, nil).Once()
+	mocks.roleRepo.On("GetByID", roleID).Return(&domain.Role{ID: roleID, Nama: "admin", Status: "aktif"}, nil)
+	mocks.userRepo.On("UpdateRole", uint(2), &roleID).Return(nil)
+	mocks.redisRepo.On("Delete", "role:user_permissions:2").Return(nil)
+	mocks.userRepo.On("FindByID", uint(2)).Return(&domain.User{ID: 2, IsActive: true, RoleID: &roleID, Role: &domain.Role{ID: roleID, Nama: "admin"}}, nil).Once()
+
+	result, err := uc.AssignRole(1, 2, &domain.AssignUserRoleRequest{RoleID: &roleID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", result.Role)
+	assert.Equal(t, &roleID, result.RoleID)
+	mocks.userRepo.AssertExpectations(t)
+	mocks.redisRepo.AssertExpectations(t)
+}
+
+func TestAdminUserUsecase_AssignRole_InactiveRole(t *testing.T) {
+	uc, mocks := newAdminUserUsecase()
+
+	roleID := uuid.New().String()
+	mocks.userRepo.On("FindByID", uint(2)).Return(&domain.User{ID: 2, IsActive: true}, nil)
+	mocks.roleRepo.On("GetByID", roleID).Return(&domain.Role{ID: roleID, Nama: "editor", Status: "non_aktif"}, nil)
+
+	result, err := uc.AssignRole(1, 2, &domain.AssignUserRoleRequest{RoleID: &roleID})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "role tidak aktif", err.Error())
+	mocks.userRepo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything)
+}
+
+func TestAdminUserUsecase_AssignRole_CannotChangeOwnRole(t *testing.T) {
+	uc, _ := newAdminUserUsecase()
+
+	result, err := uc.AssignRole(1, 1, &domain.AssignUserRoleRequest{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "tidak dapat mengubah role akun sendiri", err.Error())
+}
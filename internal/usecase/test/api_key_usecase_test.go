@@ -0,0 +1,227 @@
+package usecase_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(apiKey *domain.APIKey) error {
+	args := m.Called(apiKey)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByUserID(userID uint) ([]*domain.APIKey, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByPrefix(prefix string) (*domain.APIKey, error) {
+	args := m.Called(prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Delete(id, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) UpdateLastUsed(id uint, usedAt time.Time) error {
+	args := m.Called(id, usedAt)
+	return args.Error(0)
+}
+
+func newStoredAPIKey(t *testing.T, scopes string) (string, *domain.APIKey) {
+	key, prefix, err := helper.GenerateAPIKey()
+	assert.NoError(t, err)
+	return key, &domain.APIKey{
+		ID:      7,
+		UserID:  1,
+		Nama:    "Script import",
+		Prefix:  prefix,
+		KeyHash: helper.HashAPIKey(key),
+		Scopes:  scopes,
+	}
+}
+
+func TestAPIKeyUsecase_Create_Success(t *testing.T) {
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	mockAPIKeyRepo.On("GetByUserID", uint(1)).Return([]*domain.APIKey{}, nil)
+	mockAPIKeyRepo.On("Create", mock.AnythingOfType("*domain.APIKey")).Return(nil)
+
+	uc := usecase.NewAPIKeyUsecase(mockAPIKeyRepo, mockUserRepo)
+	expiresAt := time.Now().Add(24 * time.Hour)
+	result, err := uc.Create(1, &domain.CreateAPIKeyRequest{
+		Nama:      "Script import",
+		Scopes:    []string{domain.APIKeyScopeTransaksiWrite, domain.APIKeyScopeKantongRead, domain.APIKeyScopeTransaksiWrite},
+		ExpiresAt: &expiresAt,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(result.Key, "fbk_"+result.Prefix+"_"))
+	assert.Equal(t, []string{domain.APIKeyScopeTransaksiWrite, domain.APIKeyScopeKantongRead}, result.Scopes)
+
+	stored := mockAPIKeyRepo.Calls[1].Arguments.Get(0).(*domain.APIKey)
+	assert.Equal(t, helper.HashAPIKey(result.Key), stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, result.Key)
+	assert.Equal(t, "transaksi:write,kantong:read", stored.Scopes)
+}
+
+func TestAPIKeyUsecase_Create_ExpiryInPast(t *testing.T) {
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+	uc := usecase.NewAPIKeyUsecase(mockAPIKeyRepo, new(MockUserRepository))
+
+	expiresAt := time.Now().Add(-time.Hour)
+	result, err := uc.Create(1, &domain.CreateAPIKeyRequest{
+		Nama:      "Script import",
+		Scopes:    []string{domain.APIKeyScopeLaporanRead},
+		ExpiresAt: &expiresAt,
+	})
+
+	assert.Nil(t, result)
+	assert.Equal(t, "tanggal kedaluwarsa harus di masa depan", err.Error())
+	mockAPIKeyRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAPIKeyUsecase_Create_LimitReached(t *testing.T) {
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+	existing := make([]*domain.APIKey, 20)
+	mockAPIKeyRepo.On("GetByUserID", uint(1)).Return(existing, nil)
+
+	uc := usecase.NewAPIKeyUsecase(mockAPIKeyRepo, new(MockUserRepository))
+	result, err := uc.Create(1, &domain.CreateAPIKeyRequest{
+		Nama:   "Script import",
+		Scopes: []string{domain.APIKeyScopeLaporanRead},
+	})
+
+	assert.Nil(t, result)
+	assert.Equal(t, "jumlah api key sudah mencapai batas maksimal", err.Error())
+}
+
+func TestAPIKeyUsecase_Delete_NotFound(t *testing.T) {
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+	mockAPIKeyRepo.On("Delete", uint(9), uint(1)).Return(gorm.ErrRecordNotFound)
+
+	uc := usecase.NewAPIKeyUsecase(mockAPIKeyRepo, new(MockUserRepository))
+	err := uc.Delete(1, 9)
+
+	assert.Equal(t, "api key tidak ditemukan", err.Error())
+}
+
+func TestAPIKeyUsecase_Authenticate_Success(t *testing.T) {
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+	mockUserRepo := new(MockUserRepository)
+	key, stored := newStoredAPIKey(t, "kantong:read")
+
+	mockAPIKeyRepo.On("GetByPrefix", stored.Prefix).Return(stored, nil)
+	mockAPIKeyRepo.On("UpdateLastUsed", uint(7), mock.AnythingOfType("time.Time")).Return(nil)
+	mockUserRepo.On("FindByID", uint(1)).Return(&domain.User{ID: 1, IsActive: true}, nil)
+
+	uc := usecase.NewAPIKeyUsecase(mockAPIKeyRepo, mockUserRepo)
+	apiKey, err := uc.Authenticate(key)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), apiKey.UserID)
+	assert.NotNil(t, apiKey.LastUsedAt)
+	mockAPIKeyRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyUsecase_Authenticate_RecentlyUsedSkipsUpdate(t *testing.T) {
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+	mockUserRepo := new(MockUserRepository)
+	key, stored := newStoredAPIKey(t, "kantong:read")
+	lastUsedAt := time.Now().Add(-10 * time.Second)
+	stored.LastUsedAt = &lastUsedAt
+
+	mockAPIKeyRepo.On("GetByPrefix", stored.Prefix).Return(stored, nil)
+	mockUserRepo.On("FindByID", uint(1)).Return(&domain.User{ID: 1, IsActive: true}, nil)
+
+	uc := usecase.NewAPIKeyUsecase(mockAPIKeyRepo, mockUserRepo)
+	_, err := uc.Authenticate(key)
+
+	assert.NoError(t, err)
+	mockAPIKeyRepo.AssertNotCalled(t, "UpdateLastUsed", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyUsecase_Authenticate_WrongSecret(t *testing.T) {
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+	_, stored := newStoredAPIKey(t, "kantong:read")
+
+	mockAPIKeyRepo.On("GetByPrefix", stored.Prefix).Return(stored, nil)
+
+	uc := usecase.NewAPIKeyUsecase(mockAPIKeyRepo, new(MockUserRepository))
+	apiKey, err := uc.Authenticate("fbk_" + stored.Prefix + "_deadbeef")
+
+	assert.Nil(t, apiKey)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyInvalid)
+}
+
+func TestAPIKeyUsecase_Authenticate_MalformedKey(t *testing.T) {
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+
+	uc := usecase.NewAPIKeyUsecase(mockAPIKeyRepo, new(MockUserRepository))
+	_, err := uc.Authenticate("not-a-key")
+
+	assert.ErrorIs(t, err, domain.ErrAPIKeyInvalid)
+	mockAPIKeyRepo.AssertNotCalled(t, "GetByPrefix", mock.Anything)
+}
+
+func TestAPIKeyUsecase_Authenticate_Expired(t *testing.T) {
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+	key, stored := newStoredAPIKey(t, "kantong:read")
+	expiresAt := time.Now().Add(-time.Minute)
+	stored.ExpiresAt = &expiresAt
+
+	mockAPIKeyRepo.On("GetByPrefix", stored.Prefix).Return(stored, nil)
+
+	uc := usecase.NewAPIKeyUsecase(mockAPIKeyRepo, new(MockUserRepository))
+	_, err := uc.Authenticate(key)
+
+	assert.ErrorIs(t, err, domain.ErrAPIKeyExpired)
+}
+
+func TestAPIKeyUsecase_Authenticate_InactiveUser(t *testing.T) {
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+	mockUserRepo := new(MockUserRepository)
+	key, stored := newStoredAPIKey(t, "kantong:read")
+
+	mockAPIKeyRepo.On("GetByPrefix", stored.Prefix).Return(stored, nil)
+	mockUserRepo.On("FindByID", uint(1)).Return(&domain.User{ID: 1, IsActive: false}, nil)
+
+	uc := usecase.NewAPIKeyUsecase(mockAPIKeyRepo, mockUserRepo)
+	_, err := uc.Authenticate(key)
+
+	assert.ErrorIs(t, err, domain.ErrAccountInactive)
+}
+
+func TestAPIKeyUsecase_Authenticate_RepositoryError(t *testing.T) {
+	mockAPIKeyRepo := new(MockAPIKeyRepository)
+	key, stored := newStoredAPIKey(t, "kantong:read")
+
+	mockAPIKeyRepo.On("GetByPrefix", stored.Prefix).Return(nil, errors.New("db down"))
+
+	uc := usecase.NewAPIKeyUsecase(mockAPIKeyRepo, new(MockUserRepository))
+	_, err := uc.Authenticate(key)
+
+	assert.Equal(t, "gagal memvalidasi api key", err.Error())
+}
@@ -1,9 +1,13 @@
 package usecase_test
 
 import (
+	"errors"
 	"fiber-boiler-plate/config"
 	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/mailer"
 	"fiber-boiler-plate/internal/usecase"
+	"fiber-boiler-plate/internal/usecase/repo"
 	"testing"
 	"time"
 
@@ -48,12 +52,48 @@ func (m *MockUserRepository) Update(user *domain.User) error {
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) MarkEmailVerified(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) IsEmailVerified(id uint) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) GetAll(req *domain.AdminUserListRequest) ([]*domain.User, int, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*domain.User), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserRepository) FindByID(id uint) (*domain.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateStatus(id uint, isActive bool) error {
+	args := m.Called(id, isActive)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateRole(id uint, roleID *string) error {
+	args := m.Called(id, roleID)
+	return args.Error(0)
+}
+
 type MockRefreshTokenRepository struct {
 	mock.Mock
 }
 
-func (m *MockRefreshTokenRepository) Create(userID uint, token string, expiresAt time.Time) (*domain.RefreshToken, error) {
-	args := m.Called(userID, token, expiresAt)
+func (m *MockRefreshTokenRepository) Create(userID uint, token string, expiresAt time.Time, meta domain.SessionMeta) (*domain.RefreshToken, error) {
+	args := m.Called(userID, token, expiresAt, meta)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -68,21 +108,71 @@ func (m *MockRefreshTokenRepository) GetByToken(token string) (*domain.RefreshTo
 	return args.Get(0).(*domain.RefreshToken), args.Error(1)
 }
 
+func (m *MockRefreshTokenRepository) GetActiveByUserID(userID uint) ([]*domain.RefreshToken, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) Rotate(parent *domain.RefreshToken, token string, expiresAt time.Time, meta domain.SessionMeta) (*domain.RefreshToken, error) {
+	args := m.Called(parent, token, expiresAt, meta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(familyID string) error {
+	args := m.Called(familyID)
+	return args.Error(0)
+}
+
 func (m *MockRefreshTokenRepository) RevokeToken(token string) error {
 	args := m.Called(token)
 	return args.Error(0)
 }
 
-func (m *MockRefreshTokenRepository) RevokeAllUserTokens(userID uint) error {
-	args := m.Called(userID)
+func (m *MockRefreshTokenRepository) RevokeUserToken(userID, id uint) (string, error) {
+	args := m.Called(userID, id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeAllUserTokens(userID uint, exceptFamilyIDs ...string) error {
+	args := m.Called(userID, exceptFamilyIDs)
 	return args.Error(0)
 }
 
-func (m *MockRefreshTokenRepository) CleanupExpired() error {
+func (m *MockRefreshTokenRepository) CleanupExpired() (int64, error) {
 	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockTokenRevocationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTokenRevocationRepository) RevokeAccessToken(tokenID string, expiresAt time.Time) error {
+	args := m.Called(tokenID, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTokenRevocationRepository) RevokeSessionAccess(sessionID string, ttl time.Duration) error {
+	args := m.Called(sessionID, ttl)
+	return args.Error(0)
+}
+
+func (m *MockTokenRevocationRepository) InvalidateUserTokens(userID uint, before time.Time) error {
+	args := m.Called(userID, before)
 	return args.Error(0)
 }
 
+func (m *MockTokenRevocationRepository) IsAccessTokenRevoked(tokenID string, userID uint, sessionID string, issuedAt time.Time) (bool, error) {
+	args := m.Called(tokenID, userID, sessionID, issuedAt)
+	return args.Bool(0), args.Error(1)
+}
+
 type MockPasswordResetTokenRepository struct {
 	mock.Mock
 }
@@ -108,15 +198,48 @@ func (m *MockPasswordResetTokenRepository) MarkAsUsed(token string) error {
 	return args.Error(0)
 }
 
-func (m *MockPasswordResetTokenRepository) CleanupExpired() error {
+func (m *MockPasswordResetTokenRepository) CleanupExpired() (int64, error) {
 	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockEmailVerificationTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmailVerificationTokenRepository) Create(userID uint, email, token string, expiresAt time.Time) (*domain.EmailVerificationToken, error) {
+	args := m.Called(userID, email, token, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EmailVerificationToken), args.Error(1)
+}
+
+func (m *MockEmailVerificationTokenRepository) GetByToken(token string) (*domain.EmailVerificationToken, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EmailVerificationToken), args.Error(1)
+}
+
+func (m *MockEmailVerificationTokenRepository) MarkAsUsed(token string) error {
+	args := m.Called(token)
 	return args.Error(0)
 }
 
+func (m *MockEmailVerificationTokenRepository) CleanupExpired() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func TestAuthUsecase_Register_Success(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
 	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
 
 	cfg := &config.Config{
 		JWT: config.JWTConfig{
@@ -126,7 +249,7 @@ func TestAuthUsecase_Register_Success(t *testing.T) {
 		},
 	}
 
-	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, cfg)
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
 
 	req := domain.RegisterRequest{
 		Name:     "Test User",
@@ -136,6 +259,7 @@ func TestAuthUsecase_Register_Success(t *testing.T) {
 
 	mockUserRepo.On("GetByEmail", req.Email).Return(nil, gorm.ErrRecordNotFound)
 	mockUserRepo.On("Create", mock.AnythingOfType("*domain.User")).Return(nil)
+	mockVerificationTokenRepo.On("Create", mock.AnythingOfType("uint"), req.Email, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(&domain.EmailVerificationToken{ID: 1}, nil)
 
 	refreshToken := &domain.RefreshToken{
 		ID:        1,
@@ -143,29 +267,122 @@ func TestAuthUsecase_Register_Success(t *testing.T) {
 		Token:     "refresh_token",
 		ExpiresAt: time.Now().Add(24 * time.Hour),
 	}
-	mockRefreshTokenRepo.On("Create", mock.AnythingOfType("uint"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(refreshToken, nil)
+	mockRefreshTokenRepo.On("Create", mock.AnythingOfType("uint"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("domain.SessionMeta")).Return(refreshToken, nil)
 
-	result, err := authUC.Register(req)
+	result, err := authUC.Register(req, domain.SessionMeta{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, req.Name, result.User.Name)
 	assert.Equal(t, req.Email, result.User.Email)
+	assert.Nil(t, result.User.EmailVerifiedAt)
 	assert.NotEmpty(t, result.AccessToken)
 	assert.NotEmpty(t, result.RefreshToken)
 	assert.Equal(t, "Bearer", result.TokenType)
+	assert.False(t, result.EmailVerificationRequired)
 
 	mockUserRepo.AssertExpectations(t)
 	mockRefreshTokenRepo.AssertExpectations(t)
+	mockVerificationTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_Register_BlockLoginPolicy(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+	memoryMailer := mailer.NewMemoryMailer()
+
+	cfg := &config.Config{
+		App: config.AppConfig{
+			Name:        "Test App",
+			FrontendURL: "https://app.example.com",
+		},
+		Auth: config.AuthConfig{
+			EmailVerificationPolicy:      config.EmailVerificationPolicyBlockLogin,
+			EmailVerificationExpireHours: 24,
+		},
+	}
+
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, memoryMailer, helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	req := domain.RegisterRequest{
+		Name:     "Test User",
+		Email:    "test@example.com",
+		Password: "password123",
+	}
+
+	mockUserRepo.On("GetByEmail", req.Email).Return(nil, gorm.ErrRecordNotFound)
+	mockUserRepo.On("Create", mock.AnythingOfType("*domain.User")).Return(nil)
+	mockVerificationTokenRepo.On("Create", mock.AnythingOfType("uint"), req.Email, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(&domain.EmailVerificationToken{ID: 1}, nil)
+
+	result, err := authUC.Register(req, domain.SessionMeta{})
+
+	assert.NoError(t, err)
+	assert.True(t, result.EmailVerificationRequired)
+	assert.Empty(t, result.AccessToken)
+	assert.Empty(t, result.RefreshToken)
+
+	sent := memoryMailer.Last()
+	assert.NotNil(t, sent)
+	assert.Equal(t, []string{req.Email}, sent.To)
+
+	savedToken := mockVerificationTokenRepo.Calls[0].Arguments.String(2)
+	assert.Contains(t, sent.TextBody, "https://app.example.com/verify-email?token="+savedToken)
+	assert.Contains(t, sent.HTMLBody, "24 jam")
+
+	mockRefreshTokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockUserRepo.AssertExpectations(t)
+	mockVerificationTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_Register_VerificationEmailFailureDoesNotFail(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:                  "test_secret",
+			ExpireHours:             1,
+			RefreshTokenExpireHours: 24,
+		},
+	}
+
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, failingMailer{}, helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	req := domain.RegisterRequest{
+		Name:     "Test User",
+		Email:    "test@example.com",
+		Password: "password123",
+	}
+
+	mockUserRepo.On("GetByEmail", req.Email).Return(nil, gorm.ErrRecordNotFound)
+	mockUserRepo.On("Create", mock.AnythingOfType("*domain.User")).Return(nil)
+	mockVerificationTokenRepo.On("Create", mock.AnythingOfType("uint"), req.Email, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(&domain.EmailVerificationToken{ID: 1}, nil)
+	mockRefreshTokenRepo.On("Create", mock.AnythingOfType("uint"), mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("domain.SessionMeta")).Return(&domain.RefreshToken{ID: 1}, nil)
+
+	result, err := authUC.Register(req, domain.SessionMeta{})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.AccessToken)
 }
 
 func TestAuthUsecase_Register_EmailAlreadyExists(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
 	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
 
 	cfg := &config.Config{}
-	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, cfg)
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
 
 	req := domain.RegisterRequest{
 		Name:     "Test User",
@@ -181,7 +398,7 @@ func TestAuthUsecase_Register_EmailAlreadyExists(t *testing.T) {
 
 	mockUserRepo.On("GetByEmail", req.Email).Return(existingUser, nil)
 
-	result, err := authUC.Register(req)
+	result, err := authUC.Register(req, domain.SessionMeta{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -194,6 +411,9 @@ func TestAuthUsecase_Login_Success(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
 	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
 
 	cfg := &config.Config{
 		JWT: config.JWTConfig{
@@ -203,7 +423,7 @@ func TestAuthUsecase_Login_Success(t *testing.T) {
 		},
 	}
 
-	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, cfg)
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
 
 	password := "password123"
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -222,16 +442,19 @@ func TestAuthUsecase_Login_Success(t *testing.T) {
 	}
 
 	mockUserRepo.On("GetByEmail", req.Email).Return(user, nil)
+	mockMFARepo.On("GetByUserID", user.ID).Return(nil, gorm.ErrRecordNotFound)
 
 	refreshToken := &domain.RefreshToken{
 		ID:        1,
 		UserID:    user.ID,
 		Token:     "refresh_token",
 		ExpiresAt: time.Now().Add(24 * time.Hour),
+		FamilyID:  "family-login",
 	}
-	mockRefreshTokenRepo.On("Create", user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(refreshToken, nil)
+	meta := domain.SessionMeta{UserAgent: "Mozilla/5.0", IPAddress: "10.0.0.1", DeviceName: "Chrome di Windows"}
+	mockRefreshTokenRepo.On("Create", user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), meta).Return(refreshToken, nil)
 
-	result, err := authUC.Login(req)
+	result, err := authUC.Login(req, meta)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -240,6 +463,11 @@ func TestAuthUsecase_Login_Success(t *testing.T) {
 	assert.NotEmpty(t, result.AccessToken)
 	assert.NotEmpty(t, result.RefreshToken)
 
+	claims, err := helper.ValidateAccessToken(result.AccessToken, helper.NewHMACKeySet(cfg.JWT.Secret))
+	assert.NoError(t, err)
+	assert.Equal(t, refreshToken.FamilyID, claims.SessionID)
+
+	mockRefreshTokenRepo.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
 	mockRefreshTokenRepo.AssertExpectations(t)
 }
@@ -248,9 +476,12 @@ func TestAuthUsecase_Login_InvalidCredentials(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
 	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
 
 	cfg := &config.Config{}
-	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, cfg)
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
 
 	req := domain.AuthRequest{
 		Email:    "test@example.com",
@@ -259,7 +490,7 @@ func TestAuthUsecase_Login_InvalidCredentials(t *testing.T) {
 
 	mockUserRepo.On("GetByEmail", req.Email).Return(nil, gorm.ErrRecordNotFound)
 
-	result, err := authUC.Login(req)
+	result, err := authUC.Login(req, domain.SessionMeta{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -268,10 +499,259 @@ func TestAuthUsecase_Login_InvalidCredentials(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
+func TestAuthUsecase_Login_EmailNotVerified(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{
+		Auth: config.AuthConfig{EmailVerificationPolicy: config.EmailVerificationPolicyBlockLogin},
+	}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	user := &domain.User{
+		ID:       1,
+		Email:    "test@example.com",
+		Password: string(hashedPassword),
+		IsActive: true,
+	}
+
+	mockUserRepo.On("GetByEmail", user.Email).Return(user, nil)
+
+	result, err := authUC.Login(domain.AuthRequest{Email: user.Email, Password: password}, domain.SessionMeta{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "email belum diverifikasi", err.Error())
+
+	mockRefreshTokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthUsecase_Login_InactiveUser(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	user := &domain.User{
+		ID:       1,
+		Email:    "test@example.com",
+		Password: string(hashedPassword),
+		IsActive: false,
+	}
+
+	mockUserRepo.On("GetByEmail", user.Email).Return(user, nil)
+
+	result, err := authUC.Login(domain.AuthRequest{Email: user.Email, Password: password}, domain.SessionMeta{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "akun dinonaktifkan", err.Error())
+
+	mockMFARepo.AssertNotCalled(t, "GetByUserID", mock.Anything)
+	mockRefreshTokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthUsecase_Login_LimitedPolicyUnverified(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:                  "test_secret",
+			ExpireHours:             1,
+			RefreshTokenExpireHours: 24,
+		},
+		Auth: config.AuthConfig{EmailVerificationPolicy: config.EmailVerificationPolicyLimited},
+	}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	user := &domain.User{
+		ID:       1,
+		Email:    "test@example.com",
+		Password: string(hashedPassword),
+		IsActive: true,
+	}
+
+	mockUserRepo.On("GetByEmail", user.Email).Return(user, nil)
+	mockMFARepo.On("GetByUserID", user.ID).Return(nil, gorm.ErrRecordNotFound)
+	mockRefreshTokenRepo.On("Create", user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("domain.SessionMeta")).Return(&domain.RefreshToken{ID: 1}, nil)
+
+	result, err := authUC.Login(domain.AuthRequest{Email: user.Email, Password: password}, domain.SessionMeta{})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.AccessToken)
+	assert.True(t, result.EmailVerificationRequired)
+}
+
+func TestAuthUsecase_Login_MFARequired(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{Secret: "test_secret", ExpireHours: 1, RefreshTokenExpireHours: 24},
+	}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	user := &domain.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword), IsActive: true}
+
+	mockUserRepo.On("GetByEmail", user.Email).Return(user, nil)
+	mockMFARepo.On("GetByUserID", user.ID).Return(&domain.UserMFA{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP", IsEnabled: true}, nil)
+
+	result, err := authUC.Login(domain.AuthRequest{Email: user.Email, Password: password}, domain.SessionMeta{})
+
+	assert.NoError(t, err)
+	assert.True(t, result.MFARequired)
+	assert.NotEmpty(t, result.MFAToken)
+	assert.Empty(t, result.AccessToken)
+	assert.Empty(t, result.RefreshToken)
+
+	_, err = helper.ValidateAccessToken(result.MFAToken, helper.NewHMACKeySet(cfg.JWT.Secret))
+	assert.Error(t, err)
+
+	mockRefreshTokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthUsecase_VerifyMFA_Success(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{Secret: "test_secret", ExpireHours: 1, RefreshTokenExpireHours: 24},
+	}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	secret := "JBSWY3DPEHPK3PXP"
+	user := &domain.User{ID: 1, Email: "test@example.com", IsActive: true}
+	mfaToken, _ := helper.GenerateMFAToken(user.ID, user.Email, helper.NewHMACKeySet(cfg.JWT.Secret), 5)
+	code, _ := helper.GenerateTOTPCode(secret, time.Now())
+
+	mockUserRepo.On("GetByID", user.ID).Return(user, nil)
+	mockMFARepo.On("GetByUserID", user.ID).Return(&domain.UserMFA{UserID: user.ID, Secret: secret, IsEnabled: true}, nil)
+	mockMFARepo.On("UpdateLastUsedStep", user.ID, mock.AnythingOfType("int64")).Return(true, nil)
+	mockRefreshTokenRepo.On("Create", user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("domain.SessionMeta")).Return(&domain.RefreshToken{ID: 1}, nil)
+
+	result, err := authUC.VerifyMFA(domain.MFAVerifyRequest{MFAToken: mfaToken, Code: code}, domain.SessionMeta{})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.AccessToken)
+	assert.False(t, result.MFARequired)
+
+	mockMFARepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_VerifyMFA_RecoveryCode(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{Secret: "test_secret", ExpireHours: 1, RefreshTokenExpireHours: 24},
+	}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	user := &domain.User{ID: 1, Email: "test@example.com", IsActive: true}
+	mfaToken, _ := helper.GenerateMFAToken(user.ID, user.Email, helper.NewHMACKeySet(cfg.JWT.Secret), 5)
+
+	mockUserRepo.On("GetByID", user.ID).Return(user, nil)
+	mockMFARepo.On("GetByUserID", user.ID).Return(&domain.UserMFA{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP", IsEnabled: true}, nil)
+	mockMFARepo.On("UseRecoveryCode", user.ID, helper.HashRecoveryCode("abcde-12345")).Return(true, nil)
+	mockRefreshTokenRepo.On("Create", user.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("domain.SessionMeta")).Return(&domain.RefreshToken{ID: 1}, nil)
+
+	result, err := authUC.VerifyMFA(domain.MFAVerifyRequest{MFAToken: mfaToken, Code: "ABCDE-12345"}, domain.SessionMeta{})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.AccessToken)
+
+	mockMFARepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_VerifyMFA_InvalidCode(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{Secret: "test_secret", ExpireHours: 1, RefreshTokenExpireHours: 24},
+	}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	user := &domain.User{ID: 1, Email: "test@example.com", IsActive: true}
+	mfaToken, _ := helper.GenerateMFAToken(user.ID, user.Email, helper.NewHMACKeySet(cfg.JWT.Secret), 5)
+
+	mockUserRepo.On("GetByID", user.ID).Return(user, nil)
+	mockMFARepo.On("GetByUserID", user.ID).Return(&domain.UserMFA{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP", IsEnabled: true}, nil)
+	mockMFARepo.On("UseRecoveryCode", user.ID, mock.AnythingOfType("string")).Return(false, nil)
+
+	result, err := authUC.VerifyMFA(domain.MFAVerifyRequest{MFAToken: mfaToken, Code: "000000x"}, domain.SessionMeta{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "kode 2FA tidak valid", err.Error())
+}
+
+func TestAuthUsecase_VerifyMFA_RejectsAccessToken(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test_secret"}}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	accessToken, _ := helper.GenerateAccessToken(1, "test@example.com", "", helper.NewHMACKeySet(cfg.JWT.Secret), 1)
+
+	result, err := authUC.VerifyMFA(domain.MFAVerifyRequest{MFAToken: accessToken, Code: "123456"}, domain.SessionMeta{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "token 2FA tidak valid atau sudah expired", err.Error())
+}
+
 func TestAuthUsecase_RefreshToken_Success(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
 	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
 
 	cfg := &config.Config{
 		JWT: config.JWTConfig{
@@ -281,17 +761,19 @@ func TestAuthUsecase_RefreshToken_Success(t *testing.T) {
 		},
 	}
 
-	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, cfg)
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
 
 	refreshTokenString := "valid_refresh_token"
 	userID := uint(1)
 
 	refreshToken := &domain.RefreshToken{
-		ID:        1,
-		UserID:    userID,
-		Token:     refreshTokenString,
-		ExpiresAt: time.Now().Add(24 * time.Hour),
-		IsRevoked: false,
+		ID:         1,
+		UserID:     userID,
+		Token:      refreshTokenString,
+		ExpiresAt:  time.Now().Add(24 * time.Hour),
+		IsRevoked:  false,
+		FamilyID:   "family-1",
+		DeviceName: "Pixel 8",
 	}
 
 	user := &domain.User{
@@ -306,18 +788,19 @@ func TestAuthUsecase_RefreshToken_Success(t *testing.T) {
 	}
 
 	mockRefreshTokenRepo.On("GetByToken", refreshTokenString).Return(refreshToken, nil)
-	mockUserRepo.On("GetByID", userID).Return(user, nil)
-	mockRefreshTokenRepo.On("RevokeToken", refreshTokenString).Return(nil)
+	mockUserRepo.On("FindByID", userID).Return(user, nil)
 
 	newRefreshToken := &domain.RefreshToken{
 		ID:        2,
 		UserID:    userID,
 		Token:     "new_refresh_token",
 		ExpiresAt: time.Now().Add(24 * time.Hour),
+		FamilyID:  refreshToken.FamilyID,
+		ParentID:  &refreshToken.ID,
 	}
-	mockRefreshTokenRepo.On("Create", userID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(newRefreshToken, nil)
+	mockRefreshTokenRepo.On("Rotate", refreshToken, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), domain.SessionMeta{IPAddress: "10.0.0.2", DeviceName: "Pixel 8"}).Return(newRefreshToken, nil)
 
-	result, err := authUC.RefreshToken(req)
+	result, err := authUC.RefreshToken(req, domain.SessionMeta{IPAddress: "10.0.0.2"})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -329,19 +812,187 @@ func TestAuthUsecase_RefreshToken_Success(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
+func TestAuthUsecase_RefreshToken_InactiveUser(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:                  "test_secret",
+			ExpireHours:             1,
+			RefreshTokenExpireHours: 24,
+		},
+	}
+
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	refreshToken := &domain.RefreshToken{
+		ID:        1,
+		UserID:    1,
+		Token:     "valid_refresh_token",
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+		FamilyID:  "family-1",
+	}
+
+	mockRefreshTokenRepo.On("GetByToken", refreshToken.Token).Return(refreshToken, nil)
+	mockUserRepo.On("FindByID", uint(1)).Return(&domain.User{ID: 1, Email: "test@example.com", IsActive: false}, nil)
+
+	result, err := authUC.RefreshToken(domain.RefreshTokenRequest{RefreshToken: refreshToken.Token}, domain.SessionMeta{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "akun dinonaktifkan", err.Error())
+
+	mockRefreshTokenRepo.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthUsecase_RefreshToken_ReuseRevokesFamily(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:                  "test-secret",
+			ExpireHours:             24,
+			RefreshTokenExpireHours: 168,
+		},
+	}
+
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	rotatedAt := time.Now().Add(-time.Hour)
+	refreshToken := &domain.RefreshToken{
+		ID:        1,
+		UserID:    1,
+		Token:     "stolen_refresh_token",
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+		IsRevoked: true,
+		FamilyID:  "family-1",
+		RotatedAt: &rotatedAt,
+	}
+
+	mockRefreshTokenRepo.On("GetByToken", refreshToken.Token).Return(refreshToken, nil)
+	mockRefreshTokenRepo.On("RevokeFamily", "family-1").Return(nil)
+	mockTokenRevocationRepo.On("RevokeSessionAccess", "family-1", 24*time.Hour).Return(nil)
+
+	result, err := authUC.RefreshToken(domain.RefreshTokenRequest{RefreshToken: refreshToken.Token}, domain.SessionMeta{IPAddress: "10.0.0.9"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "refresh token tidak valid atau sudah expired", err.Error())
+
+	mockRefreshTokenRepo.AssertExpectations(t)
+	mockTokenRevocationRepo.AssertExpectations(t)
+	mockUserRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+}
+
+func TestAuthUsecase_RefreshToken_ConcurrentRotationRevokesFamily(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:                  "test-secret",
+			ExpireHours:             24,
+			RefreshTokenExpireHours: 168,
+		},
+	}
+
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	refreshToken := &domain.RefreshToken{
+		ID:        1,
+		UserID:    1,
+		Token:     "raced_refresh_token",
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+		FamilyID:  "family-1",
+	}
+
+	mockRefreshTokenRepo.On("GetByToken", refreshToken.Token).Return(refreshToken, nil)
+	mockUserRepo.On("FindByID", uint(1)).Return(&domain.User{ID: 1, Email: "test@example.com", IsActive: true}, nil)
+	mockRefreshTokenRepo.On("Rotate", refreshToken, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("domain.SessionMeta")).Return(nil, repo.ErrRefreshTokenReused)
+	mockRefreshTokenRepo.On("RevokeFamily", "family-1").Return(nil)
+	mockTokenRevocationRepo.On("RevokeSessionAccess", "family-1", 24*time.Hour).Return(nil)
+
+	result, err := authUC.RefreshToken(domain.RefreshTokenRequest{RefreshToken: refreshToken.Token}, domain.SessionMeta{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_RefreshToken_LoggedOutTokenDoesNotRevokeFamily(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:                  "test-secret",
+			ExpireHours:             24,
+			RefreshTokenExpireHours: 168,
+		},
+	}
+
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	refreshToken := &domain.RefreshToken{
+		ID:        1,
+		UserID:    1,
+		Token:     "logged_out_refresh_token",
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+		IsRevoked: true,
+		FamilyID:  "family-1",
+	}
+
+	mockRefreshTokenRepo.On("GetByToken", refreshToken.Token).Return(refreshToken, nil)
+
+	result, err := authUC.RefreshToken(domain.RefreshTokenRequest{RefreshToken: refreshToken.Token}, domain.SessionMeta{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	mockRefreshTokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything)
+}
+
 func TestAuthUsecase_ResetPassword_Success(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
 	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+	memoryMailer := mailer.NewMemoryMailer()
 
-	cfg := &config.Config{}
-	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, cfg)
+	cfg := &config.Config{
+		App: config.AppConfig{
+			Name:        "Test App",
+			FrontendURL: "https://app.example.com/",
+		},
+	}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, memoryMailer, helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
 
 	email := "test@example.com"
 	user := &domain.User{
-		ID:    1,
-		Email: email,
-		Name:  "Test User",
+		ID:       1,
+		Email:    email,
+		Name:     "Test User",
+		IsActive: true,
 	}
 
 	req := domain.ResetPasswordRequest{
@@ -362,17 +1013,56 @@ func TestAuthUsecase_ResetPassword_Success(t *testing.T) {
 
 	assert.NoError(t, err)
 
+	sent := memoryMailer.Last()
+	assert.NotNil(t, sent)
+	assert.Equal(t, []string{email}, sent.To)
+	assert.Contains(t, sent.Subject, "Test App")
+
+	savedToken := mockResetTokenRepo.Calls[0].Arguments.String(1)
+	assert.Contains(t, sent.TextBody, "https://app.example.com/reset-password?token="+savedToken)
+	assert.Contains(t, sent.HTMLBody, "https://app.example.com/reset-password?token="+savedToken)
+
 	mockUserRepo.AssertExpectations(t)
 	mockResetTokenRepo.AssertExpectations(t)
 }
 
+func TestAuthUsecase_ResetPassword_MailerError(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, failingMailer{}, helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	email := "test@example.com"
+	mockUserRepo.On("GetByEmail", email).Return(&domain.User{ID: 1, Email: email, Name: "Test User", IsActive: true}, nil)
+	mockResetTokenRepo.On("Create", email, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(&domain.PasswordResetToken{ID: 1, Email: email}, nil)
+
+	err := authUC.ResetPassword(domain.ResetPasswordRequest{Email: email})
+
+	assert.Error(t, err)
+	assert.Equal(t, "gagal mengirim email reset password", err.Error())
+}
+
+type failingMailer struct{}
+
+func (failingMailer) Send(msg *mailer.Message) error {
+	return errors.New("smtp unavailable")
+}
+
 func TestAuthUsecase_ConfirmResetPassword_Success(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
 	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
 
 	cfg := &config.Config{}
-	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, cfg)
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
 
 	token := "valid_reset_token"
 	email := "test@example.com"
@@ -394,6 +1084,9 @@ func TestAuthUsecase_ConfirmResetPassword_Success(t *testing.T) {
 	mockResetTokenRepo.On("GetByToken", token).Return(resetToken, nil)
 	mockUserRepo.On("UpdatePassword", email, mock.AnythingOfType("string")).Return(nil)
 	mockResetTokenRepo.On("MarkAsUsed", token).Return(nil)
+	mockUserRepo.On("GetByEmail", email).Return(&domain.User{ID: 7, Email: email}, nil)
+	mockTokenRevocationRepo.On("InvalidateUserTokens", uint(7), mock.AnythingOfType("time.Time")).Return(nil)
+	mockRefreshTokenRepo.On("RevokeAllUserTokens", uint(7), []string(nil)).Return(nil)
 
 	err := authUC.ConfirmResetPassword(req)
 
@@ -401,23 +1094,134 @@ func TestAuthUsecase_ConfirmResetPassword_Success(t *testing.T) {
 
 	mockResetTokenRepo.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
+	mockTokenRevocationRepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_VerifyEmail_Success(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	token := "valid_verification_token"
+	verificationToken := &domain.EmailVerificationToken{
+		ID:        1,
+		UserID:    7,
+		Email:     "test@example.com",
+		Token:     token,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	mockVerificationTokenRepo.On("GetByToken", token).Return(verificationToken, nil)
+	mockUserRepo.On("MarkEmailVerified", uint(7)).Return(nil)
+	mockVerificationTokenRepo.On("MarkAsUsed", token).Return(nil)
+
+	err := authUC.VerifyEmail(domain.VerifyEmailRequest{Token: token})
+
+	assert.NoError(t, err)
+
+	mockVerificationTokenRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_VerifyEmail_InvalidToken(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+
+	cfg := &config.Config{}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	mockVerificationTokenRepo.On("GetByToken", "expired").Return(nil, gorm.ErrRecordNotFound)
+
+	err := authUC.VerifyEmail(domain.VerifyEmailRequest{Token: "expired"})
+
+	assert.Error(t, err)
+	assert.Equal(t, "token verifikasi email tidak valid atau sudah expired", err.Error())
+	mockUserRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything)
+}
+
+func TestAuthUsecase_ResendVerification_Success(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+	memoryMailer := mailer.NewMemoryMailer()
+
+	cfg := &config.Config{
+		Auth: config.AuthConfig{EmailVerificationExpireHours: 24},
+	}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, memoryMailer, helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	email := "test@example.com"
+	mockUserRepo.On("GetByEmail", email).Return(&domain.User{ID: 1, Email: email, Name: "Test User", IsActive: true}, nil)
+	mockVerificationTokenRepo.On("Create", uint(1), email, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(&domain.EmailVerificationToken{ID: 1}, nil)
+
+	err := authUC.ResendVerification(domain.ResendVerificationRequest{Email: email})
+
+	assert.NoError(t, err)
+	assert.Len(t, memoryMailer.Messages(), 1)
+
+	mockUserRepo.AssertExpectations(t)
+	mockVerificationTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_ResendVerification_AlreadyVerified(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+	memoryMailer := mailer.NewMemoryMailer()
+
+	cfg := &config.Config{}
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, memoryMailer, helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
+
+	verifiedAt := time.Now()
+	email := "test@example.com"
+	mockUserRepo.On("GetByEmail", email).Return(&domain.User{ID: 1, Email: email, EmailVerifiedAt: &verifiedAt, IsActive: true}, nil)
+
+	err := authUC.ResendVerification(domain.ResendVerificationRequest{Email: email})
+
+	assert.Error(t, err)
+	assert.Equal(t, "email sudah diverifikasi", err.Error())
+	assert.Empty(t, memoryMailer.Messages())
 }
 
 func TestAuthUsecase_Logout_Success(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
 	mockResetTokenRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationTokenRepo := new(MockEmailVerificationTokenRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
 
 	cfg := &config.Config{}
-	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, cfg)
+	authUC := usecase.NewAuthUsecase(mockUserRepo, mockRefreshTokenRepo, mockResetTokenRepo, mockVerificationTokenRepo, mockMFARepo, mockTokenRevocationRepo, mailer.NewMemoryMailer(), helper.NewHMACKeySet(cfg.JWT.Secret), cfg)
 
 	token := "refresh_token_to_revoke"
 
+	expiresAt := time.Now().Add(time.Hour)
+
 	mockRefreshTokenRepo.On("RevokeToken", token).Return(nil)
+	mockTokenRevocationRepo.On("RevokeAccessToken", "access-jti", expiresAt).Return(nil)
 
-	err := authUC.Logout(token)
+	err := authUC.Logout(token, "access-jti", expiresAt)
 
 	assert.NoError(t, err)
 
 	mockRefreshTokenRepo.AssertExpectations(t)
+	mockTokenRevocationRepo.AssertExpectations(t)
 }
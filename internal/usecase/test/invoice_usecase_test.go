@@ -33,6 +33,14 @@ func (m *MockInvoiceRepository) GetByID(id string) (*domain.Invoice, error) {
 	return args.Get(0).(*domain.Invoice), args.Error(1)
 }
 
+func (m *MockInvoiceRepository) GetByUserID(userID uint, limit int) ([]*domain.Invoice, error) {
+	args := m.Called(userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Invoice), args.Error(1)
+}
+
 func (m *MockInvoiceRepository) UpdateStatus(id string, status string, keterangan *string) error {
 	args := m.Called(id, status, keterangan)
 	return args.Error(0)
@@ -66,7 +74,7 @@ func TestInvoiceUsecase_GetAll_Success(t *testing.T) {
 			ID:                 "INV-2024-001",
 			UserID:             userID,
 			User:               domain.User{ID: userID, Name: "John Doe", Email: "john@example.com"},
-			Jumlah:             99000,
+			Jumlah:             domain.NewMoney(99000),
 			Status:             "sukses",
 			DibayarPada:        &now,
 			MetodePembayaran:   stringPtr("Bank Transfer"),
@@ -136,7 +144,7 @@ func TestInvoiceUsecase_GetByID_Success(t *testing.T) {
 		ID:                 invoiceID,
 		UserID:             userID,
 		User:               domain.User{ID: userID, Name: "John Doe", Email: "john@example.com"},
-		Jumlah:             99000,
+		Jumlah:             domain.NewMoney(99000),
 		Status:             "sukses",
 		DibayarPada:        &now,
 		MetodePembayaran:   stringPtr("Bank Transfer"),
@@ -157,7 +165,7 @@ func TestInvoiceUsecase_GetByID_Success(t *testing.T) {
 	assert.Equal(t, "John Doe", result.NamaUser)
 	assert.Equal(t, "john@example.com", result.UserEmail)
 	assert.Equal(t, userID, result.UserID)
-	assert.Equal(t, float64(99000), result.Jumlah)
+	assert.Equal(t, domain.NewMoney(99000), result.Jumlah)
 	assert.Equal(t, "sukses", result.Status)
 	assert.Equal(t, "PRO Monthly", *result.SubscriptionPlanNama)
 
@@ -212,7 +220,7 @@ func TestInvoiceUsecase_UpdateStatus_Success(t *testing.T) {
 		ID:                 invoiceID,
 		UserID:             userID,
 		User:               domain.User{ID: userID, Name: "John Doe", Email: "john@example.com"},
-		Jumlah:             99000,
+		Jumlah:             domain.NewMoney(99000),
 		Status:             "pending",
 		DibayarPada:        nil,
 		MetodePembayaran:   stringPtr("Bank Transfer"),
@@ -227,7 +235,7 @@ func TestInvoiceUsecase_UpdateStatus_Success(t *testing.T) {
 		ID:                 invoiceID,
 		UserID:             userID,
 		User:               domain.User{ID: userID, Name: "John Doe", Email: "john@example.com"},
-		Jumlah:             99000,
+		Jumlah:             domain.NewMoney(99000),
 		Status:             "sukses",
 		DibayarPada:        &now,
 		MetodePembayaran:   stringPtr("Bank Transfer"),
@@ -327,21 +335,21 @@ func TestInvoiceUsecase_GetStatistics_Success(t *testing.T) {
 		TotalSukses:        85,
 		TotalGagal:         10,
 		TotalPending:       5,
-		TotalPendapatan:    8500000,
-		RataRataPembayaran: 100000,
+		TotalPendapatan:    domain.NewMoney(8500000),
+		RataRataPembayaran: domain.NewMoney(100000),
 		InvoiceBulanan: []domain.InvoiceStatsBulanan{
 			{
 				Bulan:           "Januari",
 				TotalInvoice:    100,
 				TotalSukses:     85,
-				TotalPendapatan: 8500000,
+				TotalPendapatan: domain.NewMoney(8500000),
 			},
 		},
 		TopSubscriptionPlans: []domain.TopSubscriptionPlan{
 			{
 				SubscriptionPlanNama: "PRO Monthly",
 				JumlahInvoice:        50,
-				TotalPendapatan:      5000000,
+				TotalPendapatan:      domain.NewMoney(5000000),
 			},
 		},
 	}
@@ -356,8 +364,8 @@ func TestInvoiceUsecase_GetStatistics_Success(t *testing.T) {
 	assert.Equal(t, int64(85), result.TotalSukses)
 	assert.Equal(t, int64(10), result.TotalGagal)
 	assert.Equal(t, int64(5), result.TotalPending)
-	assert.Equal(t, float64(8500000), result.TotalPendapatan)
-	assert.Equal(t, float64(100000), result.RataRataPembayaran)
+	assert.Equal(t, domain.NewMoney(8500000), result.TotalPendapatan)
+	assert.Equal(t, domain.NewMoney(100000), result.RataRataPembayaran)
 	assert.Equal(t, 1, len(result.InvoiceBulanan))
 	assert.Equal(t, 1, len(result.TopSubscriptionPlans))
 
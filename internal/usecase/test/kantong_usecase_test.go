@@ -0,0 +1,285 @@
+package usecase_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockKantongRepository struct {
+	mock.Mock
+}
+
+func (m *MockKantongRepository) GetByUserID(userID uint, req *domain.KantongListRequest) ([]*domain.Kantong, int, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*domain.Kantong), args.Int(1), args.Error(2)
+}
+
+func (m *MockKantongRepository) GetByID(id string, userID uint) (*domain.Kantong, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Kantong), args.Error(1)
+}
+
+func (m *MockKantongRepository) GetByIDKartu(idKartu string, userID uint) (*domain.Kantong, error) {
+	args := m.Called(idKartu, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Kantong), args.Error(1)
+}
+
+func (m *MockKantongRepository) Create(kantong *domain.Kantong) error {
+	args := m.Called(kantong)
+	return args.Error(0)
+}
+
+func (m *MockKantongRepository) Update(kantong *domain.Kantong) error {
+	args := m.Called(kantong)
+	return args.Error(0)
+}
+
+func (m *MockKantongRepository) Delete(id string, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockKantongRepository) IsNameExistForUser(nama string, userID uint, excludeID ...string) (bool, error) {
+	args := m.Called(nama, userID, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockKantongRepository) HasTransaksiSplit(id string) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockKantongRepository) GenerateUniqueIDKartu() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockKantongRepository) Transfer(transfer *domain.Transfer) (*domain.Kantong, *domain.Kantong, error) {
+	args := m.Called(transfer)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*domain.Kantong), args.Get(1).(*domain.Kantong), args.Error(2)
+}
+
+func (m *MockKantongRepository) ReverseTransfer(id string, userID uint, reversal *domain.Transfer) (*domain.Kantong, *domain.Kantong, error) {
+	args := m.Called(id, userID, reversal)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*domain.Kantong), args.Get(1).(*domain.Kantong), args.Error(2)
+}
+
+func (m *MockKantongRepository) GetTransfers(userID uint, req *domain.TransferListRequest) ([]*domain.TransferResult, int, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*domain.TransferResult), args.Int(1), args.Error(2)
+}
+
+func (m *MockKantongRepository) GetTransferByID(id string, userID uint) (*domain.TransferResult, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransferResult), args.Error(1)
+}
+
+const (
+	kantongAsalID   = "550e8400-e29b-41d4-a716-446655440001"
+	kantongTujuanID = "550e8400-e29b-41d4-a716-446655440002"
+	transferID      = "550e8400-e29b-41d4-a716-446655440003"
+)
+
+func TestKantongUsecase_DeleteKantong_DipakaiSplit(t *testing.T) {
+	mockKantongRepo := new(MockKantongRepository)
+	kantongUsecase := usecase.NewKantongUsecase(mockKantongRepo, new(MockUserRepository))
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1, Nama: "Utama"}, nil)
+	mockKantongRepo.On("HasTransaksiSplit", kantongAsalID).Return(true, nil)
+
+	err := kantongUsecase.DeleteKantong(kantongAsalID, 1)
+
+	assert.EqualError(t, err, "kantong tidak dapat dihapus karena masih dipakai pada rincian transaksi")
+	mockKantongRepo.AssertExpectations(t)
+	mockKantongRepo.AssertNotCalled(t, "Delete", kantongAsalID, uint(1))
+}
+
+func TestKantongUsecase_DeleteKantong_Success(t *testing.T) {
+	mockKantongRepo := new(MockKantongRepository)
+	kantongUsecase := usecase.NewKantongUsecase(mockKantongRepo, new(MockUserRepository))
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1, Nama: "Utama"}, nil)
+	mockKantongRepo.On("HasTransaksiSplit", kantongAsalID).Return(false, nil)
+	mockKantongRepo.On("Delete", kantongAsalID, uint(1)).Return(nil)
+
+	err := kantongUsecase.DeleteKantong(kantongAsalID, 1)
+
+	assert.NoError(t, err)
+	mockKantongRepo.AssertExpectations(t)
+}
+
+func TestKantongUsecase_TransferKantong_PersistsTransfer(t *testing.T) {
+	mockKantongRepo := new(MockKantongRepository)
+	kantongUsecase := usecase.NewKantongUsecase(mockKantongRepo, new(MockUserRepository))
+
+	catatan := "tabungan bulanan"
+	createdAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1, Nama: "Utama", Saldo: domain.NewMoney(500000)}, nil)
+	mockKantongRepo.On("GetByID", kantongTujuanID, uint(1)).Return(&domain.Kantong{ID: kantongTujuanID, UserID: 1, Nama: "Tabungan", Saldo: domain.NewMoney(200000)}, nil)
+	mockKantongRepo.On("Transfer", mock.MatchedBy(func(transfer *domain.Transfer) bool {
+		return transfer.UserID == 1 &&
+			transfer.KantongAsalID == kantongAsalID &&
+			transfer.KantongTujuanID == kantongTujuanID &&
+			transfer.Jumlah == domain.NewMoney(100000) &&
+			transfer.Catatan != nil && *transfer.Catatan == catatan
+	})).Run(func(args mock.Arguments) {
+		transfer := args.Get(0).(*domain.Transfer)
+		transfer.ID = transferID
+		transfer.SaldoAsalSebelum = domain.NewMoney(500000)
+		transfer.SaldoAsalSesudah = domain.NewMoney(400000)
+		transfer.SaldoTujuanSebelum = domain.NewMoney(200000)
+		transfer.SaldoTujuanSesudah = domain.NewMoney(300000)
+		transfer.CreatedAt = createdAt
+	}).Return(
+		&domain.Kantong{ID: kantongAsalID, Nama: "Utama", Saldo: domain.NewMoney(400000)},
+		&domain.Kantong{ID: kantongTujuanID, Nama: "Tabungan", Saldo: domain.NewMoney(300000)},
+		nil,
+	)
+
+	result, err := kantongUsecase.TransferKantong(&domain.TransferKantongRequest{
+		KantongAsalID:   kantongAsalID,
+		KantongTujuanID: kantongTujuanID,
+		Jumlah:          domain.NewMoney(100000),
+		Catatan:         &catatan,
+	}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, transferID, result.Data.TransferID)
+	assert.Equal(t, &catatan, result.Data.Catatan)
+	assert.Equal(t, "Utama", result.Data.KantongAsal.Nama)
+	assert.Equal(t, domain.NewMoney(500000), result.Data.KantongAsal.SaldoSebelum)
+	assert.Equal(t, domain.NewMoney(300000), result.Data.KantongTujuan.SaldoSesudah)
+	assert.Equal(t, createdAt, result.Data.TanggalTransfer)
+	mockKantongRepo.AssertExpectations(t)
+}
+
+func TestKantongUsecase_TransferKantong_SameKantong(t *testing.T) {
+	mockKantongRepo := new(MockKantongRepository)
+	kantongUsecase := usecase.NewKantongUsecase(mockKantongRepo, new(MockUserRepository))
+
+	result, err := kantongUsecase.TransferKantong(&domain.TransferKantongRequest{
+		KantongAsalID:   kantongAsalID,
+		KantongTujuanID: kantongAsalID,
+		Jumlah:          domain.NewMoney(100000),
+	}, 1)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "kantong asal dan kantong tujuan tidak boleh sama")
+	mockKantongRepo.AssertNotCalled(t, "Transfer", mock.Anything)
+}
+
+func TestKantongUsecase_GetTransferList_NormalizesPagination(t *testing.T) {
+	mockKantongRepo := new(MockKantongRepository)
+	kantongUsecase := usecase.NewKantongUsecase(mockKantongRepo, new(MockUserRepository))
+
+	req := &domain.TransferListRequest{Page: 0, PerPage: 500}
+	transfers := []*domain.TransferResult{{TransferID: transferID}}
+
+	mockKantongRepo.On("GetTransfers", uint(1), req).Return(transfers, 150, nil)
+
+	result, meta, err := kantongUsecase.GetTransferList(1, req)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, 1, meta.CurrentPage)
+	assert.Equal(t, 100, meta.PerPage)
+	assert.Equal(t, 2, meta.TotalPages)
+	assert.Equal(t, 150, meta.TotalRecords)
+}
+
+func TestKantongUsecase_GetTransferByID_NotFound(t *testing.T) {
+	mockKantongRepo := new(MockKantongRepository)
+	kantongUsecase := usecase.NewKantongUsecase(mockKantongRepo, new(MockUserRepository))
+
+	mockKantongRepo.On("GetTransferByID", transferID, uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := kantongUsecase.GetTransferByID(transferID, 1)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "transfer tidak ditemukan")
+}
+
+func TestKantongUsecase_GetTransferByID_InvalidID(t *testing.T) {
+	mockKantongRepo := new(MockKantongRepository)
+	kantongUsecase := usecase.NewKantongUsecase(mockKantongRepo, new(MockUserRepository))
+
+	result, err := kantongUsecase.GetTransferByID("bukan-uuid", 1)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "transfer tidak ditemukan")
+	mockKantongRepo.AssertNotCalled(t, "GetTransferByID", mock.Anything, mock.Anything)
+}
+
+func TestKantongUsecase_ReverseTransfer_Success(t *testing.T) {
+	mockKantongRepo := new(MockKantongRepository)
+	kantongUsecase := usecase.NewKantongUsecase(mockKantongRepo, new(MockUserRepository))
+
+	catatan := "salah kantong"
+	originalID := transferID
+
+	mockKantongRepo.On("ReverseTransfer", transferID, uint(1), mock.MatchedBy(func(reversal *domain.Transfer) bool {
+		return reversal.Catatan != nil && *reversal.Catatan == catatan
+	})).Run(func(args mock.Arguments) {
+		reversal := args.Get(2).(*domain.Transfer)
+		reversal.ID = "550e8400-e29b-41d4-a716-446655440004"
+		reversal.KantongAsalID = kantongTujuanID
+		reversal.KantongTujuanID = kantongAsalID
+		reversal.Jumlah = domain.NewMoney(100000)
+		reversal.ReversalOfID = &originalID
+	}).Return(
+		&domain.Kantong{ID: kantongTujuanID, Nama: "Tabungan", Saldo: domain.NewMoney(200000)},
+		&domain.Kantong{ID: kantongAsalID, Nama: "Utama", Saldo: domain.NewMoney(500000)},
+		nil,
+	)
+
+	result, err := kantongUsecase.ReverseTransfer(transferID, &domain.ReverseTransferRequest{Catatan: &catatan}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440004", result.TransferID)
+	assert.Equal(t, transferID, *result.ReversalOfID)
+	assert.Equal(t, kantongTujuanID, result.KantongAsal.ID)
+	assert.Equal(t, "Tabungan", result.KantongAsal.Nama)
+	assert.Equal(t, "Utama", result.KantongTujuan.Nama)
+	mockKantongRepo.AssertExpectations(t)
+}
+
+func TestKantongUsecase_ReverseTransfer_AlreadyReversed(t *testing.T) {
+	mockKantongRepo := new(MockKantongRepository)
+	kantongUsecase := usecase.NewKantongUsecase(mockKantongRepo, new(MockUserRepository))
+
+	mockKantongRepo.On("ReverseTransfer", transferID, uint(1), mock.Anything).Return(nil, nil, errors.New("transfer sudah dibatalkan"))
+
+	result, err := kantongUsecase.ReverseTransfer(transferID, &domain.ReverseTransferRequest{}, 1)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "transfer sudah dibatalkan")
+}
@@ -0,0 +1,201 @@
+package usecase_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/storage"
+	"fiber-boiler-plate/internal/usecase"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLampiranTransaksiRepository struct {
+	mock.Mock
+}
+
+func (m *MockLampiranTransaksiRepository) Create(lampiran *domain.LampiranTransaksi, maxJumlah int) error {
+	args := m.Called(lampiran, maxJumlah)
+	return args.Error(0)
+}
+
+func (m *MockLampiranTransaksiRepository) GetByID(id, transaksiID string, userID uint) (*domain.LampiranTransaksi, error) {
+	args := m.Called(id, transaksiID, userID)
+	return args.Get(0).(*domain.LampiranTransaksi), args.Error(1)
+}
+
+func (m *MockLampiranTransaksiRepository) GetByTransaksiID(transaksiID string, userID uint) ([]*domain.LampiranTransaksi, error) {
+	args := m.Called(transaksiID, userID)
+	return args.Get(0).([]*domain.LampiranTransaksi), args.Error(1)
+}
+
+func (m *MockLampiranTransaksiRepository) CountByTransaksiID(transaksiID string, userID uint) (int, error) {
+	args := m.Called(transaksiID, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLampiranTransaksiRepository) Delete(id string, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+const (
+	lampiranTransaksiID = "550e8400-e29b-41d4-a716-446655440020"
+	lampiranID          = "550e8400-e29b-41d4-a716-446655440021"
+	isiPNG              = "\x89PNG\r\n\x1a\nisi gambar struk"
+)
+
+func setupLampiranTransaksiUsecase() (usecase.LampiranTransaksiUsecase, *MockLampiranTransaksiRepository, *MockTransaksiRepository, *storage.MemoryStorage) {
+	mockLampiranRepo := new(MockLampiranTransaksiRepository)
+	mockTransaksiRepo := new(MockTransaksiRepository)
+	blobStorage := storage.NewMemoryStorage()
+
+	lampiranUsecase := usecase.NewLampiranTransaksiUsecase(mockLampiranRepo, mockTransaksiRepo, blobStorage, 1024, 2)
+
+	return lampiranUsecase, mockLampiranRepo, mockTransaksiRepo, blobStorage
+}
+
+func TestUploadLampiran_Success(t *testing.T) {
+	lampiranUsecase, mockLampiranRepo, mockTransaksiRepo, blobStorage := setupLampiranTransaksiUsecase()
+
+	mockTransaksiRepo.On("GetByID", lampiranTransaksiID, uint(1)).Return(&domain.TransaksiResponse{ID: lampiranTransaksiID}, nil)
+	mockLampiranRepo.On("CountByTransaksiID", lampiranTransaksiID, uint(1)).Return(1, nil)
+	mockLampiranRepo.On("Create", mock.MatchedBy(func(lampiran *domain.LampiranTransaksi) bool {
+		return lampiran.ContentType == "image/png" &&
+			lampiran.NamaFile == "struk.png" &&
+			strings.HasPrefix(lampiran.StorageKey, "transaksi/1/"+lampiranTransaksiID+"/")
+	}), 2).Return(nil)
+
+	result, err := lampiranUsecase.UploadLampiran(lampiranTransaksiID, 1, "C:\\Users\\budi\\struk.png", int64(len(isiPNG)), strings.NewReader(isiPNG))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", result.ContentType)
+
+	object, ok := blobStorage.Object("transaksi/1/" + lampiranTransaksiID + "/" + result.ID)
+	assert.True(t, ok)
+	assert.Equal(t, isiPNG, string(object.Data))
+	assert.Equal(t, "image/png", object.ContentType)
+}
+
+func TestUploadLampiran_Validasi(t *testing.T) {
+	cases := []struct {
+		nama     string
+		isi      string
+		ukuran   int64
+		jumlah   int
+		expected string
+	}{
+		{"kosong", "", 0, 0, "file lampiran kosong"},
+		{"terlalu besar", isiPNG, 2048, 0, "ukuran file melebihi batas"},
+		{"batas jumlah", isiPNG, int64(len(isiPNG)), 2, "jumlah lampiran melebihi batas"},
+		{"tipe tidak didukung", "<html>skrip</html>", 18, 0, "tipe file tidak didukung"},
+	}
+
+	for _, c := range cases {
+		lampiranUsecase, mockLampiranRepo, mockTransaksiRepo, _ := setupLampiranTransaksiUsecase()
+		mockTransaksiRepo.On("GetByID", lampiranTransaksiID, uint(1)).Return(&domain.TransaksiResponse{ID: lampiranTransaksiID}, nil)
+		mockLampiranRepo.On("CountByTransaksiID", lampiranTransaksiID, uint(1)).Return(c.jumlah, nil)
+
+		result, err := lampiranUsecase.UploadLampiran(lampiranTransaksiID, 1, "file", c.ukuran, strings.NewReader(c.isi))
+
+		assert.Nil(t, result, c.nama)
+		assert.EqualError(t, err, c.expected, c.nama)
+		mockLampiranRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	}
+}
+
+func TestUploadLampiran_TransaksiTidakDitemukan(t *testing.T) {
+	lampiranUsecase, _, mockTransaksiRepo, _ := setupLampiranTransaksiUsecase()
+
+	mockTransaksiRepo.On("GetByID", lampiranTransaksiID, uint(1)).Return((*domain.TransaksiResponse)(nil), errors.New("transaksi tidak ditemukan"))
+
+	result, err := lampiranUsecase.UploadLampiran(lampiranTransaksiID, 1, "struk.png", int64(len(isiPNG)), strings.NewReader(isiPNG))
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "transaksi tidak ditemukan")
+}
+
+func TestUploadLampiran_GagalSimpanMenghapusBlob(t *testing.T) {
+	lampiranUsecase, mockLampiranRepo, mockTransaksiRepo, blobStorage := setupLampiranTransaksiUsecase()
+
+	var storageKey string
+	mockTransaksiRepo.On("GetByID", lampiranTransaksiID, uint(1)).Return(&domain.TransaksiResponse{ID: lampiranTransaksiID}, nil)
+	mockLampiranRepo.On("CountByTransaksiID", lampiranTransaksiID, uint(1)).Return(0, nil)
+	mockLampiranRepo.On("Create", mock.Anything, 2).Run(func(args mock.Arguments) {
+		storageKey = args.Get(0).(*domain.LampiranTransaksi).StorageKey
+	}).Return(errors.New("database error"))
+
+	result, err := lampiranUsecase.UploadLampiran(lampiranTransaksiID, 1, "struk.png", int64(len(isiPNG)), strings.NewReader(isiPNG))
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+	_, ok := blobStorage.Object(storageKey)
+	assert.False(t, ok)
+}
+
+func TestUploadLampiran_BatasTerlampauiSaatSimpan(t *testing.T) {
+	lampiranUsecase, mockLampiranRepo, mockTransaksiRepo, blobStorage := setupLampiranTransaksiUsecase()
+
+	var storageKey string
+	mockTransaksiRepo.On("GetByID", lampiranTransaksiID, uint(1)).Return(&domain.TransaksiResponse{ID: lampiranTransaksiID}, nil)
+	mockLampiranRepo.On("CountByTransaksiID", lampiranTransaksiID, uint(1)).Return(1, nil)
+	mockLampiranRepo.On("Create", mock.Anything, 2).Run(func(args mock.Arguments) {
+		storageKey = args.Get(0).(*domain.LampiranTransaksi).StorageKey
+	}).Return(domain.ErrJumlahLampiranMelebihiBatas)
+
+	result, err := lampiranUsecase.UploadLampiran(lampiranTransaksiID, 1, "struk.png", int64(len(isiPNG)), strings.NewReader(isiPNG))
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domain.ErrJumlahLampiranMelebihiBatas)
+	_, ok := blobStorage.Object(storageKey)
+	assert.False(t, ok)
+}
+
+func TestDownloadLampiran_Success(t *testing.T) {
+	lampiranUsecase, mockLampiranRepo, _, blobStorage := setupLampiranTransaksiUsecase()
+
+	blobStorage.Put("kunci-struk", strings.NewReader(isiPNG), int64(len(isiPNG)), "image/png")
+	mockLampiranRepo.On("GetByID", lampiranID, lampiranTransaksiID, uint(1)).Return(&domain.LampiranTransaksi{
+		ID:          lampiranID,
+		TransaksiID: lampiranTransaksiID,
+		NamaFile:    "struk.png",
+		ContentType: "image/png",
+		StorageKey:  "kunci-struk",
+	}, nil)
+
+	lampiran, reader, err := lampiranUsecase.DownloadLampiran(lampiranID, lampiranTransaksiID, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "struk.png", lampiran.NamaFile)
+	data, _ := io.ReadAll(reader)
+	assert.Equal(t, isiPNG, string(data))
+}
+
+func TestDownloadLampiran_BlobHilang(t *testing.T) {
+	lampiranUsecase, mockLampiranRepo, _, _ := setupLampiranTransaksiUsecase()
+
+	mockLampiranRepo.On("GetByID", lampiranID, lampiranTransaksiID, uint(1)).Return(&domain.LampiranTransaksi{ID: lampiranID, StorageKey: "tidak-ada"}, nil)
+
+	lampiran, reader, err := lampiranUsecase.DownloadLampiran(lampiranID, lampiranTransaksiID, 1)
+
+	assert.Nil(t, lampiran)
+	assert.Nil(t, reader)
+	assert.EqualError(t, err, "lampiran tidak ditemukan")
+}
+
+func TestDeleteLampiran_MenghapusBlob(t *testing.T) {
+	lampiranUsecase, mockLampiranRepo, _, blobStorage := setupLampiranTransaksiUsecase()
+
+	blobStorage.Put("kunci-struk", strings.NewReader(isiPNG), int64(len(isiPNG)), "image/png")
+	mockLampiranRepo.On("GetByID", lampiranID, lampiranTransaksiID, uint(1)).Return(&domain.LampiranTransaksi{ID: lampiranID, StorageKey: "kunci-struk"}, nil)
+	mockLampiranRepo.On("Delete", lampiranID, uint(1)).Return(nil)
+
+	err := lampiranUsecase.DeleteLampiran(lampiranID, lampiranTransaksiID, 1)
+
+	assert.NoError(t, err)
+	_, ok := blobStorage.Object("kunci-struk")
+	assert.False(t, ok)
+}
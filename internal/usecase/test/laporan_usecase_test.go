@@ -39,6 +39,11 @@ func (m *MockRedisRepository) Delete(key string) error {
 	return args.Error(0)
 }
 
+func (m *MockRedisRepository) SetNX(key string, value interface{}, ttl time.Duration) (bool, error) {
+	args := m.Called(key, value, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockRedisRepository) Exists(key string) (bool, error) {
 	args := m.Called(key)
 	return args.Bool(0), args.Error(1)
@@ -49,6 +54,11 @@ func (m *MockRedisRepository) Increment(key string) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockRedisRepository) IncrementWithExpire(key string, ttl time.Duration) (int64, error) {
+	args := m.Called(key, ttl)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockRedisRepository) Decrement(key string) (int64, error) {
 	args := m.Called(key)
 	return args.Get(0).(int64), args.Error(1)
@@ -74,6 +84,11 @@ func (m *MockRedisRepository) FlushAll() error {
 	return args.Error(0)
 }
 
+func (m *MockRedisRepository) DeleteIfEqual(key, value string) (bool, error) {
+	args := m.Called(key, value)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockRedisRepository) Ping() error {
 	args := m.Called()
 	return args.Error(0)
@@ -155,6 +170,14 @@ func (m *MockLaporanRepository) GetDetailPerbandinganKantong(userID uint, bulanI
 	return args.Get(0).(*domain.DetailPerbandinganKantong), args.Error(1)
 }
 
+func (m *MockLaporanRepository) GetPengeluaranPerTag(userID uint, tanggalMulai, tanggalSelesai time.Time) (*domain.PengeluaranPerTag, error) {
+	args := m.Called(userID, tanggalMulai, tanggalSelesai)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PengeluaranPerTag), args.Error(1)
+}
+
 func TestLaporanUsecase_GetRingkasanLaporan_Success(t *testing.T) {
 	mockLaporanRepo := new(MockLaporanRepository)
 	mockRedisRepo := new(MockRedisRepository)
@@ -164,10 +187,10 @@ func TestLaporanUsecase_GetRingkasanLaporan_Success(t *testing.T) {
 	req := &domain.RingkasanLaporanRequest{}
 
 	expectedData := &domain.RingkasanLaporan{
-		TotalPemasukan:            5000000,
-		TotalPengeluaran:          3500000,
-		TotalSaldo:                12500000,
-		RataRataPengeluaranHarian: 112903.23,
+		TotalPemasukan:            domain.NewMoney(5000000),
+		TotalPengeluaran:          domain.NewMoney(3500000),
+		TotalSaldo:                domain.NewMoney(12500000),
+		RataRataPengeluaranHarian: domain.NewMoneyFromFloat(112903.23),
 		Periode: domain.PeriodeTanggal{
 			TanggalMulai:   "2024-01-01",
 			TanggalSelesai: "2024-01-31",
@@ -206,12 +229,12 @@ func TestLaporanUsecase_GetStatistikTahunan_Success(t *testing.T) {
 			{
 				Bulan:            1,
 				NamaBulan:        "Januari",
-				TotalPemasukan:   5000000,
-				TotalPengeluaran: 3500000,
+				TotalPemasukan:   domain.NewMoney(5000000),
+				TotalPengeluaran: domain.NewMoney(3500000),
 			},
 		},
-		TotalPemasukanTahun:   60000000,
-		TotalPengeluaranTahun: 42000000,
+		TotalPemasukanTahun:   domain.NewMoney(60000000),
+		TotalPengeluaranTahun: domain.NewMoney(42000000),
 	}
 
 	mockRedisRepo.On("GetJSON", mock.AnythingOfType("string"), mock.AnythingOfType("*domain.StatistikTahunanResponse")).Return(assert.AnError)
@@ -255,10 +278,10 @@ func TestLaporanUsecase_GetStatistikKantongPeriode_Success(t *testing.T) {
 			{
 				KantongID:        "1",
 				KantongNama:      "Wallet Utama",
-				TotalPengeluaran: 3500000,
+				TotalPengeluaran: domain.NewMoney(3500000),
 			},
 		},
-		TotalPengeluaran: 3500000,
+		TotalPengeluaran: domain.NewMoney(3500000),
 	}
 
 	mockRedisRepo.On("GetJSON", mock.AnythingOfType("string"), mock.Anything).Return(assert.AnError)
@@ -303,15 +326,15 @@ func TestLaporanUsecase_GetPengeluaranKantongDetail_Success(t *testing.T) {
 			{
 				KantongID:           "1",
 				KantongNama:         "Wallet Utama",
-				TotalPengeluaran:    3500000,
+				TotalPengeluaran:    domain.NewMoney(3500000),
 				PersentaseDariSaldo: 75.5,
 				JumlahTransaksi:     25,
-				RataRataPengeluaran: 140000,
-				SaldoKantong:        2000000,
+				RataRataPengeluaran: domain.NewMoney(140000),
+				SaldoKantong:        domain.NewMoney(2000000),
 			},
 		},
-		TotalPengeluaran:       3500000,
-		TotalSaldoSemuaKantong: 2000000,
+		TotalPengeluaran:       domain.NewMoney(3500000),
+		TotalSaldoSemuaKantong: domain.NewMoney(2000000),
 	}
 
 	mockRedisRepo.On("GetJSON", mock.AnythingOfType("string"), mock.Anything).Return(assert.AnError)
@@ -348,18 +371,18 @@ func TestLaporanUsecase_GetTrenBulanan_Success(t *testing.T) {
 			{
 				Bulan:            1,
 				NamaBulan:        "Januari",
-				TotalPemasukan:   5000000,
-				TotalPengeluaran: 3500000,
+				TotalPemasukan:   domain.NewMoney(5000000),
+				TotalPengeluaran: domain.NewMoney(3500000),
 			},
 			{
 				Bulan:            2,
 				NamaBulan:        "Februari",
-				TotalPemasukan:   5200000,
-				TotalPengeluaran: 3800000,
+				TotalPemasukan:   domain.NewMoney(5200000),
+				TotalPengeluaran: domain.NewMoney(3800000),
 			},
 		},
-		TotalPemasukanTahun:   62400000,
-		TotalPengeluaranTahun: 45600000,
+		TotalPemasukanTahun:   domain.NewMoney(62400000),
+		TotalPengeluaranTahun: domain.NewMoney(45600000),
 	}
 
 	cacheKey := "tren_bulanan:1:2024"
@@ -412,12 +435,12 @@ func TestLaporanUsecase_GetPerbandinganKantong_Success(t *testing.T) {
 			{
 				KantongID:       "550e8400-e29b-41d4-a716-446655440001",
 				KantongNama:     "Kantong Belanja",
-				JumlahBulanIni:  1500000,
-				JumlahBulanLalu: 1200000,
+				JumlahBulanIni:  domain.NewMoney(1500000),
+				JumlahBulanLalu: domain.NewMoney(1200000),
 			},
 		},
-		TotalBulanIni:  2300000,
-		TotalBulanLalu: 1900000,
+		TotalBulanIni:  domain.NewMoney(2300000),
+		TotalBulanLalu: domain.NewMoney(1900000),
 	}
 
 	mockLaporanRepo.On("GetPerbandinganKantong", userID, mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(expectedData, nil)
@@ -464,16 +487,16 @@ func TestLaporanUsecase_GetDetailPerbandinganKantong_Success(t *testing.T) {
 			{
 				KantongID:           "550e8400-e29b-41d4-a716-446655440001",
 				KantongNama:         "Kantong Belanja",
-				JumlahBulanIni:      1500000,
-				JumlahBulanLalu:     1200000,
-				RataRataPengeluaran: 100000,
+				JumlahBulanIni:      domain.NewMoney(1500000),
+				JumlahBulanLalu:     domain.NewMoney(1200000),
+				RataRataPengeluaran: domain.NewMoney(100000),
 				Persentase:          25.0,
 				Trend:               "naik",
 			},
 		},
-		TotalBulanIni:   2300000,
-		TotalBulanLalu:  1900000,
-		RataRataTotal:   100000,
+		TotalBulanIni:   domain.NewMoney(2300000),
+		TotalBulanLalu:  domain.NewMoney(1900000),
+		RataRataTotal:   domain.NewMoney(100000),
 		PersentaseTotal: 21.05,
 		TrendTotal:      "naik",
 	}
@@ -504,3 +527,51 @@ func TestLaporanUsecase_GetDetailPerbandinganKantong_Success(t *testing.T) {
 	mockLaporanRepo.AssertExpectations(t)
 	mockRedisRepo.AssertExpectations(t)
 }
+
+func TestLaporanUsecase_GetPengeluaranPerTag_Success(t *testing.T) {
+	mockLaporanRepo := new(MockLaporanRepository)
+	mockRedisRepo := new(MockRedisRepository)
+	laporanUsecase := usecase.NewLaporanUsecase(mockLaporanRepo, mockRedisRepo)
+
+	userID := uint(1)
+	tanggalMulai := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	tanggalSelesai := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	tanggalMulaiStr := "2024-07-01"
+	tanggalSelesaiStr := "2024-07-31"
+	req := &domain.PengeluaranPerTagRequest{
+		TanggalMulai:   &tanggalMulaiStr,
+		TanggalSelesai: &tanggalSelesaiStr,
+	}
+
+	expectedData := &domain.PengeluaranPerTag{
+		Periode: domain.PeriodeTanggal{
+			TanggalMulai:   "2024-07-01",
+			TanggalSelesai: "2024-07-31",
+		},
+		DataTag: []domain.DataTagPengeluaran{
+			{
+				TagID:            "tag-1",
+				TagNama:          "liburan",
+				TotalPengeluaran: domain.NewMoney(2500000),
+				JumlahTransaksi:  4,
+				Persentase:       62.5,
+			},
+		},
+		TotalPengeluaran:    domain.NewMoney(4000000),
+		PengeluaranTanpaTag: domain.NewMoney(1500000),
+	}
+
+	mockRedisRepo.On("GetJSON", "pengeluaran_per_tag:1:2024-07-01:2024-07-31", mock.Anything).Return(assert.AnError)
+	mockLaporanRepo.On("GetPengeluaranPerTag", userID, tanggalMulai, tanggalSelesai).Return(expectedData, nil)
+	mockRedisRepo.On("SetJSON", "pengeluaran_per_tag:1:2024-07-01:2024-07-31", mock.Anything, mock.AnythingOfType("time.Duration")).Return(nil)
+
+	result, err := laporanUsecase.GetPengeluaranPerTag(userID, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Pengeluaran per tag berhasil diambil", result.Message)
+	assert.Len(t, result.Data.DataTag, 1)
+	assert.Equal(t, "liburan", result.Data.DataTag[0].TagNama)
+	assert.Equal(t, domain.NewMoney(1500000), result.Data.PengeluaranTanpaTag)
+	mockLaporanRepo.AssertExpectations(t)
+	mockRedisRepo.AssertExpectations(t)
+}
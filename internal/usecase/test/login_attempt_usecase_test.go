@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newLoginAttemptUsecase() usecase.LoginAttemptUsecase {
+	mockRedis := new(MockRedisRepository)
+	redisDown := errors.New("redis: connection refused")
+	mockRedis.On("IncrementWithExpire", mock.Anything, mock.Anything).Return(int64(0), redisDown)
+	mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(redisDown)
+	mockRedis.On("GetTTL", mock.Anything).Return(time.Duration(0), redisDown)
+	mockRedis.On("Delete", mock.Anything).Return(redisDown)
+
+	cfg := &config.Config{
+		Auth: config.AuthConfig{
+			BruteForce: config.BruteForceConfig{
+				MaxAttemptsPerEmail: 5,
+				MaxAttemptsPerIP:    20,
+				WindowMinutes:       15,
+				LockoutMinutes:      15,
+				DelayAfterAttempts:  3,
+				DelayBaseSeconds:    2,
+			},
+		},
+	}
+
+	return usecase.NewLoginAttemptUsecase(repo.NewLoginAttemptRepository(mockRedis), cfg)
+}
+
+func TestLoginAttemptUsecase_ProgressiveDelayThenLockout(t *testing.T) {
+	attemptUC := newLoginAttemptUsecase()
+	identifiers := []domain.AttemptIdentifier{{Kind: domain.AttemptIdentifierEmail, Value: "user@example.com"}}
+
+	assert.Equal(t, time.Duration(0), attemptUC.RegisterFailure("login", identifiers))
+	assert.Equal(t, time.Duration(0), attemptUC.RegisterFailure("login", identifiers))
+	assert.Equal(t, time.Duration(0), attemptUC.Check("login", identifiers))
+
+	assert.Equal(t, 2*time.Second, attemptUC.RegisterFailure("login", identifiers))
+	assert.Greater(t, attemptUC.Check("login", identifiers), time.Duration(0))
+
+	assert.Equal(t, 4*time.Second, attemptUC.RegisterFailure("login", identifiers))
+	assert.Equal(t, 15*time.Minute, attemptUC.RegisterFailure("login", identifiers))
+
+	retryAfter := attemptUC.Check("login", identifiers)
+	assert.Greater(t, retryAfter, 14*time.Minute)
+	assert.LessOrEqual(t, retryAfter, 15*time.Minute)
+}
+
+func TestLoginAttemptUsecase_IPHasSeparateLimit(t *testing.T) {
+	attemptUC := newLoginAttemptUsecase()
+	identifiers := []domain.AttemptIdentifier{{Kind: domain.AttemptIdentifierIP, Value: "10.0.0.1"}}
+
+	for i := 0; i < 5; i++ {
+		attemptUC.RegisterFailure("login", identifiers)
+	}
+
+	assert.Less(t, attemptUC.Check("login", identifiers), time.Minute)
+}
+
+func TestLoginAttemptUsecase_ResetClearsDelay(t *testing.T) {
+	attemptUC := newLoginAttemptUsecase()
+	identifiers := []domain.AttemptIdentifier{{Kind: domain.AttemptIdentifierEmail, Value: "User@Example.com"}}
+
+	for i := 0; i < 3; i++ {
+		attemptUC.RegisterFailure("login", identifiers)
+	}
+	assert.Greater(t, attemptUC.Check("login", identifiers), time.Duration(0))
+
+	attemptUC.Reset("login", []domain.AttemptIdentifier{{Kind: domain.AttemptIdentifierEmail, Value: "user@example.com"}})
+
+	assert.Equal(t, time.Duration(0), attemptUC.Check("login", identifiers))
+	assert.Equal(t, time.Duration(0), attemptUC.RegisterFailure("login", identifiers))
+}
+
+func TestLoginAttemptUsecase_ScopesAreIndependent(t *testing.T) {
+	attemptUC := newLoginAttemptUsecase()
+	identifiers := []domain.AttemptIdentifier{{Kind: domain.AttemptIdentifierEmail, Value: "user@example.com"}}
+
+	for i := 0; i < 5; i++ {
+		attemptUC.RegisterFailure("login", identifiers)
+	}
+
+	assert.Greater(t, attemptUC.Check("login", identifiers), time.Duration(0))
+	assert.Equal(t, time.Duration(0), attemptUC.Check("reset_password", identifiers))
+}
@@ -0,0 +1,246 @@
+package usecase_test
+
+import (
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type MockMFARepository struct {
+	mock.Mock
+}
+
+func (m *MockMFARepository) GetByUserID(userID uint) (*domain.UserMFA, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserMFA), args.Error(1)
+}
+
+func (m *MockMFARepository) Save(mfa *domain.UserMFA) error {
+	args := m.Called(mfa)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) Delete(userID uint) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) UpdateLastUsedStep(userID uint, step int64) (bool, error) {
+	args := m.Called(userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	args := m.Called(userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	args := m.Called(userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestMFAUsecase_Setup_Success(t *testing.T) {
+	mockMFARepo := new(MockMFARepository)
+	mockUserRepo := new(MockUserRepository)
+
+	cfg := &config.Config{App: config.AppConfig{Name: "Fast Track"}}
+	mfaUC := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, cfg)
+
+	mockUserRepo.On("GetByID", uint(1)).Return(&domain.User{ID: 1, Email: "test@example.com"}, nil)
+	mockMFARepo.On("GetByUserID", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	mockMFARepo.On("Save", mock.MatchedBy(func(mfa *domain.UserMFA) bool {
+		return mfa.UserID == 1 && mfa.Secret != "" && !mfa.IsEnabled
+	})).Return(nil)
+
+	result, err := mfaUC.Setup(1)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.Secret)
+	assert.True(t, strings.HasPrefix(result.OTPAuthURI, "otpauth://totp/Fast%20Track:test@example.com?"))
+	assert.Contains(t, result.OTPAuthURI, "secret="+result.Secret)
+
+	mockMFARepo.AssertExpectations(t)
+}
+
+func TestMFAUsecase_Setup_AlreadyEnabled(t *testing.T) {
+	mockMFARepo := new(MockMFARepository)
+	mockUserRepo := new(MockUserRepository)
+
+	mfaUC := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, &config.Config{})
+
+	mockUserRepo.On("GetByID", uint(1)).Return(&domain.User{ID: 1, Email: "test@example.com"}, nil)
+	mockMFARepo.On("GetByUserID", uint(1)).Return(&domain.UserMFA{UserID: 1, IsEnabled: true}, nil)
+
+	result, err := mfaUC.Setup(1)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "2FA sudah aktif", err.Error())
+	mockMFARepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestMFAUsecase_Confirm_Success(t *testing.T) {
+	mockMFARepo := new(MockMFARepository)
+	mockUserRepo := new(MockUserRepository)
+
+	mfaUC := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, &config.Config{})
+
+	secret, _ := helper.GenerateTOTPSecret()
+	code, _ := helper.GenerateTOTPCode(secret, time.Now())
+
+	mockMFARepo.On("GetByUserID", uint(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret}, nil)
+	mockMFARepo.On("Save", mock.MatchedBy(func(mfa *domain.UserMFA) bool {
+		return mfa.IsEnabled && mfa.EnabledAt != nil && mfa.LastUsedStep > 0
+	})).Return(nil)
+	mockMFARepo.On("ReplaceRecoveryCodes", uint(1), mock.AnythingOfType("[]string")).Return(nil)
+
+	result, err := mfaUC.Confirm(1, domain.MFAConfirmRequest{Code: code})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.RecoveryCodes, 10)
+
+	savedHashes := mockMFARepo.Calls[2].Arguments.Get(1).([]string)
+	assert.Equal(t, helper.HashRecoveryCode(result.RecoveryCodes[0]), savedHashes[0])
+	assert.NotEqual(t, result.RecoveryCodes[0], savedHashes[0])
+
+	mockMFARepo.AssertExpectations(t)
+}
+
+func TestMFAUsecase_Confirm_InvalidCode(t *testing.T) {
+	mockMFARepo := new(MockMFARepository)
+	mockUserRepo := new(MockUserRepository)
+
+	mfaUC := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, &config.Config{})
+
+	secret, _ := helper.GenerateTOTPSecret()
+	mockMFARepo.On("GetByUserID", uint(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret}, nil)
+
+	code, _ := helper.GenerateTOTPCode(secret, time.Now().Add(-time.Hour))
+	result, err := mfaUC.Confirm(1, domain.MFAConfirmRequest{Code: code})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "kode 2FA tidak valid", err.Error())
+	mockMFARepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestMFAUsecase_Confirm_NotSetup(t *testing.T) {
+	mockMFARepo := new(MockMFARepository)
+	mockUserRepo := new(MockUserRepository)
+
+	mfaUC := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, &config.Config{})
+
+	mockMFARepo.On("GetByUserID", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := mfaUC.Confirm(1, domain.MFAConfirmRequest{Code: "123456"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "2FA belum disiapkan", err.Error())
+}
+
+func TestMFAUsecase_Disable_Success(t *testing.T) {
+	mockMFARepo := new(MockMFARepository)
+	mockUserRepo := new(MockUserRepository)
+
+	mfaUC := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, &config.Config{})
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	secret, _ := helper.GenerateTOTPSecret()
+	code, _ := helper.GenerateTOTPCode(secret, time.Now())
+
+	mockUserRepo.On("GetByID", uint(1)).Return(&domain.User{ID: 1, Password: string(hashedPassword)}, nil)
+	mockMFARepo.On("GetByUserID", uint(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret, IsEnabled: true}, nil)
+	mockMFARepo.On("UpdateLastUsedStep", uint(1), mock.AnythingOfType("int64")).Return(true, nil)
+	mockMFARepo.On("Delete", uint(1)).Return(nil)
+
+	err := mfaUC.Disable(1, domain.MFADisableRequest{Password: "password123", Code: code})
+
+	assert.NoError(t, err)
+	mockMFARepo.AssertExpectations(t)
+}
+
+func TestMFAUsecase_Disable_WrongPassword(t *testing.T) {
+	mockMFARepo := new(MockMFARepository)
+	mockUserRepo := new(MockUserRepository)
+
+	mfaUC := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, &config.Config{})
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	mockUserRepo.On("GetByID", uint(1)).Return(&domain.User{ID: 1, Password: string(hashedPassword)}, nil)
+
+	err := mfaUC.Disable(1, domain.MFADisableRequest{Password: "wrongpassword", Code: "123456"})
+
+	assert.Error(t, err)
+	assert.Equal(t, "password salah", err.Error())
+	mockMFARepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestMFAUsecase_Disable_ReplayedCode(t *testing.T) {
+	mockMFARepo := new(MockMFARepository)
+	mockUserRepo := new(MockUserRepository)
+
+	mfaUC := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, &config.Config{})
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	secret, _ := helper.GenerateTOTPSecret()
+	code, _ := helper.GenerateTOTPCode(secret, time.Now())
+
+	mockUserRepo.On("GetByID", uint(1)).Return(&domain.User{ID: 1, Password: string(hashedPassword)}, nil)
+	mockMFARepo.On("GetByUserID", uint(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret, IsEnabled: true}, nil)
+	mockMFARepo.On("UpdateLastUsedStep", uint(1), mock.AnythingOfType("int64")).Return(false, nil)
+
+	err := mfaUC.Disable(1, domain.MFADisableRequest{Password: "password123", Code: code})
+
+	assert.Error(t, err)
+	assert.Equal(t, "kode 2FA tidak valid", err.Error())
+	mockMFARepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestMFAUsecase_RegenerateRecoveryCodes_NotEnabled(t *testing.T) {
+	mockMFARepo := new(MockMFARepository)
+	mockUserRepo := new(MockUserRepository)
+
+	mfaUC := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, &config.Config{})
+
+	mockMFARepo.On("GetByUserID", uint(1)).Return(&domain.UserMFA{UserID: 1, IsEnabled: false}, nil)
+
+	result, err := mfaUC.RegenerateRecoveryCodes(1, domain.MFARegenerateRecoveryCodesRequest{Code: "123456"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "2FA belum aktif", err.Error())
+}
+
+func TestMFAUsecase_RegenerateRecoveryCodes_Success(t *testing.T) {
+	mockMFARepo := new(MockMFARepository)
+	mockUserRepo := new(MockUserRepository)
+
+	mfaUC := usecase.NewMFAUsecase(mockMFARepo, mockUserRepo, &config.Config{})
+
+	secret, _ := helper.GenerateTOTPSecret()
+	code, _ := helper.GenerateTOTPCode(secret, time.Now())
+
+	mockMFARepo.On("GetByUserID", uint(1)).Return(&domain.UserMFA{UserID: 1, Secret: secret, IsEnabled: true}, nil)
+	mockMFARepo.On("UpdateLastUsedStep", uint(1), mock.AnythingOfType("int64")).Return(true, nil)
+	mockMFARepo.On("ReplaceRecoveryCodes", uint(1), mock.AnythingOfType("[]string")).Return(nil)
+
+	result, err := mfaUC.RegenerateRecoveryCodes(1, domain.MFARegenerateRecoveryCodesRequest{Code: code})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.RecoveryCodes, 10)
+	mockMFARepo.AssertExpectations(t)
+}
@@ -0,0 +1,267 @@
+package usecase_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/oauth"
+	"fiber-boiler-plate/internal/usecase"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockOAuthStateRepository struct {
+	mock.Mock
+}
+
+func (m *MockOAuthStateRepository) Create(state *domain.OAuthState) error {
+	args := m.Called(state)
+	return args.Error(0)
+}
+
+func (m *MockOAuthStateRepository) Consume(stateHash string) (*domain.OAuthState, error) {
+	args := m.Called(stateHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OAuthState), args.Error(1)
+}
+
+func (m *MockOAuthStateRepository) CleanupExpired() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockUserIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserIdentityRepository) GetByProviderSubject(provider, subject string) (*domain.UserIdentity, error) {
+	args := m.Called(provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) GetByUserID(userID uint) ([]*domain.UserIdentity, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) Create(identity *domain.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+type MockLoginCompleter struct {
+	mock.Mock
+}
+
+func (m *MockLoginCompleter) CompleteLogin(user *domain.User, meta domain.SessionMeta) (*domain.AuthResponse, error) {
+	args := m.Called(user, meta)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AuthResponse), args.Error(1)
+}
+
+type oauthTestSuite struct {
+	provider     *oauth.FakeProvider
+	stateRepo    *MockOAuthStateRepository
+	identityRepo *MockUserIdentityRepository
+	userRepo     *MockUserRepository
+	login        *MockLoginCompleter
+	usecase      usecase.OAuthUsecase
+}
+
+func newOAuthTestSuite(identity domain.OAuthIdentity) *oauthTestSuite {
+	s := &oauthTestSuite{
+		provider:     oauth.NewFakeProvider("http://localhost:3000/oauth/callback/fake", identity),
+		stateRepo:    new(MockOAuthStateRepository),
+		identityRepo: new(MockUserIdentityRepository),
+		userRepo:     new(MockUserRepository),
+		login:        new(MockLoginCompleter),
+	}
+	cfg := &config.Config{OAuth: config.OAuthConfig{StateTTLMinutes: 10, HTTPTimeoutSeconds: 5}}
+	s.usecase = usecase.NewOAuthUsecase(oauth.NewRegistry(s.provider), s.stateRepo, s.identityRepo, s.userRepo, s.login, cfg)
+	return s
+}
+
+func (s *oauthTestSuite) authorize(t *testing.T) *domain.OAuthCallbackRequest {
+	var stored *domain.OAuthState
+	s.stateRepo.On("Create", mock.AnythingOfType("*domain.OAuthState")).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*domain.OAuthState)
+	}).Return(nil).Once()
+
+	result, err := s.usecase.Start(domain.OAuthProviderFake)
+	assert.NoError(t, err)
+
+	redirect, err := url.Parse(result.AuthorizationURL)
+	assert.NoError(t, err)
+	assert.Equal(t, result.State, redirect.Query().Get("state"))
+	assert.Equal(t, oauth.HashState(result.State), stored.StateHash)
+
+	s.stateRepo.On("Consume", stored.StateHash).Return(stored, nil).Once()
+
+	return &domain.OAuthCallbackRequest{Code: redirect.Query().Get("code"), State: result.State}
+}
+
+func TestOAuthUsecase_Start_UnknownProvider(t *testing.T) {
+	s := newOAuthTestSuite(domain.OAuthIdentity{})
+
+	result, err := s.usecase.Start("myspace")
+
+	assert.Nil(t, result)
+	assert.Equal(t, "provider oauth tidak didukung", err.Error())
+}
+
+func TestOAuthUsecase_Callback_CreatesNewUser(t *testing.T) {
+	s := newOAuthTestSuite(domain.OAuthIdentity{Subject: "fake-1", Email: "baru@example.com", EmailVerified: true, Name: "Pengguna Baru"})
+	req := s.authorize(t)
+	meta := domain.SessionMeta{IPAddress: "127.0.0.1"}
+
+	s.identityRepo.On("GetByProviderSubject", domain.OAuthProviderFake, "fake-1").Return(nil, gorm.ErrRecordNotFound)
+	s.userRepo.On("GetByEmail", "baru@example.com").Return(nil, gorm.ErrRecordNotFound)
+	s.userRepo.On("Create", mock.MatchedBy(func(user *domain.User) bool {
+		return user.Email == "baru@example.com" && user.Name == "Pengguna Baru" && user.IsActive && user.EmailVerifiedAt != nil && user.Password != ""
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*domain.User).ID = 10
+	}).Return(nil)
+	s.identityRepo.On("Create", mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+		return identity.UserID == 10 && identity.Provider == domain.OAuthProviderFake && identity.Subject == "fake-1"
+	})).Return(nil)
+	s.login.On("CompleteLogin", mock.MatchedBy(func(user *domain.User) bool { return user.ID == 10 }), meta).
+		Return(&domain.AuthResponse{AccessToken: "access"}, nil)
+
+	result, err := s.usecase.Callback(domain.OAuthProviderFake, req, meta)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "access", result.AccessToken)
+	s.identityRepo.AssertExpectations(t)
+	s.login.AssertExpectations(t)
+}
+
+func TestOAuthUsecase_Callback_LinksExistingUserByVerifiedEmail(t *testing.T) {
+	s := newOAuthTestSuite(domain.OAuthIdentity{Subject: "fake-2", Email: "lama@example.com", EmailVerified: true})
+	req := s.authorize(t)
+	verifiedAt := time.Now().Add(-time.Hour)
+	existing := &domain.User{ID: 3, Email: "lama@example.com", IsActive: true, EmailVerifiedAt: &verifiedAt}
+
+	s.identityRepo.On("GetByProviderSubject", domain.OAuthProviderFake, "fake-2").Return(nil, gorm.ErrRecordNotFound)
+	s.userRepo.On("GetByEmail", "lama@example.com").Return(existing, nil)
+	s.identityRepo.On("Create", mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+		return identity.UserID == 3 && identity.Subject == "fake-2"
+	})).Return(nil)
+	s.login.On("CompleteLogin", existing, mock.Anything).Return(&domain.AuthResponse{AccessToken: "access"}, nil)
+
+	_, err := s.usecase.Callback(domain.OAuthProviderFake, req, domain.SessionMeta{})
+
+	assert.NoError(t, err)
+	s.userRepo.AssertNotCalled(t, "Create", mock.Anything)
+	s.userRepo.AssertExpectations(t)
+}
+
+func TestOAuthUsecase_Callback_TidakMenautkanAkunBelumTerverifikasi(t *testing.T) {
+	s := newOAuthTestSuite(domain.OAuthIdentity{Subject: "fake-5", Email: "korban@example.com", EmailVerified: true})
+	req := s.authorize(t)
+	existing := &domain.User{ID: 5, Email: "korban@example.com", IsActive: true}
+
+	s.identityRepo.On("GetByProviderSubject", domain.OAuthProviderFake, "fake-5").Return(nil, gorm.ErrRecordNotFound)
+	s.userRepo.On("GetByEmail", "korban@example.com").Return(existing, nil)
+
+	result, err := s.usecase.Callback(domain.OAuthProviderFake, req, domain.SessionMeta{})
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "email sudah terdaftar dan belum diverifikasi, silakan login dengan password lalu verifikasi email")
+	s.userRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything)
+	s.identityRepo.AssertNotCalled(t, "Create", mock.Anything)
+	s.login.AssertNotCalled(t, "CompleteLogin", mock.Anything, mock.Anything)
+}
+
+func TestOAuthUsecase_Callback_UsesLinkedIdentity(t *testing.T) {
+	s := newOAuthTestSuite(domain.OAuthIdentity{Subject: "fake-3", Email: "email-baru@example.com", EmailVerified: false})
+	req := s.authorize(t)
+	linkedUser := &domain.User{ID: 4, Email: "lama@example.com", IsActive: true}
+
+	s.identityRepo.On("GetByProviderSubject", domain.OAuthProviderFake, "fake-3").Return(&domain.UserIdentity{UserID: 4}, nil)
+	s.userRepo.On("FindByID", uint(4)).Return(linkedUser, nil)
+	s.login.On("CompleteLogin", linkedUser, mock.Anything).Return(&domain.AuthResponse{AccessToken: "access"}, nil)
+
+	_, err := s.usecase.Callback(domain.OAuthProviderFake, req, domain.SessionMeta{})
+
+	assert.NoError(t, err)
+	s.userRepo.AssertNotCalled(t, "GetByEmail", mock.Anything)
+	s.identityRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestOAuthUsecase_Callback_UnverifiedEmail(t *testing.T) {
+	s := newOAuthTestSuite(domain.OAuthIdentity{Subject: "fake-4", Email: "korban@example.com", EmailVerified: false})
+	req := s.authorize(t)
+
+	s.identityRepo.On("GetByProviderSubject", domain.OAuthProviderFake, "fake-4").Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := s.usecase.Callback(domain.OAuthProviderFake, req, domain.SessionMeta{})
+
+	assert.Nil(t, result)
+	assert.Equal(t, "email akun provider belum terverifikasi", err.Error())
+	s.userRepo.AssertNotCalled(t, "GetByEmail", mock.Anything)
+}
+
+func TestOAuthUsecase_Callback_InvalidState(t *testing.T) {
+	s := newOAuthTestSuite(domain.OAuthIdentity{})
+	s.stateRepo.On("Consume", oauth.HashState("palsu")).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := s.usecase.Callback(domain.OAuthProviderFake, &domain.OAuthCallbackRequest{Code: "kode", State: "palsu"}, domain.SessionMeta{})
+
+	assert.Nil(t, result)
+	assert.Equal(t, "state oauth tidak valid atau sudah kedaluwarsa", err.Error())
+}
+
+func TestOAuthUsecase_Callback_StateFromOtherProvider(t *testing.T) {
+	s := newOAuthTestSuite(domain.OAuthIdentity{})
+	s.stateRepo.On("Consume", oauth.HashState("state")).Return(&domain.OAuthState{Provider: domain.OAuthProviderGitHub, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+
+	_, err := s.usecase.Callback(domain.OAuthProviderFake, &domain.OAuthCallbackRequest{Code: "kode", State: "state"}, domain.SessionMeta{})
+
+	assert.Equal(t, "state oauth tidak valid atau sudah kedaluwarsa", err.Error())
+}
+
+func TestOAuthUsecase_Callback_CodeReplay(t *testing.T) {
+	s := newOAuthTestSuite(domain.OAuthIdentity{Subject: "fake-5", Email: "a@example.com", EmailVerified: true})
+	req := s.authorize(t)
+	linkedUser := &domain.User{ID: 5, IsActive: true}
+
+	s.identityRepo.On("GetByProviderSubject", domain.OAuthProviderFake, "fake-5").Return(&domain.UserIdentity{UserID: 5}, nil)
+	s.userRepo.On("FindByID", uint(5)).Return(linkedUser, nil)
+	s.login.On("CompleteLogin", linkedUser, mock.Anything).Return(&domain.AuthResponse{}, nil)
+
+	_, err := s.usecase.Callback(domain.OAuthProviderFake, req, domain.SessionMeta{})
+	assert.NoError(t, err)
+
+	s.stateRepo.On("Consume", oauth.HashState(req.State)).Return(&domain.OAuthState{Provider: domain.OAuthProviderFake, CodeVerifier: "x"}, nil).Once()
+	_, err = s.usecase.Callback(domain.OAuthProviderFake, req, domain.SessionMeta{})
+	assert.Equal(t, "gagal memverifikasi akun provider", err.Error())
+}
+
+func TestOAuthUsecase_Callback_LoginRejected(t *testing.T) {
+	s := newOAuthTestSuite(domain.OAuthIdentity{Subject: "fake-6", Email: "nonaktif@example.com", EmailVerified: true})
+	req := s.authorize(t)
+	user := &domain.User{ID: 6, IsActive: false}
+
+	s.identityRepo.On("GetByProviderSubject", domain.OAuthProviderFake, "fake-6").Return(&domain.UserIdentity{UserID: 6}, nil)
+	s.userRepo.On("FindByID", uint(6)).Return(user, nil)
+	s.login.On("CompleteLogin", user, mock.Anything).Return(nil, errors.New("akun dinonaktifkan"))
+
+	_, err := s.usecase.Callback(domain.OAuthProviderFake, req, domain.SessionMeta{})
+
+	assert.Equal(t, "akun dinonaktifkan", err.Error())
+}
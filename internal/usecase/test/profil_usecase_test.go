@@ -0,0 +1,91 @@
+package usecase_test
+
+import (
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newProfilUsecaseForPassword(password string) (usecase.ProfilUsecase, *MockUserRepository, *MockRefreshTokenRepository, *MockTokenRevocationRepository, *helper.JWTKeySet) {
+	mockUserRepo := new(MockUserRepository)
+	mockRedisRepo := new(MockRedisRepository)
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+	keys := helper.NewHMACKeySet("test-secret")
+	cfg := &config.Config{JWT: config.JWTConfig{ExpireHours: 1}}
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	mockUserRepo.On("GetByID", uint(1)).Return(&domain.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword)}, nil)
+
+	profilUC := usecase.NewProfilUsecase(mockUserRepo, mockRedisRepo, mockRefreshTokenRepo, mockTokenRevocationRepo, keys, cfg)
+	return profilUC, mockUserRepo, mockRefreshTokenRepo, mockTokenRevocationRepo, keys
+}
+
+func TestProfilUsecase_ChangePassword_KeepsCurrentSession(t *testing.T) {
+	profilUC, mockUserRepo, mockRefreshTokenRepo, mockTokenRevocationRepo, keys := newProfilUsecaseForPassword("oldpassword123")
+
+	mockUserRepo.On("UpdatePassword", "test@example.com", mock.AnythingOfType("string")).Return(nil)
+	mockRefreshTokenRepo.On("RevokeAllUserTokens", uint(1), []string{"family-5"}).Return(nil)
+	var invalidBefore time.Time
+	mockTokenRevocationRepo.On("InvalidateUserTokens", uint(1), mock.AnythingOfType("time.Time")).Run(func(args mock.Arguments) {
+		invalidBefore = args.Get(1).(time.Time)
+	}).Return(nil)
+
+	result, err := profilUC.ChangePassword(1, "family-5", domain.ChangePasswordRequest{CurrentPassword: "oldpassword123", NewPassword: "newpassword123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer", result.TokenType)
+	assert.Equal(t, 3600, result.ExpiresIn)
+
+	claims, err := helper.ValidateAccessToken(result.AccessToken, keys)
+	assert.NoError(t, err)
+	assert.Equal(t, "family-5", claims.SessionID)
+	assert.True(t, claims.IssuedAt.Time.After(invalidBefore.Truncate(time.Second)))
+
+	mockUserRepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertExpectations(t)
+	mockTokenRevocationRepo.AssertExpectations(t)
+}
+
+func TestProfilUsecase_ChangePassword_WrongCurrentPassword(t *testing.T) {
+	profilUC, mockUserRepo, mockRefreshTokenRepo, _, _ := newProfilUsecaseForPassword("oldpassword123")
+
+	result, err := profilUC.ChangePassword(1, "family-5", domain.ChangePasswordRequest{CurrentPassword: "wrongpassword", NewPassword: "newpassword123"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "password saat ini salah", err.Error())
+	mockUserRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+	mockRefreshTokenRepo.AssertNotCalled(t, "RevokeAllUserTokens", mock.Anything, mock.Anything)
+}
+
+func TestProfilUsecase_ChangePassword_SamePassword(t *testing.T) {
+	profilUC, mockUserRepo, _, _, _ := newProfilUsecaseForPassword("oldpassword123")
+
+	result, err := profilUC.ChangePassword(1, "family-5", domain.ChangePasswordRequest{CurrentPassword: "oldpassword123", NewPassword: "oldpassword123"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "password baru tidak boleh sama dengan password saat ini", err.Error())
+	mockUserRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+}
+
+func TestProfilUsecase_ChangePassword_WithoutSessionRevokesAll(t *testing.T) {
+	profilUC, mockUserRepo, mockRefreshTokenRepo, mockTokenRevocationRepo, _ := newProfilUsecaseForPassword("oldpassword123")
+
+	mockUserRepo.On("UpdatePassword", "test@example.com", mock.AnythingOfType("string")).Return(nil)
+	mockRefreshTokenRepo.On("RevokeAllUserTokens", uint(1), []string(nil)).Return(nil)
+	mockTokenRevocationRepo.On("InvalidateUserTokens", uint(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+	_, err := profilUC.ChangePassword(1, "", domain.ChangePasswordRequest{CurrentPassword: "oldpassword123", NewPassword: "newpassword123"})
+
+	assert.NoError(t, err)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
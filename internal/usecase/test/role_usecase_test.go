@@ -81,6 +81,14 @@ func (m *MockRoleRepository) ValidatePermissions(permissionIDs []string) ([]stri
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockRoleRepository) GetUserPermissions(userID uint) ([]string, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func TestRoleUsecase_GetRoleList(t *testing.T) {
 	t.Run("should return role list successfully", func(t *testing.T) {
 		mockRoleRepo := new(MockRoleRepository)
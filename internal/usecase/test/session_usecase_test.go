@@ -0,0 +1,86 @@
+package usecase_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/config"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+var sessionTestConfig = &config.Config{JWT: config.JWTConfig{ExpireHours: 24}}
+
+func TestSessionUsecase_GetSessions_MarksCurrent(t *testing.T) {
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+	sessionUC := usecase.NewSessionUsecase(mockRefreshTokenRepo, mockTokenRevocationRepo, sessionTestConfig)
+
+	now := time.Now()
+	refreshTokens := []*domain.RefreshToken{
+		{ID: 3, UserID: 1, Token: "secret-a", FamilyID: "family-a", DeviceName: "Chrome di Windows", IPAddress: "10.0.0.1", LastUsedAt: now},
+		{ID: 5, UserID: 1, Token: "secret-b", FamilyID: "family-b", DeviceName: "Safari di iOS", IPAddress: "10.0.0.2", LastUsedAt: now.Add(-time.Hour)},
+	}
+	mockRefreshTokenRepo.On("GetActiveByUserID", uint(1)).Return(refreshTokens, nil)
+
+	sessions, err := sessionUC.GetSessions(1, "family-b")
+
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.False(t, sessions[0].IsCurrent)
+	assert.True(t, sessions[1].IsCurrent)
+	assert.Equal(t, "Safari di iOS", sessions[1].DeviceName)
+
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func TestSessionUsecase_RevokeSession_NotFound(t *testing.T) {
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+	sessionUC := usecase.NewSessionUsecase(mockRefreshTokenRepo, mockTokenRevocationRepo, sessionTestConfig)
+
+	mockRefreshTokenRepo.On("RevokeUserToken", uint(1), uint(99)).Return("", gorm.ErrRecordNotFound)
+
+	err := sessionUC.RevokeSession(1, 99)
+
+	assert.Error(t, err)
+	assert.Equal(t, "sesi tidak ditemukan", err.Error())
+}
+
+func TestSessionUsecase_RevokeSession_Success(t *testing.T) {
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+	sessionUC := usecase.NewSessionUsecase(mockRefreshTokenRepo, mockTokenRevocationRepo, sessionTestConfig)
+
+	mockRefreshTokenRepo.On("RevokeUserToken", uint(1), uint(3)).Return("family-a", nil)
+	mockTokenRevocationRepo.On("RevokeSessionAccess", "family-a", 24*time.Hour).Return(nil)
+
+	err := sessionUC.RevokeSession(1, 3)
+
+	assert.NoError(t, err)
+	mockRefreshTokenRepo.AssertExpectations(t)
+	mockTokenRevocationRepo.AssertExpectations(t)
+}
+
+func TestSessionUsecase_RevokeAllSessions(t *testing.T) {
+	mockRefreshTokenRepo := new(MockRefreshTokenRepository)
+	mockTokenRevocationRepo := new(MockTokenRevocationRepository)
+	sessionUC := usecase.NewSessionUsecase(mockRefreshTokenRepo, mockTokenRevocationRepo, sessionTestConfig)
+
+	mockRefreshTokenRepo.On("RevokeAllUserTokens", uint(1), []string(nil)).Return(nil)
+	mockTokenRevocationRepo.On("InvalidateUserTokens", uint(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+	assert.NoError(t, sessionUC.RevokeAllSessions(1))
+	mockTokenRevocationRepo.AssertExpectations(t)
+
+	failingRepo := new(MockRefreshTokenRepository)
+	failingRepo.On("RevokeAllUserTokens", uint(1), []string(nil)).Return(errors.New("db down"))
+
+	err := usecase.NewSessionUsecase(failingRepo, new(MockTokenRevocationRepository), sessionTestConfig).RevokeAllSessions(1)
+	assert.Error(t, err)
+	assert.Equal(t, "gagal mengakhiri semua sesi", err.Error())
+}
@@ -81,7 +81,7 @@ func TestSubscriptionPlanUsecase_GetAll(t *testing.T) {
 			{
 				ID:            uuid.New(),
 				Nama:          "PRO Monthly",
-				Harga:         99000,
+				Harga:         domain.NewMoney(99000),
 				Interval:      "bulan",
 				HariPercobaan: 7,
 				Status:        "aktif",
@@ -129,7 +129,7 @@ func TestSubscriptionPlanUsecase_GetByID(t *testing.T) {
 		expectedPlan := &domain.SubscriptionPlan{
 			ID:            uuid.MustParse(planID),
 			Nama:          "PRO Monthly",
-			Harga:         99000,
+			Harga:         domain.NewMoney(99000),
 			Interval:      "bulan",
 			HariPercobaan: 7,
 			Status:        "aktif",
@@ -175,7 +175,7 @@ func TestSubscriptionPlanUsecase_Create(t *testing.T) {
 	t.Run("should create subscription plan successfully", func(t *testing.T) {
 		req := &domain.CreateSubscriptionPlanRequest{
 			Nama:          "PRO Monthly",
-			Harga:         99000,
+			Harga:         domain.NewMoney(99000),
 			Interval:      "bulan",
 			HariPercobaan: 7,
 			Status:        "aktif",
@@ -201,7 +201,7 @@ func TestSubscriptionPlanUsecase_Create(t *testing.T) {
 
 		req := &domain.CreateSubscriptionPlanRequest{
 			Nama:          "PRO Monthly",
-			Harga:         99000,
+			Harga:         domain.NewMoney(99000),
 			Interval:      "bulan",
 			HariPercobaan: 7,
 			Status:        "aktif",
@@ -227,7 +227,7 @@ func TestSubscriptionPlanUsecase_Delete(t *testing.T) {
 		existingPlan := &domain.SubscriptionPlan{
 			ID:            uuid.MustParse(planID),
 			Nama:          "PRO Monthly",
-			Harga:         99000,
+			Harga:         domain.NewMoney(99000),
 			Interval:      "bulan",
 			HariPercobaan: 7,
 			Status:        "aktif",
@@ -248,7 +248,7 @@ func TestSubscriptionPlanUsecase_Delete(t *testing.T) {
 		existingPlan := &domain.SubscriptionPlan{
 			ID:            uuid.MustParse(planID),
 			Nama:          "PRO Monthly",
-			Harga:         99000,
+			Harga:         domain.NewMoney(99000),
 			Interval:      "bulan",
 			HariPercobaan: 7,
 			Status:        "aktif",
@@ -0,0 +1,207 @@
+package usecase_test
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) GetByUserID(userID uint, req *domain.TagListRequest) ([]*domain.TagResponse, int, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).([]*domain.TagResponse), args.Int(1), args.Error(2)
+}
+
+func (m *MockTagRepository) GetByID(id string, userID uint) (*domain.Tag, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) CountTransaksi(id string) (int, error) {
+	args := m.Called(id)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTagRepository) Create(tag *domain.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Update(tag *domain.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Delete(id string, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) IsNamaExists(userID uint, nama string, excludeID ...string) (bool, error) {
+	args := m.Called(userID, nama, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+const tagID = "550e8400-e29b-41d4-a716-446655440030"
+
+func setupTagUsecase() (usecase.TagUsecase, *MockTagRepository, *MockRedisRepository) {
+	mockTagRepo := new(MockTagRepository)
+	mockRedisRepo := new(MockRedisRepository)
+
+	return usecase.NewTagUsecase(mockTagRepo, mockRedisRepo), mockTagRepo, mockRedisRepo
+}
+
+func TestCreateTag_NamaDinormalisasi(t *testing.T) {
+	tagUsecase, mockTagRepo, _ := setupTagUsecase()
+
+	mockTagRepo.On("IsNamaExists", uint(1), "liburan bali", []string(nil)).Return(false, nil)
+	mockTagRepo.On("Create", mock.MatchedBy(func(tag *domain.Tag) bool {
+		return tag.Nama == "liburan bali" && tag.UserID == 1
+	})).Return(nil)
+
+	result, err := tagUsecase.CreateTag(1, &domain.CreateTagRequest{Nama: "  liburan   bali "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "liburan bali", result.Nama)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestCreateTag_NamaSudahDigunakan(t *testing.T) {
+	tagUsecase, mockTagRepo, _ := setupTagUsecase()
+
+	mockTagRepo.On("IsNamaExists", uint(1), "Kantor", []string(nil)).Return(true, nil)
+
+	result, err := tagUsecase.CreateTag(1, &domain.CreateTagRequest{Nama: "Kantor"})
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "nama tag sudah digunakan")
+	mockTagRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateTag_NamaKosong(t *testing.T) {
+	tagUsecase, mockTagRepo, _ := setupTagUsecase()
+
+	result, err := tagUsecase.CreateTag(1, &domain.CreateTagRequest{Nama: "   "})
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "nama tag tidak boleh kosong")
+	mockTagRepo.AssertNotCalled(t, "IsNamaExists", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateTag_MenghapusCacheTransaksi(t *testing.T) {
+	tagUsecase, mockTagRepo, mockRedisRepo := setupTagUsecase()
+
+	mockTagRepo.On("GetByID", tagID, uint(1)).Return(&domain.Tag{ID: tagID, UserID: 1, Nama: "kantor"}, nil)
+	mockTagRepo.On("IsNamaExists", uint(1), "Kantor Pusat", []string{tagID}).Return(false, nil)
+	mockTagRepo.On("Update", mock.MatchedBy(func(tag *domain.Tag) bool {
+		return tag.Nama == "Kantor Pusat"
+	})).Return(nil)
+	mockTagRepo.On("CountTransaksi", tagID).Return(3, nil)
+	mockRedisRepo.On("GetKeys", "transaksi_list:1:*").Return([]string{"transaksi_list:1:a"}, nil)
+	mockRedisRepo.On("GetKeys", "transaksi_detail:*:1").Return([]string{"transaksi_detail:x:1"}, nil)
+	mockRedisRepo.On("Delete", "transaksi_list:1:a").Return(nil)
+	mockRedisRepo.On("Delete", "transaksi_detail:x:1").Return(nil)
+
+	result, err := tagUsecase.UpdateTag(tagID, 1, &domain.UpdateTagRequest{Nama: "Kantor Pusat"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Kantor Pusat", result.Nama)
+	assert.Equal(t, 3, result.JumlahTransaksi)
+	mockTagRepo.AssertExpectations(t)
+	mockRedisRepo.AssertExpectations(t)
+}
+
+func TestGetTagByID_IDTidakValid(t *testing.T) {
+	tagUsecase, mockTagRepo, _ := setupTagUsecase()
+
+	result, err := tagUsecase.GetTagByID("bukan-uuid", 1)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "tag tidak ditemukan")
+	mockTagRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestDeleteTag_TidakDitemukan(t *testing.T) {
+	tagUsecase, mockTagRepo, _ := setupTagUsecase()
+
+	mockTagRepo.On("Delete", tagID, uint(1)).Return(gorm.ErrRecordNotFound)
+
+	err := tagUsecase.DeleteTag(tagID, 1)
+
+	assert.EqualError(t, err, "tag tidak ditemukan")
+}
+
+func TestCreateTransaksi_DenganTag(t *testing.T) {
+	mockTransaksiRepo := new(MockTransaksiRepository)
+	mockKantongRepo := new(MockKantongRepository)
+	mockRedisRepo := new(MockRedisRepository)
+	transaksiUsecase := usecase.NewTransaksiUsecase(mockTransaksiRepo, mockKantongRepo, mockRedisRepo)
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockTransaksiRepo.On("Create", mock.MatchedBy(func(transaksi *domain.Transaksi) bool {
+		return len(transaksi.Tags) == 2 &&
+			transaksi.Tags[0] == domain.Tag{UserID: 1, Nama: "Liburan"} &&
+			transaksi.Tags[1] == domain.Tag{UserID: 1, Nama: "kantor"}
+	})).Return(nil)
+	mockTransaksiRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.TransaksiResponse{
+		Tags: []domain.TransaksiTagResponse{{ID: tagID, Nama: "Liburan"}},
+	}, nil)
+	mockRedisRepo.On("GetKeys", mock.Anything).Return([]string{}, nil)
+	mockRedisRepo.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRedisRepo.On("GetJSON", mock.Anything, mock.Anything).Return(assert.AnError)
+	mockRedisRepo.On("SetJSON", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	result, err := transaksiUsecase.CreateTransaksi(1, &domain.CreateTransaksiRequest{
+		KantongID: kantongAsalID,
+		Tanggal:   "2026-10-01",
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(50000),
+		Tags:      []string{" Liburan ", "kantor", "liburan"},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Data.Tags, 1)
+	mockTransaksiRepo.AssertExpectations(t)
+}
+
+func TestPatchTransaksi_TanpaTagTidakMengubahTag(t *testing.T) {
+	mockTransaksiRepo := new(MockTransaksiRepository)
+	mockKantongRepo := new(MockKantongRepository)
+	mockRedisRepo := new(MockRedisRepository)
+	transaksiUsecase := usecase.NewTransaksiUsecase(mockTransaksiRepo, mockKantongRepo, mockRedisRepo)
+
+	transaksiID := "550e8400-e29b-41d4-a716-446655440031"
+	catatan := "makan malam"
+
+	mockTransaksiRepo.On("GetByID", transaksiID, uint(1)).Return(&domain.TransaksiResponse{
+		ID:        transaksiID,
+		Tanggal:   "2026-10-01",
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(50000),
+		KantongID: kantongAsalID,
+	}, nil)
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockTransaksiRepo.On("Update", mock.MatchedBy(func(transaksi *domain.Transaksi) bool {
+		return transaksi.Tags == nil && transaksi.Catatan != nil && *transaksi.Catatan == catatan
+	})).Return(nil)
+	mockRedisRepo.On("GetKeys", mock.Anything).Return([]string{}, nil)
+	mockRedisRepo.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRedisRepo.On("Delete", mock.Anything).Return(nil)
+	mockRedisRepo.On("GetJSON", mock.Anything, mock.Anything).Return(assert.AnError)
+	mockRedisRepo.On("SetJSON", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := transaksiUsecase.PatchTransaksi(transaksiID, 1, &domain.PatchTransaksiRequest{Catatan: &catatan})
+
+	assert.NoError(t, err)
+	mockTransaksiRepo.AssertExpectations(t)
+}
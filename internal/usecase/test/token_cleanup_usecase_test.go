@@ -0,0 +1,54 @@
+package usecase_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenCleanupUsecase_CleanupExpiredTokens_Success(t *testing.T) {
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	mockResetRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationRepo := new(MockEmailVerificationTokenRepository)
+	mockOAuthStateRepo := new(MockOAuthStateRepository)
+
+	mockRefreshRepo.On("CleanupExpired").Return(int64(5), nil)
+	mockResetRepo.On("CleanupExpired").Return(int64(2), nil)
+	mockVerificationRepo.On("CleanupExpired").Return(int64(0), nil)
+	mockOAuthStateRepo.On("CleanupExpired").Return(int64(3), nil)
+
+	uc := usecase.NewTokenCleanupUsecase(mockRefreshRepo, mockResetRepo, mockVerificationRepo, mockOAuthStateRepo)
+	deleted, err := uc.CleanupExpiredTokens()
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		"refresh_tokens":            5,
+		"password_reset_tokens":     2,
+		"email_verification_tokens": 0,
+		"oauth_states":              3,
+	}, deleted)
+}
+
+func TestTokenCleanupUsecase_CleanupExpiredTokens_PartialFailure(t *testing.T) {
+	mockRefreshRepo := new(MockRefreshTokenRepository)
+	mockResetRepo := new(MockPasswordResetTokenRepository)
+	mockVerificationRepo := new(MockEmailVerificationTokenRepository)
+	mockOAuthStateRepo := new(MockOAuthStateRepository)
+
+	mockRefreshRepo.On("CleanupExpired").Return(int64(3), nil)
+	mockResetRepo.On("CleanupExpired").Return(int64(0), errors.New("database error"))
+	mockVerificationRepo.On("CleanupExpired").Return(int64(1), nil)
+	mockOAuthStateRepo.On("CleanupExpired").Return(int64(0), nil)
+
+	uc := usecase.NewTokenCleanupUsecase(mockRefreshRepo, mockResetRepo, mockVerificationRepo, mockOAuthStateRepo)
+	deleted, err := uc.CleanupExpiredTokens()
+
+	assert.Error(t, err)
+	assert.Equal(t, "gagal membersihkan sebagian token yang kedaluwarsa", err.Error())
+	assert.Equal(t, int64(3), deleted["refresh_tokens"])
+	assert.Equal(t, int64(1), deleted["email_verification_tokens"])
+	assert.NotContains(t, deleted, "password_reset_tokens")
+	mockVerificationRepo.AssertExpectations(t)
+}
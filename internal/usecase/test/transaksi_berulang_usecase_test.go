@@ -0,0 +1,581 @@
+package usecase_test
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockTransaksiBerulangRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransaksiBerulangRepository) Create(transaksiBerulang *domain.TransaksiBerulang) error {
+	args := m.Called(transaksiBerulang)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiBerulangRepository) GetByID(id string, userID uint) (*domain.TransaksiBerulang, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransaksiBerulang), args.Error(1)
+}
+
+func (m *MockTransaksiBerulangRepository) GetByUserID(userID uint, req *domain.TransaksiBerulangListRequest) ([]*domain.TransaksiBerulang, int, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).([]*domain.TransaksiBerulang), args.Int(1), args.Error(2)
+}
+
+func (m *MockTransaksiBerulangRepository) Update(transaksiBerulang *domain.TransaksiBerulang) error {
+	args := m.Called(transaksiBerulang)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiBerulangRepository) Delete(id string, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiBerulangRepository) GetDue(tanggal time.Time, limit int) ([]*domain.TransaksiBerulang, error) {
+	args := m.Called(tanggal, limit)
+	return args.Get(0).([]*domain.TransaksiBerulang), args.Error(1)
+}
+
+func (m *MockTransaksiBerulangRepository) AdvanceSchedule(transaksiBerulang *domain.TransaksiBerulang, urutanSebelum int) (bool, error) {
+	args := m.Called(transaksiBerulang, urutanSebelum)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransaksiBerulangRepository) CreateKejadian(kejadian *domain.KejadianTransaksiBerulang) (bool, error) {
+	args := m.Called(kejadian)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransaksiBerulangRepository) ReclaimKejadian(kejadian *domain.KejadianTransaksiBerulang, staleBefore time.Time) (bool, error) {
+	args := m.Called(kejadian, staleBefore)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransaksiBerulangRepository) UpdateKejadian(kejadian *domain.KejadianTransaksiBerulang) error {
+	args := m.Called(kejadian)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiBerulangRepository) DeleteKejadian(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiBerulangRepository) DeleteKejadianDilewati(transaksiBerulangID string, urutanMulai int) error {
+	args := m.Called(transaksiBerulangID, urutanMulai)
+	return args.Error(0)
+}
+
+type MockTransaksiUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTransaksiUsecase) GetTransaksiList(userID uint, req *domain.TransaksiListRequest) (*domain.TransaksiListResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransaksiListResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) GetTransaksiDetail(id string, userID uint) (*domain.TransaksiDetailResponse, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransaksiDetailResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) CreateTransaksi(userID uint, req *domain.CreateTransaksiRequest) (*domain.TransaksiDetailResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransaksiDetailResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) CreateTransaksiWithID(userID uint, id string, req *domain.CreateTransaksiRequest) (*domain.TransaksiDetailResponse, error) {
+	args := m.Called(userID, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransaksiDetailResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) UpdateTransaksi(id string, userID uint, req *domain.UpdateTransaksiRequest) (*domain.TransaksiDetailResponse, error) {
+	args := m.Called(id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransaksiDetailResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) PatchTransaksi(id string, userID uint, req *domain.PatchTransaksiRequest) (*domain.TransaksiDetailResponse, error) {
+	args := m.Called(id, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransaksiDetailResponse), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) DeleteTransaksi(id string, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiUsecase) PreviewImportTransaksi(userID uint, req *domain.ImportTransaksiRequest, file io.Reader) (*domain.ImportTransaksiPreview, error) {
+	args := m.Called(userID, req, file)
+	return args.Get(0).(*domain.ImportTransaksiPreview), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) ImportTransaksi(userID uint, req *domain.ImportTransaksiRequest, file io.Reader) (*domain.ImportTransaksiResult, error) {
+	args := m.Called(userID, req, file)
+	return args.Get(0).(*domain.ImportTransaksiResult), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) ExportTransaksi(userID uint, req *domain.TransaksiListRequest, format string) (func(w io.Writer) error, error) {
+	args := m.Called(userID, req, format)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(func(w io.Writer) error), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) BatchTransaksi(userID uint, req *domain.BatchTransaksiRequest) (*domain.BatchTransaksiResult, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).(*domain.BatchTransaksiResult), args.Error(1)
+}
+
+func (m *MockTransaksiUsecase) SetAnggaranUsecase(anggaranUsecase usecase.AnggaranUsecase) {
+}
+
+const transaksiBerulangID = "550e8400-e29b-41d4-a716-446655440010"
+
+func hariIniUTC() time.Time {
+	tahun, bulan, hari := time.Now().Date()
+	return time.Date(tahun, bulan, hari, 0, 0, 0, 0, time.UTC)
+}
+
+func newTransaksiBerulangHarian(mulai time.Time) *domain.TransaksiBerulang {
+	catatan := "Langganan"
+	return &domain.TransaksiBerulang{
+		ID:           transaksiBerulangID,
+		UserID:       1,
+		KantongID:    kantongAsalID,
+		Jenis:        "Pengeluaran",
+		Jumlah:       domain.NewMoney(50000),
+		Catatan:      &catatan,
+		Frekuensi:    domain.FrekuensiHarian,
+		Interval:     1,
+		TanggalMulai: mulai,
+		TanggalAcuan: mulai,
+		Status:       domain.StatusTransaksiBerulangAktif,
+	}
+}
+
+func setupTransaksiBerulangUsecase() (usecase.TransaksiBerulangUsecase, *MockTransaksiBerulangRepository, *MockKantongRepository, *MockTransaksiUsecase) {
+	mockRepo := new(MockTransaksiBerulangRepository)
+	mockKantongRepo := new(MockKantongRepository)
+	mockTransaksiUsecase := new(MockTransaksiUsecase)
+	uc := usecase.NewTransaksiBerulangUsecase(mockRepo, mockKantongRepo, mockTransaksiUsecase)
+	return uc, mockRepo, mockKantongRepo, mockTransaksiUsecase
+}
+
+func TestTransaksiBerulangUsecase_ProcessDueTransaksi_MaterializesDueOccurrences(t *testing.T) {
+	uc, mockRepo, _, mockTransaksiUsecase := setupTransaksiBerulangUsecase()
+
+	hariIni := hariIniUTC()
+	berulang := newTransaksiBerulangHarian(hariIni.AddDate(0, 0, -2))
+	berulang.Jadwalkan()
+
+	mockRepo.On("GetDue", hariIni, 100).Return([]*domain.TransaksiBerulang{berulang}, nil)
+	mockRepo.On("CreateKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang")).Return(true, nil)
+	mockRepo.On("UpdateKejadian", mock.MatchedBy(func(kejadian *domain.KejadianTransaksiBerulang) bool {
+		return kejadian.Status == domain.StatusKejadianDibuat && kejadian.TransaksiID != nil
+	})).Return(nil)
+	mockRepo.On("AdvanceSchedule", berulang, mock.AnythingOfType("int")).Return(true, nil)
+
+	var tanggalDibuat []string
+	var transaksiIDs []string
+	mockTransaksiUsecase.On("CreateTransaksiWithID", uint(1), mock.AnythingOfType("string"), mock.MatchedBy(func(req *domain.CreateTransaksiRequest) bool {
+		return req.KantongID == kantongAsalID &&
+			req.Jenis == "Pengeluaran" &&
+			req.Jumlah == domain.NewMoney(50000) &&
+			*req.Catatan == "Langganan"
+	})).Run(func(args mock.Arguments) {
+		transaksiIDs = append(transaksiIDs, args.String(1))
+		tanggalDibuat = append(tanggalDibuat, args.Get(2).(*domain.CreateTransaksiRequest).Tanggal)
+	}).Return(&domain.TransaksiDetailResponse{Data: domain.TransaksiResponse{ID: "trx-1"}}, nil)
+
+	counts, err := uc.ProcessDueTransaksi()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), counts["transaksis"])
+	assert.Equal(t, []string{
+		hariIni.AddDate(0, 0, -2).Format("2006-01-02"),
+		hariIni.AddDate(0, 0, -1).Format("2006-01-02"),
+		hariIni.Format("2006-01-02"),
+	}, tanggalDibuat)
+	assert.Len(t, transaksiIDs, 3)
+	assert.NotEqual(t, transaksiIDs[0], transaksiIDs[1])
+	assert.Equal(t, 3, berulang.Urutan)
+	assert.Equal(t, hariIni.AddDate(0, 0, 1), *berulang.TanggalBerikutnya)
+	mockRepo.AssertNumberOfCalls(t, "AdvanceSchedule", 3)
+}
+
+func TestTransaksiBerulangUsecase_ProcessDueTransaksi_SkipsClaimedOccurrence(t *testing.T) {
+	uc, mockRepo, _, mockTransaksiUsecase := setupTransaksiBerulangUsecase()
+
+	hariIni := hariIniUTC()
+	berulang := newTransaksiBerulangHarian(hariIni)
+	berulang.Jadwalkan()
+
+	mockRepo.On("GetDue", hariIni, 100).Return([]*domain.TransaksiBerulang{berulang}, nil)
+	mockRepo.On("CreateKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang")).Return(false, nil)
+	mockRepo.On("ReclaimKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang"), mock.AnythingOfType("time.Time")).Return(false, nil)
+	mockRepo.On("AdvanceSchedule", berulang, 0).Return(true, nil)
+
+	counts, err := uc.ProcessDueTransaksi()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), counts["transaksis"])
+	assert.Equal(t, int64(1), counts["kejadian_dilewati"])
+	mockTransaksiUsecase.AssertNotCalled(t, "CreateTransaksiWithID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransaksiBerulangUsecase_ProcessDueTransaksi_RecordsInsufficientSaldo(t *testing.T) {
+	uc, mockRepo, _, mockTransaksiUsecase := setupTransaksiBerulangUsecase()
+
+	hariIni := hariIniUTC()
+	berulang := newTransaksiBerulangHarian(hariIni)
+	berulang.Jadwalkan()
+
+	mockRepo.On("GetDue", hariIni, 100).Return([]*domain.TransaksiBerulang{berulang}, nil)
+	mockRepo.On("CreateKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang")).Return(true, nil)
+	mockRepo.On("UpdateKejadian", mock.MatchedBy(func(kejadian *domain.KejadianTransaksiBerulang) bool {
+		return kejadian.Status == domain.StatusKejadianGagal && *kejadian.Pesan == "saldo tidak mencukupi"
+	})).Return(nil)
+	mockRepo.On("AdvanceSchedule", berulang, 0).Return(true, nil)
+	mockTransaksiUsecase.On("CreateTransaksiWithID", uint(1), mock.AnythingOfType("string"), mock.AnythingOfType("*domain.CreateTransaksiRequest")).Return(nil, domain.ErrSaldoTidakMencukupi)
+
+	counts, err := uc.ProcessDueTransaksi()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), counts["kejadian_gagal"])
+	assert.Equal(t, domain.StatusTransaksiBerulangAktif, berulang.Status)
+	assert.Equal(t, 1, berulang.Urutan)
+}
+
+func TestTransaksiBerulangUsecase_ProcessDueTransaksi_PausesWhenKantongMissing(t *testing.T) {
+	uc, mockRepo, _, mockTransaksiUsecase := setupTransaksiBerulangUsecase()
+
+	hariIni := hariIniUTC()
+	berulang := newTransaksiBerulangHarian(hariIni.AddDate(0, 0, -1))
+	berulang.Jadwalkan()
+
+	mockRepo.On("GetDue", hariIni, 100).Return([]*domain.TransaksiBerulang{berulang}, nil)
+	mockRepo.On("CreateKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang")).Return(true, nil)
+	mockRepo.On("UpdateKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang")).Return(nil)
+	mockRepo.On("AdvanceSchedule", berulang, 0).Return(true, nil)
+	mockTransaksiUsecase.On("CreateTransaksiWithID", uint(1), mock.AnythingOfType("string"), mock.AnythingOfType("*domain.CreateTransaksiRequest")).Return(nil, domain.ErrKantongTidakDitemukan)
+
+	counts, err := uc.ProcessDueTransaksi()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), counts["kejadian_gagal"])
+	assert.Equal(t, domain.StatusTransaksiBerulangDijeda, berulang.Status)
+	mockTransaksiUsecase.AssertNumberOfCalls(t, "CreateTransaksiWithID", 1)
+}
+
+func TestTransaksiBerulangUsecase_ProcessDueTransaksi_ReleasesClaimOnError(t *testing.T) {
+	uc, mockRepo, _, mockTransaksiUsecase := setupTransaksiBerulangUsecase()
+
+	hariIni := hariIniUTC()
+	berulang := newTransaksiBerulangHarian(hariIni)
+	berulang.Jadwalkan()
+
+	mockRepo.On("GetDue", hariIni, 100).Return([]*domain.TransaksiBerulang{berulang}, nil)
+	mockRepo.On("CreateKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang")).Run(func(args mock.Arguments) {
+		args.Get(0).(*domain.KejadianTransaksiBerulang).ID = 7
+	}).Return(true, nil)
+	mockRepo.On("DeleteKejadian", uint(7)).Return(nil)
+	mockTransaksiUsecase.On("CreateTransaksiWithID", uint(1), mock.AnythingOfType("string"), mock.AnythingOfType("*domain.CreateTransaksiRequest")).Return(nil, errors.New("database error"))
+	mockTransaksiUsecase.On("GetTransaksiDetail", mock.AnythingOfType("string"), uint(1)).Return(nil, domain.ErrTransaksiTidakDitemukan)
+
+	counts, err := uc.ProcessDueTransaksi()
+
+	assert.Error(t, err)
+	assert.Equal(t, "gagal memproses sebagian transaksi berulang", err.Error())
+	assert.Equal(t, int64(0), counts["transaksis"])
+	assert.Equal(t, 0, berulang.Urutan)
+	mockRepo.AssertNotCalled(t, "AdvanceSchedule", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTransaksiBerulangUsecase_ProcessDueTransaksi_ReclaimsStaleOccurrence(t *testing.T) {
+	uc, mockRepo, _, mockTransaksiUsecase := setupTransaksiBerulangUsecase()
+
+	hariIni := hariIniUTC()
+	berulang := newTransaksiBerulangHarian(hariIni)
+	berulang.Jadwalkan()
+
+	mockRepo.On("GetDue", hariIni, 100).Return([]*domain.TransaksiBerulang{berulang}, nil)
+	mockRepo.On("CreateKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang")).Return(false, nil)
+	mockRepo.On("ReclaimKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang"), mock.MatchedBy(func(staleBefore time.Time) bool {
+		return staleBefore.Before(time.Now().Add(-29 * time.Minute))
+	})).Return(true, nil)
+	mockRepo.On("UpdateKejadian", mock.MatchedBy(func(kejadian *domain.KejadianTransaksiBerulang) bool {
+		return kejadian.Status == domain.StatusKejadianDibuat && kejadian.TransaksiID != nil
+	})).Return(nil)
+	mockRepo.On("AdvanceSchedule", berulang, 0).Return(true, nil)
+
+	var transaksiID string
+	mockTransaksiUsecase.On("GetTransaksiDetail", mock.AnythingOfType("string"), uint(1)).Run(func(args mock.Arguments) {
+		transaksiID = args.String(0)
+	}).Return(nil, domain.ErrTransaksiTidakDitemukan)
+	mockTransaksiUsecase.On("CreateTransaksiWithID", uint(1), mock.MatchedBy(func(id string) bool {
+		return id == transaksiID
+	}), mock.AnythingOfType("*domain.CreateTransaksiRequest")).Return(&domain.TransaksiDetailResponse{Data: domain.TransaksiResponse{ID: "trx-1"}}, nil)
+
+	counts, err := uc.ProcessDueTransaksi()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), counts["transaksis"])
+	assert.Equal(t, int64(0), counts["kejadian_dilewati"])
+	assert.Equal(t, 1, berulang.Urutan)
+	mockTransaksiUsecase.AssertExpectations(t)
+}
+
+func TestTransaksiBerulangUsecase_ProcessDueTransaksi_ReclaimTidakMembuatDuplikat(t *testing.T) {
+	uc, mockRepo, _, mockTransaksiUsecase := setupTransaksiBerulangUsecase()
+
+	hariIni := hariIniUTC()
+	berulang := newTransaksiBerulangHarian(hariIni)
+	berulang.Jadwalkan()
+
+	mockRepo.On("GetDue", hariIni, 100).Return([]*domain.TransaksiBerulang{berulang}, nil)
+	mockRepo.On("CreateKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang")).Return(false, nil)
+	mockRepo.On("ReclaimKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang"), mock.AnythingOfType("time.Time")).Return(true, nil)
+	mockRepo.On("UpdateKejadian", mock.MatchedBy(func(kejadian *domain.KejadianTransaksiBerulang) bool {
+		return kejadian.Status == domain.StatusKejadianDibuat && kejadian.TransaksiID != nil
+	})).Return(nil)
+	mockRepo.On("AdvanceSchedule", berulang, 0).Return(true, nil)
+	mockTransaksiUsecase.On("GetTransaksiDetail", mock.AnythingOfType("string"), uint(1)).Return(&domain.TransaksiDetailResponse{}, nil)
+
+	counts, err := uc.ProcessDueTransaksi()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), counts["transaksis"])
+	assert.Equal(t, int64(1), counts["kejadian_dipulihkan"])
+	mockTransaksiUsecase.AssertNotCalled(t, "CreateTransaksiWithID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransaksiBerulangUsecase_ProcessDueTransaksi_KejadianTetapDiprosesJikaTransaksiAda(t *testing.T) {
+	uc, mockRepo, _, mockTransaksiUsecase := setupTransaksiBerulangUsecase()
+
+	hariIni := hariIniUTC()
+	berulang := newTransaksiBerulangHarian(hariIni)
+	berulang.Jadwalkan()
+
+	mockRepo.On("GetDue", hariIni, 100).Return([]*domain.TransaksiBerulang{berulang}, nil)
+	mockRepo.On("CreateKejadian", mock.AnythingOfType("*domain.KejadianTransaksiBerulang")).Return(true, nil)
+	mockTransaksiUsecase.On("CreateTransaksiWithID", uint(1), mock.AnythingOfType("string"), mock.AnythingOfType("*domain.CreateTransaksiRequest")).Return(nil, errors.New("database error"))
+	mockTransaksiUsecase.On("GetTransaksiDetail", mock.AnythingOfType("string"), uint(1)).Return(&domain.TransaksiDetailResponse{}, nil)
+
+	_, err := uc.ProcessDueTransaksi()
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "DeleteKejadian", mock.Anything)
+	mockRepo.AssertNotCalled(t, "AdvanceSchedule", mock.Anything, mock.Anything)
+}
+
+func TestTransaksiBerulangUsecase_CreateTransaksiBerulang_Success(t *testing.T) {
+	uc, mockRepo, mockKantongRepo, _ := setupTransaksiBerulangUsecase()
+
+	mulai := hariIniUTC().AddDate(0, 0, 3)
+	jumlahKejadian := 12
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, Nama: "Utama"}, nil)
+	mockRepo.On("Create", mock.MatchedBy(func(berulang *domain.TransaksiBerulang) bool {
+		return berulang.Interval == 1 &&
+			berulang.TanggalAcuan.Equal(mulai) &&
+			berulang.TanggalBerikutnya != nil && berulang.TanggalBerikutnya.Equal(mulai) &&
+			berulang.Status == domain.StatusTransaksiBerulangAktif
+	})).Return(nil)
+
+	result, err := uc.CreateTransaksiBerulang(1, &domain.CreateTransaksiBerulangRequest{
+		KantongID:      kantongAsalID,
+		Jenis:          "Pemasukan",
+		Jumlah:         domain.NewMoney(8000000),
+		Frekuensi:      domain.FrekuensiBulanan,
+		TanggalMulai:   mulai.Format("2006-01-02"),
+		JumlahKejadian: &jumlahKejadian,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Utama", result.KantongNama)
+	assert.Equal(t, mulai.Format("2006-01-02"), *result.TanggalBerikutnya)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTransaksiBerulangUsecase_CreateTransaksiBerulang_RejectsPastStart(t *testing.T) {
+	uc, mockRepo, mockKantongRepo, _ := setupTransaksiBerulangUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID}, nil)
+
+	result, err := uc.CreateTransaksiBerulang(1, &domain.CreateTransaksiBerulangRequest{
+		KantongID:    kantongAsalID,
+		Jenis:        "Pengeluaran",
+		Jumlah:       domain.NewMoney(10000),
+		Frekuensi:    domain.FrekuensiHarian,
+		TanggalMulai: hariIniUTC().AddDate(0, 0, -1).Format("2006-01-02"),
+	})
+
+	assert.Nil(t, result)
+	assert.Equal(t, "tanggal mulai tidak boleh sebelum hari ini", err.Error())
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTransaksiBerulangUsecase_SkipKejadian_NextOccurrenceAdvancesSchedule(t *testing.T) {
+	uc, mockRepo, _, _ := setupTransaksiBerulangUsecase()
+
+	mulai := hariIniUTC().AddDate(0, 0, 1)
+	berulang := newTransaksiBerulangHarian(mulai)
+	berulang.Jadwalkan()
+
+	mockRepo.On("GetByID", transaksiBerulangID, uint(1)).Return(berulang, nil)
+	mockRepo.On("CreateKejadian", mock.MatchedBy(func(kejadian *domain.KejadianTransaksiBerulang) bool {
+		return kejadian.Urutan == 0 && kejadian.Status == domain.StatusKejadianDilewati && kejadian.Tanggal.Equal(mulai)
+	})).Return(true, nil)
+	mockRepo.On("Update", berulang).Return(nil)
+
+	result, err := uc.SkipKejadian(transaksiBerulangID, 1, &domain.LewatiKejadianRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, mulai.AddDate(0, 0, 1).Format("2006-01-02"), *result.TanggalBerikutnya)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTransaksiBerulangUsecase_SkipKejadian_FutureOccurrenceKeepsSchedule(t *testing.T) {
+	uc, mockRepo, _, _ := setupTransaksiBerulangUsecase()
+
+	mulai := hariIniUTC().AddDate(0, 0, 1)
+	berulang := newTransaksiBerulangHarian(mulai)
+	berulang.Jadwalkan()
+	tanggal := mulai.AddDate(0, 0, 4).Format("2006-01-02")
+
+	mockRepo.On("GetByID", transaksiBerulangID, uint(1)).Return(berulang, nil)
+	mockRepo.On("CreateKejadian", mock.MatchedBy(func(kejadian *domain.KejadianTransaksiBerulang) bool {
+		return kejadian.Urutan == 4
+	})).Return(true, nil)
+
+	result, err := uc.SkipKejadian(transaksiBerulangID, 1, &domain.LewatiKejadianRequest{Tanggal: &tanggal})
+
+	assert.NoError(t, err)
+	assert.Equal(t, mulai.Format("2006-01-02"), *result.TanggalBerikutnya)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestTransaksiBerulangUsecase_SkipKejadian_NotOnSchedule(t *testing.T) {
+	uc, mockRepo, _, _ := setupTransaksiBerulangUsecase()
+
+	berulang := newTransaksiBerulangHarian(hariIniUTC())
+	berulang.Frekuensi = domain.FrekuensiMingguan
+	berulang.Jadwalkan()
+	tanggal := hariIniUTC().AddDate(0, 0, 3).Format("2006-01-02")
+
+	mockRepo.On("GetByID", transaksiBerulangID, uint(1)).Return(berulang, nil)
+
+	result, err := uc.SkipKejadian(transaksiBerulangID, 1, &domain.LewatiKejadianRequest{Tanggal: &tanggal})
+
+	assert.Nil(t, result)
+	assert.Equal(t, "tanggal bukan jadwal transaksi berulang", err.Error())
+}
+
+func TestTransaksiBerulangUsecase_ResumeTransaksiBerulang_SkipsMissedOccurrences(t *testing.T) {
+	uc, mockRepo, _, _ := setupTransaksiBerulangUsecase()
+
+	hariIni := hariIniUTC()
+	berulang := newTransaksiBerulangHarian(hariIni.AddDate(0, 0, -3))
+	berulang.Status = domain.StatusTransaksiBerulangDijeda
+	berulang.Jadwalkan()
+
+	mockRepo.On("GetByID", transaksiBerulangID, uint(1)).Return(berulang, nil)
+	mockRepo.On("CreateKejadian", mock.MatchedBy(func(kejadian *domain.KejadianTransaksiBerulang) bool {
+		return kejadian.Status == domain.StatusKejadianDilewati && kejadian.Tanggal.Before(hariIni)
+	})).Return(true, nil)
+	mockRepo.On("Update", berulang).Return(nil)
+
+	result, err := uc.ResumeTransaksiBerulang(transaksiBerulangID, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusTransaksiBerulangAktif, result.Status)
+	assert.Equal(t, hariIni.Format("2006-01-02"), *result.TanggalBerikutnya)
+	mockRepo.AssertNumberOfCalls(t, "CreateKejadian", 3)
+}
+
+func TestTransaksiBerulangUsecase_PauseTransaksiBerulang_NotActive(t *testing.T) {
+	uc, mockRepo, _, _ := setupTransaksiBerulangUsecase()
+
+	berulang := newTransaksiBerulangHarian(hariIniUTC())
+	berulang.Status = domain.StatusTransaksiBerulangDijeda
+
+	mockRepo.On("GetByID", transaksiBerulangID, uint(1)).Return(berulang, nil)
+
+	result, err := uc.PauseTransaksiBerulang(transaksiBerulangID, 1)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "transaksi berulang tidak aktif", err.Error())
+}
+
+func TestTransaksiBerulangUsecase_PatchTransaksiBerulang_ChangesFutureSchedule(t *testing.T) {
+	uc, mockRepo, _, _ := setupTransaksiBerulangUsecase()
+
+	hariIni := hariIniUTC()
+	berulang := newTransaksiBerulangHarian(hariIni.AddDate(0, 0, -5))
+	berulang.Urutan = 6
+	berulang.Jadwalkan()
+
+	frekuensi := domain.FrekuensiMingguan
+	jumlah := domain.NewMoney(75000)
+
+	mockRepo.On("GetByID", transaksiBerulangID, uint(1)).Return(berulang, nil)
+	mockRepo.On("DeleteKejadianDilewati", transaksiBerulangID, 6).Return(nil)
+	mockRepo.On("Update", berulang).Return(nil)
+
+	result, err := uc.PatchTransaksiBerulang(transaksiBerulangID, 1, &domain.PatchTransaksiBerulangRequest{
+		Frekuensi: &frekuensi,
+		Jumlah:    &jumlah,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, jumlah, result.Jumlah)
+	assert.Equal(t, hariIni.AddDate(0, 0, 1).Format("2006-01-02"), *result.TanggalBerikutnya)
+	assert.Equal(t, hariIni.AddDate(0, 0, 8), berulang.TanggalKejadian(7))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTransaksiBerulangUsecase_GetTransaksiBerulangByID_NotFound(t *testing.T) {
+	uc, mockRepo, _, _ := setupTransaksiBerulangUsecase()
+
+	mockRepo.On("GetByID", transaksiBerulangID, uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+	result, err := uc.GetTransaksiBerulangByID(transaksiBerulangID, 1)
+
+	assert.Nil(t, result)
+	assert.Equal(t, "transaksi berulang tidak ditemukan", err.Error())
+}
@@ -0,0 +1,888 @@
+package usecase_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/usecase"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTransaksiRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransaksiRepository) GetByUserID(userID uint, req *domain.TransaksiListRequest) ([]*domain.TransaksiResponse, int, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).([]*domain.TransaksiResponse), args.Int(1), args.Error(2)
+}
+
+func (m *MockTransaksiRepository) GetByUserIDCursor(userID uint, req *domain.TransaksiListRequest, cursor *domain.TransaksiCursor, limit int) ([]*domain.TransaksiResponse, error) {
+	args := m.Called(userID, req, cursor, limit)
+	return args.Get(0).([]*domain.TransaksiResponse), args.Error(1)
+}
+
+func (m *MockTransaksiRepository) CountByUserID(userID uint, req *domain.TransaksiListRequest) (int, error) {
+	args := m.Called(userID, req)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTransaksiRepository) GetByID(id string, userID uint) (*domain.TransaksiResponse, error) {
+	args := m.Called(id, userID)
+	return args.Get(0).(*domain.TransaksiResponse), args.Error(1)
+}
+
+func (m *MockTransaksiRepository) GetByKantongAndPeriod(kantongID string, userID uint, tanggalMulai, tanggalSelesai time.Time) ([]*domain.Transaksi, error) {
+	args := m.Called(kantongID, userID, tanggalMulai, tanggalSelesai)
+	return args.Get(0).([]*domain.Transaksi), args.Error(1)
+}
+
+func (m *MockTransaksiRepository) StreamForExport(userID uint, req *domain.TransaksiListRequest, kelompokkanKantong bool, fn func(row *domain.TransaksiExportRow) error) error {
+	args := m.Called(userID, req, kelompokkanKantong)
+	for _, row := range args.Get(0).([]*domain.TransaksiExportRow) {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockTransaksiRepository) GetSaldoAwalKantong(userID uint, tanggalMulai *string) (map[string]domain.Money, error) {
+	args := m.Called(userID, tanggalMulai)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]domain.Money), args.Error(1)
+}
+
+func (m *MockTransaksiRepository) GetMutasiTransfer(userID uint, tanggalMulai, tanggalSelesai *string) ([]domain.MutasiTransferExport, error) {
+	args := m.Called(userID, tanggalMulai, tanggalSelesai)
+	return args.Get(0).([]domain.MutasiTransferExport), args.Error(1)
+}
+
+func (m *MockTransaksiRepository) Create(transaksi *domain.Transaksi) error {
+	args := m.Called(transaksi)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiRepository) CreateBatch(transaksis []*domain.Transaksi) error {
+	args := m.Called(transaksis)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiRepository) Update(transaksi *domain.Transaksi) error {
+	args := m.Called(transaksi)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiRepository) Delete(id string, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+func (m *MockTransaksiRepository) ExecuteBatch(userID uint, operasi []*domain.OperasiBatchTransaksi, atomik bool) error {
+	args := m.Called(userID, operasi, atomik)
+	return args.Error(0)
+}
+
+type MockAnggaranUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAnggaranUsecase) GetAnggaranList(userID uint, req *domain.AnggaranListRequest) ([]*domain.AnggaranResponse, *domain.PaginationMeta, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).([]*domain.AnggaranResponse), args.Get(1).(*domain.PaginationMeta), args.Error(2)
+}
+
+func (m *MockAnggaranUsecase) GetAnggaranDetail(kantongID string, userID uint, bulan, tahun *int) (*domain.AnggaranDetailResponse, error) {
+	args := m.Called(kantongID, userID, bulan, tahun)
+	return args.Get(0).(*domain.AnggaranDetailResponse), args.Error(1)
+}
+
+func (m *MockAnggaranUsecase) CreatePenyesuaianAnggaran(userID uint, req *domain.PenyesuaianAnggaranRequest) (*domain.AnggaranResponse, error) {
+	args := m.Called(userID, req)
+	return args.Get(0).(*domain.AnggaranResponse), args.Error(1)
+}
+
+func (m *MockAnggaranUsecase) CreateAnggaranForNewKantong(kantong *domain.Kantong) error {
+	args := m.Called(kantong)
+	return args.Error(0)
+}
+
+func (m *MockAnggaranUsecase) UpdateAnggaranAfterTransaction(kantongID string, userID uint) error {
+	args := m.Called(kantongID, userID)
+	return args.Error(0)
+}
+
+func (m *MockAnggaranUsecase) RecalculateAnggaranBulan(kantongID string, userID uint, bulan, tahun int) error {
+	args := m.Called(kantongID, userID, bulan, tahun)
+	return args.Error(0)
+}
+
+func setupTransaksiUsecase() (usecase.TransaksiUsecase, *MockTransaksiRepository, *MockKantongRepository, *MockRedisRepository, *MockAnggaranUsecase) {
+	mockTransaksiRepo := new(MockTransaksiRepository)
+	mockKantongRepo := new(MockKantongRepository)
+	mockRedisRepo := new(MockRedisRepository)
+	mockAnggaranUsecase := new(MockAnggaranUsecase)
+
+	transaksiUsecase := usecase.NewTransaksiUsecase(mockTransaksiRepo, mockKantongRepo, mockRedisRepo)
+	transaksiUsecase.SetAnggaranUsecase(mockAnggaranUsecase)
+
+	return transaksiUsecase, mockTransaksiRepo, mockKantongRepo, mockRedisRepo, mockAnggaranUsecase
+}
+
+func tanggalImport(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func TestPreviewImportTransaksi_FormatBankIndonesia(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, _, _ := setupTransaksiUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1, Saldo: domain.NewMoney(1000000)}, nil)
+	mockTransaksiRepo.On("GetByKantongAndPeriod", kantongAsalID, uint(1), tanggalImport("2026-10-01"), tanggalImport("2026-10-03")).Return([]*domain.Transaksi{}, nil)
+
+	csv := "Tanggal;Keterangan;Mutasi\n" +
+		"01/10/2026;Gaji Oktober;1.234.567,89 CR\n" +
+		"02/10/2026;Belanja bulanan;250.000,00 DB\n" +
+		"\n" +
+		"03/10/2026;Tarik tunai;-100.000\n"
+
+	req := &domain.ImportTransaksiRequest{
+		KantongID:       kantongAsalID,
+		KolomTanggal:    "Tanggal",
+		KolomJumlah:     "mutasi",
+		KolomKeterangan: "Keterangan",
+		FormatTanggal:   "DD/MM/YYYY",
+		PemisahKolom:    "titik_koma",
+	}
+
+	preview, err := transaksiUsecase.PreviewImportTransaksi(1, req, strings.NewReader(csv))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, preview.TotalBaris)
+	assert.Equal(t, 3, preview.BarisSiapImpor)
+	assert.Equal(t, domain.Money(123456789), preview.TotalPemasukan)
+	assert.Equal(t, domain.NewMoney(350000), preview.TotalPengeluaran)
+	assert.Equal(t, domain.Money(188456789), preview.SaldoSesudah)
+	assert.Equal(t, "Pemasukan", preview.Baris[0].Jenis)
+	assert.Equal(t, "Pengeluaran", preview.Baris[1].Jenis)
+	assert.Equal(t, "Gaji Oktober", *preview.Baris[0].Catatan)
+	assert.Equal(t, 5, preview.Baris[2].Baris)
+}
+
+func TestPreviewImportTransaksi_KolomDebitKreditDanBarisError(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, _, _ := setupTransaksiUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockTransaksiRepo.On("GetByKantongAndPeriod", kantongAsalID, uint(1), tanggalImport("2026-10-01"), tanggalImport("2026-10-01")).Return([]*domain.Transaksi{}, nil)
+
+	csv := "2026-10-01,Listrik,150.000,\n" +
+		"2026-13-01,Salah,10.000,\n" +
+		"2026-10-02,Kosong,,\n"
+
+	req := &domain.ImportTransaksiRequest{
+		KantongID:       kantongAsalID,
+		KolomTanggal:    "1",
+		KolomKeterangan: "2",
+		KolomDebit:      "3",
+		KolomKredit:     "4",
+		TanpaHeader:     true,
+	}
+
+	preview, err := transaksiUsecase.PreviewImportTransaksi(1, req, strings.NewReader(csv))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, preview.BarisSiapImpor)
+	assert.Equal(t, 2, preview.BarisError)
+	assert.Equal(t, "Pengeluaran", preview.Baris[0].Jenis)
+	assert.Equal(t, domain.NewMoney(150000), preview.Baris[0].Jumlah)
+	assert.Equal(t, "tanggal tidak sesuai format", preview.Baris[1].Error)
+	assert.Equal(t, "jumlah tidak boleh nol", preview.Baris[2].Error)
+}
+
+func TestPreviewImportTransaksi_KolomTidakDitemukan(t *testing.T) {
+	transaksiUsecase, _, mockKantongRepo, _, _ := setupTransaksiUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+
+	req := &domain.ImportTransaksiRequest{
+		KantongID:    kantongAsalID,
+		KolomTanggal: "Tanggal",
+		KolomJumlah:  "Nominal",
+	}
+
+	preview, err := transaksiUsecase.PreviewImportTransaksi(1, req, strings.NewReader("Tanggal,Jumlah\n2026-10-01,1000\n"))
+
+	assert.Nil(t, preview)
+	assert.EqualError(t, err, "kolom jumlah tidak ditemukan")
+}
+
+func TestPreviewImportTransaksi_TandaiDuplikat(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, _, _ := setupTransaksiUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockTransaksiRepo.On("GetByKantongAndPeriod", kantongAsalID, uint(1), tanggalImport("2026-10-01"), tanggalImport("2026-10-01")).Return([]*domain.Transaksi{
+		{Tanggal: tanggalImport("2026-10-01"), Jenis: "Pengeluaran", Jumlah: domain.NewMoney(25000)},
+	}, nil)
+
+	csv := "Tanggal,Jumlah\n2026-10-01,-25.000\n2026-10-01,-25.000\n"
+	req := &domain.ImportTransaksiRequest{
+		KantongID:    kantongAsalID,
+		KolomTanggal: "Tanggal",
+		KolomJumlah:  "Jumlah",
+	}
+
+	preview, err := transaksiUsecase.PreviewImportTransaksi(1, req, strings.NewReader(csv))
+
+	assert.NoError(t, err)
+	assert.True(t, preview.Baris[0].Duplikat)
+	assert.False(t, preview.Baris[1].Duplikat)
+	assert.Equal(t, 1, preview.BarisDuplikat)
+	assert.Equal(t, 1, preview.BarisSiapImpor)
+}
+
+func TestImportTransaksi_Success(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, mockRedisRepo, mockAnggaranUsecase := setupTransaksiUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockTransaksiRepo.On("GetByKantongAndPeriod", kantongAsalID, uint(1), tanggalImport("2026-10-01"), tanggalImport("2026-10-02")).Return([]*domain.Transaksi{
+		{Tanggal: tanggalImport("2026-10-01"), Jenis: "Pemasukan", Jumlah: domain.NewMoney(500000)},
+	}, nil)
+	mockTransaksiRepo.On("CreateBatch", mock.MatchedBy(func(transaksis []*domain.Transaksi) bool {
+		return len(transaksis) == 1 &&
+			transaksis[0].KantongID == kantongAsalID &&
+			transaksis[0].Jenis == "Pengeluaran" &&
+			transaksis[0].Jumlah == domain.NewMoney(75000) &&
+			transaksis[0].Tanggal.Equal(tanggalImport("2026-10-02"))
+	})).Return(nil)
+	mockAnggaranUsecase.On("RecalculateAnggaranBulan", kantongAsalID, uint(1), 10, 2026).Return(nil).Once()
+	mockRedisRepo.On("GetKeys", "transaksi_list:1:*").Return([]string{}, nil).Once()
+	mockRedisRepo.On("Set", "cache_disabled:1", "1", 5*time.Second).Return(nil).Once()
+
+	csv := "Tanggal,Jumlah\n2026-10-01,500.000\n2026-10-02,-75.000\n"
+	req := &domain.ImportTransaksiRequest{
+		KantongID:    kantongAsalID,
+		KolomTanggal: "Tanggal",
+		KolomJumlah:  "Jumlah",
+	}
+
+	result, err := transaksiUsecase.ImportTransaksi(1, req, strings.NewReader(csv))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Diimpor)
+	assert.Equal(t, 1, result.DuplikatDilewati)
+	assert.Equal(t, domain.NewMoney(75000), result.TotalPengeluaran)
+	mockTransaksiRepo.AssertExpectations(t)
+	mockAnggaranUsecase.AssertExpectations(t)
+	mockRedisRepo.AssertExpectations(t)
+}
+
+func TestImportTransaksi_BulanLaluDibebankanKeAnggaranBulannya(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, mockRedisRepo, mockAnggaranUsecase := setupTransaksiUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockTransaksiRepo.On("GetByKantongAndPeriod", kantongAsalID, uint(1), tanggalImport("2026-07-30"), tanggalImport("2026-09-02")).Return([]*domain.Transaksi{}, nil)
+	mockTransaksiRepo.On("CreateBatch", mock.MatchedBy(func(transaksis []*domain.Transaksi) bool {
+		return len(transaksis) == 3 &&
+			transaksis[0].Tanggal.Equal(tanggalImport("2026-07-30")) &&
+			transaksis[1].Tanggal.Equal(tanggalImport("2026-08-15")) &&
+			transaksis[2].Tanggal.Equal(tanggalImport("2026-09-02"))
+	})).Return(nil)
+	mockAnggaranUsecase.On("RecalculateAnggaranBulan", kantongAsalID, uint(1), 7, 2026).Return(nil).Once()
+	mockAnggaranUsecase.On("RecalculateAnggaranBulan", kantongAsalID, uint(1), 8, 2026).Return(nil).Once()
+	mockAnggaranUsecase.On("RecalculateAnggaranBulan", kantongAsalID, uint(1), 9, 2026).Return(nil).Once()
+	mockRedisRepo.On("GetKeys", "transaksi_list:1:*").Return([]string{}, nil).Once()
+	mockRedisRepo.On("Set", "cache_disabled:1", "1", 5*time.Second).Return(nil).Once()
+
+	csv := "Tanggal,Jumlah\n2026-07-30,-20.000\n2026-08-15,-30.000\n2026-09-02,-40.000\n"
+	req := &domain.ImportTransaksiRequest{
+		KantongID:    kantongAsalID,
+		KolomTanggal: "Tanggal",
+		KolomJumlah:  "Jumlah",
+	}
+
+	result, err := transaksiUsecase.ImportTransaksi(1, req, strings.NewReader(csv))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Diimpor)
+	mockAnggaranUsecase.AssertExpectations(t)
+	mockAnggaranUsecase.AssertNotCalled(t, "UpdateAnggaranAfterTransaction", mock.Anything, mock.Anything)
+}
+
+func TestImportTransaksi_BarisTidakValidMembatalkanImport(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, _, mockAnggaranUsecase := setupTransaksiUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockTransaksiRepo.On("GetByKantongAndPeriod", kantongAsalID, uint(1), tanggalImport("2026-10-01"), tanggalImport("2026-10-01")).Return([]*domain.Transaksi{}, nil)
+
+	csv := "Tanggal,Jumlah\n2026-10-01,10.000\n2026-10-02,sepuluh\n"
+	req := &domain.ImportTransaksiRequest{
+		KantongID:    kantongAsalID,
+		KolomTanggal: "Tanggal",
+		KolomJumlah:  "Jumlah",
+	}
+
+	result, err := transaksiUsecase.ImportTransaksi(1, req, strings.NewReader(csv))
+
+	assert.EqualError(t, err, "terdapat baris yang tidak valid")
+	assert.Len(t, result.BarisError, 1)
+	assert.Equal(t, 3, result.BarisError[0].Baris)
+	mockTransaksiRepo.AssertNotCalled(t, "CreateBatch", mock.Anything)
+	mockAnggaranUsecase.AssertNotCalled(t, "RecalculateAnggaranBulan", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportTransaksi_SaldoTidakMencukupi(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, _, mockAnggaranUsecase := setupTransaksiUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockTransaksiRepo.On("GetByKantongAndPeriod", kantongAsalID, uint(1), tanggalImport("2026-10-01"), tanggalImport("2026-10-01")).Return([]*domain.Transaksi{}, nil)
+	mockTransaksiRepo.On("CreateBatch", mock.Anything).Return(errors.New("saldo tidak mencukupi"))
+
+	req := &domain.ImportTransaksiRequest{
+		KantongID:    kantongAsalID,
+		KolomTanggal: "Tanggal",
+		KolomJumlah:  "Jumlah",
+	}
+
+	result, err := transaksiUsecase.ImportTransaksi(1, req, strings.NewReader("Tanggal,Jumlah\n2026-10-01,-10.000\n"))
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "saldo tidak mencukupi")
+	mockAnggaranUsecase.AssertNotCalled(t, "RecalculateAnggaranBulan", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func dataExport() []*domain.TransaksiExportRow {
+	catatan := "Gaji & bonus"
+	return []*domain.TransaksiExportRow{
+		{ID: "trx-1", Tanggal: tanggalImport("2026-10-01"), Jenis: "Pemasukan", Jumlah: domain.NewMoney(1000000), KantongID: "kantong-a", KantongNama: "Utama", Catatan: &catatan},
+		{ID: "trx-2", Tanggal: tanggalImport("2026-10-02"), Jenis: "Pengeluaran", Jumlah: domain.Money(2500050), KantongID: "kantong-b", KantongNama: "Tabungan"},
+		{ID: "trx-3", Tanggal: tanggalImport("2026-10-03"), Jenis: "Pengeluaran", Jumlah: domain.NewMoney(300000), KantongID: "kantong-a", KantongNama: "Utama"},
+	}
+}
+
+func mockSaldoExport(mockTransaksiRepo *MockTransaksiRepository, req *domain.TransaksiListRequest) {
+	mockTransaksiRepo.On("GetSaldoAwalKantong", uint(1), req.TanggalMulai).Return(map[string]domain.Money{
+		"kantong-a": domain.NewMoney(50000),
+		"kantong-b": domain.NewMoney(100000),
+	}, nil)
+	mockTransaksiRepo.On("GetMutasiTransfer", uint(1), req.TanggalMulai, req.TanggalSelesai).Return([]domain.MutasiTransferExport{
+		{KantongID: "kantong-a", Tanggal: tanggalImport("2026-10-02"), Jumlah: domain.NewMoney(-200000)},
+		{KantongID: "kantong-b", Tanggal: tanggalImport("2026-10-02"), Jumlah: domain.NewMoney(200000)},
+	}, nil)
+}
+
+func TestExportTransaksi_CSVDenganSaldoBerjalanPerKantong(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, _, _ := setupTransaksiUsecase()
+
+	req := &domain.TransaksiListRequest{SortBy: "tanggal", SortDirection: "desc", Page: 1, PerPage: 10}
+	mockSaldoExport(mockTransaksiRepo, req)
+	mockTransaksiRepo.On("StreamForExport", uint(1), req, false).Return(dataExport(), nil)
+
+	var output strings.Builder
+	tulis, err := transaksiUsecase.ExportTransaksi(1, req, domain.FormatExportCSV)
+	assert.NoError(t, err)
+	err = tulis(&output)
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(strings.TrimPrefix(output.String(), "\ufeff")), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, "Tanggal,Kantong,Jenis,Jumlah,Saldo Berjalan,Catatan,ID", lines[0])
+	assert.Equal(t, "2026-10-01,Utama,Pemasukan,1000000.00,1050000.00,Gaji & bonus,trx-1", lines[1])
+	assert.Equal(t, "2026-10-02,Tabungan,Pengeluaran,25000.50,274999.50,,trx-2", lines[2])
+	assert.Equal(t, "2026-10-03,Utama,Pengeluaran,300000.00,550000.00,,trx-3", lines[3])
+}
+
+func TestExportTransaksi_CSVMenetralkanFormula(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, _, _ := setupTransaksiUsecase()
+
+	jenis := "Pengeluaran"
+	req := &domain.TransaksiListRequest{Jenis: &jenis}
+	catatan := "=HYPERLINK(\"http://contoh.test\")"
+	mockTransaksiRepo.On("StreamForExport", uint(1), req, false).Return([]*domain.TransaksiExportRow{
+		{ID: "trx-1", Tanggal: tanggalImport("2026-10-01"), Jenis: "Pengeluaran", Jumlah: domain.NewMoney(5000), KantongNama: "@Utama", Catatan: &catatan},
+	}, nil)
+
+	var output strings.Builder
+	tulis, err := transaksiUsecase.ExportTransaksi(1, req, domain.FormatExportCSV)
+	assert.NoError(t, err)
+	err = tulis(&output)
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(strings.TrimPrefix(output.String(), "\ufeff")), "\n")
+	assert.Equal(t, `2026-10-01,'@Utama,Pengeluaran,5000.00,"'=HYPERLINK(""http://contoh.test"")",trx-1`, lines[1])
+}
+
+func TestExportTransaksi_FilterTanpaSaldoBerjalan(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, _, _ := setupTransaksiUsecase()
+
+	jenis := "Pengeluaran"
+	req := &domain.TransaksiListRequest{Jenis: &jenis}
+	rows := dataExport()
+	mockTransaksiRepo.On("StreamForExport", uint(1), req, false).Return([]*domain.TransaksiExportRow{rows[1], rows[2]}, nil)
+
+	var output strings.Builder
+	tulis, err := transaksiUsecase.ExportTransaksi(1, req, domain.FormatExportCSV)
+	assert.NoError(t, err)
+	err = tulis(&output)
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(strings.TrimPrefix(output.String(), "\ufeff")), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "Tanggal,Kantong,Jenis,Jumlah,Catatan,ID", lines[0])
+	assert.Equal(t, "2026-10-02,Tabungan,Pengeluaran,25000.50,,trx-2", lines[1])
+	mockTransaksiRepo.AssertNotCalled(t, "GetSaldoAwalKantong", mock.Anything, mock.Anything)
+	mockTransaksiRepo.AssertNotCalled(t, "GetMutasiTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExportTransaksi_OFXDenganFilterTanpaLedgerBal(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, _, _ := setupTransaksiUsecase()
+
+	search := "gaji"
+	req := &domain.TransaksiListRequest{Search: &search}
+	rows := dataExport()
+	mockTransaksiRepo.On("StreamForExport", uint(1), req, true).Return([]*domain.TransaksiExportRow{rows[0]}, nil)
+
+	var output strings.Builder
+	tulis, err := transaksiUsecase.ExportTransaksi(1, req, domain.FormatExportOFX)
+	assert.NoError(t, err)
+	err = tulis(&output)
+
+	assert.NoError(t, err)
+	ofx := output.String()
+	assert.NotContains(t, ofx, "<LEDGERBAL>")
+	assert.Contains(t, ofx, "</BANKTRANLIST></STMTRS></STMTTRNRS>")
+}
+
+func TestExportTransaksi_XLSX(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, _, _ := setupTransaksiUsecase()
+
+	req := &domain.TransaksiListRequest{}
+	mockSaldoExport(mockTransaksiRepo, req)
+	mockTransaksiRepo.On("StreamForExport", uint(1), req, false).Return(dataExport(), nil)
+
+	var output bytes.Buffer
+	tulis, err := transaksiUsecase.ExportTransaksi(1, req, domain.FormatExportXLSX)
+	assert.NoError(t, err)
+	err = tulis(&output)
+	assert.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	assert.NoError(t, err)
+
+	var sheet string
+	for _, file := range archive.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			reader, _ := file.Open()
+			isi, _ := io.ReadAll(reader)
+			sheet = string(isi)
+		}
+	}
+
+	assert.Len(t, archive.File, 5)
+	assert.Contains(t, sheet, "Gaji &amp; bonus")
+	assert.Contains(t, sheet, "<c><v>550000.00</v></c>")
+	assert.Equal(t, 4, strings.Count(sheet, "<row>"))
+}
+
+func TestExportTransaksi_OFXDikelompokkanPerKantong(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, _, _ := setupTransaksiUsecase()
+
+	tanggalSelesai := "2026-10-31"
+	req := &domain.TransaksiListRequest{TanggalSelesai: &tanggalSelesai}
+	rows := dataExport()
+	mockSaldoExport(mockTransaksiRepo, req)
+	mockTransaksiRepo.On("StreamForExport", uint(1), req, true).Return([]*domain.TransaksiExportRow{rows[0], rows[2], rows[1]}, nil)
+
+	var output strings.Builder
+	tulis, err := transaksiUsecase.ExportTransaksi(1, req, domain.FormatExportOFX)
+	assert.NoError(t, err)
+	err = tulis(&output)
+
+	assert.NoError(t, err)
+	ofx := output.String()
+	assert.Equal(t, 2, strings.Count(ofx, "<STMTTRNRS>"))
+	assert.Contains(t, ofx, "<ACCTID>kantong-a</ACCTID>")
+	assert.Contains(t, ofx, "<DTSTART>20261001</DTSTART><DTEND>20261031</DTEND>")
+	assert.Contains(t, ofx, "<TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20261003</DTPOSTED><TRNAMT>-300000.00</TRNAMT><FITID>trx-3</FITID>")
+	assert.Contains(t, ofx, "<NAME>Gaji &amp; bonus</NAME>")
+	assert.Contains(t, ofx, "<LEDGERBAL><BALAMT>550000.00</BALAMT><DTASOF>20261003</DTASOF></LEDGERBAL>")
+	assert.Contains(t, ofx, "<LEDGERBAL><BALAMT>274999.50</BALAMT><DTASOF>20261002</DTASOF></LEDGERBAL>")
+	assert.True(t, strings.HasSuffix(ofx, "</BANKMSGSRSV1>\n</OFX>\n"))
+}
+
+func TestExportTransaksi_RepositoryError(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, _, _ := setupTransaksiUsecase()
+
+	req := &domain.TransaksiListRequest{}
+	mockSaldoExport(mockTransaksiRepo, req)
+	mockTransaksiRepo.On("StreamForExport", uint(1), req, false).Return([]*domain.TransaksiExportRow{}, errors.New("database error"))
+
+	var output strings.Builder
+	tulis, err := transaksiUsecase.ExportTransaksi(1, req, domain.FormatExportCSV)
+	assert.NoError(t, err)
+	err = tulis(&output)
+
+	assert.EqualError(t, err, "database error")
+}
+
+func TestExportTransaksi_SaldoAwalGagalSebelumStream(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, _, _ := setupTransaksiUsecase()
+
+	req := &domain.TransaksiListRequest{}
+	mockTransaksiRepo.On("GetSaldoAwalKantong", uint(1), req.TanggalMulai).Return(nil, errors.New("database error"))
+
+	tulis, err := transaksiUsecase.ExportTransaksi(1, req, domain.FormatExportCSV)
+
+	assert.EqualError(t, err, "database error")
+	assert.Nil(t, tulis)
+	mockTransaksiRepo.AssertNotCalled(t, "StreamForExport", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateTransaksi_SplitKeBeberapaKantong(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, mockRedisRepo, mockAnggaranUsecase := setupTransaksiUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockKantongRepo.On("GetByID", kantongTujuanID, uint(1)).Return(&domain.Kantong{ID: kantongTujuanID, UserID: 1}, nil)
+	mockTransaksiRepo.On("Create", mock.MatchedBy(func(transaksi *domain.Transaksi) bool {
+		return transaksi.KantongID == kantongAsalID &&
+			len(transaksi.Splits) == 2 &&
+			transaksi.Splits[0].KantongID == kantongAsalID &&
+			transaksi.Splits[0].Jumlah == domain.NewMoney(60000) &&
+			transaksi.Splits[1].KantongID == kantongTujuanID &&
+			transaksi.Splits[1].Jumlah == domain.NewMoney(40000)
+	})).Return(nil)
+	mockTransaksiRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.TransaksiResponse{
+		KantongID: kantongAsalID,
+		Jumlah:    domain.NewMoney(100000),
+		Splits: []domain.TransaksiSplitResponse{
+			{KantongID: kantongAsalID, Jumlah: domain.NewMoney(60000)},
+			{KantongID: kantongTujuanID, Jumlah: domain.NewMoney(40000)},
+		},
+	}, nil)
+	mockAnggaranUsecase.On("UpdateAnggaranAfterTransaction", kantongAsalID, uint(1)).Return(nil).Once()
+	mockAnggaranUsecase.On("UpdateAnggaranAfterTransaction", kantongTujuanID, uint(1)).Return(nil).Once()
+	mockRedisRepo.On("GetKeys", mock.Anything).Return([]string{}, nil)
+	mockRedisRepo.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRedisRepo.On("GetJSON", mock.Anything, mock.Anything).Return(assert.AnError)
+	mockRedisRepo.On("SetJSON", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	result, err := transaksiUsecase.CreateTransaksi(1, &domain.CreateTransaksiRequest{
+		Tanggal: "2026-10-01",
+		Jenis:   "Pengeluaran",
+		Jumlah:  domain.NewMoney(100000),
+		Splits: []domain.TransaksiSplitRequest{
+			{KantongID: kantongAsalID, Jumlah: domain.NewMoney(60000)},
+			{KantongID: kantongTujuanID, Jumlah: domain.NewMoney(40000)},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Data.Splits, 2)
+	mockTransaksiRepo.AssertExpectations(t)
+	mockAnggaranUsecase.AssertExpectations(t)
+}
+
+func TestCreateTransaksi_SplitTidakValid(t *testing.T) {
+	cases := []struct {
+		splits   []domain.TransaksiSplitRequest
+		expected string
+	}{
+		{
+			[]domain.TransaksiSplitRequest{{KantongID: kantongAsalID, Jumlah: domain.NewMoney(100000)}},
+			"split minimal terdiri dari 2 kantong",
+		},
+		{
+			[]domain.TransaksiSplitRequest{
+				{KantongID: kantongAsalID, Jumlah: domain.NewMoney(60000)},
+				{KantongID: kantongTujuanID, Jumlah: domain.NewMoney(30000)},
+			},
+			"total split harus sama dengan jumlah transaksi",
+		},
+		{
+			[]domain.TransaksiSplitRequest{
+				{KantongID: kantongAsalID, Jumlah: domain.NewMoney(60000)},
+				{KantongID: kantongAsalID, Jumlah: domain.NewMoney(40000)},
+			},
+			"kantong split tidak boleh duplikat",
+		},
+	}
+
+	for _, c := range cases {
+		transaksiUsecase, mockTransaksiRepo, mockKantongRepo, _, _ := setupTransaksiUsecase()
+		mockKantongRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Kantong{UserID: 1}, nil)
+
+		_, err := transaksiUsecase.CreateTransaksi(1, &domain.CreateTransaksiRequest{
+			Tanggal: "2026-10-01",
+			Jenis:   "Pengeluaran",
+			Jumlah:  domain.NewMoney(100000),
+			Splits:  c.splits,
+		})
+
+		assert.EqualError(t, err, c.expected)
+		mockTransaksiRepo.AssertNotCalled(t, "Create", mock.Anything)
+	}
+}
+
+func TestPatchTransaksi_KantongMenghapusSplit(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, mockRedisRepo, mockAnggaranUsecase := setupTransaksiUsecase()
+
+	transaksiID := "550e8400-e29b-41d4-a716-446655440041"
+	mockTransaksiRepo.On("GetByID", transaksiID, uint(1)).Return(&domain.TransaksiResponse{
+		ID:        transaksiID,
+		Tanggal:   "2026-10-01",
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(100000),
+		KantongID: kantongAsalID,
+		Splits: []domain.TransaksiSplitResponse{
+			{KantongID: kantongAsalID, Jumlah: domain.NewMoney(60000)},
+			{KantongID: kantongTujuanID, Jumlah: domain.NewMoney(40000)},
+		},
+	}, nil)
+	mockKantongRepo.On("GetByID", kantongTujuanID, uint(1)).Return(&domain.Kantong{ID: kantongTujuanID, UserID: 1}, nil)
+	mockTransaksiRepo.On("Update", mock.MatchedBy(func(transaksi *domain.Transaksi) bool {
+		return transaksi.KantongID == kantongTujuanID && len(transaksi.Splits) == 0
+	})).Return(nil)
+	mockAnggaranUsecase.On("UpdateAnggaranAfterTransaction", kantongAsalID, uint(1)).Return(nil).Once()
+	mockAnggaranUsecase.On("UpdateAnggaranAfterTransaction", kantongTujuanID, uint(1)).Return(nil).Once()
+	mockRedisRepo.On("GetKeys", mock.Anything).Return([]string{}, nil)
+	mockRedisRepo.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRedisRepo.On("Delete", mock.Anything).Return(nil)
+	mockRedisRepo.On("GetJSON", mock.Anything, mock.Anything).Return(assert.AnError)
+	mockRedisRepo.On("SetJSON", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	kantongID := kantongTujuanID
+	_, err := transaksiUsecase.PatchTransaksi(transaksiID, 1, &domain.PatchTransaksiRequest{KantongID: &kantongID})
+
+	assert.NoError(t, err)
+	mockTransaksiRepo.AssertExpectations(t)
+	mockAnggaranUsecase.AssertExpectations(t)
+}
+
+func TestPatchTransaksi_JumlahSplitTidakSesuai(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, _, _ := setupTransaksiUsecase()
+
+	transaksiID := "550e8400-e29b-41d4-a716-446655440042"
+	mockTransaksiRepo.On("GetByID", transaksiID, uint(1)).Return(&domain.TransaksiResponse{
+		ID:        transaksiID,
+		Tanggal:   "2026-10-01",
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(100000),
+		KantongID: kantongAsalID,
+		Splits: []domain.TransaksiSplitResponse{
+			{KantongID: kantongAsalID, Jumlah: domain.NewMoney(60000)},
+			{KantongID: kantongTujuanID, Jumlah: domain.NewMoney(40000)},
+		},
+	}, nil)
+
+	jumlah := domain.NewMoney(120000)
+	_, err := transaksiUsecase.PatchTransaksi(transaksiID, 1, &domain.PatchTransaksiRequest{Jumlah: &jumlah})
+
+	assert.EqualError(t, err, "total split harus sama dengan jumlah transaksi")
+	mockTransaksiRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func dataBatchTransaksi(kantongID string, jumlah int64) *domain.CreateTransaksiRequest {
+	return &domain.CreateTransaksiRequest{
+		KantongID: kantongID,
+		Tanggal:   "2026-10-01",
+		Jenis:     "Pengeluaran",
+		Jumlah:    domain.NewMoney(jumlah),
+	}
+}
+
+func TestBatchTransaksi_AtomikBerhasil(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, mockRedisRepo, mockAnggaranUsecase := setupTransaksiUsecase()
+
+	transaksiID := "550e8400-e29b-41d4-a716-446655440051"
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockTransaksiRepo.On("ExecuteBatch", uint(1), mock.MatchedBy(func(operasi []*domain.OperasiBatchTransaksi) bool {
+		return len(operasi) == 2 &&
+			operasi[0].Aksi == domain.AksiBatchCreate && operasi[0].Transaksi.KantongID == kantongAsalID &&
+			operasi[1].Aksi == domain.AksiBatchDelete && operasi[1].Transaksi.ID == transaksiID
+	}), true).Run(func(args mock.Arguments) {
+		operasi := args.Get(1).([]*domain.OperasiBatchTransaksi)
+		operasi[1].Sebelumnya = &domain.Transaksi{ID: transaksiID, KantongID: kantongAsalID, Tanggal: tanggalImport("2026-10-05")}
+	}).Return(nil)
+	mockAnggaranUsecase.On("RecalculateAnggaranBulan", kantongAsalID, uint(1), 10, 2026).Return(nil).Once()
+	mockRedisRepo.On("GetKeys", "transaksi_list:1:*").Return([]string{}, nil).Once()
+	mockRedisRepo.On("Set", "cache_disabled:1", "1", 5*time.Second).Return(nil).Once()
+	mockRedisRepo.On("Delete", "transaksi_detail:"+transaksiID+":1").Return(nil).Once()
+
+	result, err := transaksiUsecase.BatchTransaksi(1, &domain.BatchTransaksiRequest{
+		Operasi: []domain.BatchTransaksiOperasi{
+			{Aksi: domain.AksiBatchCreate, Data: dataBatchTransaksi(kantongAsalID, 50000)},
+			{Aksi: domain.AksiBatchDelete, ID: transaksiID},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ModeBatchAtomik, result.Mode)
+	assert.Equal(t, 2, result.Berhasil)
+	assert.NotEmpty(t, result.Hasil[0].ID)
+	mockTransaksiRepo.AssertExpectations(t)
+	mockAnggaranUsecase.AssertExpectations(t)
+	mockRedisRepo.AssertExpectations(t)
+}
+
+func TestBatchTransaksi_AtomikDibatalkanSaatPersiapanGagal(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, mockRedisRepo, _ := setupTransaksiUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockKantongRepo.On("GetByID", kantongTujuanID, uint(1)).Return((*domain.Kantong)(nil), errors.New("record not found"))
+
+	result, err := transaksiUsecase.BatchTransaksi(1, &domain.BatchTransaksiRequest{
+		Mode: domain.ModeBatchAtomik,
+		Operasi: []domain.BatchTransaksiOperasi{
+			{Aksi: domain.AksiBatchCreate, Data: dataBatchTransaksi(kantongAsalID, 50000)},
+			{Aksi: domain.AksiBatchCreate, Data: dataBatchTransaksi(kantongTujuanID, 50000)},
+		},
+	})
+
+	assert.EqualError(t, err, "batch transaksi dibatalkan")
+	assert.Equal(t, domain.StatusBatchDibatalkan, result.Hasil[0].Status)
+	assert.Equal(t, domain.StatusBatchGagal, result.Hasil[1].Status)
+	assert.Equal(t, "kantong tidak ditemukan", result.Hasil[1].Error)
+	mockTransaksiRepo.AssertNotCalled(t, "ExecuteBatch", mock.Anything, mock.Anything, mock.Anything)
+	mockRedisRepo.AssertNotCalled(t, "GetKeys", mock.Anything)
+}
+
+func TestBatchTransaksi_AtomikDibatalkanOlehRepository(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, _, mockAnggaranUsecase := setupTransaksiUsecase()
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockTransaksiRepo.On("ExecuteBatch", uint(1), mock.Anything, true).Run(func(args mock.Arguments) {
+		operasi := args.Get(1).([]*domain.OperasiBatchTransaksi)
+		operasi[0].Err = errors.New("saldo tidak mencukupi")
+	}).Return(errors.New("saldo tidak mencukupi"))
+
+	result, err := transaksiUsecase.BatchTransaksi(1, &domain.BatchTransaksiRequest{
+		Operasi: []domain.BatchTransaksiOperasi{
+			{Aksi: domain.AksiBatchCreate, Data: dataBatchTransaksi(kantongAsalID, 50000)},
+			{Aksi: domain.AksiBatchCreate, Data: dataBatchTransaksi(kantongAsalID, 10000)},
+		},
+	})
+
+	assert.EqualError(t, err, "batch transaksi dibatalkan")
+	assert.Equal(t, 1, result.Gagal)
+	assert.Equal(t, domain.StatusBatchDibatalkan, result.Hasil[1].Status)
+	mockAnggaranUsecase.AssertNotCalled(t, "RecalculateAnggaranBulan", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBatchTransaksi_SebagianTetapMenyimpanYangBerhasil(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, mockKantongRepo, mockRedisRepo, mockAnggaranUsecase := setupTransaksiUsecase()
+
+	transaksiID := "550e8400-e29b-41d4-a716-446655440052"
+
+	mockKantongRepo.On("GetByID", kantongAsalID, uint(1)).Return(&domain.Kantong{ID: kantongAsalID, UserID: 1}, nil)
+	mockKantongRepo.On("GetByID", kantongTujuanID, uint(1)).Return(&domain.Kantong{ID: kantongTujuanID, UserID: 1}, nil)
+	mockTransaksiRepo.On("ExecuteBatch", uint(1), mock.Anything, false).Run(func(args mock.Arguments) {
+		operasi := args.Get(1).([]*domain.OperasiBatchTransaksi)
+		operasi[0].Sebelumnya = &domain.Transaksi{ID: transaksiID, KantongID: kantongAsalID, Tanggal: tanggalImport("2026-09-20")}
+		operasi[1].Err = errors.New("saldo tidak mencukupi")
+	}).Return(nil)
+	mockAnggaranUsecase.On("RecalculateAnggaranBulan", kantongAsalID, uint(1), 9, 2026).Return(nil).Once()
+	mockAnggaranUsecase.On("RecalculateAnggaranBulan", kantongTujuanID, uint(1), 10, 2026).Return(nil).Once()
+	mockRedisRepo.On("GetKeys", "transaksi_list:1:*").Return([]string{}, nil).Once()
+	mockRedisRepo.On("Set", "cache_disabled:1", "1", 5*time.Second).Return(nil).Once()
+	mockRedisRepo.On("Delete", "transaksi_detail:"+transaksiID+":1").Return(nil).Once()
+
+	result, err := transaksiUsecase.BatchTransaksi(1, &domain.BatchTransaksiRequest{
+		Mode: domain.ModeBatchSebagian,
+		Operasi: []domain.BatchTransaksiOperasi{
+			{Aksi: domain.AksiBatchUpdate, ID: transaksiID, Data: dataBatchTransaksi(kantongTujuanID, 20000)},
+			{Aksi: domain.AksiBatchCreate, Data: dataBatchTransaksi(kantongAsalID, 900000)},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Berhasil)
+	assert.Equal(t, 1, result.Gagal)
+	assert.Equal(t, domain.StatusBatchBerhasil, result.Hasil[0].Status)
+	assert.Equal(t, "saldo tidak mencukupi", result.Hasil[1].Error)
+	mockAnggaranUsecase.AssertExpectations(t)
+	mockRedisRepo.AssertExpectations(t)
+}
+
+func dataCursorTransaksi(jumlah int) []*domain.TransaksiResponse {
+	ids := []string{
+		"550e8400-e29b-41d4-a716-446655440071",
+		"550e8400-e29b-41d4-a716-446655440072",
+		"550e8400-e29b-41d4-a716-446655440073",
+	}
+	result := make([]*domain.TransaksiResponse, 0, jumlah)
+	for i := 0; i < jumlah; i++ {
+		result = append(result, &domain.TransaksiResponse{ID: ids[i], Tanggal: fmt.Sprintf("2026-10-%02d", 10-i)})
+	}
+	return result
+}
+
+func setupCursorRedis(mockRedisRepo *MockRedisRepository) {
+	mockRedisRepo.On("Exists", mock.Anything).Return(false, nil)
+	mockRedisRepo.On("GetJSON", mock.Anything, mock.Anything).Return(assert.AnError)
+	mockRedisRepo.On("SetJSON", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+}
+
+func TestGetTransaksiList_CursorHalamanPertama(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, mockRedisRepo, _ := setupTransaksiUsecase()
+	setupCursorRedis(mockRedisRepo)
+
+	req := &domain.TransaksiListRequest{SortBy: "tanggal", SortDirection: "desc", Page: 1, PerPage: 2, Pagination: "cursor"}
+	mockTransaksiRepo.On("GetByUserIDCursor", uint(1), req, (*domain.TransaksiCursor)(nil), 3).Return(dataCursorTransaksi(3), nil)
+
+	result, err := transaksiUsecase.GetTransaksiList(1, req)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 2)
+	assert.Nil(t, result.CursorMeta.PrevCursor)
+	assert.Nil(t, result.CursorMeta.TotalRecords)
+	if assert.NotNil(t, result.CursorMeta.NextCursor) {
+		cursor, err := domain.DecodeTransaksiCursor(*result.CursorMeta.NextCursor, "tanggal", "desc")
+		assert.NoError(t, err)
+		assert.Equal(t, result.Data[1].ID, cursor.ID)
+		assert.False(t, cursor.Mundur)
+	}
+	mockTransaksiRepo.AssertNotCalled(t, "CountByUserID", mock.Anything, mock.Anything)
+	mockTransaksiRepo.AssertNotCalled(t, "GetByUserID", mock.Anything, mock.Anything)
+}
+
+func TestGetTransaksiList_CursorMundurDenganTotal(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, mockRedisRepo, _ := setupTransaksiUsecase()
+	setupCursorRedis(mockRedisRepo)
+
+	data := dataCursorTransaksi(3)
+	prev := domain.NewTransaksiCursor("tanggal", "desc", &domain.TransaksiResponse{ID: "550e8400-e29b-41d4-a716-446655440079", Tanggal: "2026-10-07"}, true).Encode()
+	req := &domain.TransaksiListRequest{SortBy: "tanggal", SortDirection: "desc", Page: 1, PerPage: 2, Cursor: &prev, IncludeTotal: true}
+	mockTransaksiRepo.On("GetByUserIDCursor", uint(1), req, mock.MatchedBy(func(cursor *domain.TransaksiCursor) bool {
+		return cursor != nil && cursor.Mundur && cursor.Nilai == "2026-10-07"
+	}), 3).Return(data, nil)
+	mockTransaksiRepo.On("CountByUserID", uint(1), req).Return(25, nil)
+
+	result, err := transaksiUsecase.GetTransaksiList(1, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{data[1].ID, data[2].ID}, []string{result.Data[0].ID, result.Data[1].ID})
+	assert.NotNil(t, result.CursorMeta.PrevCursor)
+	assert.NotNil(t, result.CursorMeta.NextCursor)
+	assert.Equal(t, 25, *result.CursorMeta.TotalRecords)
+}
+
+func TestGetTransaksiList_CursorTidakValid(t *testing.T) {
+	transaksiUsecase, mockTransaksiRepo, _, mockRedisRepo, _ := setupTransaksiUsecase()
+	setupCursorRedis(mockRedisRepo)
+
+	cursor := "rusak"
+	_, err := transaksiUsecase.GetTransaksiList(1, &domain.TransaksiListRequest{SortBy: "tanggal", SortDirection: "desc", Page: 1, PerPage: 2, Cursor: &cursor})
+
+	assert.EqualError(t, err, "cursor tidak valid")
+	mockTransaksiRepo.AssertNotCalled(t, "GetByUserIDCursor", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
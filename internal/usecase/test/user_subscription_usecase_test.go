@@ -47,6 +47,14 @@ func (m *MockUserSubscriptionRepository) GetStatistics() (*domain.UserSubscripti
 	return args.Get(0).(*domain.UserSubscriptionStatistics), args.Error(1)
 }
 
+func (m *MockUserSubscriptionRepository) GetLatestByUserID(userID uint) (*domain.UserSubscription, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserSubscription), args.Error(1)
+}
+
 func (m *MockUserSubscriptionRepository) Create(subscription *domain.UserSubscription) error {
 	args := m.Called(subscription)
 	return args.Error(0)
@@ -74,7 +82,7 @@ func TestUserSubscriptionUsecase_GetAll_Success(t *testing.T) {
 			UserID:             1,
 			User:               domain.User{ID: 1, Name: "John Doe", Email: "john@example.com", IsActive: true},
 			SubscriptionPlanID: uuid.New(),
-			SubscriptionPlan:   domain.SubscriptionPlan{ID: uuid.New(), Nama: "PRO Monthly", Harga: 99000},
+			SubscriptionPlan:   domain.SubscriptionPlan{ID: uuid.New(), Nama: "PRO Monthly", Harga: domain.NewMoney(99000)},
 			Status:             "active",
 			CurrentPeriodStart: now,
 			CurrentPeriodEnd:   now.AddDate(0, 1, 0),
@@ -132,6 +140,41 @@ func TestUserSubscriptionUsecase_GetByID_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, subscriptionID, result.ID.String())
 	assert.Equal(t, "John Doe", result.User.Nama)
+	assert.Equal(t, "User", result.User.Role)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserSubscriptionUsecase_GetByID_WithRole(t *testing.T) {
+	mockRepo := new(MockUserSubscriptionRepository)
+
+	useCase := usecase.NewUserSubscriptionUsecase(mockRepo, nil, nil)
+
+	roleID := uuid.New().String()
+	subscriptionID := uuid.New().String()
+	subscription := &domain.UserSubscription{
+		ID:     uuid.MustParse(subscriptionID),
+		UserID: 1,
+		User: domain.User{
+			ID:     1,
+			Name:   "Jane Admin",
+			Email:  "jane@example.com",
+			RoleID: &roleID,
+			Role:   &domain.Role{ID: roleID, Nama: "admin", Status: "aktif"},
+		},
+		SubscriptionPlanID: uuid.New(),
+		Status:             "active",
+		CurrentPeriodStart: time.Now(),
+		CurrentPeriodEnd:   time.Now().AddDate(0, 1, 0),
+		PaymentMethod:      "Bank Transfer",
+	}
+
+	mockRepo.On("GetByID", subscriptionID).Return(subscription, nil)
+
+	result, err := useCase.GetByID(subscriptionID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", result.User.Role)
 
 	mockRepo.AssertExpectations(t)
 }
@@ -166,8 +209,8 @@ func TestUserSubscriptionUsecase_GetStatistics_Success(t *testing.T) {
 		PaymentMethods: []domain.PaymentMethodStatistic{
 			{Method: "Bank Transfer", Count: 75, Percentage: 50.0},
 		},
-		MonthlyRevenue: 9500000,
-		YearlyRevenue:  114000000,
+		MonthlyRevenue: domain.NewMoney(9500000),
+		YearlyRevenue:  domain.NewMoney(114000000),
 	}
 
 	mockRepo.On("GetStatistics").Return(stats, nil)
@@ -178,7 +221,7 @@ func TestUserSubscriptionUsecase_GetStatistics_Success(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, int64(150), result.TotalSubscriptions)
 	assert.Equal(t, int64(120), result.ActiveSubscriptions)
-	assert.Equal(t, float64(9500000), result.MonthlyRevenue)
+	assert.Equal(t, domain.NewMoney(9500000), result.MonthlyRevenue)
 
 	mockRepo.AssertExpectations(t)
 }
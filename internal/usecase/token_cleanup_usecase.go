@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase/repo"
+
+	"github.com/sirupsen/logrus"
+)
+
+type TokenCleanupUsecase interface {
+	CleanupExpiredTokens() (map[string]int64, error)
+}
+
+type tokenCleanupUsecase struct {
+	refreshTokenRepo      repo.RefreshTokenRepository
+	resetTokenRepo        repo.PasswordResetTokenRepository
+	verificationTokenRepo repo.EmailVerificationTokenRepository
+	oauthStateRepo        repo.OAuthStateRepository
+}
+
+func NewTokenCleanupUsecase(
+	refreshTokenRepo repo.RefreshTokenRepository,
+	resetTokenRepo repo.PasswordResetTokenRepository,
+	verificationTokenRepo repo.EmailVerificationTokenRepository,
+	oauthStateRepo repo.OAuthStateRepository,
+) TokenCleanupUsecase {
+	return &tokenCleanupUsecase{
+		refreshTokenRepo:      refreshTokenRepo,
+		resetTokenRepo:        resetTokenRepo,
+		verificationTokenRepo: verificationTokenRepo,
+		oauthStateRepo:        oauthStateRepo,
+	}
+}
+
+func (uc *tokenCleanupUsecase) CleanupExpiredTokens() (map[string]int64, error) {
+	deleted := make(map[string]int64)
+	var failed bool
+
+	cleanups := []struct {
+		table   string
+		cleanup func() (int64, error)
+	}{
+		{"refresh_tokens", uc.refreshTokenRepo.CleanupExpired},
+		{"password_reset_tokens", uc.resetTokenRepo.CleanupExpired},
+		{"email_verification_tokens", uc.verificationTokenRepo.CleanupExpired},
+		{"oauth_states", uc.oauthStateRepo.CleanupExpired},
+	}
+
+	for _, c := range cleanups {
+		rows, err := c.cleanup()
+		if err != nil {
+			helper.Error("Gagal membersihkan token yang kedaluwarsa", err, logrus.Fields{
+				"table": c.table,
+			})
+			failed = true
+			continue
+		}
+		deleted[c.table] = rows
+	}
+
+	if failed {
+		return deleted, errors.New("gagal membersihkan sebagian token yang kedaluwarsa")
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"fiber-boiler-plate/internal/domain"
+	"time"
+)
+
+type kantongBulan struct {
+	kantongID string
+	bulan     int
+	tahun     int
+}
+
+func hasilBatch(mode string, operasi []*domain.OperasiBatchTransaksi, dibatalkan bool) *domain.BatchTransaksiResult {
+	result := &domain.BatchTransaksiResult{
+		Mode:  mode,
+		Total: len(operasi),
+		Hasil: make([]domain.BatchTransaksiHasil, 0, len(operasi)),
+	}
+
+	for i, op := range operasi {
+		hasil := domain.BatchTransaksiHasil{
+			Indeks: i,
+			Aksi:   op.Aksi,
+			ID:     op.Transaksi.ID,
+		}
+
+		switch {
+		case op.Err != nil:
+			hasil.Status = domain.StatusBatchGagal
+			hasil.Error = op.Err.Error()
+			result.Gagal++
+		case dibatalkan:
+			hasil.Status = domain.StatusBatchDibatalkan
+		default:
+			hasil.Status = domain.StatusBatchBerhasil
+			result.Berhasil++
+		}
+
+		result.Hasil = append(result.Hasil, hasil)
+	}
+
+	return result
+}
+
+func batchMemilikiGagal(operasi []*domain.OperasiBatchTransaksi) bool {
+	for _, op := range operasi {
+		if op.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func kantongBulanTransaksi(transaksis []*domain.Transaksi) []kantongBulan {
+	var result []kantongBulan
+	seen := make(map[kantongBulan]bool)
+	for _, transaksi := range transaksis {
+		for _, kantongID := range kantongIDTransaksi(transaksi) {
+			kb := kantongBulan{kantongID: kantongID, bulan: int(transaksi.Tanggal.Month()), tahun: transaksi.Tanggal.Year()}
+			if !seen[kb] {
+				seen[kb] = true
+				result = append(result, kb)
+			}
+		}
+	}
+	return result
+}
+
+func kantongBulanBatch(operasi []*domain.OperasiBatchTransaksi) []kantongBulan {
+	var result []kantongBulan
+	seen := make(map[kantongBulan]bool)
+	tambah := func(kantongIDs []string, waktu time.Time) {
+		for _, kantongID := range kantongIDs {
+			kb := kantongBulan{kantongID: kantongID, bulan: int(waktu.Month()), tahun: waktu.Year()}
+			if !seen[kb] {
+				seen[kb] = true
+				result = append(result, kb)
+			}
+		}
+	}
+
+	for _, op := range operasi {
+		if op.Err != nil {
+			continue
+		}
+
+		switch op.Aksi {
+		case domain.AksiBatchCreate:
+			tambah(kantongIDTransaksi(op.Transaksi), op.Transaksi.Tanggal)
+		case domain.AksiBatchUpdate:
+			tambah(kantongIDTransaksi(op.Sebelumnya), op.Sebelumnya.Tanggal)
+			tambah(kantongIDTransaksi(op.Transaksi), op.Transaksi.Tanggal)
+		case domain.AksiBatchDelete:
+			tambah(kantongIDTransaksi(op.Sebelumnya), op.Sebelumnya.Tanggal)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,509 @@
+package usecase
+
+import (
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
+	"fiber-boiler-plate/internal/usecase/repo"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	batasTransaksiBerulangPerProses = 100
+	batasKejadianDiproses           = 30 * time.Minute
+)
+
+type TransaksiBerulangUsecase interface {
+	GetTransaksiBerulangList(userID uint, req *domain.TransaksiBerulangListRequest) ([]*domain.TransaksiBerulangResponse, *domain.PaginationMeta, error)
+	GetTransaksiBerulangByID(id string, userID uint) (*domain.TransaksiBerulangResponse, error)
+	CreateTransaksiBerulang(userID uint, req *domain.CreateTransaksiBerulangRequest) (*domain.TransaksiBerulangResponse, error)
+	PatchTransaksiBerulang(id string, userID uint, req *domain.PatchTransaksiBerulangRequest) (*domain.TransaksiBerulangResponse, error)
+	DeleteTransaksiBerulang(id string, userID uint) error
+	PauseTransaksiBerulang(id string, userID uint) (*domain.TransaksiBerulangResponse, error)
+	ResumeTransaksiBerulang(id string, userID uint) (*domain.TransaksiBerulangResponse, error)
+	SkipKejadian(id string, userID uint, req *domain.LewatiKejadianRequest) (*domain.TransaksiBerulangResponse, error)
+	ProcessDueTransaksi() (map[string]int64, error)
+}
+
+type transaksiBerulangUsecase struct {
+	transaksiBerulangRepo repo.TransaksiBerulangRepository
+	kantongRepo           repo.KantongRepository
+	transaksiUsecase      TransaksiUsecase
+}
+
+func NewTransaksiBerulangUsecase(
+	transaksiBerulangRepo repo.TransaksiBerulangRepository,
+	kantongRepo repo.KantongRepository,
+	transaksiUsecase TransaksiUsecase,
+) TransaksiBerulangUsecase {
+	return &transaksiBerulangUsecase{
+		transaksiBerulangRepo: transaksiBerulangRepo,
+		kantongRepo:           kantongRepo,
+		transaksiUsecase:      transaksiUsecase,
+	}
+}
+
+func (uc *transaksiBerulangUsecase) GetTransaksiBerulangList(userID uint, req *domain.TransaksiBerulangListRequest) ([]*domain.TransaksiBerulangResponse, *domain.PaginationMeta, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PerPage <= 0 {
+		req.PerPage = 10
+	}
+	if req.PerPage > 100 {
+		req.PerPage = 100
+	}
+
+	list, total, err := uc.transaksiBerulangRepo.GetByUserID(userID, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*domain.TransaksiBerulangResponse, 0, len(list))
+	for _, transaksiBerulang := range list {
+		responses = append(responses, domain.ToTransaksiBerulangResponse(transaksiBerulang))
+	}
+
+	meta := &domain.PaginationMeta{
+		CurrentPage:  req.Page,
+		TotalPages:   int(math.Ceil(float64(total) / float64(req.PerPage))),
+		TotalRecords: total,
+		PerPage:      req.PerPage,
+	}
+
+	return responses, meta, nil
+}
+
+func (uc *transaksiBerulangUsecase) GetTransaksiBerulangByID(id string, userID uint) (*domain.TransaksiBerulangResponse, error) {
+	transaksiBerulang, err := uc.getTransaksiBerulang(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.ToTransaksiBerulangResponse(transaksiBerulang), nil
+}
+
+func (uc *transaksiBerulangUsecase) CreateTransaksiBerulang(userID uint, req *domain.CreateTransaksiBerulangRequest) (*domain.TransaksiBerulangResponse, error) {
+	kantong, err := uc.kantongRepo.GetByID(req.KantongID, userID)
+	if err != nil {
+		return nil, domain.ErrKantongTidakDitemukan
+	}
+
+	tanggalMulai, err := time.Parse("2006-01-02", req.TanggalMulai)
+	if err != nil {
+		return nil, errors.New("format tanggal tidak valid")
+	}
+	if tanggalMulai.Before(hariIni()) {
+		return nil, errors.New("tanggal mulai tidak boleh sebelum hari ini")
+	}
+
+	interval := req.Interval
+	if interval == 0 {
+		interval = 1
+	}
+
+	transaksiBerulang := &domain.TransaksiBerulang{
+		ID:             uuid.New().String(),
+		UserID:         userID,
+		KantongID:      req.KantongID,
+		Jenis:          req.Jenis,
+		Jumlah:         req.Jumlah,
+		Catatan:        req.Catatan,
+		Frekuensi:      req.Frekuensi,
+		Interval:       interval,
+		TanggalMulai:   tanggalMulai,
+		TanggalAcuan:   tanggalMulai,
+		JumlahKejadian: req.JumlahKejadian,
+		Status:         domain.StatusTransaksiBerulangAktif,
+	}
+
+	if req.TanggalSelesai != nil {
+		tanggalSelesai, err := time.Parse("2006-01-02", *req.TanggalSelesai)
+		if err != nil {
+			return nil, errors.New("format tanggal tidak valid")
+		}
+		if tanggalSelesai.Before(tanggalMulai) {
+			return nil, errors.New("tanggal selesai tidak boleh sebelum tanggal mulai")
+		}
+		transaksiBerulang.TanggalSelesai = &tanggalSelesai
+	}
+
+	transaksiBerulang.Jadwalkan()
+
+	if err := uc.transaksiBerulangRepo.Create(transaksiBerulang); err != nil {
+		return nil, err
+	}
+
+	transaksiBerulang.Kantong = *kantong
+
+	return domain.ToTransaksiBerulangResponse(transaksiBerulang), nil
+}
+
+func (uc *transaksiBerulangUsecase) PatchTransaksiBerulang(id string, userID uint, req *domain.PatchTransaksiBerulangRequest) (*domain.TransaksiBerulangResponse, error) {
+	transaksiBerulang, err := uc.getTransaksiBerulang(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.KantongID != nil && *req.KantongID != transaksiBerulang.KantongID {
+		kantong, err := uc.kantongRepo.GetByID(*req.KantongID, userID)
+		if err != nil {
+			return nil, domain.ErrKantongTidakDitemukan
+		}
+		transaksiBerulang.KantongID = kantong.ID
+		transaksiBerulang.Kantong = *kantong
+	}
+	if req.Jenis != nil {
+		transaksiBerulang.Jenis = *req.Jenis
+	}
+	if req.Jumlah != nil {
+		transaksiBerulang.Jumlah = *req.Jumlah
+	}
+	if req.Catatan != nil {
+		transaksiBerulang.Catatan = req.Catatan
+	}
+
+	if req.Frekuensi != nil || req.Interval != nil || req.TanggalBerikutnya != nil {
+		acuan := transaksiBerulang.TanggalKejadian(transaksiBerulang.Urutan)
+		if req.TanggalBerikutnya != nil {
+			acuan, err = time.Parse("2006-01-02", *req.TanggalBerikutnya)
+			if err != nil {
+				return nil, errors.New("format tanggal tidak valid")
+			}
+			if acuan.Before(hariIni()) {
+				return nil, errors.New("tanggal berikutnya tidak boleh sebelum hari ini")
+			}
+		}
+
+		transaksiBerulang.TetapkanAcuan(acuan)
+		if req.Frekuensi != nil {
+			transaksiBerulang.Frekuensi = *req.Frekuensi
+		}
+		if req.Interval != nil {
+			transaksiBerulang.Interval = *req.Interval
+		}
+
+		if err := uc.transaksiBerulangRepo.DeleteKejadianDilewati(transaksiBerulang.ID, transaksiBerulang.Urutan); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.TanggalSelesai != nil {
+		tanggalSelesai, err := time.Parse("2006-01-02", *req.TanggalSelesai)
+		if err != nil {
+			return nil, errors.New("format tanggal tidak valid")
+		}
+		if tanggalSelesai.Before(transaksiBerulang.TanggalMulai) {
+			return nil, errors.New("tanggal selesai tidak boleh sebelum tanggal mulai")
+		}
+		transaksiBerulang.TanggalSelesai = &tanggalSelesai
+	}
+	if req.JumlahKejadian != nil {
+		transaksiBerulang.JumlahKejadian = req.JumlahKejadian
+	}
+
+	transaksiBerulang.Jadwalkan()
+
+	if err := uc.transaksiBerulangRepo.Update(transaksiBerulang); err != nil {
+		return nil, err
+	}
+
+	return domain.ToTransaksiBerulangResponse(transaksiBerulang), nil
+}
+
+func (uc *transaksiBerulangUsecase) DeleteTransaksiBerulang(id string, userID uint) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return errors.New("transaksi berulang tidak ditemukan")
+	}
+
+	if err := uc.transaksiBerulangRepo.Delete(id, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("transaksi berulang tidak ditemukan")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (uc *transaksiBerulangUsecase) PauseTransaksiBerulang(id string, userID uint) (*domain.TransaksiBerulangResponse, error) {
+	transaksiBerulang, err := uc.getTransaksiBerulang(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaksiBerulang.Status != domain.StatusTransaksiBerulangAktif {
+		return nil, errors.New("transaksi berulang tidak aktif")
+	}
+
+	transaksiBerulang.Status = domain.StatusTransaksiBerulangDijeda
+
+	if err := uc.transaksiBerulangRepo.Update(transaksiBerulang); err != nil {
+		return nil, err
+	}
+
+	return domain.ToTransaksiBerulangResponse(transaksiBerulang), nil
+}
+
+func (uc *transaksiBerulangUsecase) ResumeTransaksiBerulang(id string, userID uint) (*domain.TransaksiBerulangResponse, error) {
+	transaksiBerulang, err := uc.getTransaksiBerulang(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaksiBerulang.Status != domain.StatusTransaksiBerulangDijeda {
+		return nil, errors.New("transaksi berulang tidak sedang dijeda")
+	}
+
+	hari := hariIni()
+	pesan := "dilewati karena transaksi berulang dijeda"
+	for !transaksiBerulang.Berakhir(transaksiBerulang.Urutan) {
+		tanggal := transaksiBerulang.TanggalKejadian(transaksiBerulang.Urutan)
+		if !tanggal.Before(hari) {
+			break
+		}
+
+		kejadian := &domain.KejadianTransaksiBerulang{
+			TransaksiBerulangID: transaksiBerulang.ID,
+			Urutan:              transaksiBerulang.Urutan,
+			Tanggal:             tanggal,
+			Status:              domain.StatusKejadianDilewati,
+			Pesan:               &pesan,
+		}
+		if _, err := uc.transaksiBerulangRepo.CreateKejadian(kejadian); err != nil {
+			return nil, err
+		}
+		transaksiBerulang.Urutan++
+	}
+
+	transaksiBerulang.Status = domain.StatusTransaksiBerulangAktif
+	transaksiBerulang.Jadwalkan()
+
+	if err := uc.transaksiBerulangRepo.Update(transaksiBerulang); err != nil {
+		return nil, err
+	}
+
+	return domain.ToTransaksiBerulangResponse(transaksiBerulang), nil
+}
+
+func (uc *transaksiBerulangUsecase) SkipKejadian(id string, userID uint, req *domain.LewatiKejadianRequest) (*domain.TransaksiBerulangResponse, error) {
+	transaksiBerulang, err := uc.getTransaksiBerulang(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaksiBerulang.Status == domain.StatusTransaksiBerulangSelesai {
+		return nil, errors.New("transaksi berulang sudah selesai")
+	}
+
+	urutan := transaksiBerulang.Urutan
+	if req.Tanggal != nil {
+		tanggal, err := time.Parse("2006-01-02", *req.Tanggal)
+		if err != nil {
+			return nil, errors.New("format tanggal tidak valid")
+		}
+
+		var ditemukan bool
+		urutan, ditemukan = transaksiBerulang.CariUrutan(tanggal)
+		if !ditemukan {
+			return nil, errors.New("tanggal bukan jadwal transaksi berulang")
+		}
+	}
+
+	kejadian := &domain.KejadianTransaksiBerulang{
+		TransaksiBerulangID: transaksiBerulang.ID,
+		Urutan:              urutan,
+		Tanggal:             transaksiBerulang.TanggalKejadian(urutan),
+		Status:              domain.StatusKejadianDilewati,
+	}
+
+	created, err := uc.transaksiBerulangRepo.CreateKejadian(kejadian)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, errors.New("kejadian sudah dilewati atau diproses")
+	}
+
+	if urutan == transaksiBerulang.Urutan {
+		transaksiBerulang.Urutan++
+		transaksiBerulang.Jadwalkan()
+
+		if err := uc.transaksiBerulangRepo.Update(transaksiBerulang); err != nil {
+			return nil, err
+		}
+	}
+
+	return domain.ToTransaksiBerulangResponse(transaksiBerulang), nil
+}
+
+func (uc *transaksiBerulangUsecase) ProcessDueTransaksi() (map[string]int64, error) {
+	counts := map[string]int64{
+		"transaksis":        0,
+		"kejadian_dilewati": 0,
+		"kejadian_gagal":    0,
+	}
+
+	list, err := uc.transaksiBerulangRepo.GetDue(hariIni(), batasTransaksiBerulangPerProses)
+	if err != nil {
+		return counts, err
+	}
+
+	var failed bool
+	for _, transaksiBerulang := range list {
+		if err := uc.processSchedule(transaksiBerulang, counts); err != nil {
+			helper.Error("Gagal memproses transaksi berulang", err, logrus.Fields{
+				"transaksi_berulang_id": transaksiBerulang.ID,
+				"urutan":                transaksiBerulang.Urutan,
+			})
+			failed = true
+		}
+	}
+
+	if failed {
+		return counts, errors.New("gagal memproses sebagian transaksi berulang")
+	}
+
+	return counts, nil
+}
+
+func (uc *transaksiBerulangUsecase) processSchedule(transaksiBerulang *domain.TransaksiBerulang, counts map[string]int64) error {
+	hari := hariIni()
+
+	for transaksiBerulang.Status == domain.StatusTransaksiBerulangAktif && !transaksiBerulang.Berakhir(transaksiBerulang.Urutan) {
+		tanggal := transaksiBerulang.TanggalKejadian(transaksiBerulang.Urutan)
+		if tanggal.After(hari) {
+			break
+		}
+
+		urutan := transaksiBerulang.Urutan
+		kejadian := &domain.KejadianTransaksiBerulang{
+			TransaksiBerulangID: transaksiBerulang.ID,
+			Urutan:              urutan,
+			Tanggal:             tanggal,
+			Status:              domain.StatusKejadianDiproses,
+		}
+
+		claimed, err := uc.transaksiBerulangRepo.CreateKejadian(kejadian)
+		if err != nil {
+			return err
+		}
+
+		diklaimUlang := false
+		if !claimed {
+			claimed, err = uc.transaksiBerulangRepo.ReclaimKejadian(kejadian, time.Now().Add(-batasKejadianDiproses))
+			if err != nil {
+				return err
+			}
+			diklaimUlang = claimed
+		}
+
+		if claimed {
+			if err := uc.materializeKejadian(transaksiBerulang, kejadian, diklaimUlang, counts); err != nil {
+				return err
+			}
+		} else {
+			counts["kejadian_dilewati"]++
+		}
+
+		transaksiBerulang.Urutan++
+		transaksiBerulang.Jadwalkan()
+
+		advanced, err := uc.transaksiBerulangRepo.AdvanceSchedule(transaksiBerulang, urutan)
+		if err != nil {
+			return err
+		}
+		if !advanced {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (uc *transaksiBerulangUsecase) materializeKejadian(transaksiBerulang *domain.TransaksiBerulang, kejadian *domain.KejadianTransaksiBerulang, diklaimUlang bool, counts map[string]int64) error {
+	transaksiID := idTransaksiKejadian(transaksiBerulang.ID, kejadian.Urutan)
+
+	if diklaimUlang {
+		_, err := uc.transaksiUsecase.GetTransaksiDetail(transaksiID, transaksiBerulang.UserID)
+		if err == nil {
+			kejadian.Status = domain.StatusKejadianDibuat
+			kejadian.TransaksiID = &transaksiID
+			counts["kejadian_dipulihkan"]++
+
+			return uc.transaksiBerulangRepo.UpdateKejadian(kejadian)
+		}
+		if !errors.Is(err, domain.ErrTransaksiTidakDitemukan) {
+			return err
+		}
+	}
+
+	result, err := uc.transaksiUsecase.CreateTransaksiWithID(transaksiBerulang.UserID, transaksiID, &domain.CreateTransaksiRequest{
+		KantongID: transaksiBerulang.KantongID,
+		Tanggal:   kejadian.Tanggal.Format("2006-01-02"),
+		Jenis:     transaksiBerulang.Jenis,
+		Jumlah:    transaksiBerulang.Jumlah,
+		Catatan:   transaksiBerulang.Catatan,
+	})
+	if err != nil {
+		if !errors.Is(err, domain.ErrKantongTidakDitemukan) && !errors.Is(err, domain.ErrSaldoTidakMencukupi) {
+			if _, detailErr := uc.transaksiUsecase.GetTransaksiDetail(transaksiID, transaksiBerulang.UserID); errors.Is(detailErr, domain.ErrTransaksiTidakDitemukan) {
+				if deleteErr := uc.transaksiBerulangRepo.DeleteKejadian(kejadian.ID); deleteErr != nil {
+					helper.Error("Gagal melepas kejadian transaksi berulang", deleteErr, logrus.Fields{
+						"transaksi_berulang_id": transaksiBerulang.ID,
+						"urutan":                kejadian.Urutan,
+					})
+				}
+			}
+			return err
+		}
+
+		if errors.Is(err, domain.ErrKantongTidakDitemukan) {
+			transaksiBerulang.Status = domain.StatusTransaksiBerulangDijeda
+		}
+
+		pesan := err.Error()
+		kejadian.Status = domain.StatusKejadianGagal
+		kejadian.Pesan = &pesan
+		counts["kejadian_gagal"]++
+
+		return uc.transaksiBerulangRepo.UpdateKejadian(kejadian)
+	}
+
+	kejadian.Status = domain.StatusKejadianDibuat
+	kejadian.TransaksiID = &result.Data.ID
+	counts["transaksis"]++
+
+	return uc.transaksiBerulangRepo.UpdateKejadian(kejadian)
+}
+
+func (uc *transaksiBerulangUsecase) getTransaksiBerulang(id string, userID uint) (*domain.TransaksiBerulang, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("transaksi berulang tidak ditemukan")
+	}
+
+	transaksiBerulang, err := uc.transaksiBerulangRepo.GetByID(id, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("transaksi berulang tidak ditemukan")
+		}
+		return nil, err
+	}
+
+	return transaksiBerulang, nil
+}
+
+func idTransaksiKejadian(transaksiBerulangID string, urutan int) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("transaksi_berulang:%s:%d", transaksiBerulangID, urutan))).String()
+}
+
+func hariIni() time.Time {
+	tahun, bulan, hari := time.Now().Date()
+	return time.Date(tahun, bulan, hari, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+func headerExport(saldoBerjalan bool) []string {
+	if !saldoBerjalan {
+		return []string{"Tanggal", "Kantong", "Jenis", "Jumlah", "Catatan", "ID"}
+	}
+	return []string{"Tanggal", "Kantong", "Jenis", "Jumlah", "Saldo Berjalan", "Catatan", "ID"}
+}
+
+type transaksiExportWriter interface {
+	Tulis(row *domain.TransaksiExportRow) error
+	Tutup() error
+}
+
+func newTransaksiExportWriter(format string, w io.Writer, req *domain.TransaksiListRequest, saldoBerjalan bool, now time.Time) (transaksiExportWriter, error) {
+	switch format {
+	case domain.FormatExportCSV:
+		return newCSVExportWriter(w, saldoBerjalan)
+	case domain.FormatExportXLSX:
+		return newXLSXExportWriter(w, saldoBerjalan)
+	case domain.FormatExportOFX:
+		return newOFXExportWriter(w, req, saldoBerjalan, now)
+	default:
+		return nil, errors.New("format export tidak didukung")
+	}
+}
+
+func kolomExport(row *domain.TransaksiExportRow, saldoBerjalan bool) []string {
+	catatan := ""
+	if row.Catatan != nil {
+		catatan = *row.Catatan
+	}
+	kolom := []string{
+		row.Tanggal.Format("2006-01-02"),
+		row.KantongNama,
+		row.Jenis,
+		row.Jumlah.String(),
+	}
+	if saldoBerjalan {
+		kolom = append(kolom, row.SaldoBerjalan.String())
+	}
+	return append(kolom, catatan, row.ID)
+}
+
+type csvExportWriter struct {
+	writer        *csv.Writer
+	saldoBerjalan bool
+}
+
+func newCSVExportWriter(w io.Writer, saldoBerjalan bool) (*csvExportWriter, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(headerExport(saldoBerjalan)); err != nil {
+		return nil, err
+	}
+
+	return &csvExportWriter{writer: writer, saldoBerjalan: saldoBerjalan}, nil
+}
+
+func (e *csvExportWriter) Tulis(row *domain.TransaksiExportRow) error {
+	kolom := kolomExport(row, e.saldoBerjalan)
+	angka := map[int]bool{3: true, 4: e.saldoBerjalan}
+	for i, value := range kolom {
+		if !angka[i] {
+			kolom[i] = amankanSelCSV(value)
+		}
+	}
+	return e.writer.Write(kolom)
+}
+
+func amankanSelCSV(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (e *csvExportWriter) Tutup() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Transaksi" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+type xlsxExportWriter struct {
+	zip           *zip.Writer
+	sheet         io.Writer
+	saldoBerjalan bool
+}
+
+func newXLSXExportWriter(w io.Writer, saldoBerjalan bool) (*xlsxExportWriter, error) {
+	archive := zip.NewWriter(w)
+
+	statis := []struct {
+		nama string
+		isi  string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, file := range statis {
+		entry, err := archive.Create(file.nama)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(entry, file.isi); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+
+	e := &xlsxExportWriter{zip: archive, sheet: sheet, saldoBerjalan: saldoBerjalan}
+	if err := e.tulisBaris(headerExport(saldoBerjalan), nil); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (e *xlsxExportWriter) Tulis(row *domain.TransaksiExportRow) error {
+	return e.tulisBaris(kolomExport(row, e.saldoBerjalan), map[int]bool{3: true, 4: e.saldoBerjalan})
+}
+
+func (e *xlsxExportWriter) tulisBaris(values []string, angka map[int]bool) error {
+	var b strings.Builder
+	b.WriteString("<row>")
+	for i, value := range values {
+		if angka[i] {
+			b.WriteString("<c><v>")
+			b.WriteString(value)
+			b.WriteString("</v></c>")
+			continue
+		}
+		b.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		xml.EscapeText(&b, []byte(value))
+		b.WriteString("</t></is></c>")
+	}
+	b.WriteString("</row>")
+
+	_, err := io.WriteString(e.sheet, b.String())
+	return err
+}
+
+func (e *xlsxExportWriter) Tutup() error {
+	if _, err := io.WriteString(e.sheet, xlsxSheetFooter); err != nil {
+		return err
+	}
+	return e.zip.Close()
+}
+
+type ofxExportWriter struct {
+	w             io.Writer
+	tanggalAkhir  string
+	kantongID     string
+	saldoBerjalan bool
+	saldo         domain.Money
+	tanggalSaldo  time.Time
+	jumlahAkun    int
+}
+
+func newOFXExportWriter(w io.Writer, req *domain.TransaksiListRequest, saldoBerjalan bool, now time.Time) (*ofxExportWriter, error) {
+	e := &ofxExportWriter{w: w, tanggalAkhir: now.Format("20060102"), saldoBerjalan: saldoBerjalan}
+	if req.TanggalSelesai != nil && *req.TanggalSelesai != "" {
+		if tanggal, err := time.Parse("2006-01-02", *req.TanggalSelesai); err == nil {
+			e.tanggalAkhir = tanggal.Format("20060102")
+		}
+	}
+
+	header := `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>` + now.Format("20060102150405") + `</DTSERVER><LANGUAGE>IND</LANGUAGE></SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+`
+	if _, err := io.WriteString(w, header); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (e *ofxExportWriter) Tulis(row *domain.TransaksiExportRow) error {
+	if row.KantongID != e.kantongID {
+		if err := e.tutupAkun(); err != nil {
+			return err
+		}
+		if err := e.bukaAkun(row); err != nil {
+			return err
+		}
+	}
+
+	jenis := "CREDIT"
+	if row.Jenis == "Pengeluaran" {
+		jenis = "DEBIT"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID>",
+		jenis, row.Tanggal.Format("20060102"), row.Mutasi().String(), row.ID)
+	nama := row.Jenis
+	if row.Catatan != nil && *row.Catatan != "" {
+		nama = *row.Catatan
+	}
+	b.WriteString("<NAME>")
+	xml.EscapeText(&b, []byte(potongTeks(nama, 32)))
+	b.WriteString("</NAME>")
+	if row.Catatan != nil && *row.Catatan != "" {
+		b.WriteString("<MEMO>")
+		xml.EscapeText(&b, []byte(potongTeks(*row.Catatan, 255)))
+		b.WriteString("</MEMO>")
+	}
+	b.WriteString("</STMTTRN>\n")
+
+	e.saldo = row.SaldoBerjalan
+	e.tanggalSaldo = row.Tanggal
+
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func (e *ofxExportWriter) bukaAkun(row *domain.TransaksiExportRow) error {
+	e.kantongID = row.KantongID
+	e.jumlahAkun++
+
+	_, err := fmt.Fprintf(e.w, "<STMTTRNRS><TRNUID>%d</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><STMTRS><CURDEF>IDR</CURDEF><BANKACCTFROM><BANKID>FASTTRACK</BANKID><ACCTID>%s</ACCTID><ACCTTYPE>SAVINGS</ACCTTYPE></BANKACCTFROM>\n<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n",
+		e.jumlahAkun, row.KantongID, row.Tanggal.Format("20060102"), e.tanggalAkhir)
+	return err
+}
+
+func (e *ofxExportWriter) tutupAkun() error {
+	if e.kantongID == "" {
+		return nil
+	}
+
+	if !e.saldoBerjalan {
+		_, err := io.WriteString(e.w, "</BANKTRANLIST></STMTRS></STMTTRNRS>\n")
+		return err
+	}
+
+	_, err := fmt.Fprintf(e.w, "</BANKTRANLIST><LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL></STMTRS></STMTTRNRS>\n",
+		e.saldo.String(), e.tanggalSaldo.Format("20060102"))
+	return err
+}
+
+func (e *ofxExportWriter) Tutup() error {
+	if err := e.tutupAkun(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, "</BANKMSGSRSV1>\n</OFX>\n")
+	return err
+}
+
+func potongTeks(value string, batas int) string {
+	if karakter := []rune(value); len(karakter) > batas {
+		return string(karakter[:batas])
+	}
+	return value
+}
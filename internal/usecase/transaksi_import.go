@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"errors"
+	"fiber-boiler-plate/internal/domain"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type kolomImport struct {
+	tanggal    int
+	jumlah     int
+	debit      int
+	kredit     int
+	jenis      int
+	keterangan int
+}
+
+func parseImportCSV(file io.Reader, req *domain.ImportTransaksiRequest) ([]domain.ImportTransaksiRow, error) {
+	reader := csv.NewReader(file)
+	reader.Comma = req.DelimiterKolom()
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var header []string
+	var records [][]string
+	var nomorBaris []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("format CSV tidak valid")
+		}
+		if barisKosong(record) {
+			continue
+		}
+
+		if header == nil && !req.TanpaHeader {
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+			header = record
+			continue
+		}
+
+		if len(records) >= domain.MaksimalBarisImport {
+			return nil, fmt.Errorf("jumlah baris melebihi batas %d", domain.MaksimalBarisImport)
+		}
+
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		nomorBaris = append(nomorBaris, line)
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("file CSV tidak memiliki baris transaksi")
+	}
+
+	kolom, err := petakanKolom(header, req)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := req.LayoutTanggal()
+	rows := make([]domain.ImportTransaksiRow, 0, len(records))
+	for i, record := range records {
+		rows = append(rows, parseBarisImport(record, nomorBaris[i], kolom, layout, req.DesimalKoma()))
+	}
+
+	return rows, nil
+}
+
+func petakanKolom(header []string, req *domain.ImportTransaksiRequest) (*kolomImport, error) {
+	cari := func(nama, label string) (int, error) {
+		nama = strings.TrimSpace(nama)
+		if nama == "" {
+			return -1, nil
+		}
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), nama) {
+				return i, nil
+			}
+		}
+		if index, err := strconv.Atoi(nama); err == nil && index > 0 {
+			return index - 1, nil
+		}
+		return -1, fmt.Errorf("kolom %s tidak ditemukan", label)
+	}
+
+	kolom := &kolomImport{}
+	pemetaan := []struct {
+		nama   string
+		label  string
+		target *int
+	}{
+		{req.KolomTanggal, "tanggal", &kolom.tanggal},
+		{req.KolomJumlah, "jumlah", &kolom.jumlah},
+		{req.KolomDebit, "debit", &kolom.debit},
+		{req.KolomKredit, "kredit", &kolom.kredit},
+		{req.KolomJenis, "jenis", &kolom.jenis},
+		{req.KolomKeterangan, "keterangan", &kolom.keterangan},
+	}
+
+	for _, p := range pemetaan {
+		index, err := cari(p.nama, p.label)
+		if err != nil {
+			return nil, err
+		}
+		*p.target = index
+	}
+
+	return kolom, nil
+}
+
+func parseBarisImport(record []string, baris int, kolom *kolomImport, layout string, desimalKoma bool) domain.ImportTransaksiRow {
+	row := domain.ImportTransaksiRow{Baris: baris}
+
+	nilai := func(index int) string {
+		if index < 0 || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	tanggal, err := time.Parse(layout, nilai(kolom.tanggal))
+	if err != nil {
+		row.Error = "tanggal tidak sesuai format"
+		return row
+	}
+	row.Tanggal = tanggal.Format("2006-01-02")
+
+	if keterangan := nilai(kolom.keterangan); keterangan != "" {
+		keterangan = potongTeks(keterangan, 500)
+		row.Catatan = &keterangan
+	}
+
+	var jumlah domain.Money
+	if kolom.jumlah >= 0 {
+		teks, penanda := pisahkanPenandaJenis(nilai(kolom.jumlah))
+		jumlah, err = domain.ParseMoneyLocale(teks, desimalKoma)
+		if err != nil {
+			row.Error = "jumlah tidak valid"
+			return row
+		}
+		if penanda == "" {
+			penanda = nilai(kolom.jenis)
+		}
+		if penanda != "" {
+			jenis, ok := jenisDariPenanda(penanda)
+			if !ok {
+				row.Error = "jenis transaksi tidak dikenali"
+				return row
+			}
+			jumlah = jumlah.Abs()
+			if jenis == "Pengeluaran" {
+				jumlah = -jumlah
+			}
+		}
+	} else {
+		debit, kredit := nilai(kolom.debit), nilai(kolom.kredit)
+		if debit != "" {
+			nominal, err := domain.ParseMoneyLocale(debit, desimalKoma)
+			if err != nil {
+				row.Error = "jumlah tidak valid"
+				return row
+			}
+			jumlah -= nominal.Abs()
+		}
+		if kredit != "" {
+			nominal, err := domain.ParseMoneyLocale(kredit, desimalKoma)
+			if err != nil {
+				row.Error = "jumlah tidak valid"
+				return row
+			}
+			jumlah += nominal.Abs()
+		}
+	}
+
+	if jumlah == 0 {
+		row.Error = "jumlah tidak boleh nol"
+		return row
+	}
+
+	row.Jenis = "Pemasukan"
+	if jumlah < 0 {
+		row.Jenis = "Pengeluaran"
+	}
+	row.Jumlah = jumlah.Abs()
+
+	return row
+}
+
+func pisahkanPenandaJenis(value string) (string, string) {
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return value, ""
+	}
+	terakhir := fields[len(fields)-1]
+	if _, ok := jenisDariPenanda(terakhir); ok {
+		return strings.Join(fields[:len(fields)-1], " "), terakhir
+	}
+	return value, ""
+}
+
+func jenisDariPenanda(value string) (string, bool) {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case "DB", "D", "DEBIT", "DEBET", "KELUAR", "PENGELUARAN":
+		return "Pengeluaran", true
+	case "CR", "K", "KR", "KREDIT", "CREDIT", "MASUK", "PEMASUKAN":
+		return "Pemasukan", true
+	default:
+		return "", false
+	}
+}
+
+func barisKosong(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func kunciDuplikat(tanggal, jenis string, jumlah domain.Money) string {
+	return tanggal + "|" + jenis + "|" + jumlah.String()
+}
@@ -4,20 +4,28 @@ import (
 	"encoding/json"
 	"errors"
 	"fiber-boiler-plate/internal/domain"
+	"fiber-boiler-plate/internal/helper"
 	"fiber-boiler-plate/internal/usecase/repo"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 )
 
 type TransaksiUsecase interface {
 	GetTransaksiList(userID uint, req *domain.TransaksiListRequest) (*domain.TransaksiListResponse, error)
 	GetTransaksiDetail(id string, userID uint) (*domain.TransaksiDetailResponse, error)
 	CreateTransaksi(userID uint, req *domain.CreateTransaksiRequest) (*domain.TransaksiDetailResponse, error)
+	CreateTransaksiWithID(userID uint, id string, req *domain.CreateTransaksiRequest) (*domain.TransaksiDetailResponse, error)
 	UpdateTransaksi(id string, userID uint, req *domain.UpdateTransaksiRequest) (*domain.TransaksiDetailResponse, error)
 	PatchTransaksi(id string, userID uint, req *domain.PatchTransaksiRequest) (*domain.TransaksiDetailResponse, error)
 	DeleteTransaksi(id string, userID uint) error
+	PreviewImportTransaksi(userID uint, req *domain.ImportTransaksiRequest, file io.Reader) (*domain.ImportTransaksiPreview, error)
+	ImportTransaksi(userID uint, req *domain.ImportTransaksiRequest, file io.Reader) (*domain.ImportTransaksiResult, error)
+	ExportTransaksi(userID uint, req *domain.TransaksiListRequest, format string) (func(w io.Writer) error, error)
+	BatchTransaksi(userID uint, req *domain.BatchTransaksiRequest) (*domain.BatchTransaksiResult, error)
 	SetAnggaranUsecase(anggaranUsecase AnggaranUsecase)
 }
 
@@ -55,6 +63,19 @@ func (uc *transaksiUsecase) GetTransaksiList(userID uint, req *domain.TransaksiL
 		}
 	}
 
+	if req.PaginasiCursor() {
+		response, err := uc.getTransaksiListCursor(userID, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !uc.isCacheDisabledForUser(userID) {
+			uc.redisRepo.SetJSON(cacheKey, response, 5*time.Minute)
+		}
+
+		return response, nil
+	}
+
 	transaksiList, total, err := uc.transaksiRepo.GetByUserID(userID, req)
 	if err != nil {
 		return nil, err
@@ -90,6 +111,70 @@ func (uc *transaksiUsecase) GetTransaksiList(userID uint, req *domain.TransaksiL
 	return response, nil
 }
 
+func (uc *transaksiUsecase) getTransaksiListCursor(userID uint, req *domain.TransaksiListRequest) (*domain.TransaksiListResponse, error) {
+	var cursor *domain.TransaksiCursor
+	if req.Cursor != nil && *req.Cursor != "" {
+		decoded, err := domain.DecodeTransaksiCursor(*req.Cursor, req.SortBy, req.SortDirection)
+		if err != nil {
+			return nil, err
+		}
+		cursor = decoded
+	}
+
+	transaksiList, err := uc.transaksiRepo.GetByUserIDCursor(userID, req, cursor, req.PerPage+1)
+	if err != nil {
+		return nil, err
+	}
+
+	mundur := cursor != nil && cursor.Mundur
+	adaLagi := len(transaksiList) > req.PerPage
+	if adaLagi {
+		if mundur {
+			transaksiList = transaksiList[1:]
+		} else {
+			transaksiList = transaksiList[:req.PerPage]
+		}
+	}
+
+	meta := &domain.CursorPaginationMeta{PerPage: req.PerPage}
+	if len(transaksiList) > 0 {
+		pertama := transaksiList[0]
+		terakhir := transaksiList[len(transaksiList)-1]
+
+		if (mundur && adaLagi) || (!mundur && cursor != nil) {
+			prev := domain.NewTransaksiCursor(req.SortBy, req.SortDirection, pertama, true).Encode()
+			meta.PrevCursor = &prev
+		}
+		if mundur || adaLagi {
+			next := domain.NewTransaksiCursor(req.SortBy, req.SortDirection, terakhir, false).Encode()
+			meta.NextCursor = &next
+		}
+	}
+
+	if req.IncludeTotal {
+		total, err := uc.transaksiRepo.CountByUserID(userID, req)
+		if err != nil {
+			return nil, err
+		}
+		meta.TotalRecords = &total
+	}
+
+	response := &domain.TransaksiListResponse{
+		Success:    true,
+		Message:    "Daftar transaksi berhasil diambil",
+		Code:       200,
+		Data:       make([]domain.TransaksiResponse, 0, len(transaksiList)),
+		CursorMeta: meta,
+		Timestamp:  time.Now(),
+	}
+
+	for _, transaksi := range transaksiList {
+		response.Data = append(response.Data, *transaksi)
+	}
+
+	return response, nil
+}
+
 func (uc *transaksiUsecase) GetTransaksiDetail(id string, userID uint) (*domain.TransaksiDetailResponse, error) {
 	cacheKey := uc.generateDetailCacheKey(id, userID)
 
@@ -117,37 +202,22 @@ func (uc *transaksiUsecase) GetTransaksiDetail(id string, userID uint) (*domain.
 }
 
 func (uc *transaksiUsecase) CreateTransaksi(userID uint, req *domain.CreateTransaksiRequest) (*domain.TransaksiDetailResponse, error) {
-	_, err := uc.kantongRepo.GetByID(req.KantongID, userID)
-	if err != nil {
-		return nil, errors.New("kantong tidak ditemukan")
-	}
+	return uc.CreateTransaksiWithID(userID, uuid.New().String(), req)
+}
 
-	tanggal, err := time.Parse("2006-01-02", req.Tanggal)
+func (uc *transaksiUsecase) CreateTransaksiWithID(userID uint, id string, req *domain.CreateTransaksiRequest) (*domain.TransaksiDetailResponse, error) {
+	transaksi, err := uc.siapkanTransaksi(userID, id, req)
 	if err != nil {
-		return nil, errors.New("format tanggal tidak valid")
+		return nil, err
 	}
 
 	uc.invalidateUserCache(userID)
 
-	transaksi := &domain.Transaksi{
-		ID:        uuid.New().String(),
-		UserID:    userID,
-		KantongID: req.KantongID,
-		Tanggal:   tanggal,
-		Jenis:     req.Jenis,
-		Jumlah:    req.Jumlah,
-		Catatan:   req.Catatan,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-
 	if err := uc.transaksiRepo.Create(transaksi); err != nil {
 		return nil, err
 	}
 
-	if uc.anggaranUsecase != nil {
-		uc.anggaranUsecase.UpdateAnggaranAfterTransaction(req.KantongID, userID)
-	}
+	uc.updateAnggaranKantong(userID, kantongIDTransaksi(transaksi)...)
 
 	uc.invalidateUserCache(userID)
 
@@ -155,14 +225,14 @@ func (uc *transaksiUsecase) CreateTransaksi(userID uint, req *domain.CreateTrans
 }
 
 func (uc *transaksiUsecase) UpdateTransaksi(id string, userID uint, req *domain.UpdateTransaksiRequest) (*domain.TransaksiDetailResponse, error) {
-	_, err := uc.transaksiRepo.GetByID(id, userID)
+	existingTransaksi, err := uc.transaksiRepo.GetByID(id, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = uc.kantongRepo.GetByID(req.KantongID, userID)
+	kantongID, splits, err := uc.resolveSplits(userID, req.KantongID, req.Jumlah, req.Splits)
 	if err != nil {
-		return nil, errors.New("kantong tidak ditemukan")
+		return nil, err
 	}
 
 	tanggal, err := time.Parse("2006-01-02", req.Tanggal)
@@ -170,16 +240,20 @@ func (uc *transaksiUsecase) UpdateTransaksi(id string, userID uint, req *domain.
 		return nil, errors.New("format tanggal tidak valid")
 	}
 
+	tags := uc.resolveTags(userID, req.Tags)
+
 	uc.invalidateUserCache(userID)
 
 	transaksi := &domain.Transaksi{
 		ID:        id,
 		UserID:    userID,
-		KantongID: req.KantongID,
+		KantongID: kantongID,
 		Tanggal:   tanggal,
 		Jenis:     req.Jenis,
 		Jumlah:    req.Jumlah,
 		Catatan:   req.Catatan,
+		Tags:      tags,
+		Splits:    splits,
 		UpdatedAt: time.Now(),
 	}
 
@@ -187,7 +261,10 @@ func (uc *transaksiUsecase) UpdateTransaksi(id string, userID uint, req *domain.
 		return nil, err
 	}
 
+	uc.updateAnggaranKantong(userID, append(kantongIDResponse(existingTransaksi), kantongIDTransaksi(transaksi)...)...)
+
 	uc.invalidateUserCache(userID)
+	uc.redisRepo.Delete(uc.generateDetailCacheKey(id, userID))
 
 	return uc.GetTransaksiDetail(id, userID)
 }
@@ -204,6 +281,14 @@ func (uc *transaksiUsecase) PatchTransaksi(id string, userID uint, req *domain.P
 	updateReq.Jumlah = existingTransaksi.Jumlah
 	updateReq.KantongID = existingTransaksi.KantongID
 	updateReq.Catatan = existingTransaksi.Catatan
+	updateReq.Tags = req.Tags
+	for _, split := range existingTransaksi.Splits {
+		updateReq.Splits = append(updateReq.Splits, domain.TransaksiSplitRequest{
+			KantongID: split.KantongID,
+			Jumlah:    split.Jumlah,
+			Catatan:   split.Catatan,
+		})
+	}
 
 	if req.Tanggal != nil {
 		updateReq.Tanggal = *req.Tanggal
@@ -216,16 +301,20 @@ func (uc *transaksiUsecase) PatchTransaksi(id string, userID uint, req *domain.P
 	}
 	if req.KantongID != nil {
 		updateReq.KantongID = *req.KantongID
+		updateReq.Splits = nil
 	}
 	if req.Catatan != nil {
 		updateReq.Catatan = req.Catatan
 	}
+	if req.Splits != nil {
+		updateReq.Splits = req.Splits
+	}
 
 	return uc.UpdateTransaksi(id, userID, &updateReq)
 }
 
 func (uc *transaksiUsecase) DeleteTransaksi(id string, userID uint) error {
-	_, err := uc.transaksiRepo.GetByID(id, userID)
+	existingTransaksi, err := uc.transaksiRepo.GetByID(id, userID)
 	if err != nil {
 		return err
 	}
@@ -237,12 +326,415 @@ func (uc *transaksiUsecase) DeleteTransaksi(id string, userID uint) error {
 		return err
 	}
 
+	uc.updateAnggaranKantong(userID, kantongIDResponse(existingTransaksi)...)
+
 	uc.invalidateUserCache(userID)
 	uc.redisRepo.Delete(uc.generateDetailCacheKey(id, userID))
 
 	return nil
 }
 
+func (uc *transaksiUsecase) PreviewImportTransaksi(userID uint, req *domain.ImportTransaksiRequest, file io.Reader) (*domain.ImportTransaksiPreview, error) {
+	kantong, err := uc.kantongRepo.GetByID(req.KantongID, userID)
+	if err != nil {
+		return nil, domain.ErrKantongTidakDitemukan
+	}
+
+	rows, err := parseImportCSV(file, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.tandaiDuplikat(userID, req.KantongID, rows); err != nil {
+		return nil, err
+	}
+
+	preview := &domain.ImportTransaksiPreview{
+		KantongID:    req.KantongID,
+		TotalBaris:   len(rows),
+		SaldoSebelum: kantong.Saldo,
+		Baris:        rows,
+	}
+
+	for _, row := range rows {
+		if !row.Valid() {
+			preview.BarisError++
+			continue
+		}
+		if row.Duplikat {
+			preview.BarisDuplikat++
+			if !req.SertakanDuplikat {
+				continue
+			}
+		}
+
+		preview.BarisSiapImpor++
+		if row.Jenis == "Pemasukan" {
+			preview.TotalPemasukan += row.Jumlah
+		} else {
+			preview.TotalPengeluaran += row.Jumlah
+		}
+	}
+
+	preview.SaldoSesudah = preview.SaldoSebelum + preview.TotalPemasukan - preview.TotalPengeluaran
+
+	return preview, nil
+}
+
+func (uc *transaksiUsecase) ImportTransaksi(userID uint, req *domain.ImportTransaksiRequest, file io.Reader) (*domain.ImportTransaksiResult, error) {
+	preview, err := uc.PreviewImportTransaksi(userID, req, file)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ImportTransaksiResult{
+		KantongID:        req.KantongID,
+		TotalPemasukan:   preview.TotalPemasukan,
+		TotalPengeluaran: preview.TotalPengeluaran,
+	}
+
+	if preview.BarisError > 0 {
+		for _, row := range preview.Baris {
+			if !row.Valid() {
+				result.BarisError = append(result.BarisError, row)
+			}
+		}
+		return result, errors.New("terdapat baris yang tidak valid")
+	}
+
+	now := time.Now()
+	transaksis := make([]*domain.Transaksi, 0, preview.BarisSiapImpor)
+	for _, row := range preview.Baris {
+		if row.Duplikat && !req.SertakanDuplikat {
+			result.DuplikatDilewati++
+			continue
+		}
+
+		tanggal, _ := time.Parse("2006-01-02", row.Tanggal)
+		transaksis = append(transaksis, &domain.Transaksi{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			KantongID: req.KantongID,
+			Tanggal:   tanggal,
+			Jenis:     row.Jenis,
+			Jumlah:    row.Jumlah,
+			Catatan:   row.Catatan,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+
+	if len(transaksis) == 0 {
+		return result, nil
+	}
+
+	if err := uc.transaksiRepo.CreateBatch(transaksis); err != nil {
+		return nil, err
+	}
+	result.Diimpor = len(transaksis)
+
+	if uc.anggaranUsecase != nil {
+		for _, kb := range kantongBulanTransaksi(transaksis) {
+			uc.anggaranUsecase.RecalculateAnggaranBulan(kb.kantongID, userID, kb.bulan, kb.tahun)
+		}
+	}
+
+	uc.invalidateUserCache(userID)
+
+	return result, nil
+}
+
+func (uc *transaksiUsecase) ExportTransaksi(userID uint, req *domain.TransaksiListRequest, format string) (func(w io.Writer) error, error) {
+	saldoBerjalan := domain.SaldoBerjalanTersedia(req)
+	saldo := make(map[string]domain.Money)
+	transfer := make(map[string][]domain.MutasiTransferExport)
+	if saldoBerjalan {
+		saldoAwal, err := uc.transaksiRepo.GetSaldoAwalKantong(userID, req.TanggalMulai)
+		if err != nil {
+			return nil, err
+		}
+		saldo = saldoAwal
+
+		mutasiTransfer, err := uc.transaksiRepo.GetMutasiTransfer(userID, req.TanggalMulai, req.TanggalSelesai)
+		if err != nil {
+			return nil, err
+		}
+		for _, mutasi := range mutasiTransfer {
+			transfer[mutasi.KantongID] = append(transfer[mutasi.KantongID], mutasi)
+		}
+	}
+
+	return func(w io.Writer) error {
+		return uc.tulisExportTransaksi(userID, req, format, w, saldoBerjalan, saldo, transfer)
+	}, nil
+}
+
+func (uc *transaksiUsecase) tulisExportTransaksi(userID uint, req *domain.TransaksiListRequest, format string, w io.Writer, saldoBerjalan bool, saldo map[string]domain.Money, transfer map[string][]domain.MutasiTransferExport) error {
+	writer, err := newTransaksiExportWriter(format, w, req, saldoBerjalan, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = uc.transaksiRepo.StreamForExport(userID, req, format == domain.FormatExportOFX, func(row *domain.TransaksiExportRow) error {
+		if saldoBerjalan {
+			tertunda := transfer[row.KantongID]
+			for len(tertunda) > 0 && !tertunda[0].Tanggal.After(row.Tanggal) {
+				saldo[row.KantongID] += tertunda[0].Jumlah
+				tertunda = tertunda[1:]
+			}
+			transfer[row.KantongID] = tertunda
+
+			saldo[row.KantongID] += row.Mutasi()
+			row.SaldoBerjalan = saldo[row.KantongID]
+		}
+		return writer.Tulis(row)
+	})
+	if err == nil {
+		err = writer.Tutup()
+	}
+	if err != nil {
+		helper.Error("Gagal mengekspor transaksi", err, logrus.Fields{
+			"user_id": userID,
+			"format":  format,
+		})
+		return err
+	}
+
+	return nil
+}
+
+func (uc *transaksiUsecase) BatchTransaksi(userID uint, req *domain.BatchTransaksiRequest) (*domain.BatchTransaksiResult, error) {
+	mode := req.Mode
+	if mode == "" {
+		mode = domain.ModeBatchAtomik
+	}
+	atomik := mode == domain.ModeBatchAtomik
+
+	operasi := make([]*domain.OperasiBatchTransaksi, 0, len(req.Operasi))
+	adaGagal := false
+	for _, item := range req.Operasi {
+		op := uc.siapkanOperasiBatch(userID, item)
+		if op.Err != nil {
+			adaGagal = true
+		}
+		operasi = append(operasi, op)
+	}
+
+	if atomik && adaGagal {
+		return hasilBatch(mode, operasi, true), errors.New("batch transaksi dibatalkan")
+	}
+
+	if err := uc.transaksiRepo.ExecuteBatch(userID, operasi, atomik); err != nil {
+		if atomik && batchMemilikiGagal(operasi) {
+			return hasilBatch(mode, operasi, true), errors.New("batch transaksi dibatalkan")
+		}
+		return nil, err
+	}
+
+	if uc.anggaranUsecase != nil {
+		for _, kb := range kantongBulanBatch(operasi) {
+			uc.anggaranUsecase.RecalculateAnggaranBulan(kb.kantongID, userID, kb.bulan, kb.tahun)
+		}
+	}
+
+	uc.invalidateUserCache(userID)
+	for _, op := range operasi {
+		if op.Err == nil && op.Aksi != domain.AksiBatchCreate {
+			uc.redisRepo.Delete(uc.generateDetailCacheKey(op.Transaksi.ID, userID))
+		}
+	}
+
+	return hasilBatch(mode, operasi, false), nil
+}
+
+func (uc *transaksiUsecase) siapkanOperasiBatch(userID uint, item domain.BatchTransaksiOperasi) *domain.OperasiBatchTransaksi {
+	op := &domain.OperasiBatchTransaksi{
+		Aksi:      item.Aksi,
+		Transaksi: &domain.Transaksi{ID: item.ID, UserID: userID},
+	}
+
+	if item.Aksi == domain.AksiBatchDelete {
+		return op
+	}
+
+	if item.Data == nil {
+		op.Err = errors.New("data transaksi wajib diisi")
+		return op
+	}
+
+	id := item.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	transaksi, err := uc.siapkanTransaksi(userID, id, item.Data)
+	if err != nil {
+		op.Err = err
+		return op
+	}
+
+	if item.Aksi == domain.AksiBatchUpdate {
+		transaksi.CreatedAt = time.Time{}
+	}
+	op.Transaksi = transaksi
+
+	return op
+}
+
+func (uc *transaksiUsecase) tandaiDuplikat(userID uint, kantongID string, rows []domain.ImportTransaksiRow) error {
+	var tanggalMulai, tanggalSelesai string
+	for _, row := range rows {
+		if !row.Valid() {
+			continue
+		}
+		if tanggalMulai == "" || row.Tanggal < tanggalMulai {
+			tanggalMulai = row.Tanggal
+		}
+		if row.Tanggal > tanggalSelesai {
+			tanggalSelesai = row.Tanggal
+		}
+	}
+	if tanggalMulai == "" {
+		return nil
+	}
+
+	mulai, _ := time.Parse("2006-01-02", tanggalMulai)
+	selesai, _ := time.Parse("2006-01-02", tanggalSelesai)
+
+	existing, err := uc.transaksiRepo.GetByKantongAndPeriod(kantongID, userID, mulai, selesai)
+	if err != nil {
+		return err
+	}
+
+	tersedia := make(map[string]int, len(existing))
+	for _, transaksi := range existing {
+		tersedia[kunciDuplikat(transaksi.Tanggal.Format("2006-01-02"), transaksi.Jenis, transaksi.Jumlah)]++
+	}
+
+	for i := range rows {
+		if !rows[i].Valid() {
+			continue
+		}
+		kunci := kunciDuplikat(rows[i].Tanggal, rows[i].Jenis, rows[i].Jumlah)
+		if tersedia[kunci] > 0 {
+			tersedia[kunci]--
+			rows[i].Duplikat = true
+		}
+	}
+
+	return nil
+}
+
+func (uc *transaksiUsecase) resolveTags(userID uint, names []string) []domain.Tag {
+	names = domain.NormalisasiDaftarTag(names)
+	if names == nil {
+		return nil
+	}
+
+	tags := make([]domain.Tag, 0, len(names))
+	for _, nama := range names {
+		tags = append(tags, domain.Tag{UserID: userID, Nama: nama})
+	}
+	return tags
+}
+
+func (uc *transaksiUsecase) siapkanTransaksi(userID uint, id string, req *domain.CreateTransaksiRequest) (*domain.Transaksi, error) {
+	kantongID, splits, err := uc.resolveSplits(userID, req.KantongID, req.Jumlah, req.Splits)
+	if err != nil {
+		return nil, err
+	}
+
+	tanggal, err := time.Parse("2006-01-02", req.Tanggal)
+	if err != nil {
+		return nil, errors.New("format tanggal tidak valid")
+	}
+
+	tags := uc.resolveTags(userID, req.Tags)
+
+	return &domain.Transaksi{
+		ID:        id,
+		UserID:    userID,
+		KantongID: kantongID,
+		Tanggal:   tanggal,
+		Jenis:     req.Jenis,
+		Jumlah:    req.Jumlah,
+		Catatan:   req.Catatan,
+		Tags:      tags,
+		Splits:    splits,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (uc *transaksiUsecase) resolveSplits(userID uint, kantongID string, jumlah domain.Money, splits []domain.TransaksiSplitRequest) (string, []domain.TransaksiSplit, error) {
+	if len(splits) == 0 {
+		if _, err := uc.kantongRepo.GetByID(kantongID, userID); err != nil {
+			return "", nil, domain.ErrKantongTidakDitemukan
+		}
+		return kantongID, nil, nil
+	}
+
+	if len(splits) < 2 {
+		return "", nil, errors.New("split minimal terdiri dari 2 kantong")
+	}
+
+	if domain.TotalSplit(splits) != jumlah {
+		return "", nil, errors.New("total split harus sama dengan jumlah transaksi")
+	}
+
+	result := make([]domain.TransaksiSplit, 0, len(splits))
+	seen := make(map[string]bool, len(splits))
+	for _, split := range splits {
+		if seen[split.KantongID] {
+			return "", nil, errors.New("kantong split tidak boleh duplikat")
+		}
+		seen[split.KantongID] = true
+
+		if _, err := uc.kantongRepo.GetByID(split.KantongID, userID); err != nil {
+			return "", nil, domain.ErrKantongTidakDitemukan
+		}
+
+		result = append(result, domain.TransaksiSplit{
+			KantongID: split.KantongID,
+			Jumlah:    split.Jumlah,
+			Catatan:   split.Catatan,
+		})
+	}
+
+	return splits[0].KantongID, result, nil
+}
+
+func (uc *transaksiUsecase) updateAnggaranKantong(userID uint, kantongIDs ...string) {
+	if uc.anggaranUsecase == nil {
+		return
+	}
+
+	diperbarui := make(map[string]bool, len(kantongIDs))
+	for _, kantongID := range kantongIDs {
+		if diperbarui[kantongID] {
+			continue
+		}
+		diperbarui[kantongID] = true
+		uc.anggaranUsecase.UpdateAnggaranAfterTransaction(kantongID, userID)
+	}
+}
+
+func kantongIDTransaksi(transaksi *domain.Transaksi) []string {
+	kantongIDs := []string{transaksi.KantongID}
+	for _, split := range transaksi.Splits {
+		kantongIDs = append(kantongIDs, split.KantongID)
+	}
+	return kantongIDs
+}
+
+func kantongIDResponse(transaksi *domain.TransaksiResponse) []string {
+	kantongIDs := []string{transaksi.KantongID}
+	for _, split := range transaksi.Splits {
+		kantongIDs = append(kantongIDs, split.KantongID)
+	}
+	return kantongIDs
+}
+
 func (uc *transaksiUsecase) generateListCacheKey(userID uint, req *domain.TransaksiListRequest) string {
 	params := make(map[string]interface{})
 
@@ -261,6 +753,19 @@ func (uc *transaksiUsecase) generateListCacheKey(userID uint, req *domain.Transa
 	if req.TanggalSelesai != nil {
 		params["tanggal_selesai"] = *req.TanggalSelesai
 	}
+	if req.Tag != nil {
+		params["tag"] = *req.Tag
+	}
+
+	if req.Pagination != "" {
+		params["pagination"] = req.Pagination
+	}
+	if req.Cursor != nil {
+		params["cursor"] = *req.Cursor
+	}
+	if req.IncludeTotal {
+		params["include_total"] = true
+	}
 
 	params["sort_by"] = req.SortBy
 	params["sort_direction"] = req.SortDirection
@@ -155,7 +155,7 @@ func (uc *userSubscriptionUsecase) mapToResponse(subscription *domain.UserSubscr
 			ID:    subscription.User.ID,
 			Nama:  subscription.User.Name,
 			Email: subscription.User.Email,
-			Role:  "User",
+			Role:  userRoleName(&subscription.User),
 		},
 		SubscriptionPlan: domain.SubscriptionPlanInfo{
 			ID:    subscription.SubscriptionPlan.ID,
@@ -178,7 +178,7 @@ func (uc *userSubscriptionUsecase) mapToDetailResponse(subscription *domain.User
 			ID:        subscription.User.ID,
 			Nama:      subscription.User.Name,
 			Email:     subscription.User.Email,
-			Role:      "User",
+			Role:      userRoleName(&subscription.User),
 			IsActive:  subscription.User.IsActive,
 			CreatedAt: subscription.User.CreatedAt,
 		},
@@ -204,3 +204,10 @@ func (uc *userSubscriptionUsecase) mapToDetailResponse(subscription *domain.User
 		UpdatedAt:          subscription.UpdatedAt,
 	}
 }
+
+func userRoleName(user *domain.User) string {
+	if user.Role != nil && user.Role.Nama != "" {
+		return user.Role.Nama
+	}
+	return "User"
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_role_id;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS fk_users_role_id;
+ALTER TABLE users DROP COLUMN IF EXISTS role_id;
\ No newline at end of file
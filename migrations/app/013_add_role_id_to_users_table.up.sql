@@ -0,0 +1,7 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role_id UUID NULL;
+
+ALTER TABLE users
+ADD CONSTRAINT fk_users_role_id
+FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_users_role_id ON users(role_id);
\ No newline at end of file
@@ -0,0 +1,9 @@
+DELETE FROM role_permissions WHERE role_id IN (SELECT id FROM roles WHERE nama = 'admin');
+DELETE FROM roles WHERE nama = 'admin';
+DELETE FROM permissions WHERE nama IN (
+    'subscription_plan.read', 'subscription_plan.create', 'subscription_plan.update', 'subscription_plan.delete',
+    'permission.read', 'permission.create', 'permission.update', 'permission.delete',
+    'role.read', 'role.create', 'role.update', 'role.delete',
+    'user_subscription.read', 'user_subscription.update',
+    'invoice.read', 'invoice.update'
+);
\ No newline at end of file
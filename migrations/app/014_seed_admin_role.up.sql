@@ -0,0 +1,29 @@
+INSERT INTO permissions (nama, kategori, deskripsi) VALUES
+    ('subscription_plan.read', 'admin', 'Melihat daftar dan detail subscription plan'),
+    ('subscription_plan.create', 'admin', 'Membuat subscription plan'),
+    ('subscription_plan.update', 'admin', 'Mengubah subscription plan'),
+    ('subscription_plan.delete', 'admin', 'Menghapus subscription plan'),
+    ('permission.read', 'admin', 'Melihat daftar dan detail permission'),
+    ('permission.create', 'admin', 'Membuat permission'),
+    ('permission.update', 'admin', 'Mengubah permission'),
+    ('permission.delete', 'admin', 'Menghapus permission'),
+    ('role.read', 'admin', 'Melihat daftar dan detail role'),
+    ('role.create', 'admin', 'Membuat role'),
+    ('role.update', 'admin', 'Mengubah role'),
+    ('role.delete', 'admin', 'Menghapus role'),
+    ('user_subscription.read', 'admin', 'Melihat subscription pengguna'),
+    ('user_subscription.update', 'admin', 'Mengubah subscription pengguna'),
+    ('invoice.read', 'admin', 'Melihat daftar dan detail invoice'),
+    ('invoice.update', 'admin', 'Mengubah status invoice')
+ON CONFLICT (nama) DO NOTHING;
+
+INSERT INTO roles (nama, deskripsi, status)
+VALUES ('admin', 'Administrator dengan akses penuh ke modul admin', 'aktif')
+ON CONFLICT (nama) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.nama = 'admin' AND p.kategori = 'admin'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_mfa_recovery_codes_code_hash;
+DROP INDEX IF EXISTS idx_mfa_recovery_codes_user_id;
+DROP TABLE IF EXISTS mfa_recovery_codes;
+DROP TABLE IF EXISTS user_mfas;
\ No newline at end of file
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_refresh_tokens_user_id_last_used_at;
+
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS last_used_at;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS device_name;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS ip_address;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS user_agent;
\ No newline at end of file
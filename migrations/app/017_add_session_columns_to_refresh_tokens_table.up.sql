@@ -0,0 +1,6 @@
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS user_agent TEXT DEFAULT '';
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45) DEFAULT '';
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS device_name VARCHAR(100) DEFAULT '';
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id_last_used_at ON refresh_tokens(user_id, last_used_at);
\ No newline at end of file
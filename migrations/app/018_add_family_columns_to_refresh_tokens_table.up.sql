@@ -0,0 +1,9 @@
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS family_id VARCHAR(36);
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES refresh_tokens(id) ON DELETE SET NULL;
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMP;
+
+UPDATE refresh_tokens SET family_id = gen_random_uuid()::text WHERE family_id IS NULL;
+
+ALTER TABLE refresh_tokens ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
\ No newline at end of file
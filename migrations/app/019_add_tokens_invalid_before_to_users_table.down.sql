@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN IF EXISTS tokens_invalid_before;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tokens_invalid_before TIMESTAMP;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM role_permissions WHERE permission_id IN (SELECT id FROM permissions WHERE nama IN ('user.read', 'user.update'));
+DELETE FROM permissions WHERE nama IN ('user.read', 'user.update');
\ No newline at end of file
@@ -0,0 +1,11 @@
+INSERT INTO permissions (nama, kategori, deskripsi) VALUES
+    ('user.read', 'admin', 'Melihat daftar dan detail pengguna'),
+    ('user.update', 'admin', 'Mengubah status, role, dan sesi pengguna')
+ON CONFLICT (nama) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE r.nama = 'admin' AND p.nama IN ('user.read', 'user.update')
+ON CONFLICT (role_id, permission_id) DO NOTHING;
\ No newline at end of file
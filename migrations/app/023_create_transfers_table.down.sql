@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_transfers_user_created_at;
+DROP INDEX IF EXISTS idx_transfers_kantong_tujuan_id;
+DROP INDEX IF EXISTS idx_transfers_kantong_asal_id;
+DROP INDEX IF EXISTS idx_transfers_user_id;
+DROP TABLE IF EXISTS transfers;
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kantong_asal_id UUID NOT NULL REFERENCES kantongs(id) ON DELETE CASCADE,
+    kantong_tujuan_id UUID NOT NULL REFERENCES kantongs(id) ON DELETE CASCADE,
+    jumlah DECIMAL(15,2) NOT NULL CHECK (jumlah > 0),
+    catatan VARCHAR(500),
+    saldo_asal_sebelum DECIMAL(15,2) NOT NULL,
+    saldo_asal_sesudah DECIMAL(15,2) NOT NULL,
+    saldo_tujuan_sebelum DECIMAL(15,2) NOT NULL,
+    saldo_tujuan_sesudah DECIMAL(15,2) NOT NULL,
+    reversal_of_id UUID UNIQUE NULL REFERENCES transfers(id) ON DELETE CASCADE,
+    reversed_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_transfers_user_id ON transfers(user_id);
+CREATE INDEX IF NOT EXISTS idx_transfers_kantong_asal_id ON transfers(kantong_asal_id);
+CREATE INDEX IF NOT EXISTS idx_transfers_kantong_tujuan_id ON transfers(kantong_tujuan_id);
+CREATE INDEX IF NOT EXISTS idx_transfers_user_created_at ON transfers(user_id, created_at);
\ No newline at end of file
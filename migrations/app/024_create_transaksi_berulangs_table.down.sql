@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_kejadian_transaksi_berulang_urutan;
+DROP TABLE IF EXISTS kejadian_transaksi_berulangs;
+DROP INDEX IF EXISTS idx_transaksi_berulangs_status_tanggal_berikutnya;
+DROP INDEX IF EXISTS idx_transaksi_berulangs_kantong_id;
+DROP INDEX IF EXISTS idx_transaksi_berulangs_user_id;
+DROP TABLE IF EXISTS transaksi_berulangs;
\ No newline at end of file
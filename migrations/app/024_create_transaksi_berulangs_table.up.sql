@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS transaksi_berulangs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kantong_id UUID NOT NULL REFERENCES kantongs(id) ON DELETE CASCADE,
+    jenis VARCHAR(20) NOT NULL CHECK (jenis IN ('Pemasukan', 'Pengeluaran')),
+    jumlah DECIMAL(15,2) NOT NULL CHECK (jumlah > 0),
+    catatan VARCHAR(500),
+    frekuensi VARCHAR(10) NOT NULL CHECK (frekuensi IN ('harian', 'mingguan', 'bulanan', 'tahunan')),
+    interval_frekuensi INTEGER NOT NULL DEFAULT 1 CHECK (interval_frekuensi > 0),
+    tanggal_mulai DATE NOT NULL,
+    tanggal_selesai DATE NULL,
+    jumlah_kejadian INTEGER NULL CHECK (jumlah_kejadian > 0),
+    tanggal_acuan DATE NOT NULL,
+    urutan_acuan INTEGER NOT NULL DEFAULT 0,
+    urutan INTEGER NOT NULL DEFAULT 0,
+    tanggal_berikutnya DATE NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'aktif' CHECK (status IN ('aktif', 'dijeda', 'selesai')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaksi_berulangs_user_id ON transaksi_berulangs(user_id);
+CREATE INDEX IF NOT EXISTS idx_transaksi_berulangs_kantong_id ON transaksi_berulangs(kantong_id);
+CREATE INDEX IF NOT EXISTS idx_transaksi_berulangs_status_tanggal_berikutnya ON transaksi_berulangs(status, tanggal_berikutnya);
+
+CREATE TABLE IF NOT EXISTS kejadian_transaksi_berulangs (
+    id SERIAL PRIMARY KEY,
+    transaksi_berulang_id UUID NOT NULL REFERENCES transaksi_berulangs(id) ON DELETE CASCADE,
+    urutan INTEGER NOT NULL,
+    tanggal DATE NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('diproses', 'dibuat', 'dilewati', 'gagal')),
+    transaksi_id UUID NULL REFERENCES transaksis(id) ON DELETE SET NULL,
+    pesan VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_kejadian_transaksi_berulang_urutan ON kejadian_transaksi_berulangs(transaksi_berulang_id, urutan);
\ No newline at end of file
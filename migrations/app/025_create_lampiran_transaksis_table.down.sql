@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_lampiran_transaksis_storage_key;
+DROP INDEX IF EXISTS idx_lampiran_transaksis_user_id;
+DROP INDEX IF EXISTS idx_lampiran_transaksis_transaksi_id;
+DROP TABLE IF EXISTS lampiran_transaksis;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS lampiran_transaksis (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaksi_id UUID NOT NULL REFERENCES transaksis(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    nama_file VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    ukuran BIGINT NOT NULL CHECK (ukuran > 0),
+    storage_key VARCHAR(500) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_lampiran_transaksis_transaksi_id ON lampiran_transaksis(transaksi_id);
+CREATE INDEX IF NOT EXISTS idx_lampiran_transaksis_user_id ON lampiran_transaksis(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lampiran_transaksis_storage_key ON lampiran_transaksis(storage_key);
\ No newline at end of file
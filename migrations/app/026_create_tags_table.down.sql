@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_transaksi_tags_tag_id;
+DROP TABLE IF EXISTS transaksi_tags;
+DROP INDEX IF EXISTS idx_tags_user_id_nama;
+DROP INDEX IF EXISTS idx_tags_user_id;
+DROP TABLE IF EXISTS tags;
\ No newline at end of file
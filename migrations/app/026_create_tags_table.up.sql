@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    nama VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_tags_user_id ON tags(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_id_nama ON tags(user_id, LOWER(nama));
+
+CREATE TABLE IF NOT EXISTS transaksi_tags (
+    transaksi_id UUID NOT NULL REFERENCES transaksis(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (transaksi_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaksi_tags_tag_id ON transaksi_tags(tag_id);
\ No newline at end of file
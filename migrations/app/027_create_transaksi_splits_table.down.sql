@@ -0,0 +1,4 @@
+DROP VIEW IF EXISTS transaksi_kantongs;
+DROP INDEX IF EXISTS idx_transaksi_splits_kantong_id;
+DROP INDEX IF EXISTS idx_transaksi_splits_transaksi_id;
+DROP TABLE IF EXISTS transaksi_splits;
\ No newline at end of file
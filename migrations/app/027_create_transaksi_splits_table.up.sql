@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS transaksi_splits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaksi_id UUID NOT NULL REFERENCES transaksis(id) ON DELETE CASCADE,
+    kantong_id UUID NOT NULL REFERENCES kantongs(id) ON DELETE RESTRICT,
+    jumlah DECIMAL(15,2) NOT NULL CHECK (jumlah > 0),
+    catatan VARCHAR(500),
+    urutan INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (transaksi_id, kantong_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaksi_splits_transaksi_id ON transaksi_splits(transaksi_id);
+CREATE INDEX IF NOT EXISTS idx_transaksi_splits_kantong_id ON transaksi_splits(kantong_id);
+
+CREATE OR REPLACE VIEW transaksi_kantongs AS
+    SELECT t.id, t.user_id, t.kantong_id, t.tanggal, t.jenis, t.jumlah, t.catatan, t.created_at, t.updated_at
+    FROM transaksis t
+    WHERE NOT EXISTS (SELECT 1 FROM transaksi_splits s WHERE s.transaksi_id = t.id)
+    UNION ALL
+    SELECT t.id, t.user_id, s.kantong_id, t.tanggal, t.jenis, s.jumlah, COALESCE(s.catatan, t.catatan), t.created_at, t.updated_at
+    FROM transaksi_splits s
+    JOIN transaksis t ON t.id = s.transaksi_id;
\ No newline at end of file
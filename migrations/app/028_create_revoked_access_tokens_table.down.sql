@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_revoked_access_tokens_expires_at;
+DROP TABLE IF EXISTS revoked_access_tokens;
\ No newline at end of file
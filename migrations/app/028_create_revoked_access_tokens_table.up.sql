@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS revoked_access_tokens (
+    revocation_key VARCHAR(100) PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_access_tokens_expires_at ON revoked_access_tokens(expires_at);
\ No newline at end of file